@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/stormlightlabs/git-storm/internal/changelog"
@@ -18,13 +19,24 @@ func bumpCmd() *cobra.Command {
 		Use:   "bump",
 		Short: "Calculate the next semantic version and optionally update toolchain manifests",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			kind, err := versioning.ParseBumpType(bumpKind)
-			if err != nil {
-				return err
+			var kind versioning.BumpType
+			if strings.EqualFold(bumpKind, versioning.BumpAuto) {
+				inferred, reason, count, err := inferBumpFromEntries("", "", nil)
+				if err != nil {
+					return err
+				}
+				style.Println("Inferred %s bump from %d entries: %s", inferred, count, reason)
+				kind = inferred
+			} else {
+				parsed, err := versioning.ParseBumpType(bumpKind)
+				if err != nil {
+					return err
+				}
+				kind = parsed
 			}
 
 			changelogPath := filepath.Join(repoPath, output)
-			parsed, err := changelog.Parse(changelogPath)
+			parsed, err := changelog.ParseCached(changelogPath)
 			if err != nil {
 				return fmt.Errorf("failed to parse changelog: %w", err)
 			}
@@ -50,7 +62,7 @@ func bumpCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVar(&bumpKind, "bump", "", "Which semver component to bump (major, minor, or patch)")
+	cmd.Flags().StringVar(&bumpKind, "bump", "", "Which semver component to bump (major, minor, patch, or auto to infer it from pending entries)")
 	cmd.Flags().StringSliceVar(&toolchainSelectors, "toolchain", nil, "Toolchain manifests to update (paths, types, or 'interactive')")
 	cmd.MarkFlagRequired("bump")
 
@@ -6,6 +6,9 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/stormlightlabs/git-storm/internal/changeset"
+	"github.com/stormlightlabs/git-storm/internal/style"
 )
 
 const sampleChangelog = `# Changelog
@@ -84,3 +87,33 @@ func writeFile(t *testing.T, path, contents string) {
 		t.Fatalf("failed to write %s: %v", path, err)
 	}
 }
+
+func TestBumpCommandAuto(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "CHANGELOG.md"), sampleChangelog)
+	chdirRepo(t, dir)
+	output = "CHANGELOG.md"
+	useMemoryStore(t,
+		changeset.Entry{Type: "fixed", Summary: "Fix crash"},
+		changeset.Entry{Type: "added", Summary: "Add export"},
+	)
+
+	var log bytes.Buffer
+	style.SetWriter(&log)
+	defer style.SetWriter(nil)
+
+	cmd := bumpCmd()
+	cmd.SetArgs([]string{"--bump", "auto"})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("bump command failed: %v", err)
+	}
+	if out.String() != "1.3.0\n" {
+		t.Errorf("expected 1.3.0, got %q", out.String())
+	}
+	if !strings.Contains(log.String(), `Inferred minor bump from 2 entries: "Add export" adds a feature`) {
+		t.Errorf("expected the bump reasoning, got %q", log.String())
+	}
+}
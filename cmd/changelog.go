@@ -0,0 +1,205 @@
+/*
+USAGE
+
+	storm changelog <subcommand> [options]
+
+SUBCOMMANDS
+
+	add-version   Insert a historical version block into the changelog
+	promote       Relabel the Unreleased section as a numbered version
+
+USAGE
+
+	storm changelog add-version <X.Y.Z> --date <YYYY-MM-DD> [options]
+
+FLAGS
+
+	--date <YYYY-MM-DD>    Release date of the version (required)
+	--<type> <text>        Entry for the section of a change type, e.g.
+	                       --added or --fixed (repeatable). Every type in the
+	                       registry has a flag, including custom ones from
+	                       .storm.yaml.
+	--repo <path>          Path to the Git repository (default: .)
+	--output <path>        Changelog file path (default: CHANGELOG.md)
+
+USAGE
+
+	storm changelog promote <X.Y.Z> [options]
+
+FLAGS
+
+	--date <YYYY-MM-DD>    Release date of the version (default: today)
+	--repo <path>          Path to the Git repository (default: .)
+	--output <path>        Changelog file path (default: CHANGELOG.md)
+
+Moves everything under [Unreleased] to the new version and inserts an empty
+Unreleased section above it. Unlike release, .changes/ is not read or cleared
+and no tag is created.
+*/
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stormlightlabs/git-storm/internal/changelog"
+	"github.com/stormlightlabs/git-storm/internal/changeset"
+	"github.com/stormlightlabs/git-storm/internal/style"
+)
+
+func changelogCmd() *cobra.Command {
+	var date string
+
+	addVersion := &cobra.Command{
+		Use:   "add-version <X.Y.Z>",
+		Short: "Insert a historical version block into the changelog",
+		Long: `Builds a version from the given section entries and inserts it into
+CHANGELOG.md at its chronological position by semantic version. Useful for
+importing releases that predate storm.`,
+		// The section flags depend on the change types, which --repo and
+		// --types select, so flags are parsed in RunE once those are known.
+		DisableFlagParsing: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sectionTypes, sectionEntries, err := parseSectionFlags(cmd, args)
+			if err != nil || sectionEntries == nil {
+				return err
+			}
+			args = cmd.Flags().Args()
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return err
+			}
+
+			var entries []changeset.Entry
+			for _, typ := range sectionTypes {
+				summaries, ok := sectionEntries[typ.Name]
+				if !ok || len(*summaries) == 0 {
+					continue
+				}
+				if err := changeTypes.Validate(typ.Name); err != nil {
+					return fmt.Errorf("--%s: %w", typ.Name, err)
+				}
+				for _, summary := range *summaries {
+					entries = append(entries, changeset.Entry{Type: typ.Name, Summary: summary})
+				}
+			}
+
+			if len(entries) == 0 {
+				return fmt.Errorf("at least one entry is required (e.g. --added \"...\")")
+			}
+
+			version, err := changelog.BuildWithOptions(entries, args[0], date, changelog.Options{Types: changeTypes})
+			if err != nil {
+				return err
+			}
+
+			changelogPath := filepath.Join(repoPath, output)
+			existing, err := changelog.Parse(changelogPath)
+			if err != nil {
+				return fmt.Errorf("failed to parse changelog: %w", err)
+			}
+
+			if err := changelog.Insert(existing, version); err != nil {
+				return err
+			}
+
+			opts, err := writeOptions()
+			if err != nil {
+				return err
+			}
+			applyConfiguredHeader(existing)
+			if err := changelog.WriteWithOptions(changelogPath, existing, repoPath, opts); err != nil {
+				return fmt.Errorf("failed to write changelog: %w", err)
+			}
+
+			style.Addedf("✓ Added version %s to %s", version.Number, changelogPath)
+			return nil
+		},
+	}
+
+	addVersion.Flags().StringVar(&date, "date", "", "Release date of the version in YYYY-MM-DD format")
+	addVersion.MarkFlagRequired("date")
+
+	var promoteDate string
+	promote := &cobra.Command{
+		Use:   "promote <X.Y.Z>",
+		Short: "Relabel the Unreleased section as a numbered version",
+		Long: `Moves the entries under [Unreleased] in CHANGELOG.md to a new version and
+inserts a fresh empty Unreleased section above it. Comparison links are
+regenerated, but .changes/ is left untouched and no tag is created.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if promoteDate == "" {
+				promoteDate = time.Now().Format("2006-01-02")
+			}
+
+			changelogPath := filepath.Join(repoPath, output)
+			existing, err := changelog.Parse(changelogPath)
+			if err != nil {
+				return fmt.Errorf("failed to parse changelog: %w", err)
+			}
+
+			version, err := changelog.Promote(existing, args[0], promoteDate)
+			if err != nil {
+				return err
+			}
+
+			opts, err := writeOptions()
+			if err != nil {
+				return err
+			}
+			applyConfiguredHeader(existing)
+			if err := changelog.WriteWithOptions(changelogPath, existing, repoPath, opts); err != nil {
+				return fmt.Errorf("failed to write changelog: %w", err)
+			}
+
+			style.Addedf("✓ Promoted Unreleased to %s (%s) in %s", version.Number, version.Date, changelogPath)
+			return nil
+		},
+	}
+	promote.Flags().StringVar(&promoteDate, "date", "", "Release date of the version in YYYY-MM-DD format (default: today)")
+
+	root := &cobra.Command{
+		Use:   "changelog",
+		Short: "Edit CHANGELOG.md directly",
+		Long:  "Commands that operate on the changelog file itself rather than .changes entries.",
+	}
+	root.AddCommand(addVersion, promote)
+	return root
+}
+
+// parseSectionFlags registers a flag for each of [flagTypes] on cmd, which
+// has flag parsing disabled, and parses args. The root's persistent pre-run
+// skips such commands, so it runs here once the flags are parsed.
+//
+// It returns nil entries when --help was given and the help has been shown.
+func parseSectionFlags(cmd *cobra.Command, args []string) ([]changeset.ChangeType, map[string]*[]string, error) {
+	types := flagTypes(args)
+	entries := make(map[string]*[]string, len(types))
+	for _, typ := range types {
+		if cmd.Flags().Lookup(typ.Name) != nil {
+			continue
+		}
+		var values []string
+		entries[typ.Name] = &values
+		cmd.Flags().StringArrayVar(&values, typ.Name, nil, fmt.Sprintf("Entry for the %s section (repeatable)", typ.Title))
+	}
+
+	cmd.DisableFlagParsing = false
+	if err := cmd.ParseFlags(args); err != nil {
+		return nil, nil, err
+	}
+	if help, _ := cmd.Flags().GetBool("help"); help {
+		return nil, nil, cmd.Help()
+	}
+	if err := cmd.ValidateRequiredFlags(); err != nil {
+		return nil, nil, err
+	}
+	if root := cmd.Root(); root != cmd && root.PersistentPreRunE != nil {
+		if err := root.PersistentPreRunE(cmd, cmd.Flags().Args()); err != nil {
+			return nil, nil, err
+		}
+	}
+	return types, entries, nil
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/git-storm/internal/changeset"
+)
+
+func TestChangelogAddVersion(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "CHANGELOG.md"), `# Changelog
+
+## [Unreleased]
+
+## [1.0.0] - 2024-01-01
+
+### Added
+
+- Stable release
+
+## [0.5.0] - 2023-01-01
+
+### Added
+
+- Preview release
+`)
+
+	oldRepo := repoPath
+	oldOutput := output
+	repoPath = dir
+	output = "CHANGELOG.md"
+	t.Cleanup(func() {
+		repoPath = oldRepo
+		output = oldOutput
+	})
+
+	cmd := changelogCmd()
+	cmd.SetArgs([]string{"add-version", "0.9.0", "--date", "2023-06-01", "--added", "Beta feature", "--fixed", "Beta fix"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("add-version failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "CHANGELOG.md"))
+	if err != nil {
+		t.Fatalf("failed to read changelog: %v", err)
+	}
+	text := string(content)
+
+	stable := strings.Index(text, "## [1.0.0]")
+	inserted := strings.Index(text, "## [0.9.0] - 2023-06-01")
+	preview := strings.Index(text, "## [0.5.0]")
+	if inserted < 0 || !(stable < inserted && inserted < preview) {
+		t.Fatalf("expected 0.9.0 between 1.0.0 and 0.5.0, got:\n%s", text)
+	}
+	if !strings.Contains(text, "- Beta fix") {
+		t.Fatalf("expected fixed entry in output, got:\n%s", text)
+	}
+}
+
+func TestChangelogPromote(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "CHANGELOG.md"), `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- Hand-written feature
+
+### Fixed
+
+- Hand-written fix
+
+## [1.0.0] - 2024-01-01
+
+### Added
+
+- Stable release
+`)
+
+	oldRepo := repoPath
+	oldOutput := output
+	repoPath = dir
+	output = "CHANGELOG.md"
+	t.Cleanup(func() {
+		repoPath = oldRepo
+		output = oldOutput
+	})
+
+	cmd := changelogCmd()
+	cmd.SetArgs([]string{"promote", "1.1.0", "--date", "2024-02-01"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("promote failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "CHANGELOG.md"))
+	if err != nil {
+		t.Fatalf("failed to read changelog: %v", err)
+	}
+	text := string(content)
+
+	if !strings.Contains(text, "## [Unreleased]\n\n## [1.1.0] - 2024-02-01\n\n### Added\n\n- Hand-written feature") {
+		t.Fatalf("expected empty Unreleased above promoted 1.1.0, got:\n%s", text)
+	}
+	if strings.Index(text, "- Hand-written fix") > strings.Index(text, "## [1.0.0]") {
+		t.Fatalf("expected fixed entry under 1.1.0, got:\n%s", text)
+	}
+
+	cmd = changelogCmd()
+	cmd.SetArgs([]string{"promote", "1.2.0"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("promote with an empty Unreleased section should fail")
+	}
+}
+
+func TestChangelogPromote_ConfiguredTemplate(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "CHANGELOG.md"), "# Changelog\n\n## [Unreleased]\n\n### Added\n\n- Feature\n")
+	writeFile(t, filepath.Join(dir, "release.tmpl"), "{{range .Versions}}* {{.Number}}\n{{end}}")
+
+	oldRepo, oldOutput, oldConfig := repoPath, output, projectConfig
+	repoPath, output = dir, "CHANGELOG.md"
+	projectConfig.Template = filepath.Join(dir, "release.tmpl")
+	t.Cleanup(func() {
+		repoPath, output, projectConfig = oldRepo, oldOutput, oldConfig
+	})
+
+	cmd := changelogCmd()
+	cmd.SetArgs([]string{"promote", "1.0.0", "--date", "2024-02-01"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("promote failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "CHANGELOG.md"))
+	if err != nil {
+		t.Fatalf("failed to read changelog: %v", err)
+	}
+	if string(content) != "* Unreleased\n* 1.0.0\n" {
+		t.Fatalf("expected the configured template's layout, got:\n%s", content)
+	}
+}
+
+func TestChangelogAddVersion_RegistryTypes(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "CHANGELOG.md"), "# Changelog\n\n## [Unreleased]\n")
+	writeFile(t, filepath.Join(dir, ".storm.yaml"), "types:\n  - added\n  - name: perf\n    title: Performance\n")
+	t.Chdir(dir)
+
+	oldRepo, oldOutput, oldTypes := repoPath, output, changeTypes
+	t.Cleanup(func() {
+		repoPath, output, changeTypes = oldRepo, oldOutput, oldTypes
+	})
+	repoPath, output = dir, "CHANGELOG.md"
+	registry, err := changeset.NewRegistry([]changeset.ChangeType{{Name: "added"}, {Name: "perf", Title: "Performance"}})
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+	changeTypes = registry
+
+	cmd := changelogCmd()
+	cmd.SetArgs([]string{"add-version", "1.1.0", "--date", "2024-02-01", "--perf", "Faster startup"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("add-version failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "CHANGELOG.md"))
+	if err != nil {
+		t.Fatalf("failed to read changelog: %v", err)
+	}
+	if !strings.Contains(string(content), "### Performance\n\n- Faster startup") {
+		t.Fatalf("expected a Performance section, got:\n%s", content)
+	}
+
+	cmd = changelogCmd()
+	cmd.SetArgs([]string{"add-version", "1.2.0", "--date", "2024-03-01", "--fixed", "Not registered"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--fixed") {
+		t.Errorf("expected an error for an unregistered type flag, got %v", err)
+	}
+}
+
+func TestChangelogAddVersion_RepoConfigTypes(t *testing.T) {
+	dir := chdirTemp(t)
+	restoreGlobals(t)
+	project := filepath.Join(dir, "project")
+	if err := os.Mkdir(project, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(project, "CHANGELOG.md"), "# Changelog\n\n## [Unreleased]\n")
+	writeFile(t, filepath.Join(project, ".storm.yaml"), "types:\n  - added\n  - name: perf\n    title: Performance\n")
+
+	root := rootCmd()
+	root.SetArgs([]string{"changelog", "add-version", "1.1.0", "--repo", project, "--date", "2024-02-01", "--perf", "Faster startup"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("add-version failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(project, "CHANGELOG.md"))
+	if err != nil {
+		t.Fatalf("failed to read changelog: %v", err)
+	}
+	if !strings.Contains(string(content), "### Performance\n\n- Faster startup") {
+		t.Fatalf("expected the --repo config's Performance section, got:\n%s", content)
+	}
+}
@@ -2,10 +2,24 @@
 USAGE
 
 	storm check [from] [to] [options]
+	storm check [from] [to] --lint [--format json]
+	storm check --commit-msg <file>
+	storm check changelog [--date-format <layout>]
+	storm check changes [--metadata-dir <d>] [--no-metadata]
 
 FLAGS
 
 	--since <tag>       Check changes since the given tag
+	--metadata-dir <d>  Read dedup metadata from d instead of .changes/data
+	--no-metadata       Match commits using entry frontmatter only
+	--schema            Validate .changes/*.md frontmatter against the entry schema
+	--tags              Cross-check CHANGELOG.md versions against git tags
+	--lint              Lint commit messages in the range instead of checking entries
+	--commit-msg <file> Lint the commit message in file (for a commit-msg hook)
+	--format <fmt>      Lint report format: text (default) or json
+	--no-merges         Skip merge commits
+	--first-parent      Follow only the first parent of merges
+	--no-cache          Compute every diff hash instead of reading .changes/cache
 	--repo <path>       Path to the Git repository (default: .)
 
 # DESCRIPTION
@@ -16,10 +30,49 @@ document their changes.
 
 Commits containing [nochanges] or [skip changelog] in the message are skipped.
 
+With --schema, every entry's frontmatter is validated first and each violation
+(e.g. "summary is required") is reported. Without a range, only the schema is
+checked.
+
+With --tags, every released version in CHANGELOG.md must have a matching
+X.Y.Z or vX.Y.Z tag, and every version-like tag must have a changelog
+version. Both kinds of discrepancy are reported. Like --schema, it may be
+used without a range.
+
+A warning is printed when CHANGELOG.md lists versions out of semantic order.
+
+With --lint, each commit message in the range is checked against the
+conventional commit rules instead: subject format, allowed types and scopes,
+subject length, body line wrapping, and required footers. The rules come from
+the lint section of .storm.yaml. Merge and revert commits are not linted.
+
+With --commit-msg, the single message in file is linted after dropping the
+comment lines git strips, so storm can run as a commit-msg hook:
+
+	storm check --commit-msg "$1"
+
+--format json prints the lint report as JSON for CI tooling. Either way, a
+failing lint exits non-zero.
+
+# SUBCOMMANDS
+
+	changelog    Validate CHANGELOG.md against itself and the repository's tags
+	changes      Validate the unreleased entries in .changes
+
+storm check changelog fails when versions are out of semantic order, dates
+aren't ISO (YYYY-MM-DD, or the layout given with --date-format), a version is
+listed twice, a version lacks a tag or a version tag lacks a version, or
+version link references don't resolve.
+
+storm check changes fails when an entry's frontmatter doesn't parse, its type
+isn't a configured change type, or its summary is empty; when a metadata JSON
+file's entry no longer exists; or when an entry's commit_hash is unreachable
+from HEAD, e.g. after a rebase dropped the commit.
+
 Exit codes:
 
-	0 - All commits have changelog entries
-	1 - One or more commits are missing changelog entries
+	0 - All commits have changelog entries (or pass lint)
+	1 - One or more commits are missing changelog entries (or fail lint)
 	2 - Command execution error
 
 TODO(issue-linking): Support checking for issue numbers in entries when --issue flag is implemented in `unreleased partial`.
@@ -29,12 +82,21 @@ TODO(issue-linking): Support checking for issue numbers in entries when --issue
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
 
-	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/plumbing/object"
 	"github.com/spf13/cobra"
+	"github.com/stormlightlabs/git-storm/internal/changelog"
 	"github.com/stormlightlabs/git-storm/internal/changeset"
+	"github.com/stormlightlabs/git-storm/internal/config"
 	"github.com/stormlightlabs/git-storm/internal/gitlog"
 	"github.com/stormlightlabs/git-storm/internal/style"
 )
@@ -42,6 +104,15 @@ import (
 // checkCmd validates that all commits in a range have corresponding changelog entries.
 func checkCmd() *cobra.Command {
 	var sinceTag string
+	var metadataDir string
+	var noMetadata bool
+	var schema bool
+	var tags bool
+	var lint bool
+	var commitMsg string
+	var format string
+	var commitRange gitlog.RangeOptions
+	var noCache bool
 
 	c := &cobra.Command{
 		Use:   "check [from] [to]",
@@ -49,11 +120,39 @@ func checkCmd() *cobra.Command {
 		Long: `Checks that all commits in the specified range have corresponding
 .changes/*.md entries. Useful for CI enforcement.
 
-Commits with [nochanges] or [skip changelog] in their message are skipped.`,
+Commits with [nochanges] or [skip changelog] in their message are skipped.
+
+With --lint, commit messages in the range are linted against the project's
+conventional commit rules instead. With --commit-msg, a single message file is
+linted, for use as a commit-msg hook.`,
 		Args: cobra.MaximumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var from, to string
 
+			if format != checkFormatText && format != checkFormatJSON {
+				return fmt.Errorf("unknown --format %q: want %s or %s", format, checkFormatText, checkFormatJSON)
+			}
+			if commitMsg != "" {
+				return checkCommitMsg(commitMsg, format)
+			}
+
+			if schema {
+				if err := checkSchema(changesDir); err != nil {
+					return err
+				}
+			}
+			if tags {
+				if err := checkTags(repoPath, filepath.Join(repoPath, output)); err != nil {
+					return err
+				}
+			}
+			if schema || tags {
+				if sinceTag == "" && len(args) == 0 {
+					return nil
+				}
+				style.Newline()
+			}
+
 			if sinceTag != "" {
 				from = sinceTag
 				if len(args) > 0 {
@@ -67,23 +166,27 @@ Commits with [nochanges] or [skip changelog] in their message are skipped.`,
 				from, to = gitlog.ParseRefArgs(args)
 			}
 
-			repo, err := git.PlainOpen(repoPath)
+			repo, err := gitlog.OpenRepo(repoPath)
 			if err != nil {
 				return fmt.Errorf("failed to open repository: %w", err)
 			}
 
-			commits, err := gitlog.GetCommitRange(repo, from, to)
+			commits, err := gitlog.GetCommitRange(repo, from, to, commitRange)
 			if err != nil {
 				return err
 			}
 
+			if lint {
+				return lintCommits(commits, from, to, format)
+			}
+
 			if len(commits) == 0 {
 				style.Headlinef("No commits found between %s and %s", from, to)
 				return nil
 			}
 
-			changesDir := ".changes"
-			existingMetadata, err := changeset.LoadExistingMetadata(changesDir)
+			metaConfig := changeset.MetadataConfig{Dir: metadataDir, Disabled: noMetadata}
+			existingMetadata, err := metaConfig.Load(changesDir)
 			if err != nil {
 				return fmt.Errorf("failed to load existing metadata: %w", err)
 			}
@@ -91,7 +194,18 @@ Commits with [nochanges] or [skip changelog] in their message are skipped.`,
 			style.Headlinef("Checking %d commits between %s and %s", len(commits), from, to)
 			style.Newline()
 
+			if existing, err := changelog.ParseCached(filepath.Join(repoPath, output)); err == nil && !changelog.VersionsSorted(existing.Versions) {
+				style.Warningf("%s versions are out of order; expected newest first by semantic version", output)
+				style.Newline()
+			}
+
+			var cache *changeset.CommitCache
+			if !noCache {
+				cache = openCommitCache()
+			}
+
 			var missingEntries []string
+			var checked []*object.Commit
 			skippedCount := 0
 
 			for _, commit := range commits {
@@ -100,8 +214,12 @@ Commits with [nochanges] or [skip changelog] in their message are skipped.`,
 					skippedCount++
 					continue
 				}
+				checked = append(checked, commit)
+			}
 
-				diffHash, err := changeset.ComputeDiffHash(commit)
+			diffHashes, errs := cache.DiffHashes(checked, openRepoPath)
+			for i, commit := range checked {
+				diffHash, err := diffHashes[i], errs[i]
 				if err != nil {
 					style.Println("Warning: failed to compute diff hash for commit %s: %v", commit.Hash.String()[:7], err)
 					continue
@@ -114,6 +232,10 @@ Commits with [nochanges] or [skip changelog] in their message are skipped.`,
 				}
 			}
 
+			if err := cache.Save(); err != nil {
+				style.Println("Warning: %v", err)
+			}
+
 			if len(missingEntries) == 0 {
 				style.Addedf("✓ All commits have changelog entries")
 				if skippedCount > 0 {
@@ -122,7 +244,7 @@ Commits with [nochanges] or [skip changelog] in their message are skipped.`,
 				return nil
 			}
 
-			style.Println("%s", style.StyleRemoved.Render(fmt.Sprintf("✗ %d commits missing changelog entries:", len(missingEntries))))
+			style.Println("%s", style.Render(style.StyleRemoved, fmt.Sprintf("✗ %d commits missing changelog entries:", len(missingEntries))))
 			style.Newline()
 
 			for _, entry := range missingEntries {
@@ -140,5 +262,384 @@ Commits with [nochanges] or [skip changelog] in their message are skipped.`,
 	}
 
 	c.Flags().StringVar(&sinceTag, "since", "", "Check changes since the given tag")
+	c.Flags().StringVar(&metadataDir, "metadata-dir", "", "Directory for deduplication metadata (default: .changes/data)")
+	c.Flags().BoolVar(&noMetadata, "no-metadata", false, "Match commits using entry frontmatter only")
+	c.Flags().BoolVar(&schema, "schema", false, "Validate .changes/*.md frontmatter against the entry schema")
+	c.Flags().BoolVar(&tags, "tags", false, "Report changelog versions without git tags and version tags without changelog entries")
+	c.Flags().BoolVar(&lint, "lint", false, "Lint commit messages in the range instead of checking for entries")
+	c.Flags().StringVar(&commitMsg, "commit-msg", "", "Lint the commit message in this file, as a commit-msg hook")
+	c.Flags().StringVar(&format, "format", checkFormatText, "Lint report format: text or json")
+	c.Flags().BoolVar(&noCache, "no-cache", false, "Compute every commit's diff hash instead of reading .changes/cache")
+	addRangeFlags(c, &commitRange)
+	c.AddCommand(checkChangelogCmd(), checkChangesCmd())
 	return c
 }
+
+// checkChangesCmd validates the unreleased entries and their metadata.
+func checkChangesCmd() *cobra.Command {
+	var metadataDir string
+	var noMetadata bool
+
+	c := &cobra.Command{
+		Use:   "changes",
+		Short: "Validate unreleased entries and their metadata",
+		Long: `Validates every unreleased entry: its frontmatter must parse, its type must
+be a configured change type, and its summary must not be empty. Also reports
+metadata files whose entry was deleted and entries whose commit is no longer
+reachable from HEAD.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return checkChanges(repoPath, changesDir, changeset.MetadataConfig{Dir: metadataDir, Disabled: noMetadata})
+		},
+	}
+
+	c.Flags().StringVar(&metadataDir, "metadata-dir", "", "Directory for deduplication metadata (default: .changes/data)")
+	c.Flags().BoolVar(&noMetadata, "no-metadata", false, "Skip the metadata orphan check")
+	return c
+}
+
+// checkChangelogCmd validates CHANGELOG.md's versions, dates, and links, and
+// cross-checks its versions against the repository's tags.
+func checkChangelogCmd() *cobra.Command {
+	var dateFormat string
+
+	c := &cobra.Command{
+		Use:   "changelog",
+		Short: "Validate CHANGELOG.md versions, dates, tags, and links",
+		Long: `Parses CHANGELOG.md and fails when versions are out of semantic order,
+dates aren't YYYY-MM-DD (or --date-format), a version is listed twice, versions
+and version tags don't match up, or version link references don't resolve.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return checkChangelog(repoPath, filepath.Join(repoPath, output), dateFormat)
+		},
+	}
+
+	c.Flags().StringVar(&dateFormat, "date-format", "", "Go time layout version dates must follow (default: YYYY-MM-DD)")
+	return c
+}
+
+// checkSchema validates every entry in changesDir and reports each violation.
+func checkSchema(changesDir string) error {
+	if projectConfig.Format == config.FormatChangesets {
+		// Changesets have no storm frontmatter; they only need to parse.
+		if _, err := openStore(changesDir, "").List(); err != nil {
+			style.Println("%s", style.Render(style.StyleRemoved, "✗ "+err.Error()))
+			return fmt.Errorf("schema validation failed")
+		}
+		style.Addedf("✓ All entries match the schema")
+		return nil
+	}
+
+	results, err := changeset.ValidateDir(changesDir, changeTypes)
+	if err != nil {
+		return err
+	}
+
+	if len(results) == 0 {
+		style.Addedf("✓ All entries match the schema")
+		return nil
+	}
+
+	files := make([]string, 0, len(results))
+	for name := range results {
+		files = append(files, name)
+	}
+	sort.Strings(files)
+
+	style.Println("%s", style.Render(style.StyleRemoved, fmt.Sprintf("✗ %d entries violate the schema:", len(files))))
+	style.Newline()
+	for _, name := range files {
+		for _, v := range results[name] {
+			style.Println("  - %s: %s", name, v.Message)
+		}
+	}
+
+	return fmt.Errorf("schema validation failed")
+}
+
+// versionTagRegex matches release-like tags (X.Y.Z or vX.Y.Z, with optional
+// pre-release suffix) and captures the version.
+var versionTagRegex = regexp.MustCompile(`^v?(\d+\.\d+\.\d+\S*)$`)
+
+// tagDiscrepancies compares released changelog versions against tag names.
+// It returns versions lacking an X.Y.Z or vX.Y.Z tag, and version-like tags
+// lacking a changelog version. Non-version tags are ignored.
+func tagDiscrepancies(versions []changelog.Version, tags []string) (untagged, unlisted []string) {
+	tagged := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tagged[tag] = true
+	}
+
+	listed := make(map[string]bool, len(versions))
+	for _, v := range versions {
+		if strings.EqualFold(v.Number, "unreleased") {
+			continue
+		}
+		listed[v.Number] = true
+		if !tagged[v.Number] && !tagged["v"+v.Number] {
+			untagged = append(untagged, v.Number)
+		}
+	}
+
+	for _, tag := range tags {
+		if m := versionTagRegex.FindStringSubmatch(tag); m != nil && !listed[m[1]] {
+			unlisted = append(unlisted, tag)
+		}
+	}
+	return untagged, unlisted
+}
+
+// checkTags reports changelog versions without tags and tags without versions.
+func checkTags(repoDir, changelogPath string) error {
+	repo, err := gitlog.OpenRepo(repoDir)
+	if err != nil {
+		return fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	tags, err := gitlog.ListTags(repo)
+	if err != nil {
+		return err
+	}
+
+	existing, err := changelog.ParseCached(changelogPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse changelog: %w", err)
+	}
+
+	untagged, unlisted := tagDiscrepancies(existing.Versions, tags)
+	if len(untagged) == 0 && len(unlisted) == 0 {
+		style.Addedf("✓ Changelog versions and tags match")
+		return nil
+	}
+
+	if len(untagged) > 0 {
+		style.Println("%s", style.Render(style.StyleRemoved, fmt.Sprintf("✗ %d versions missing tags:", len(untagged))))
+		for _, v := range untagged {
+			style.Println("  - %s", v)
+		}
+	}
+	if len(unlisted) > 0 {
+		style.Println("%s", style.Render(style.StyleRemoved, fmt.Sprintf("✗ %d tags missing changelog entries:", len(unlisted))))
+		for _, tag := range unlisted {
+			style.Println("  - %s", tag)
+		}
+	}
+
+	return fmt.Errorf("tag validation failed")
+}
+
+// Report formats accepted by check --format.
+const (
+	checkFormatText = "text"
+	checkFormatJSON = "json"
+)
+
+// lintReport is the JSON form of a commit message lint.
+type lintReport struct {
+	From    string       `json:"from,omitempty"`
+	To      string       `json:"to,omitempty"`
+	Checked int          `json:"checked"`
+	Commits []lintResult `json:"commits"`
+}
+
+// lintResult lists the issues found in one commit message.
+type lintResult struct {
+	Hash    string             `json:"hash,omitempty"`
+	Subject string             `json:"subject"`
+	Issues  []gitlog.LintIssue `json:"issues"`
+}
+
+// lintCommits lints the message of every commit in from..to and reports the
+// ones breaking the project's rules.
+func lintCommits(commits []*object.Commit, from, to, format string) error {
+	rules := lintRules()
+	report := lintReport{From: from, To: to, Checked: len(commits), Commits: []lintResult{}}
+	for _, commit := range commits {
+		if issues := gitlog.LintMessage(commit.Message, rules); len(issues) > 0 {
+			report.Commits = append(report.Commits, lintResult{
+				Hash:    commit.Hash.String(),
+				Subject: strings.Split(commit.Message, "\n")[0],
+				Issues:  issues,
+			})
+		}
+	}
+
+	if format == checkFormatJSON {
+		if err := printLintReport(report); err != nil {
+			return err
+		}
+	} else if len(report.Commits) == 0 {
+		style.Addedf("✓ All %d commit messages pass lint", len(commits))
+	} else {
+		style.Println("%s", style.Render(style.StyleRemoved, fmt.Sprintf("✗ %d of %d commit messages fail lint:", len(report.Commits), len(commits))))
+		style.Newline()
+		for _, result := range report.Commits {
+			style.Println("  %s %s", result.Hash[:gitlog.ShaLen], result.Subject)
+			printLintIssues(result.Issues)
+		}
+	}
+
+	if len(report.Commits) > 0 {
+		return fmt.Errorf("commit lint failed")
+	}
+	return nil
+}
+
+// checkCommitMsg lints the commit message git left in path, the way a
+// commit-msg hook sees it.
+func checkCommitMsg(path, format string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read commit message: %w", err)
+	}
+	message := gitlog.CleanMessage(string(data))
+	issues := gitlog.LintMessage(message, lintRules())
+
+	if format == checkFormatJSON {
+		report := lintReport{Checked: 1, Commits: []lintResult{}}
+		if len(issues) > 0 {
+			report.Commits = append(report.Commits, lintResult{Subject: strings.Split(message, "\n")[0], Issues: issues})
+		}
+		if err := printLintReport(report); err != nil {
+			return err
+		}
+	} else if len(issues) == 0 {
+		style.Addedf("✓ Commit message passes lint")
+	} else {
+		style.Println("%s", style.Render(style.StyleRemoved, "✗ Commit message fails lint:"))
+		printLintIssues(issues)
+	}
+
+	if len(issues) > 0 {
+		return fmt.Errorf("commit lint failed")
+	}
+	return nil
+}
+
+// printLintIssues lists issues below a commit.
+func printLintIssues(issues []gitlog.LintIssue) {
+	for _, issue := range issues {
+		style.Println("    - %s: %s", issue.Rule, issue.Message)
+	}
+}
+
+// printLintReport writes report to stdout as indented JSON.
+func printLintReport(report lintReport) error {
+	jsonBytes, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal output to JSON: %w", err)
+	}
+	fmt.Println(string(jsonBytes))
+	return nil
+}
+
+// checkChanges reports invalid entries in changesDir, metadata files left
+// behind by deleted entries, and entries whose commit is unreachable from
+// HEAD in repoDir.
+func checkChanges(repoDir, changesDir string, metaConfig changeset.MetadataConfig) error {
+	var problems []string
+	entries, listErr := openStore(changesDir, "").List()
+	if projectConfig.Format == config.FormatChangesets {
+		// Changesets have no storm frontmatter; they only need to parse.
+		if listErr != nil {
+			problems = append(problems, listErr.Error())
+		}
+	} else {
+		results, err := changeset.ValidateDir(changesDir, changeTypes)
+		if err != nil {
+			return err
+		}
+		for _, name := range slices.Sorted(maps.Keys(results)) {
+			for _, v := range results[name] {
+				problems = append(problems, fmt.Sprintf("%s: %s", name, v.Message))
+			}
+		}
+		if listErr != nil && len(results) == 0 {
+			problems = append(problems, listErr.Error())
+		}
+	}
+
+	orphans, err := metaConfig.Orphans(changesDir)
+	if err != nil {
+		return err
+	}
+	for _, name := range orphans {
+		problems = append(problems, fmt.Sprintf("metadata %s belongs to no entry", name))
+	}
+
+	repo, err := gitlog.OpenRepo(repoDir)
+	if err != nil {
+		return fmt.Errorf("failed to open repository: %w", err)
+	}
+	history, err := allCommits(repo)
+	if err != nil {
+		return err
+	}
+	reachable := make([]string, len(history))
+	for i, commit := range history {
+		reachable[i] = commit.Hash.String()
+	}
+	for _, e := range entries {
+		hash := strings.ToLower(e.Entry.CommitHash)
+		if hash == "" {
+			continue
+		}
+		if !slices.ContainsFunc(reachable, func(full string) bool { return strings.HasPrefix(full, hash) }) {
+			problems = append(problems, fmt.Sprintf("%s: commit %s is not reachable from HEAD", e.Filename, hash[:min(len(hash), gitlog.ShaLen)]))
+		}
+	}
+
+	if len(problems) == 0 {
+		style.Addedf("✓ All %d entries are valid", len(entries))
+		return nil
+	}
+
+	style.Println("%s", style.Render(style.StyleRemoved, fmt.Sprintf("✗ %d problems in %s:", len(problems), changesDir)))
+	for _, problem := range problems {
+		style.Println("  - %s", problem)
+	}
+	return fmt.Errorf("changes validation failed")
+}
+
+// checkChangelog reports every inconsistency in the changelog at
+// changelogPath, including mismatches with the tags in repoDir.
+func checkChangelog(repoDir, changelogPath, dateFormat string) error {
+	if _, err := os.Stat(changelogPath); err != nil {
+		return fmt.Errorf("failed to read changelog: %w", err)
+	}
+	existing, err := changelog.ParseCached(changelogPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse changelog: %w", err)
+	}
+
+	repo, err := gitlog.OpenRepo(repoDir)
+	if err != nil {
+		return fmt.Errorf("failed to open repository: %w", err)
+	}
+	tags, err := gitlog.ListTags(repo)
+	if err != nil {
+		return err
+	}
+
+	problems := changelog.Validate(existing, dateFormat)
+	untagged, unlisted := tagDiscrepancies(existing.Versions, tags)
+	for _, v := range untagged {
+		problems = append(problems, fmt.Sprintf("version %s has no tag", v))
+	}
+	for _, tag := range unlisted {
+		problems = append(problems, fmt.Sprintf("tag %s has no changelog version", tag))
+	}
+
+	name := filepath.Base(changelogPath)
+	if len(problems) == 0 {
+		style.Addedf("✓ %s is consistent (%d versions)", name, len(existing.Versions))
+		return nil
+	}
+
+	style.Println("%s", style.Render(style.StyleRemoved, fmt.Sprintf("✗ %d problems in %s:", len(problems), name)))
+	for _, problem := range problems {
+		style.Println("  - %s", problem)
+	}
+	return fmt.Errorf("changelog validation failed")
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/git-storm/internal/changelog"
+	"github.com/stormlightlabs/git-storm/internal/changeset"
+	"github.com/stormlightlabs/git-storm/internal/config"
+	"github.com/stormlightlabs/git-storm/internal/gitlog"
+	"github.com/stormlightlabs/git-storm/internal/style"
+	"github.com/stormlightlabs/git-storm/internal/testutils"
+)
+
+func TestTagDiscrepancies(t *testing.T) {
+	versions := []changelog.Version{
+		{Number: "Unreleased", Date: "Unreleased"},
+		{Number: "1.2.0", Date: "2025-03-01"},
+		{Number: "1.1.0", Date: "2025-02-01"},
+		{Number: "1.0.0", Date: "2025-01-01"},
+	}
+	tags := []string{"1.0.0", "nightly", "v1.1.0", "v1.3.0", "v2.0.0-rc.1"}
+
+	untagged, unlisted := tagDiscrepancies(versions, tags)
+	testutils.Expect.Equal(t, untagged, []string{"1.2.0"})
+	testutils.Expect.Equal(t, unlisted, []string{"v1.3.0", "v2.0.0-rc.1"})
+}
+
+func TestCheckTags(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+	dir := worktree.Filesystem.Root()
+	changelogPath := filepath.Join(dir, "CHANGELOG.md")
+
+	writeFile(t, changelogPath, `# Changelog
+
+## [1.1.0] - 2025-02-01
+
+### Added
+
+- Second
+
+## [1.0.0] - 2025-01-01
+
+### Added
+
+- First
+`)
+	testutils.CreateTag(t, repo, "v1.0.0")
+	testutils.CreateTag(t, repo, "v1.1.0")
+
+	if err := checkTags(dir, changelogPath); err != nil {
+		t.Fatalf("checkTags() with matching tags error = %v", err)
+	}
+
+	testutils.CreateTag(t, repo, "v0.9.0")
+	if err := repo.DeleteTag("v1.1.0"); err != nil {
+		t.Fatalf("Failed to delete tag: %v", err)
+	}
+
+	if err := checkTags(dir, changelogPath); err == nil {
+		t.Fatal("checkTags() expected error for a missing tag and an unlisted tag")
+	}
+}
+
+func TestLintCommits(t *testing.T) {
+	restoreGlobals(t)
+	projectConfig = config.Config{Categories: map[string]string{"deps": "changed"}}
+
+	repo := testutils.SetupTestRepo(t)
+	testutils.CreateTag(t, repo, "v1.0.0")
+	testutils.AddCommit(t, repo, "a.txt", "a", "feat(cli): add lint mode")
+	testutils.AddCommit(t, repo, "b.txt", "b", "deps: bump go-git")
+	testutils.AddCommit(t, repo, "c.txt", "c", "Added stuff")
+
+	commits, err := gitlog.GetCommitRange(repo, "v1.0.0", "HEAD", gitlog.RangeOptions{})
+	if err != nil {
+		t.Fatalf("GetCommitRange() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	style.SetWriter(&buf)
+	defer style.SetWriter(nil)
+
+	if err := lintCommits(commits, "v1.0.0", "HEAD", checkFormatText); err == nil {
+		t.Fatal("lintCommits() expected an error for a non-conventional subject")
+	}
+	out := buf.String()
+	testutils.Expect.True(t, strings.Contains(out, "1 of 3 commit messages fail lint"), out)
+	testutils.Expect.True(t, strings.Contains(out, "Added stuff"), out)
+	testutils.Expect.True(t, strings.Contains(out, gitlog.LintSubjectFormat+":"), out)
+	testutils.Expect.False(t, strings.Contains(out, "bump go-git"), "types mapped in categories are allowed")
+}
+
+func TestCheckCommitMsg(t *testing.T) {
+	restoreGlobals(t)
+	projectConfig = config.Config{}
+	dir := t.TempDir()
+
+	var buf bytes.Buffer
+	style.SetWriter(&buf)
+	defer style.SetWriter(nil)
+
+	valid := filepath.Join(dir, "COMMIT_EDITMSG")
+	writeFile(t, valid, "fix: handle empty input\n\n# Please enter the commit message for your changes.\n# Lines starting with '#' will be ignored, and an empty message aborts the commit.\n")
+	if err := checkCommitMsg(valid, checkFormatText); err != nil {
+		t.Fatalf("checkCommitMsg() error = %v\n%s", err, buf.String())
+	}
+
+	projectConfig.Lint = config.Lint{RequiredFooters: []string{"Signed-off-by"}}
+	buf.Reset()
+	if err := checkCommitMsg(valid, checkFormatText); err == nil {
+		t.Fatal("checkCommitMsg() expected an error for a missing footer")
+	}
+	testutils.Expect.True(t, strings.Contains(buf.String(), "missing required Signed-off-by footer"), buf.String())
+}
+
+func TestCheckChangelog(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+	dir := worktree.Filesystem.Root()
+	changelogPath := filepath.Join(dir, "CHANGELOG.md")
+	testutils.CreateTag(t, repo, "v1.0.0")
+	testutils.CreateTag(t, repo, "v1.1.0")
+
+	var buf bytes.Buffer
+	style.SetWriter(&buf)
+	defer style.SetWriter(nil)
+
+	writeFile(t, changelogPath, `# Changelog
+
+## [1.1.0] - 2025-02-01
+
+## [1.0.0] - 2025-01-01
+
+[1.1.0]: https://github.com/acme/app/compare/v1.0.0...v1.1.0
+[1.0.0]: https://github.com/acme/app/releases/tag/v1.0.0
+`)
+	if err := checkChangelog(dir, changelogPath, ""); err != nil {
+		t.Fatalf("checkChangelog() error = %v\n%s", err, buf.String())
+	}
+
+	writeFile(t, changelogPath, `# Changelog
+
+## [1.0.0] - 2025-01-01
+
+## [1.2.0] - 2025-02-30
+`)
+	buf.Reset()
+	if err := checkChangelog(dir, changelogPath, ""); err == nil {
+		t.Fatal("checkChangelog() expected an error for an inconsistent changelog")
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"version 1.2.0 has date",
+		"version 1.2.0 is listed below older version 1.0.0",
+		"version 1.2.0 has no tag",
+		"tag v1.1.0 has no changelog version",
+	} {
+		testutils.Expect.True(t, strings.Contains(out, want), want)
+	}
+}
+
+func TestCheckChanges(t *testing.T) {
+	restoreGlobals(t)
+	projectConfig = config.Config{}
+
+	repo := testutils.SetupTestRepo(t)
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+	dir := worktree.Filesystem.Root()
+	changes := filepath.Join(dir, ".changes")
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("Failed to get HEAD: %v", err)
+	}
+
+	var buf bytes.Buffer
+	style.SetWriter(&buf)
+	defer style.SetWriter(nil)
+
+	if _, err := changeset.Write(changes, changeset.Entry{Type: "added", Summary: "Valid entry", CommitHash: head.Hash().String()}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := checkChanges(dir, changes, changeset.MetadataConfig{}); err != nil {
+		t.Fatalf("checkChanges() error = %v\n%s", err, buf.String())
+	}
+
+	if _, err := changeset.Write(changes, changeset.Entry{Type: "fixed", Summary: "Rebased away", CommitHash: "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	writeFile(t, filepath.Join(changes, "bad.md"), "---\ntype: improved\nsummary: \"\"\n---\n")
+	gone := changeset.Metadata{DiffHash: "gone111111111111111111111111111111111111111111111111111111111111", Type: "fixed", Summary: "Gone"}
+	path, err := changeset.WriteWithMetadata(changes, gone)
+	if err != nil {
+		t.Fatalf("WriteWithMetadata() error = %v", err)
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("Failed to remove entry: %v", err)
+	}
+
+	buf.Reset()
+	if err := checkChanges(dir, changes, changeset.MetadataConfig{}); err == nil {
+		t.Fatal("checkChanges() expected an error for invalid entries")
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"bad.md: ",
+		"metadata " + gone.DiffHash + ".json belongs to no entry",
+		"commit deadbee is not reachable from HEAD",
+	} {
+		testutils.Expect.True(t, strings.Contains(out, want), want)
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stormlightlabs/git-storm/internal/changelog"
+	"github.com/stormlightlabs/git-storm/internal/changeset"
+	"github.com/stormlightlabs/git-storm/internal/config"
+	"github.com/stormlightlabs/git-storm/internal/gitlog"
+)
+
+// applyProjectConfig loads .storm.yaml or .storm.toml from the repository (or
+// the working directory when --repo is not given) and uses it for every
+// setting not passed explicitly on the command line.
+func applyProjectConfig(cmd *cobra.Command) error {
+	dir := "."
+	if cmd.Flags().Changed("repo") {
+		dir = repoPath
+	}
+
+	cfg, err := config.Load(dir)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+	if cfg.Path != "" {
+		log.Debug("loaded project config", "path", cfg.Path)
+	}
+
+	if cfg.Repo != "" && !cmd.Flags().Changed("repo") {
+		repoPath = cfg.Repo
+	}
+	if cfg.Output != "" && !cmd.Flags().Changed("output") {
+		output = cfg.Output
+	}
+	// The config's changes directory is relative to the config file, so it
+	// resolves the same with --repo as from inside the repository.
+	if cfg.ChangesDir != "" {
+		changesDir = cfg.ChangesDir
+		if !filepath.IsAbs(changesDir) {
+			changesDir = filepath.Join(filepath.Dir(cfg.Path), changesDir)
+		}
+	} else if cfg.Format == config.FormatChangesets {
+		changesDir = filepath.Join(filepath.Dir(cfg.Path), ".changeset")
+	}
+
+	types := cfg.Types
+	if cmd.Flags().Changed("types") {
+		if types, err = changeset.ParseTypes(typeFlags); err != nil {
+			return err
+		}
+	}
+	registry, err := changeset.NewRegistry(types)
+	if err != nil {
+		return fmt.Errorf("invalid change types: %w", err)
+	}
+
+	changeTypes = registry
+	projectConfig = cfg
+	return nil
+}
+
+// flagTypes returns the change types that get a flag of their own, such as
+// changelog add-version's --added: the defaults followed by any other types
+// given by --types or the project config in the --repo directory.
+//
+// The type flags have to exist before args are parsed, so only --repo and
+// --types are read from args here, skipping anything else. Commands still
+// check values against the active changeTypes when they run, and a config
+// that fails to load is reported by applyProjectConfig.
+func flagTypes(args []string) []changeset.ChangeType {
+	dir, typeArgs := ".", []string(nil)
+	flags := pflag.NewFlagSet("types", pflag.ContinueOnError)
+	flags.ParseErrorsAllowlist.UnknownFlags = true
+	flags.SetOutput(io.Discard)
+	flags.StringVar(&dir, "repo", dir, "")
+	flags.StringSliceVar(&typeArgs, "types", nil, "")
+	flags.Parse(args)
+
+	types := changeset.DefaultRegistry().Types()
+	var registry *changeset.Registry
+	var err error
+	if len(typeArgs) > 0 {
+		var parsed []changeset.ChangeType
+		if parsed, err = changeset.ParseTypes(typeArgs); err == nil {
+			registry, err = changeset.NewRegistry(parsed)
+		}
+	} else {
+		var cfg config.Config
+		if cfg, err = config.Load(dir); err == nil {
+			registry, err = cfg.Registry()
+		}
+	}
+	if err != nil {
+		return types
+	}
+	for _, t := range registry.Types() {
+		if !slices.ContainsFunc(types, func(d changeset.ChangeType) bool { return d.Name == t.Name }) {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// applyConfiguredHeader replaces ch's preamble with the configured header, if any.
+func applyConfiguredHeader(ch *changelog.Changelog) {
+	if projectConfig.Header != "" {
+		ch.Header = projectConfig.Header
+	}
+}
+
+// changelogTemplate reads the CHANGELOG.md template at path, falling back to
+// the configured template. Empty means the built-in layout.
+func changelogTemplate(path string) (string, error) {
+	if path == "" {
+		path = projectConfig.Template
+	}
+	if path == "" {
+		return "", nil
+	}
+	tmpl, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read changelog template: %w", err)
+	}
+	return string(tmpl), nil
+}
+
+// writeOptions returns the options for rewriting CHANGELOG.md outside of a
+// release: the change types, configured hosts, and configured template.
+func writeOptions() (changelog.Options, error) {
+	tmpl, err := changelogTemplate("")
+	if err != nil {
+		return changelog.Options{}, err
+	}
+	return changelog.Options{Types: changeTypes, Hosts: configuredHosts(), Template: tmpl}, nil
+}
+
+// scopeMapping returns the path prefix to scope mapping used when inferring
+// scopes: the config's scope_map with the --scope-map pairs layered on top.
+func scopeMapping(pairs []string) (map[string]string, error) {
+	mapping, err := parseScopeMap(pairs)
+	if err != nil {
+		return nil, err
+	}
+	for prefix, scope := range projectConfig.ScopeMap {
+		if _, ok := mapping[prefix]; !ok {
+			mapping[prefix] = scope
+		}
+	}
+	return mapping, nil
+}
+
+// configuredHosts returns the project's self-hosted forges for changelog
+// links. Parsing the config already validated them.
+func configuredHosts() map[string]changelog.Forge {
+	hosts, _ := projectConfig.Forges()
+	return hosts
+}
+
+// newConventionalParser returns a parser using the project's category mapping.
+func newConventionalParser() *gitlog.ConventionalParser {
+	return &gitlog.ConventionalParser{Categories: projectConfig.Categories}
+}
+
+// lintRules returns the project's commit message rules. Without configured
+// types, the conventional types and any types mapped in categories are allowed.
+func lintRules() gitlog.LintRules {
+	lint := projectConfig.Lint
+	types := lint.Types
+	if len(types) == 0 {
+		types = slices.Clone(gitlog.ConventionalTypes)
+		for _, name := range slices.Sorted(maps.Keys(projectConfig.Categories)) {
+			if !slices.Contains(types, name) {
+				types = append(types, name)
+			}
+		}
+	}
+	return gitlog.LintRules{
+		Types:             types,
+		Scopes:            lint.Scopes,
+		MaxSubjectLength:  lint.MaxSubjectLength,
+		MaxBodyLineLength: lint.MaxBodyLineLength,
+		RequiredFooters:   lint.RequiredFooters,
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/git-storm/internal/changeset"
+	"github.com/stormlightlabs/git-storm/internal/gitlog"
+	"github.com/stormlightlabs/git-storm/internal/style"
+	"github.com/stormlightlabs/git-storm/internal/testutils"
+)
+
+// restoreGlobals resets the globals a root command run may change.
+func restoreGlobals(t *testing.T) {
+	t.Helper()
+	oldRepo, oldOutput, oldChanges, oldConfig := repoPath, output, changesDir, projectConfig
+	oldTypeFlags, oldTypes := typeFlags, changeTypes
+	t.Cleanup(func() {
+		repoPath, output, changesDir, projectConfig = oldRepo, oldOutput, oldChanges, oldConfig
+		typeFlags, changeTypes = oldTypeFlags, oldTypes
+	})
+}
+
+func TestApplyProjectConfig(t *testing.T) {
+	dir := chdirTemp(t)
+	restoreGlobals(t)
+	writeFile(t, filepath.Join(dir, ".storm.yaml"), `output: NOTES.md
+changes_dir: changes
+types: [added, docs]
+header: |
+  # Project Notes
+categories:
+  docs: docs
+`)
+
+	var buf bytes.Buffer
+	style.SetWriter(&buf)
+	defer style.SetWriter(nil)
+
+	root := rootCmd()
+	root.SetArgs([]string{"unreleased", "add", "--type", "docs", "--summary", "Document config"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unreleased add error = %v", err)
+	}
+
+	testutils.Expect.Equal(t, output, "NOTES.md")
+	testutils.Expect.Equal(t, changesDir, "changes")
+	testutils.Expect.Equal(t, projectConfig.Path, filepath.Join(".", ".storm.yaml"))
+	testutils.Expect.Equal(t, newConventionalParser().Categorize(gitlog.CommitMeta{Type: "docs"}), "docs")
+
+	entries, err := changeset.List("changes")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 1, "entries should be written to the configured changes_dir")
+
+	root = rootCmd()
+	root.SetArgs([]string{"unreleased", "add", "--type", "fixed", "--summary", "Not configured"})
+	root.SilenceUsage, root.SilenceErrors = true, true
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "must be one of added, docs") {
+		t.Errorf("types outside the config should be rejected, got %v", err)
+	}
+
+	root = rootCmd()
+	root.SetArgs([]string{"release", "--version", "1.0.0", "--date", "2025-01-01", "--clear-changes"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("release error = %v", err)
+	}
+	content, err := os.ReadFile("NOTES.md")
+	if err != nil {
+		t.Fatalf("configured output should be written: %v", err)
+	}
+	testutils.Expect.True(t, strings.HasPrefix(string(content), "# Project Notes\n\n## [1.0.0] - 2025-01-01"), string(content))
+}
+
+func TestApplyProjectConfig_FlagsWin(t *testing.T) {
+	dir := chdirTemp(t)
+	restoreGlobals(t)
+	writeFile(t, filepath.Join(dir, ".storm.toml"), "output = \"NOTES.md\"\n")
+
+	root := rootCmd()
+	root.SetArgs([]string{"--output", "CHANGES.md", "version"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("version error = %v", err)
+	}
+	testutils.Expect.Equal(t, output, "CHANGES.md", "an explicit --output should override the config")
+	testutils.Expect.Equal(t, projectConfig.Output, "NOTES.md")
+}
+
+func TestApplyProjectConfig_ChangesetsFormat(t *testing.T) {
+	dir := chdirTemp(t)
+	restoreGlobals(t)
+	writeFile(t, filepath.Join(dir, ".storm.yaml"), "format: changesets\n")
+
+	var buf bytes.Buffer
+	style.SetWriter(&buf)
+	defer style.SetWriter(nil)
+
+	root := rootCmd()
+	root.SetArgs([]string{"unreleased", "add", "--type", "added", "--scope", "@acme/ui", "--summary", "Add a dark theme"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unreleased add error = %v", err)
+	}
+	testutils.Expect.Equal(t, changesDir, ".changeset")
+
+	content, err := os.ReadFile(filepath.Join(".changeset", "add-a-dark-theme.md"))
+	if err != nil {
+		t.Fatalf("expected a changeset file: %v", err)
+	}
+	testutils.Expect.Equal(t, string(content), "---\n\"@acme/ui\": minor\n---\n\nAdd a dark theme\n")
+}
+
+func TestApplyProjectConfig_RelativeChangesDir(t *testing.T) {
+	dir := chdirTemp(t)
+	restoreGlobals(t)
+	project := filepath.Join(dir, "project")
+	if err := os.Mkdir(project, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(project, ".storm.yaml"), "changes_dir: changes\n")
+
+	var buf bytes.Buffer
+	style.SetWriter(&buf)
+	defer style.SetWriter(nil)
+
+	root := rootCmd()
+	root.SetArgs([]string{"--repo", project, "unreleased", "add", "--type", "added", "--summary", "Add a flag"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unreleased add error = %v", err)
+	}
+	testutils.Expect.Equal(t, changesDir, filepath.Join(project, "changes"), "changes_dir should resolve against the config's directory")
+
+	entries, err := changeset.List(filepath.Join(project, "changes"))
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 1)
+}
+
+func TestApplyProjectConfig_Invalid(t *testing.T) {
+	dir := chdirTemp(t)
+	restoreGlobals(t)
+	writeFile(t, filepath.Join(dir, ".storm.yaml"), "outptu: NOTES.md\n")
+
+	root := rootCmd()
+	root.SetArgs([]string{"version"})
+	root.SilenceUsage, root.SilenceErrors = true, true
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "failed to load project config") {
+		t.Errorf("expected config error, got %v", err)
+	}
+	testutils.Expect.Equal(t, projectConfig.Path, "", "a failed load should leave the config untouched")
+}
+
+func TestTypesFlag(t *testing.T) {
+	dir := chdirTemp(t)
+	restoreGlobals(t)
+	writeFile(t, filepath.Join(dir, ".storm.yaml"), "types: [added, fixed]\n")
+
+	var buf bytes.Buffer
+	style.SetWriter(&buf)
+	defer style.SetWriter(nil)
+
+	root := rootCmd()
+	root.SetArgs([]string{"--types", "perf=Performance,added", "unreleased", "add", "--type", "perf", "--summary", "Cache diffs"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unreleased add error = %v", err)
+	}
+	testutils.Expect.Equal(t, strings.Join(changeTypes.Names(), ","), "perf,added", "--types should override the config")
+
+	if _, err := changeset.Write(changesDir, changeset.Entry{Type: "added", Summary: "Add cache"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	root = rootCmd()
+	root.SetArgs([]string{"--types", "perf=Performance,added", "release", "--version", "1.0.0", "--date", "2025-01-01", "--clear-changes"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("release error = %v", err)
+	}
+	content, err := os.ReadFile("CHANGELOG.md")
+	if err != nil {
+		t.Fatalf("Failed to read changelog: %v", err)
+	}
+	testutils.Expect.True(t, strings.Contains(string(content), "### Performance\n\n- Cache diffs\n\n### Added\n\n- Add cache"), string(content))
+
+	if _, err := changeset.Write(changesDir, changeset.Entry{Type: "security", Summary: "Patch leak"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	root = rootCmd()
+	root.SetArgs([]string{"release", "--version", "1.1.0", "--date", "2025-02-01"})
+	root.SilenceUsage, root.SilenceErrors = true, true
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), `invalid type "security"`) {
+		t.Errorf("release should reject types outside the registry, got %v", err)
+	}
+}
@@ -7,6 +7,11 @@ SYNOPSIS
 
 	storm diff <from>..<to> [options]
 	storm diff <from> <to>   [options]
+	storm diff <ref>         [options]
+	storm diff --commit <ref> [--stat-only] [options]
+	storm diff <from>..<to> --format patch [--out <file>] [--file <path>]
+	storm diff <from>..<to> --plain [--color <auto|always|never>] [--out <file>]
+	storm diff <from>..<to> --stat [--json]
 
 DESCRIPTION
 
@@ -20,20 +25,104 @@ DESCRIPTION
 
 	If --file is not specified, storm shows all changed files with pagination.
 
+	The pseudo-refs WORKTREE and INDEX stand for the files on disk and the
+	staging area, so uncommitted and staged changes can be viewed too:
+	  • storm diff HEAD            HEAD against the working tree (like git diff HEAD)
+	  • storm diff WORKTREE        unstaged changes (like git diff)
+	  • storm diff INDEX           staged changes (like git diff --cached)
+	The longer forms INDEX WORKTREE and HEAD INDEX work too. Untracked files
+	are not listed.
+
+	Unstaged and staged changes can be staged or unstaged hunk by hunk, as
+	with git add -p and git reset -p: ‘]’/‘[’ select a hunk of the current
+	file and ‘s’ writes it to the index. Staging is unavailable for binary
+	and renamed files and while -w, -b, --ignore-blank-lines, -I, or --hex
+	hide or rewrite changes. Files added or deleted as a whole are still
+	staged with git add and git rm.
+
 	By default, large blocks of unchanged lines are compressed. Use --expanded
-	to show all lines, or toggle this interactively with ‘e’ in the TUI.
+	to show all lines, or toggle this interactively with ‘e’ in the TUI. The
+	global --no-compress flag makes expansion the default for every diff view.
+	Press ‘v’ in the TUI to switch between split and unified views, ‘tab’ to
+	focus the file tree sidebar, and ‘t’ to show or hide it.
+	Press ‘/’ to search, then ‘n’/‘N’ to jump between matches, and ‘]’/‘[’ to
+	jump to the next or previous hunk.
+
+	Use --ignore-matching-lines <regex> to treat changes whose added and removed
+	lines all match the pattern as unchanged, like git's -I.
+
+	Use --ignore-whitespace (-w), --ignore-space-change (-b), and
+	--ignore-blank-lines to suppress formatting-only changes in the TUI, plain
+	output, and diffstats, like their git counterparts. Patches are unaffected.
+
+	Use --align-replacements to pair the deleted and inserted lines of each
+	changed block positionally, so modified blocks render as aligned rows.
+
+	Use --format patch (or --patch) to print a single unified patch covering
+	every changed file (or just --file), suitable for one `git apply`. Add
+	--out <file> to write it to a file instead of stdout.
+
+	Use --plain (or --no-tui, or --format plain) to print the split or unified
+	rendering without the TUI, as happens anyway when stdin or stdout is not a
+	terminal. --color auto, the default, keeps ANSI colors only when writing to
+	a terminal and NO_COLOR and --no-color are unset; always and never force it.
+
+	Use --stat to print only the diffstat for a range or --commit. Add --json
+	for {files: [{path, added, removed, changed}], totals: {...}}, ordered by
+	path, for CI annotations and size checks.
+
+	Use --blame to annotate added and changed lines in the split view with the
+	short hash and author of the commit that introduced them. Blame walks the
+	file's history, so it is off by default.
+
+	Use --compare-algorithms with --file to run every diff algorithm over the
+	file and report per-algorithm edit counts instead of rendering the diff.
+
+	Use --commit <ref> to diff a single commit against its first parent (or an
+	empty tree for the root commit). A diffstat of the changed files is printed
+	first; --stat-only stops there instead of opening the diff.
+
+	Files whose diff exceeds --max-edits edits are rendered as hunks only, with
+	a warning. Use --full to render them in full anyway.
+
+	Binary files, those with a null byte near the start or invalid UTF-8, are
+	not split into lines. They show "Binary files differ (size X → Y)" in the
+	viewers, "Bin X -> Y bytes" in diffstats, and git's "Binary files ...
+	differ" line in patches. Use --hex to diff hex dumps of them instead.
+
+	A deleted and an added file whose contents are at least --find-renames
+	percent similar (default 50, 0 disables) are paired as a rename and the new
+	file is diffed against the old one. The viewer's file tree shows them as
+	"old → new", diffstats as "old => new", and patches with git's rename
+	headers. --find-copies also pairs added files with the modified or deleted
+	files they were copied from.
+
+	Use --theme-from-git to color additions, deletions, and context lines with
+	the color.diff.new, color.diff.old, and color.diff.context (or plain)
+	settings from git config. Unset or unrecognized values keep storm's colors.
 */
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/utils/merkletrie"
+	"github.com/muesli/termenv"
 	"github.com/spf13/cobra"
 	"github.com/stormlightlabs/git-storm/internal/diff"
 	"github.com/stormlightlabs/git-storm/internal/gitlog"
+	"github.com/stormlightlabs/git-storm/internal/style"
 	"github.com/stormlightlabs/git-storm/internal/tty"
 	"github.com/stormlightlabs/git-storm/internal/ui"
 )
@@ -42,9 +131,29 @@ func diffCmd() *cobra.Command {
 	var filePath string
 	var expanded bool
 	var viewName string
+	var full bool
+	var maxEdits int
+	var ignorePattern string
+	var compareAlgorithms bool
+	var themeFromGit bool
+	var hexDump bool
+	var findRenames int
+	var findCopies bool
+	var whitespace diff.Whitespace
+	var alignReplacements bool
+	var commitRef string
+	var statOnly bool
+	var blame bool
+	var patch bool
+	var format string
+	var outPath string
+	var plain bool
+	var colorName string
+	var stat bool
+	var statJSON bool
 
 	c := &cobra.Command{
-		Use:   "diff <from>..<to> | diff <from> <to>",
+		Use:   "diff <from>..<to> | diff <from> <to> | diff <ref> | diff --commit <ref>",
 		Short: "Show a line-based diff between two commits or tags",
 		Long: `Displays an inline diff (added/removed/unchanged lines) between two refs.
 
@@ -52,90 +161,633 @@ Supports multiple input formats:
   - Range syntax: commit1..commit2
   - Separate args: commit1 commit2
   - Truncated hashes: 7de6f6d..18363c2
+  - A single ref: diffs it against the working tree
+
+WORKTREE and INDEX name the files on disk and the staging area, e.g.
+"storm diff WORKTREE" (INDEX..WORKTREE) for unstaged changes or
+"storm diff INDEX" (HEAD..INDEX) for staged ones. In those views, ']' and
+'[' select a hunk and 's' stages or unstages it, like git add -p.
 
 If --file is not specified, shows all changed files with pagination.
 
 By default, large blocks of unchanged lines are compressed. Use --expanded
-to show all lines. You can also toggle this with 'e' in the TUI.`,
-		Args: cobra.RangeArgs(1, 2),
+to show all lines. You can also toggle this with 'e' in the TUI. The global
+--no-compress flag changes the default; --expanded=false overrides it.
+Press 'v' in the TUI to switch between split and unified views. Press 'tab'
+to browse changed files in a tree grouped by directory, and 't' to show or
+hide it; the tree opens on its own for changesets of more than 10 files.
+Press '/' to search the diff and 'n'/'N' to jump between matches. Press ']'
+and '[' to jump to the next and previous hunk.
+
+Use --commit to show a single commit's diffstat and changes; add --stat-only
+to print just the diffstat.
+
+Use --ignore-matching-lines to hide changes whose lines all match a regex.
+Use -w/--ignore-whitespace, -b/--ignore-space-change, and --ignore-blank-lines
+to hide formatting-only changes.
+Use --align-replacements to render modified blocks as aligned rows.
+Use --format patch (or --patch) to print one git-applyable patch for all
+changed files, and --out to write it to a file.
+Use --plain (or --no-tui) to print the rendered diff without the TUI, for
+scripts and CI logs; --color always|never overrides color detection.
+Use --stat to print only the diffstat; add --json for machine-readable output.
+Use --blame to annotate changed lines with the commit and author that
+introduced them.
+Use --theme-from-git to reuse the color.diff.* colors from git config.
+Binary files are summarized by size; use --hex to diff hex dumps of them.
+Deleted and added files at least --find-renames percent similar (default 50)
+are shown as renames, "old → new"; --find-copies also detects copies.
+
+Diffs larger than --max-edits edits fall back to showing hunks only.
+Use --full to render them in full.`,
+		Args: cobra.RangeArgs(0, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if commitRef == "" && len(args) == 0 {
+				return fmt.Errorf("requires a <from>..<to> range or --commit <ref>")
+			}
+			if commitRef != "" && len(args) > 0 {
+				return fmt.Errorf("--commit cannot be combined with a ref range")
+			}
+			if statOnly && commitRef == "" {
+				return fmt.Errorf("--stat-only requires --commit")
+			}
+			if statJSON && !stat && !statOnly {
+				return fmt.Errorf("--json requires --stat")
+			}
+
 			from, to := gitlog.ParseRefArgs(args)
+			if len(args) == 1 && !strings.Contains(args[0], "..") {
+				from, to = singleRefRange(args[0])
+			}
+			if blame && gitlog.IsPseudoRef(to) {
+				return fmt.Errorf("--blame requires a commit as the new side, not %s", to)
+			}
+			renames := gitlog.RenameOptions{Threshold: findRenames, Copies: findCopies}
+			if findRenames <= 0 {
+				renames.Threshold = -1
+			}
+			if stat || (statOnly && statJSON) {
+				return runStat(from, to, commitRef, statJSON, renames, whitespace, os.Stdout)
+			}
+			if patch {
+				format = diffFormatPatch
+			}
+			if plain {
+				format = diffFormatPlain
+			}
+			if format != diffFormatTUI && format != diffFormatPlain && format != diffFormatPatch {
+				return fmt.Errorf("unknown --format %q: want %s, %s, or %s", format, diffFormatTUI, diffFormatPlain, diffFormatPatch)
+			}
+			if outPath != "" && format == diffFormatTUI {
+				return fmt.Errorf("--out requires --format %s or %s", diffFormatPlain, diffFormatPatch)
+			}
+			colorMode, err := style.ParseColorMode(colorName)
+			if err != nil {
+				return err
+			}
+			if format == diffFormatPatch {
+				return writeDiffOutput(outPath, func(w io.Writer) error {
+					return runPatch(from, to, commitRef, filePath, renames, w)
+				})
+			}
+			if compareAlgorithms {
+				if filePath == "" {
+					return fmt.Errorf("--compare-algorithms requires --file")
+				}
+				return runCompareAlgorithms(from, to, filePath)
+			}
+
 			viewKind, err := parseDiffView(viewName)
 			if err != nil {
 				return err
 			}
-			return runDiff(from, to, filePath, expanded, viewKind)
+			if !cmd.Flags().Changed("expanded") {
+				expanded = noCompress
+			}
+			renderOpts := ui.RenderOptions{LargeDiffThreshold: maxEdits, Force: full, AlignReplacements: alignReplacements, Expanded: expanded, HexDump: hexDump, Whitespace: whitespace}
+			if ignorePattern != "" {
+				re, err := regexp.Compile(ignorePattern)
+				if err != nil {
+					return fmt.Errorf("invalid --ignore-matching-lines pattern: %w", err)
+				}
+				renderOpts.IgnoreMatchingLines = re
+			}
+			if themeFromGit {
+				theme, err := gitDiffTheme(repoPath)
+				if err != nil {
+					return err
+				}
+				renderOpts.Theme = theme
+			}
+			out := plainOutput{force: format == diffFormatPlain, color: colorMode}
+			return writeDiffOutput(outPath, func(w io.Writer) error {
+				if commitRef != "" {
+					return runCommitDiff(commitRef, statOnly, expanded, blame, viewKind, renderOpts, renames, out, w)
+				}
+				return runDiff(from, to, filePath, expanded, blame, viewKind, renderOpts, renames, out, w)
+			})
 		},
 	}
 
 	c.Flags().StringVarP(&filePath, "file", "f", "", "Specific file to diff (optional, shows all files if omitted)")
+	c.Flags().StringVar(&commitRef, "commit", "", "Diff a single commit against its first parent")
+	c.Flags().BoolVar(&statOnly, "stat-only", false, "With --commit, print only the diffstat")
+	c.Flags().BoolVar(&stat, "stat", false, "Print only the diffstat for every changed file")
+	c.Flags().BoolVar(&statJSON, "json", false, "With --stat, print the diffstat as JSON")
 	c.Flags().BoolVarP(&expanded, "expanded", "e", false, "Show all unchanged lines (disable compression)")
 	c.Flags().StringVarP(&viewName, "view", "v", "split", "Diff rendering: split or unified")
+	c.Flags().BoolVar(&full, "full", false, "Render large diffs in full instead of hunks only")
+	c.Flags().StringVarP(&ignorePattern, "ignore-matching-lines", "I", "", "Ignore changes whose lines all match the regex")
+	c.Flags().BoolVar(&alignReplacements, "align-replacements", false, "Pair changed lines positionally into aligned replace rows")
+	c.Flags().BoolVar(&patch, "patch", false, "Print a unified patch for every changed file (or --file) instead of rendering")
+	c.Flags().StringVar(&format, "format", diffFormatTUI, "Output format: tui, plain (rendered text), or patch (a git-applyable unified patch)")
+	c.Flags().StringVar(&outPath, "out", "", "Write --format output to this file instead of stdout")
+	c.Flags().BoolVar(&plain, "plain", false, "Print the rendered diff without the TUI (same as --format plain)")
+	c.Flags().BoolVar(&plain, "no-tui", false, "Alias for --plain")
+	c.Flags().StringVar(&colorName, "color", "auto", "Color plain output: auto, always, or never")
+	c.Flags().BoolVar(&blame, "blame", false, "Annotate added and changed lines with the commit and author that introduced them (split view)")
+	c.Flags().BoolVar(&compareAlgorithms, "compare-algorithms", false, "Compare edit scripts from every diff algorithm (requires --file)")
+	c.Flags().IntVar(&maxEdits, "max-edits", diff.DefaultLargeDiffThreshold, "Edit count above which only hunks are rendered (negative disables)")
+	c.Flags().BoolVarP(&whitespace.IgnoreAll, "ignore-whitespace", "w", false, "Ignore all whitespace when comparing lines")
+	c.Flags().BoolVarP(&whitespace.IgnoreChange, "ignore-space-change", "b", false, "Ignore changes in the amount of whitespace")
+	c.Flags().BoolVar(&whitespace.IgnoreBlankLines, "ignore-blank-lines", false, "Ignore changes whose lines are all blank")
+	c.Flags().IntVar(&findRenames, "find-renames", gitlog.DefaultRenameThreshold, "Similarity percentage for pairing deleted and added files as renames (0 disables)")
+	c.Flags().BoolVar(&findCopies, "find-copies", false, "Also detect added files copied from modified or deleted ones")
+	c.Flags().BoolVar(&hexDump, "hex", false, "Diff binary files as hex dumps instead of summarizing them")
+	c.Flags().BoolVar(&themeFromGit, "theme-from-git", false, "Color additions, deletions, and context using git's color.diff.* config")
 
 	return c
 }
 
+// singleRefRange returns the range diffed for a lone ref: WORKTREE shows
+// unstaged changes (INDEX..WORKTREE) and INDEX staged ones (HEAD..INDEX),
+// like git diff and git diff --cached. Any other ref is diffed against the
+// working tree.
+func singleRefRange(ref string) (from, to string) {
+	switch ref {
+	case gitlog.RefWorktree:
+		return gitlog.RefIndex, gitlog.RefWorktree
+	case gitlog.RefIndex:
+		return "HEAD", gitlog.RefIndex
+	}
+	return ref, gitlog.RefWorktree
+}
+
+// diffStaging returns the hunk staging the TUI offers for fromRef..toRef:
+// staging for INDEX..WORKTREE and unstaging for HEAD..INDEX. Other ranges,
+// and diffs that ignore some changes or replace binary content with hex
+// dumps, can't be staged and get the zero [ui.Staging].
+func diffStaging(repo *git.Repository, fromRef, toRef string, renderOpts ui.RenderOptions) ui.Staging {
+	if renderOpts.Whitespace != (diff.Whitespace{}) || renderOpts.IgnoreMatchingLines != nil || renderOpts.HexDump {
+		return ui.Staging{}
+	}
+	write := func(path, content string) error {
+		return gitlog.StageContent(repo, path, content)
+	}
+	switch {
+	case fromRef == gitlog.RefIndex && toRef == gitlog.RefWorktree:
+		return ui.Staging{Write: write}
+	case fromRef == "HEAD" && toRef == gitlog.RefIndex:
+		return ui.Staging{Unstage: true, Write: write}
+	}
+	return ui.Staging{}
+}
+
 // runDiff executes the diff command by reading file contents from two git refs and launching the TUI.
-func runDiff(fromRef, toRef, filePath string, expanded bool, view diff.DiffViewKind) error {
-	repo, err := git.PlainOpen(repoPath)
+//
+// Without filePath, every file changed between the refs is shown, one page per
+// file, with renames and copies detected using renames. When blame is set,
+// changed lines are annotated with git blame for toRef. Without a terminal,
+// the diffs are written to w as plain text. In the TUI, hunks of
+// INDEX..WORKTREE can be staged and hunks of HEAD..INDEX unstaged.
+func runDiff(fromRef, toRef, filePath string, expanded, blame bool, view diff.DiffViewKind, renderOpts ui.RenderOptions, renames gitlog.RenameOptions, out plainOutput, w io.Writer) error {
+	repo, err := gitlog.OpenRepo(repoPath)
 	if err != nil {
 		return fmt.Errorf("failed to open repository: %w", err)
 	}
 
-	var filesToDiff []string
+	var changes []gitlog.FileChange
 	if filePath != "" {
-		filesToDiff = []string{filePath}
+		change, err := gitlog.GetFileChange(repo, fromRef, toRef, filePath)
+		if err != nil {
+			return err
+		}
+		changes = []gitlog.FileChange{change}
 	} else {
-		filesToDiff, err = gitlog.GetChangedFiles(repo, fromRef, toRef)
+		changes, err = rangeFileChanges(repo, fromRef, toRef)
 		if err != nil {
-			return fmt.Errorf("failed to get changed files: %w", err)
+			return err
 		}
-		if len(filesToDiff) == 0 {
-			fmt.Println("No files changed between", fromRef, "and", toRef)
+		if len(changes) == 0 {
+			fmt.Fprintln(w, "No files changed between", fromRef, "and", toRef)
 			return nil
 		}
+		changes = gitlog.DetectRenames(changes, renames)
+	}
+
+	allDiffs := make([]ui.FileDiff, 0, len(changes))
+	for _, change := range changes {
+		fileDiff := changeFileDiff(change, fromRef, toRef, renderOpts)
+		if blame && change.NewContent != "" && !fileDiff.Binary {
+			fileDiff.Blame = blameAnnotations(repo, toRef, change.Path)
+		}
+		allDiffs = append(allDiffs, fileDiff)
 	}
 
-	allDiffs := make([]ui.FileDiff, 0, len(filesToDiff))
+	if !out.useTUI() {
+		return outputPlainDiff(w, allDiffs, expanded, view, renderOpts, out.colored(w))
+	}
 
-	for _, file := range filesToDiff {
-		oldContent, err := gitlog.GetFileContent(repo, fromRef, file)
-		if err != nil {
-			oldContent = ""
+	model := ui.NewMultiFileDiffModelWithStaging(allDiffs, expanded, view, renderOpts, diffStaging(repo, fromRef, toRef, renderOpts))
+
+	p := tea.NewProgram(model, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("TUI failed: %w", err)
+	}
+
+	return nil
+}
+
+// changeFileDiff builds the viewer's diff for a change between fromRef and
+// toRef, labelling the old side with the path a renamed file came from.
+func changeFileDiff(change gitlog.FileChange, fromRef, toRef string, renderOpts ui.RenderOptions) ui.FileDiff {
+	oldPath := change.Path
+	if change.OldPath != "" {
+		oldPath = change.OldPath
+	}
+	fileDiff := ui.NewFileDiff(fromRef+":"+oldPath, toRef+":"+change.Path, change.Path, change.OldContent, change.NewContent, renderOpts)
+	fileDiff.From, fileDiff.Copied, fileDiff.Similarity = change.OldPath, change.Copied, change.Similarity
+	fileDiff.Created, fileDiff.Deleted = change.Action == merkletrie.Insert, change.Action == merkletrie.Delete
+	return fileDiff
+}
+
+// fileDiffStat pairs a changed path with its line counts. Binary files have
+// no line counts; their sizes are reported instead. OldPath is set for
+// renamed and copied files.
+type fileDiffStat struct {
+	Path    string
+	OldPath string
+	diff.Stat
+	Binary           bool
+	OldSize, NewSize int
+}
+
+// name returns the path shown in a diffstat row, "old => new" for renamed
+// and copied files as in git's --stat.
+func (st fileDiffStat) name() string {
+	if st.OldPath == "" {
+		return st.Path
+	}
+	return st.OldPath + " => " + st.Path
+}
+
+// runCommitDiff prints a diffstat for a single commit and, unless statOnly is
+// set, shows its changed files like [runDiff].
+func runCommitDiff(ref string, statOnly, expanded, blame bool, view diff.DiffViewKind, renderOpts ui.RenderOptions, renames gitlog.RenameOptions, out plainOutput, w io.Writer) error {
+	repo, err := gitlog.OpenRepo(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	changes, err := gitlog.GetCommitFileChanges(repo, ref)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "No files changed in", ref)
+		return nil
+	}
+	changes = gitlog.DetectRenames(changes, renames)
+
+	stats, err := commitDiffStats(changes, renderOpts.Whitespace)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(w, formatDiffStat(stats))
+
+	if statOnly {
+		return nil
+	}
+	fmt.Fprintln(w)
+
+	allDiffs := make([]ui.FileDiff, 0, len(changes))
+	for _, change := range changes {
+		fileDiff := changeFileDiff(change, ref+"^", ref, renderOpts)
+		if blame && change.NewContent != "" && !fileDiff.Binary {
+			fileDiff.Blame = blameAnnotations(repo, ref, change.Path)
 		}
+		allDiffs = append(allDiffs, fileDiff)
+	}
 
-		newContent, err := gitlog.GetFileContent(repo, toRef, file)
+	if !out.useTUI() {
+		return outputPlainDiff(w, allDiffs, expanded, view, renderOpts, out.colored(w))
+	}
+
+	p := tea.NewProgram(ui.NewMultiFileDiffModelWithOptions(allDiffs, expanded, view, renderOpts), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("TUI failed: %w", err)
+	}
+	return nil
+}
+
+// rangeFileChanges returns both sides of every file changed between fromRef
+// and toRef, ordered by path.
+func rangeFileChanges(repo *git.Repository, fromRef, toRef string) ([]gitlog.FileChange, error) {
+	files, err := gitlog.GetChangedFiles(repo, fromRef, toRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changed files: %w", err)
+	}
+	sort.Strings(files)
+
+	changes := make([]gitlog.FileChange, 0, len(files))
+	for _, file := range files {
+		change, err := gitlog.GetFileChange(repo, fromRef, toRef, file)
 		if err != nil {
-			newContent = ""
+			return nil, err
 		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// Output formats accepted by diff --format.
+const (
+	diffFormatTUI   = "tui"
+	diffFormatPlain = "plain"
+	diffFormatPatch = "patch"
+)
+
+// plainOutput controls how diffs are written without the TUI. The zero value
+// uses the TUI on a terminal and colors plain output automatically.
+type plainOutput struct {
+	force bool            // Write plain output even when the TUI could run
+	color style.ColorMode // Auto colors terminals unless NO_COLOR or --no-color is set
+}
+
+// useTUI reports whether the interactive viewer should run.
+func (o plainOutput) useTUI() bool {
+	return !o.force && tty.IsInteractive()
+}
+
+// colored reports whether plain output written to w keeps its ANSI styling.
+func (o plainOutput) colored(w io.Writer) bool {
+	switch o.color {
+	case style.ColorAlways:
+		return true
+	case style.ColorNever:
+		return false
+	}
+	f, ok := w.(*os.File)
+	return ok && tty.IsTTY(f.Fd()) && !style.Plain()
+}
+
+// writeDiffOutput runs write against the file at path, created or truncated,
+// or against stdout when path is empty.
+func writeDiffOutput(path string, write func(io.Writer) error) error {
+	if path == "" {
+		return write(os.Stdout)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
 
-		oldLines := strings.Split(oldContent, "\n")
-		newLines := strings.Split(newContent, "\n")
+// runPatch writes a single git-applyable patch covering every file changed
+// between fromRef and toRef, or in commitRef when set, or only filePath when
+// set. A root commit is diffed against the empty tree. Renames and copies
+// detected using renames are written as such.
+func runPatch(fromRef, toRef, commitRef, filePath string, renames gitlog.RenameOptions, w io.Writer) error {
+	repo, err := gitlog.OpenRepo(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to open repository: %w", err)
+	}
 
-		myers := &diff.Myers{}
-		edits, err := myers.Compute(oldLines, newLines)
+	var changes []gitlog.FileChange
+	if commitRef != "" {
+		if changes, err = gitlog.GetCommitFileChanges(repo, commitRef); err != nil {
+			return err
+		}
+		if filePath != "" {
+			changes = slices.DeleteFunc(changes, func(c gitlog.FileChange) bool { return c.Path != filePath })
+			if len(changes) == 0 {
+				return fmt.Errorf("%s does not change %s", commitRef, filePath)
+			}
+		} else {
+			changes = gitlog.DetectRenames(changes, renames)
+		}
+	} else if filePath != "" {
+		change, err := gitlog.GetFileChange(repo, fromRef, toRef, filePath)
 		if err != nil {
-			return fmt.Errorf("diff computation failed for %s: %w", file, err)
+			return err
 		}
+		changes = []gitlog.FileChange{change}
+	} else if changes, err = rangeFileChanges(repo, fromRef, toRef); err != nil {
+		return err
+	} else {
+		changes = gitlog.DetectRenames(changes, renames)
+	}
 
-		allDiffs = append(allDiffs, ui.FileDiff{
-			Edits:   edits,
-			OldPath: fromRef + ":" + file,
-			NewPath: toRef + ":" + file,
+	patches := make([]diff.FilePatch, 0, len(changes))
+	for _, change := range changes {
+		patches = append(patches, diff.FilePatch{
+			Path:       change.Path,
+			OldContent: change.OldContent,
+			NewContent: change.NewContent,
+			OldPath:    change.OldPath,
+			Copied:     change.Copied,
+			Similarity: change.Similarity,
+			Created:    change.Action == merkletrie.Insert,
+			Deleted:    change.Action == merkletrie.Delete,
 		})
 	}
 
-	if !tty.IsInteractive() {
-		return outputPlainDiff(allDiffs, expanded, view)
+	out, err := diff.FormatMultiPatch(patches)
+	if err != nil {
+		return err
 	}
+	_, err = io.WriteString(w, out)
+	return err
+}
 
-	model := ui.NewMultiFileDiffModel(allDiffs, expanded, view)
+// DiffStatOutput is the JSON shape printed by diff --stat --json.
+type DiffStatOutput struct {
+	Files  []DiffStatFile `json:"files"`
+	Totals DiffStatTotals `json:"totals"`
+}
 
-	p := tea.NewProgram(model, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
-		return fmt.Errorf("TUI failed: %w", err)
+// DiffStatFile holds the line counts for one changed file. Changed is the
+// sum of added and removed lines, as in git's --stat. Binary files report
+// zero counts, and renamed or copied files their source in OldPath.
+type DiffStatFile struct {
+	Path    string `json:"path"`
+	OldPath string `json:"old_path,omitempty"`
+	Added   int    `json:"added"`
+	Removed int    `json:"removed"`
+	Changed int    `json:"changed"`
+	Binary  bool   `json:"binary,omitempty"`
+}
+
+// DiffStatTotals sums [DiffStatFile] counts across every changed file.
+type DiffStatTotals struct {
+	Files   int `json:"files"`
+	Added   int `json:"added"`
+	Removed int `json:"removed"`
+	Changed int `json:"changed"`
+}
+
+// newDiffStatOutput converts per-file stats into the JSON output structure.
+func newDiffStatOutput(stats []fileDiffStat) DiffStatOutput {
+	out := DiffStatOutput{Files: make([]DiffStatFile, 0, len(stats))}
+	for _, st := range stats {
+		file := DiffStatFile{Path: st.Path, OldPath: st.OldPath, Added: st.Added, Removed: st.Removed, Changed: st.Added + st.Removed, Binary: st.Binary}
+		out.Files = append(out.Files, file)
+		out.Totals.Added += file.Added
+		out.Totals.Removed += file.Removed
+		out.Totals.Changed += file.Changed
+	}
+	out.Totals.Files = len(out.Files)
+	return out
+}
+
+// runStat prints the diffstat for every file changed between fromRef and
+// toRef, or in commitRef when set, as text or, when asJSON is set, as a
+// [DiffStatOutput]. Files are ordered by path, with renames and copies
+// detected using renames.
+func runStat(fromRef, toRef, commitRef string, asJSON bool, renames gitlog.RenameOptions, ws diff.Whitespace, w io.Writer) error {
+	repo, err := gitlog.OpenRepo(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	var changes []gitlog.FileChange
+	if commitRef != "" {
+		changes, err = gitlog.GetCommitFileChanges(repo, commitRef)
+	} else {
+		changes, err = rangeFileChanges(repo, fromRef, toRef)
+	}
+	if err != nil {
+		return err
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	changes = gitlog.DetectRenames(changes, renames)
+
+	stats, err := commitDiffStats(changes, ws)
+	if err != nil {
+		return err
+	}
+
+	if !asJSON {
+		_, err = io.WriteString(w, formatDiffStat(stats))
+		return err
+	}
+
+	jsonBytes, err := json.MarshalIndent(newDiffStatOutput(stats), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal output to JSON: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(jsonBytes))
+	return err
+}
+
+// blameAnnotations returns blame annotations for path at ref, warning and
+// returning nil when blame can't be computed.
+func blameAnnotations(repo *git.Repository, ref, path string) []string {
+	lines, err := gitlog.BlameFile(repo, ref, path)
+	if err != nil {
+		style.Warningf("Skipping blame for %s: %v", path, err)
+		return nil
+	}
+	return gitlog.BlameAnnotations(lines)
+}
+
+// commitDiffStats counts added and removed lines for each changed file,
+// ignoring the whitespace differences ws selects, or records the sizes of
+// binary ones.
+func commitDiffStats(changes []gitlog.FileChange, ws diff.Whitespace) ([]fileDiffStat, error) {
+	stats := make([]fileDiffStat, 0, len(changes))
+	for _, change := range changes {
+		if diff.IsBinary(change.OldContent) || diff.IsBinary(change.NewContent) {
+			stats = append(stats, fileDiffStat{Path: change.Path, OldPath: change.OldPath, Binary: true, OldSize: len(change.OldContent), NewSize: len(change.NewContent)})
+			continue
+		}
+		edits, err := diff.IgnoreWhitespace(&diff.Myers{}, ws).Compute(statLines(change.OldContent), statLines(change.NewContent))
+		if err != nil {
+			return nil, fmt.Errorf("diff computation failed for %s: %w", change.Path, err)
+		}
+		stats = append(stats, fileDiffStat{Path: change.Path, OldPath: change.OldPath, Stat: diff.DiffStat(edits)})
+	}
+	return stats, nil
+}
+
+// statLines splits content into lines without counting a trailing newline as an
+// extra empty line, so empty files contribute no lines.
+func statLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
+
+// formatDiffStat renders stats like git's --stat: one row per file plus a summary line.
+func formatDiffStat(stats []fileDiffStat) string {
+	width := 0
+	for _, st := range stats {
+		width = max(width, len(st.name()))
 	}
 
+	var b strings.Builder
+	var added, removed int
+	for _, st := range stats {
+		added += st.Added
+		removed += st.Removed
+		if st.Binary {
+			fmt.Fprintf(&b, " %-*s | Bin %d -> %d bytes\n", width, st.name(), st.OldSize, st.NewSize)
+			continue
+		}
+		fmt.Fprintf(&b, " %-*s | %4d %s%s\n", width, st.name(), st.Added+st.Removed,
+			style.Render(style.StyleAdded, strings.Repeat("+", min(st.Added, 40))),
+			style.Render(style.StyleRemoved, strings.Repeat("-", min(st.Removed, 40))))
+	}
+	noun := "files"
+	if len(stats) == 1 {
+		noun = "file"
+	}
+	fmt.Fprintf(&b, " %d %s changed, %d insertions(+), %d deletions(-)\n", len(stats), noun, added, removed)
+	return b.String()
+}
+
+// runCompareAlgorithms prints a per-algorithm summary of the edits for a single file.
+func runCompareAlgorithms(fromRef, toRef, filePath string) error {
+	repo, err := gitlog.OpenRepo(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	oldContent, err := gitlog.GetFileContentOrEmpty(repo, fromRef, filePath)
+	if err != nil {
+		return err
+	}
+
+	newContent, err := gitlog.GetFileContentOrEmpty(repo, toRef, filePath)
+	if err != nil {
+		return err
+	}
+
+	results := diff.CompareAlgorithms(strings.Split(oldContent, "\n"), strings.Split(newContent, "\n"), diff.Algorithms())
+
+	style.Headlinef("Comparing diff algorithms for %s (%s..%s)", filePath, fromRef, toRef)
+	style.Newline()
+	style.Println("%s", diff.FormatComparison(results))
 	return nil
 }
 
@@ -150,41 +802,103 @@ func parseDiffView(viewName string) (diff.DiffViewKind, error) {
 	}
 }
 
-// outputPlainDiff outputs diffs in plain text format for non-interactive environments.
+// outputPlainDiff writes diffs to w in plain text format for non-interactive
+// environments. Without color, ANSI styling is stripped from the rendering.
 //
 // TODO: move this to package [diff]
-func outputPlainDiff(allDiffs []ui.FileDiff, expanded bool, view diff.DiffViewKind) error {
-	for i, fileDiff := range allDiffs {
-		fmt.Printf("=== File %d/%d ===\n", i+1, len(allDiffs))
-		fmt.Printf("--- %s\n", fileDiff.OldPath)
-		fmt.Printf("+++ %s\n", fileDiff.NewPath)
-		fmt.Println()
-
-		var formatter diff.Formatter
-		switch view {
-		case diff.ViewUnified:
-			formatter = &diff.UnifiedFormatter{
-				TerminalWidth:   80,
-				ShowLineNumbers: true,
-				Expanded:        expanded,
-				EnableWordWrap:  false,
-			}
-		default:
-			formatter = &diff.SideBySideFormatter{
-				TerminalWidth:   80,
-				ShowLineNumbers: true,
-				Expanded:        expanded,
-				EnableWordWrap:  false,
-			}
-		}
-
-		output := formatter.Format(fileDiff.Edits)
-		fmt.Println(output)
+func outputPlainDiff(w io.Writer, allDiffs []ui.FileDiff, expanded bool, view diff.DiffViewKind, renderOpts ui.RenderOptions, color bool) error {
+	if color && lipgloss.ColorProfile() == termenv.Ascii {
+		lipgloss.SetColorProfile(termenv.ANSI256)
+	}
+
+	width := tty.Width(os.Stdout.Fd())
+	for i := range allDiffs {
+		if err := allDiffs[i].EnsureEdits(); err != nil {
+			return err
+		}
+		fileDiff := allDiffs[i]
+
+		fmt.Fprintf(w, "=== File %d/%d ===\n", i+1, len(allDiffs))
+		fmt.Fprintf(w, "--- %s\n", fileDiff.OldPath)
+		fmt.Fprintf(w, "+++ %s\n", fileDiff.NewPath)
+		fmt.Fprintln(w)
+
+		if fileDiff.Binary {
+			fmt.Fprintln(w, fileDiff.Summary())
+			if i < len(allDiffs)-1 {
+				fmt.Fprintln(w)
+			}
+			continue
+		}
+
+		formatter := plainFormatter(view, expanded, renderOpts, width)
+		if sideBySide, ok := formatter.(*diff.SideBySideFormatter); ok {
+			sideBySide.Annotations = fileDiff.Blame
+		}
+
+		edits := fileDiff.Edits
+		if renderOpts.UseHunksOnly(edits) {
+			style.Warningf("Large diff (%d edits): showing hunks only, use --full to render everything", len(edits))
+			edits = diff.HunksOnly(edits, 3)
+		}
+
+		output := formatter.Format(edits)
+		if !color {
+			output = ansi.Strip(output)
+		}
+		fmt.Fprintln(w, output)
 
 		if i < len(allDiffs)-1 {
-			fmt.Println()
+			fmt.Fprintln(w)
 		}
 	}
 
 	return nil
 }
+
+// gitDiffTheme builds a theme from the color.diff.* config visible to the
+// repository at path. It returns nil, selecting the built-in styles, when no
+// diff colors are configured.
+func gitDiffTheme(path string) (*diff.Theme, error) {
+	repo, err := gitlog.OpenRepo(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+	colors, err := gitlog.DiffColors(repo)
+	if err != nil {
+		return nil, err
+	}
+	if len(colors) == 0 {
+		return nil, nil
+	}
+	theme := diff.ThemeFromGitColors(colors)
+	return &theme, nil
+}
+
+// plainFormatter builds the formatter used for non-interactive output at the given width.
+func plainFormatter(view diff.DiffViewKind, expanded bool, renderOpts ui.RenderOptions, width int) diff.Formatter {
+	switch view {
+	case diff.ViewUnified:
+		return &diff.UnifiedFormatter{
+			TerminalWidth:       width,
+			ShowLineNumbers:     true,
+			Expanded:            expanded,
+			EnableWordWrap:      false,
+			IgnoreMatchingLines: renderOpts.IgnoreMatchingLines,
+			AlignReplacements:   renderOpts.AlignReplacements,
+			MinUnchangedToHide:  renderOpts.UnifiedMinUnchanged,
+			Theme:               renderOpts.Theme,
+		}
+	default:
+		return &diff.SideBySideFormatter{
+			TerminalWidth:       width,
+			ShowLineNumbers:     true,
+			Expanded:            expanded,
+			EnableWordWrap:      false,
+			IgnoreMatchingLines: renderOpts.IgnoreMatchingLines,
+			AlignReplacements:   renderOpts.AlignReplacements,
+			MinUnchangedToHide:  renderOpts.SplitMinUnchanged,
+			Theme:               renderOpts.Theme,
+		}
+	}
+}
@@ -0,0 +1,681 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stormlightlabs/git-storm/internal/diff"
+	"github.com/stormlightlabs/git-storm/internal/gitlog"
+	"github.com/stormlightlabs/git-storm/internal/style"
+	"github.com/stormlightlabs/git-storm/internal/testutils"
+	"github.com/stormlightlabs/git-storm/internal/tty"
+	"github.com/stormlightlabs/git-storm/internal/ui"
+)
+
+func TestCommitDiffStats(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.AddCommit(t, repo, "a.txt", "hello world\nsee you later\nand more", "feat: reword goodbye")
+
+	t.Run("known commit", func(t *testing.T) {
+		changes, err := gitlog.GetCommitFileChanges(repo, "HEAD")
+		if err != nil {
+			t.Fatalf("GetCommitFileChanges() error = %v", err)
+		}
+
+		stats, err := commitDiffStats(changes, diff.Whitespace{})
+		if err != nil {
+			t.Fatalf("commitDiffStats() error = %v", err)
+		}
+		testutils.Expect.Equal(t, stats, []fileDiffStat{{Path: "a.txt", Stat: diff.Stat{Added: 2, Removed: 1}}})
+	})
+
+	t.Run("root commit", func(t *testing.T) {
+		commits := testutils.GetCommitHistory(t, repo)
+		changes, err := gitlog.GetCommitFileChanges(repo, commits[len(commits)-1].Hash.String())
+		if err != nil {
+			t.Fatalf("GetCommitFileChanges() error = %v", err)
+		}
+
+		stats, err := commitDiffStats(changes, diff.Whitespace{})
+		if err != nil {
+			t.Fatalf("commitDiffStats() error = %v", err)
+		}
+		testutils.Expect.Equal(t, stats, []fileDiffStat{{Path: "README.md", Stat: diff.Stat{Added: 3}}})
+
+		summary := formatDiffStat(stats)
+		testutils.Expect.True(t, strings.Contains(summary, "README.md |    3"), summary)
+		testutils.Expect.True(t, strings.Contains(summary, "1 file changed, 3 insertions(+), 0 deletions(-)"), summary)
+	})
+}
+
+func TestDiffCmd_CommitFlagValidation(t *testing.T) {
+	cmd := diffCmd()
+	cmd.SetArgs([]string{"--stat-only", "HEAD~1..HEAD"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--stat-only requires --commit") {
+		t.Errorf("Expected --stat-only validation error, got %v", err)
+	}
+
+	cmd = diffCmd()
+	cmd.SetArgs([]string{})
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error when neither a range nor --commit is given")
+	}
+}
+
+func TestPlainFormatter_Width(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+	defer f.Close()
+
+	t.Setenv("COLUMNS", "160")
+	split, ok := plainFormatter(diff.ViewSplit, false, ui.RenderOptions{}, tty.Width(f.Fd())).(*diff.SideBySideFormatter)
+	testutils.Expect.True(t, ok)
+	testutils.Expect.Equal(t, split.TerminalWidth, 160)
+
+	t.Setenv("COLUMNS", "")
+	unified, ok := plainFormatter(diff.ViewUnified, false, ui.RenderOptions{}, tty.Width(f.Fd())).(*diff.UnifiedFormatter)
+	testutils.Expect.True(t, ok)
+	testutils.Expect.Equal(t, unified.TerminalWidth, tty.DefaultWidth)
+}
+
+func TestBlameAnnotations(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.AddCommit(t, repo, "blame.txt", "one\ntwo\n", "feat: add blame file")
+	testutils.AddCommit(t, repo, "blame.txt", "one\nTWO\nthree\n", "fix: update blame file")
+	history := testutils.GetCommitHistory(t, repo)
+	first, second := history[1].Hash.String()[:7], history[0].Hash.String()[:7]
+
+	annotations := blameAnnotations(repo, "HEAD", "blame.txt")
+	testutils.Expect.Equal(t, len(annotations), 3)
+
+	file := ui.FileDiff{OldContent: "one\ntwo\n", NewContent: "one\nTWO\nthree\n", Blame: annotations}
+	if err := file.EnsureEdits(); err != nil {
+		t.Fatalf("EnsureEdits() error = %v", err)
+	}
+
+	formatter := plainFormatter(diff.ViewSplit, true, ui.RenderOptions{}, 160).(*diff.SideBySideFormatter)
+	formatter.Annotations = file.Blame
+	output := formatter.Format(file.Edits)
+
+	for line := range strings.SplitSeq(output, "\n") {
+		switch {
+		case strings.Contains(line, "three"):
+			testutils.Expect.True(t, strings.Contains(line, second), "added line should show its commit")
+		case strings.Contains(line, "one"):
+			testutils.Expect.False(t, strings.Contains(line, first), "unchanged lines are not annotated")
+		}
+	}
+	testutils.Expect.True(t, strings.Contains(output, second+" Test Author"))
+}
+
+func TestRunPatch_AppliesCleanly(t *testing.T) {
+	gitBin, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := testutils.SetupTestRepo(t)
+	testutils.AddFilesCommit(t, repo, map[string]string{
+		"a.txt":         "hello world\ngoodbye world\nagain",
+		"nested/new.go": "package nested\n",
+	}, "feat: touch several files")
+	history := testutils.GetCommitHistory(t, repo)
+	from := history[len(history)-1].Hash.String()
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	oldRepo := repoPath
+	repoPath = wt.Filesystem.Root()
+	t.Cleanup(func() { repoPath = oldRepo })
+
+	var buf bytes.Buffer
+	if err := runPatch(from, "HEAD", "", "", gitlog.RenameOptions{}, &buf); err != nil {
+		t.Fatalf("runPatch() error = %v", err)
+	}
+	patch := buf.String()
+
+	changed, err := gitlog.GetChangedFiles(repo, from, "HEAD")
+	if err != nil {
+		t.Fatalf("GetChangedFiles() error = %v", err)
+	}
+	testutils.Expect.True(t, len(changed) > 1, "expected several changed files")
+	for _, file := range changed {
+		testutils.Expect.True(t, strings.Contains(patch, "diff --git a/"+file+" b/"+file+"\n"), "missing header for "+file)
+	}
+
+	target := t.TempDir()
+	readme, err := gitlog.GetFileContent(repo, from, "README.md")
+	if err != nil {
+		t.Fatalf("GetFileContent() error = %v", err)
+	}
+	writeFile(t, filepath.Join(target, "README.md"), readme)
+	patchPath := filepath.Join(t.TempDir(), "changes.patch")
+	writeFile(t, patchPath, patch)
+
+	apply := exec.Command(gitBin, "apply", patchPath)
+	apply.Dir = target
+	if out, err := apply.CombinedOutput(); err != nil {
+		t.Fatalf("git apply failed: %v\n%s\npatch:\n%s", err, out, patch)
+	}
+
+	for _, file := range changed {
+		want, err := gitlog.GetFileContentOrEmpty(repo, "HEAD", file)
+		if err != nil {
+			t.Fatalf("GetFileContentOrEmpty() error = %v", err)
+		}
+		got, err := os.ReadFile(filepath.Join(target, file))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		testutils.Expect.Equal(t, string(got), want)
+	}
+}
+
+func TestRunPatch_EmptyFilesAndRootCommit(t *testing.T) {
+	gitBin, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := testutils.SetupTestRepo(t)
+	testutils.AddCommit(t, repo, "empty.txt", "", "chore: add empty file")
+	history := testutils.GetCommitHistory(t, repo)
+	root := history[len(history)-1].Hash.String()
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	oldRepo := repoPath
+	repoPath = wt.Filesystem.Root()
+	t.Cleanup(func() { repoPath = oldRepo })
+
+	var patch bytes.Buffer
+	if err := runPatch("", "", "HEAD", "", gitlog.RenameOptions{}, &patch); err != nil {
+		t.Fatalf("runPatch() error = %v", err)
+	}
+	testutils.Expect.Equal(t, patch.String(), "diff --git a/empty.txt b/empty.txt\nnew file mode 100644\n")
+
+	target := t.TempDir()
+	patchPath := filepath.Join(t.TempDir(), "empty.patch")
+	writeFile(t, patchPath, patch.String())
+	apply := exec.Command(gitBin, "apply", patchPath)
+	apply.Dir = target
+	if out, err := apply.CombinedOutput(); err != nil {
+		t.Fatalf("git apply failed: %v\n%s\npatch:\n%s", err, out, patch.String())
+	}
+	if _, err := os.Stat(filepath.Join(target, "empty.txt")); err != nil {
+		t.Errorf("the patch should create empty.txt: %v", err)
+	}
+
+	patch.Reset()
+	if err := runPatch("", "", root, "", gitlog.RenameOptions{}, &patch); err != nil {
+		t.Fatalf("runPatch() of the root commit error = %v", err)
+	}
+	testutils.Expect.True(t, strings.Contains(patch.String(), "new file mode 100644\n--- /dev/null\n+++ b/README.md\n"), patch.String())
+}
+
+func TestDiffCmd_Renames(t *testing.T) {
+	gitBin, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := testutils.SetupTestRepo(t)
+	testutils.RenameCommit(t, repo, "a.txt", "docs/a.txt", "docs: move a")
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	oldRepo := repoPath
+	repoPath = wt.Filesystem.Root()
+	t.Cleanup(func() { repoPath = oldRepo })
+
+	var stat bytes.Buffer
+	if err := runStat("HEAD~1", "HEAD", "", false, gitlog.RenameOptions{}, diff.Whitespace{}, &stat); err != nil {
+		t.Fatalf("runStat() error = %v", err)
+	}
+	testutils.Expect.True(t, strings.Contains(stat.String(), "a.txt => docs/a.txt |    0"), stat.String())
+	testutils.Expect.True(t, strings.Contains(stat.String(), "1 file changed"), stat.String())
+
+	stat.Reset()
+	if err := runStat("HEAD~1", "HEAD", "", false, gitlog.RenameOptions{Threshold: -1}, diff.Whitespace{}, &stat); err != nil {
+		t.Fatalf("runStat() error = %v", err)
+	}
+	testutils.Expect.True(t, strings.Contains(stat.String(), "2 files changed"), "disabled detection should list a delete and an add:\n"+stat.String())
+
+	var plain bytes.Buffer
+	if err := runDiff("HEAD~1", "HEAD", "", true, false, diff.ViewUnified, ui.RenderOptions{}, gitlog.RenameOptions{}, plainOutput{}, &plain); err != nil {
+		t.Fatalf("runDiff() error = %v", err)
+	}
+	testutils.Expect.True(t, strings.Contains(plain.String(), "--- HEAD~1:a.txt\n+++ HEAD:docs/a.txt\n"), plain.String())
+	testutils.Expect.True(t, strings.Contains(plain.String(), "=== File 1/1 ==="), plain.String())
+
+	var patch bytes.Buffer
+	if err := runPatch("HEAD~1", "HEAD", "", "", gitlog.RenameOptions{}, &patch); err != nil {
+		t.Fatalf("runPatch() error = %v", err)
+	}
+	testutils.Expect.True(t, strings.Contains(patch.String(), "rename from a.txt\nrename to docs/a.txt\n"), patch.String())
+
+	target := t.TempDir()
+	writeFile(t, filepath.Join(target, "a.txt"), "hello world\ngoodbye world")
+	patchPath := filepath.Join(t.TempDir(), "rename.patch")
+	writeFile(t, patchPath, patch.String())
+	apply := exec.Command(gitBin, "apply", patchPath)
+	apply.Dir = target
+	if out, err := apply.CombinedOutput(); err != nil {
+		t.Fatalf("git apply failed: %v\n%s\npatch:\n%s", err, out, patch.String())
+	}
+	if _, err := os.Stat(filepath.Join(target, "docs", "a.txt")); err != nil {
+		t.Errorf("the patch should move a.txt to docs/a.txt: %v", err)
+	}
+}
+
+func TestDiffCmd_IgnoreWhitespace(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.AddCommit(t, repo, "a.txt", "hello   world \ngoodbye world\n\n", "style: reformat a")
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	oldRepo := repoPath
+	repoPath = wt.Filesystem.Root()
+	t.Cleanup(func() { repoPath = oldRepo })
+
+	run := func(args ...string) string {
+		t.Helper()
+		out := filepath.Join(t.TempDir(), "diff.txt")
+		cmd := diffCmd()
+		cmd.SetArgs(append([]string{"HEAD~1..HEAD", "--plain", "--view", "unified", "--expanded", "--out", out}, args...))
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("diff %v error = %v", args, err)
+		}
+		content, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatalf("failed to read --out file: %v", err)
+		}
+		return string(content)
+	}
+
+	testutils.Expect.True(t, strings.Contains(run(), "-hello world"), "whitespace changes should show by default")
+	reformatted := run("-b", "--ignore-blank-lines")
+	testutils.Expect.False(t, strings.Contains(reformatted, "+hello") || strings.Contains(reformatted, " +\n"), "reformatting should leave no changes:\n"+reformatted)
+	testutils.Expect.False(t, strings.Contains(run("--ignore-whitespace"), "-hello"), "-w should ignore the reformatted line")
+
+	var buf bytes.Buffer
+	if err := runStat("HEAD~1", "HEAD", "", false, gitlog.RenameOptions{}, diff.Whitespace{IgnoreAll: true, IgnoreBlankLines: true}, &buf); err != nil {
+		t.Fatalf("runStat() error = %v", err)
+	}
+	testutils.Expect.True(t, strings.Contains(buf.String(), "0 insertions(+), 0 deletions(-)"), buf.String())
+}
+
+func TestDiffCmd_FormatPatchOut(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.AddCommit(t, repo, "a.txt", "hello world\ngoodbye moon", "fix: change a")
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	oldRepo := repoPath
+	repoPath = wt.Filesystem.Root()
+	t.Cleanup(func() { repoPath = oldRepo })
+
+	out := filepath.Join(t.TempDir(), "change.patch")
+	cmd := diffCmd()
+	cmd.SetArgs([]string{"HEAD~1..HEAD", "--format", "patch", "--out", out})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("diff --format patch error = %v", err)
+	}
+
+	var want bytes.Buffer
+	if err := runPatch("HEAD~1", "HEAD", "", "", gitlog.RenameOptions{}, &want); err != nil {
+		t.Fatalf("runPatch() error = %v", err)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("failed to read --out file: %v", err)
+	}
+	testutils.Expect.Equal(t, string(got), want.String())
+	testutils.Expect.True(t, strings.Contains(string(got), "@@ -1,2 +1,2 @@\n"), "patch should carry hunk headers")
+}
+
+func TestDiffCmd_FormatValidation(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"HEAD~1..HEAD", "--format", "html"}, `unknown --format "html"`},
+		{[]string{"HEAD~1..HEAD", "--out", "x.patch"}, "--out requires --format plain or patch"},
+		{[]string{"HEAD~1..HEAD", "--plain", "--color", "sometimes"}, `invalid color mode "sometimes"`},
+	}
+	for _, tt := range tests {
+		cmd := diffCmd()
+		cmd.SetArgs(tt.args)
+		cmd.SilenceUsage, cmd.SilenceErrors = true, true
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v: expected %q error, got %v", tt.args, tt.want, err)
+		}
+	}
+}
+
+func TestDiffCmd_Plain(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.AddCommit(t, repo, "a.txt", "hello world\ngoodbye moon", "fix: change a")
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	oldRepo := repoPath
+	repoPath = wt.Filesystem.Root()
+	t.Cleanup(func() { repoPath = oldRepo })
+	profile := lipgloss.ColorProfile()
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+
+	run := func(args ...string) string {
+		t.Helper()
+		out := filepath.Join(t.TempDir(), "diff.txt")
+		cmd := diffCmd()
+		cmd.SetArgs(append([]string{"HEAD~1..HEAD", "--view", "unified", "--out", out}, args...))
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("diff %v error = %v", args, err)
+		}
+		content, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatalf("failed to read --out file: %v", err)
+		}
+		return string(content)
+	}
+
+	plain := run("--plain")
+	testutils.Expect.True(t, strings.Contains(plain, "goodbye moon"), "plain output should render the diff")
+	testutils.Expect.False(t, strings.Contains(plain, "\x1b["), "output to a file should not be colored by default")
+	testutils.Expect.Equal(t, run("--no-tui"), plain)
+	testutils.Expect.Equal(t, run("--format", "plain", "--color", "never"), plain)
+
+	colored := run("--plain", "--color", "always")
+	testutils.Expect.True(t, strings.Contains(colored, "\x1b["), "--color always should keep ANSI styling")
+	testutils.Expect.Equal(t, ansi.Strip(colored), plain)
+}
+
+func TestDiffCmd_BinaryFiles(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.AddCommit(t, repo, "logo.png", "\x89PNG\r\n\x1a\n\x00\x00", "feat: add logo")
+	testutils.AddCommit(t, repo, "logo.png", "\x89PNG\r\n\x1a\n\x00\x01\x02", "feat: update logo")
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	oldRepo := repoPath
+	repoPath = wt.Filesystem.Root()
+	t.Cleanup(func() { repoPath = oldRepo })
+
+	run := func(args ...string) string {
+		t.Helper()
+		out := filepath.Join(t.TempDir(), "diff.txt")
+		cmd := diffCmd()
+		cmd.SetArgs(append([]string{"HEAD~1..HEAD", "--out", out}, args...))
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("diff %v error = %v", args, err)
+		}
+		content, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatalf("failed to read --out file: %v", err)
+		}
+		return string(content)
+	}
+
+	plain := run("--plain")
+	testutils.Expect.True(t, strings.Contains(plain, "Binary files differ (size 10 B → 11 B)"), plain)
+	testutils.Expect.False(t, strings.Contains(plain, "PNG"), "binary content should not be rendered")
+
+	hex := run("--plain", "--hex", "--view", "unified")
+	testutils.Expect.True(t, strings.Contains(hex, "89 50 4e 47"), hex)
+
+	patch := run("--format", "patch")
+	testutils.Expect.True(t, strings.Contains(patch, "Binary files a/logo.png and b/logo.png differ"), patch)
+
+	var buf bytes.Buffer
+	if err := runStat("HEAD~1", "HEAD", "", false, gitlog.RenameOptions{}, diff.Whitespace{}, &buf); err != nil {
+		t.Fatalf("runStat() error = %v", err)
+	}
+	testutils.Expect.True(t, strings.Contains(buf.String(), "logo.png | Bin 10 -> 11 bytes"), buf.String())
+}
+
+func TestPlainOutput_Colored(t *testing.T) {
+	var buf bytes.Buffer
+	testutils.Expect.False(t, plainOutput{}.colored(&buf), "auto should not color a non-terminal writer")
+	testutils.Expect.True(t, plainOutput{color: style.ColorAlways}.colored(&buf))
+	testutils.Expect.False(t, plainOutput{color: style.ColorNever}.colored(os.Stdout))
+	testutils.Expect.False(t, plainOutput{force: true}.useTUI(), "--plain should never start the TUI")
+}
+
+func TestRunStat_JSONTotals(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.AddFilesCommit(t, repo, map[string]string{
+		"a.txt":         "hello world\ngoodbye world\nagain",
+		"z/last.txt":    "one\ntwo\nthree\n",
+		"nested/new.go": "package nested\n",
+	}, "feat: touch several files")
+	history := testutils.GetCommitHistory(t, repo)
+	from := history[len(history)-1].Hash.String()
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	oldRepo := repoPath
+	repoPath = wt.Filesystem.Root()
+	t.Cleanup(func() { repoPath = oldRepo })
+
+	var buf bytes.Buffer
+	if err := runStat(from, "HEAD", "", true, gitlog.RenameOptions{}, diff.Whitespace{}, &buf); err != nil {
+		t.Fatalf("runStat() error = %v", err)
+	}
+
+	var out DiffStatOutput
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+
+	testutils.Expect.True(t, len(out.Files) > 2, "expected a multi-file stat")
+	var sum DiffStatTotals
+	for i, file := range out.Files {
+		if i > 0 {
+			testutils.Expect.True(t, out.Files[i-1].Path < file.Path, "files should be ordered by path")
+		}
+		testutils.Expect.Equal(t, file.Changed, file.Added+file.Removed)
+		sum.Files++
+		sum.Added += file.Added
+		sum.Removed += file.Removed
+		sum.Changed += file.Changed
+	}
+	testutils.Expect.Equal(t, out.Totals, sum)
+
+	buf.Reset()
+	if err := runStat("", "", "HEAD", true, gitlog.RenameOptions{}, diff.Whitespace{}, &buf); err != nil {
+		t.Fatalf("runStat() with commit error = %v", err)
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	testutils.Expect.Equal(t, out.Totals.Files, 3)
+}
+
+func TestRunDiff_AllChangedFiles(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	history := testutils.GetCommitHistory(t, repo)
+	from := history[0].Hash.String()
+	testutils.AddFilesCommit(t, repo, map[string]string{
+		"a.txt":         "hello storm\n",
+		"nested/new.go": "package nested\n",
+	}, "feat: touch two files")
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	oldRepo := repoPath
+	repoPath = wt.Filesystem.Root()
+	t.Cleanup(func() { repoPath = oldRepo })
+
+	var buf bytes.Buffer
+	if err := runDiff(from, "HEAD", "", true, false, diff.ViewUnified, ui.RenderOptions{}, gitlog.RenameOptions{}, plainOutput{}, &buf); err != nil {
+		t.Fatalf("runDiff() error = %v", err)
+	}
+	out := buf.String()
+	changed, err := gitlog.GetChangedFiles(repo, from, "HEAD")
+	if err != nil {
+		t.Fatalf("GetChangedFiles() error = %v", err)
+	}
+	testutils.Expect.True(t, strings.Contains(out, fmt.Sprintf("=== File %d/%d ===", len(changed), len(changed))), out)
+	for _, path := range []string{"a.txt", "nested/new.go"} {
+		testutils.Expect.True(t, strings.Contains(out, "+++ HEAD:"+path), "missing "+path+" in:\n"+out)
+	}
+	testutils.Expect.True(t, strings.Contains(out, "+package nested"), out)
+
+	buf.Reset()
+	if err := runDiff(from, "HEAD", "nested/new.go", true, false, diff.ViewUnified, ui.RenderOptions{}, gitlog.RenameOptions{}, plainOutput{}, &buf); err != nil {
+		t.Fatalf("runDiff() with file error = %v", err)
+	}
+	testutils.Expect.True(t, strings.Contains(buf.String(), "=== File 1/1 ==="), buf.String())
+	testutils.Expect.False(t, strings.Contains(buf.String(), "a.txt"), "--file should restrict the diff")
+
+	buf.Reset()
+	if err := runDiff("HEAD", "HEAD", "", true, false, diff.ViewUnified, ui.RenderOptions{}, gitlog.RenameOptions{}, plainOutput{}, &buf); err != nil {
+		t.Fatalf("runDiff() on an empty range error = %v", err)
+	}
+	testutils.Expect.True(t, strings.Contains(buf.String(), "No files changed"), buf.String())
+}
+
+func TestRunDiff_Worktree(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	oldRepo := repoPath
+	repoPath = wt.Filesystem.Root()
+	t.Cleanup(func() { repoPath = oldRepo })
+
+	writeFile(t, filepath.Join(repoPath, "a.txt"), "hello world\nuncommitted line")
+
+	var buf bytes.Buffer
+	if err := runDiff("HEAD", gitlog.RefWorktree, "", true, false, diff.ViewUnified, ui.RenderOptions{}, gitlog.RenameOptions{}, plainOutput{}, &buf); err != nil {
+		t.Fatalf("runDiff() error = %v", err)
+	}
+	out := buf.String()
+	testutils.Expect.True(t, strings.Contains(out, "+++ WORKTREE:a.txt"), out)
+	testutils.Expect.True(t, strings.Contains(out, "+uncommitted line"), out)
+	testutils.Expect.True(t, strings.Contains(out, "=== File 1/1 ==="), out)
+
+	buf.Reset()
+	if err := runDiff("HEAD", gitlog.RefIndex, "", true, false, diff.ViewUnified, ui.RenderOptions{}, gitlog.RenameOptions{}, plainOutput{}, &buf); err != nil {
+		t.Fatalf("runDiff() against the index error = %v", err)
+	}
+	testutils.Expect.True(t, strings.Contains(buf.String(), "No files changed"), "nothing is staged:\n"+buf.String())
+
+	cmd := diffCmd()
+	cmd.SetArgs([]string{"HEAD", "--blame"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--blame requires a commit") {
+		t.Errorf("--blame against the worktree should fail, got %v", err)
+	}
+}
+
+func TestDiffStaging(t *testing.T) {
+	for ref, want := range map[string][2]string{
+		gitlog.RefWorktree: {gitlog.RefIndex, gitlog.RefWorktree},
+		gitlog.RefIndex:    {"HEAD", gitlog.RefIndex},
+		"HEAD~1":           {"HEAD~1", gitlog.RefWorktree},
+	} {
+		from, to := singleRefRange(ref)
+		testutils.Expect.Equal(t, [2]string{from, to}, want, ref)
+	}
+
+	repo := testutils.SetupTestRepo(t)
+	stage := diffStaging(repo, gitlog.RefIndex, gitlog.RefWorktree, ui.RenderOptions{})
+	testutils.Expect.True(t, stage.Write != nil && !stage.Unstage, "INDEX..WORKTREE should stage hunks")
+	unstage := diffStaging(repo, "HEAD", gitlog.RefIndex, ui.RenderOptions{})
+	testutils.Expect.True(t, unstage.Write != nil && unstage.Unstage, "HEAD..INDEX should unstage hunks")
+	testutils.Expect.True(t, diffStaging(repo, "HEAD", gitlog.RefWorktree, ui.RenderOptions{}).Write == nil, "HEAD..WORKTREE mixes staged and unstaged changes")
+	ignored := ui.RenderOptions{Whitespace: diff.Whitespace{IgnoreAll: true}}
+	testutils.Expect.True(t, diffStaging(repo, gitlog.RefIndex, gitlog.RefWorktree, ignored).Write == nil, "ignoring whitespace should disable staging")
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	writeFile(t, filepath.Join(wt.Filesystem.Root(), "a.txt"), "hello world\nstaged by hunk")
+	if err := stage.Write("a.txt", "hello world\nstaged by hunk"); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	files, err := gitlog.GetChangedFiles(repo, gitlog.RefIndex, gitlog.RefWorktree)
+	if err != nil {
+		t.Fatalf("GetChangedFiles() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(files), 0, "the staged content should match the worktree")
+}
+
+func TestDiffCmd_JSONRequiresStat(t *testing.T) {
+	cmd := diffCmd()
+	cmd.SetArgs([]string{"HEAD~1..HEAD", "--json"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--json requires --stat") {
+		t.Fatalf("expected --json requires --stat error, got %v", err)
+	}
+}
+
+func TestGitDiffTheme(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+
+	repo := testutils.SetupTestRepo(t)
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+	root := worktree.Filesystem.Root()
+
+	theme, err := gitDiffTheme(root)
+	if err != nil {
+		t.Fatalf("gitDiffTheme() error = %v", err)
+	}
+	testutils.Expect.True(t, theme == nil, "no color.diff config should select the built-in theme")
+
+	writeFile(t, filepath.Join(root, ".git", "config"), "[core]\n\tbare = false\n[color \"diff\"]\n\tnew = green bold\n\told = 88\n")
+
+	theme, err = gitDiffTheme(root)
+	if err != nil {
+		t.Fatalf("gitDiffTheme() error = %v", err)
+	}
+	if theme == nil {
+		t.Fatal("expected a theme from color.diff config")
+	}
+	testutils.Expect.Equal(t, theme.Added.GetForeground(), lipgloss.TerminalColor(lipgloss.Color("2")))
+	testutils.Expect.True(t, theme.Added.GetBold(), "bold attribute should be mapped")
+	testutils.Expect.Equal(t, theme.Removed.GetForeground(), lipgloss.TerminalColor(lipgloss.Color("88")))
+	testutils.Expect.Equal(t, theme.Context.GetForeground(), diff.DefaultTheme().Context.GetForeground(), "unset slots keep the built-in style")
+
+	renderOpts := ui.RenderOptions{Theme: theme}
+	split := plainFormatter(diff.ViewSplit, false, renderOpts, 80).(*diff.SideBySideFormatter)
+	unified := plainFormatter(diff.ViewUnified, false, renderOpts, 80).(*diff.UnifiedFormatter)
+	testutils.Expect.True(t, split.Theme == theme && unified.Theme == theme, "formatters should receive the git theme")
+}
@@ -0,0 +1,113 @@
+/*
+USAGE
+
+	storm export [options]
+
+FLAGS
+
+	--sections <types>    Comma-separated section types to include (default: all)
+	--version <X.Y.Z>     Render under a version heading instead of [Unreleased]
+	--date <YYYY-MM-DD>   Release date used with --version (default: today)
+	--consolidated <f>    Read entries from one YAML file instead of .changes/*.md
+	--group-by-scope      Render entries sharing a scope together within each section
+	--scopeless-label <l> Label for scopeless entries when grouping (default: General)
+
+# DESCRIPTION
+
+Renders unreleased entries as a changelog section on stdout without touching
+CHANGELOG.md. Use --sections to produce focused documents such as security
+advisories (--sections security) or feature announcements (--sections added).
+Entries of other types are left out of the output but kept in .changes.
+*/
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stormlightlabs/git-storm/internal/changelog"
+	"github.com/stormlightlabs/git-storm/internal/changeset"
+	"github.com/stormlightlabs/git-storm/internal/style"
+)
+
+func exportCmd() *cobra.Command {
+	var (
+		sections     []string
+		version      string
+		date         string
+		consolidated string
+		groupByScope bool
+		scopeless    string
+	)
+
+	c := &cobra.Command{
+		Use:   "export",
+		Short: "Render unreleased entries, optionally limited to certain sections",
+		Long: `Prints unreleased entries as a Keep a Changelog section without modifying
+CHANGELOG.md. Use --sections to emit only specific section types.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries, err := openStore(changesDir, consolidated).List()
+			if err != nil {
+				return fmt.Errorf("failed to read unreleased entries: %w", err)
+			}
+
+			entryList := make([]changeset.Entry, 0, len(entries))
+			for _, e := range entries {
+				entryList = append(entryList, e.Entry)
+			}
+
+			opts := changelog.Options{
+				Sections:       normalizeSections(sections),
+				GroupByScope:   groupByScope,
+				ScopelessLabel: scopeless,
+				Types:          changeTypes,
+			}
+
+			var rendered *changelog.Version
+			if version != "" {
+				if date == "" {
+					date = time.Now().Format("2006-01-02")
+				}
+				rendered, err = changelog.BuildWithOptions(entryList, version, date, opts)
+				if err != nil {
+					return err
+				}
+			} else {
+				rendered = changelog.BuildUnreleased(entryList, opts)
+			}
+
+			if len(rendered.Sections) == 0 {
+				if len(opts.Sections) > 0 {
+					style.Headlinef("No unreleased entries in sections: %s", strings.Join(opts.Sections, ", "))
+				} else {
+					style.Headline("No unreleased changes found")
+				}
+				return nil
+			}
+
+			fmt.Fprint(cmd.OutOrStdout(), changelog.RenderVersion(rendered, opts))
+			return nil
+		},
+	}
+
+	c.Flags().StringSliceVar(&sections, "sections", nil, "Comma-separated section types to include (e.g. security,added)")
+	c.Flags().StringVar(&version, "version", "", "Render under this version instead of [Unreleased]")
+	c.Flags().StringVar(&date, "date", "", "Release date used with --version (YYYY-MM-DD, default: today)")
+	c.Flags().StringVar(&consolidated, "consolidated", "", "Read entries from a single YAML file (e.g. news.yaml) instead of .changes/*.md")
+	c.Flags().BoolVar(&groupByScope, "group-by-scope", false, "Order entries within each section by scope so shared scopes render together")
+	c.Flags().StringVar(&scopeless, "scopeless-label", "General", "With --group-by-scope, scope label for entries without one; empty lists them first unlabeled")
+	return c
+}
+
+// normalizeSections lowercases and trims section types, dropping empty values.
+func normalizeSections(sections []string) []string {
+	var result []string
+	for _, s := range sections {
+		if s = strings.ToLower(strings.TrimSpace(s)); s != "" {
+			result = append(result, s)
+		}
+	}
+	return result
+}
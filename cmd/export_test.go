@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/git-storm/internal/changeset"
+	"github.com/stormlightlabs/git-storm/internal/testutils"
+)
+
+func TestExportCmd_Sections(t *testing.T) {
+	tmpDir := t.TempDir()
+	changesDir := filepath.Join(tmpDir, ".changes")
+	for _, entry := range []changeset.Entry{
+		{Type: "added", Summary: "New feature"},
+		{Type: "security", Summary: "Patch token leak"},
+	} {
+		if _, err := changeset.Write(changesDir, entry); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	t.Chdir(tmpDir)
+
+	var out bytes.Buffer
+	cmd := exportCmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--sections", "Security"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("exportCmd() error = %v", err)
+	}
+
+	rendered := out.String()
+	testutils.Expect.True(t, strings.Contains(rendered, "## [Unreleased]"))
+	testutils.Expect.True(t, strings.Contains(rendered, "### Security\n\n- Patch token leak"))
+	testutils.Expect.False(t, strings.Contains(rendered, "Added"), "non-matching sections should be omitted")
+
+	entries, err := changeset.List(changesDir)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 2, "export must not remove entries")
+
+	out.Reset()
+	cmd = exportCmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--sections", "deprecated"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("exportCmd() with no matching sections error = %v", err)
+	}
+	testutils.Expect.Equal(t, out.String(), "")
+}
+
+func TestExportCmd_Version(t *testing.T) {
+	tmpDir := t.TempDir()
+	if _, err := changeset.Write(filepath.Join(tmpDir, ".changes"), changeset.Entry{Type: "fixed", Summary: "Bug fix"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	t.Chdir(tmpDir)
+
+	var out bytes.Buffer
+	cmd := exportCmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--version", "1.2.0", "--date", "2025-03-01"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("exportCmd() error = %v", err)
+	}
+	testutils.Expect.True(t, strings.HasPrefix(out.String(), "## [1.2.0] - 2025-03-01"))
+}
+
+func TestExportCmd_GroupByScope(t *testing.T) {
+	tmpDir := t.TempDir()
+	changesDir := filepath.Join(tmpDir, ".changes")
+	for _, entry := range []changeset.Entry{
+		{Type: "fixed", Scope: "ui", Summary: "Fix flicker"},
+		{Type: "fixed", Summary: "Fix crash"},
+		{Type: "fixed", Scope: "api", Summary: "Fix timeout"},
+	} {
+		if _, err := changeset.Write(changesDir, entry); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	t.Chdir(tmpDir)
+
+	var out bytes.Buffer
+	cmd := exportCmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--group-by-scope", "--scopeless-label", "Core"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("exportCmd() error = %v", err)
+	}
+	testutils.Expect.True(t, strings.Contains(out.String(),
+		"- **api:** Fix timeout\n- **Core:** Fix crash\n- **ui:** Fix flicker\n"), "scopeless entry should sort under Core:\n"+out.String())
+
+	out.Reset()
+	cmd = exportCmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--group-by-scope", "--scopeless-label", ""})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("exportCmd() error = %v", err)
+	}
+	testutils.Expect.True(t, strings.Contains(out.String(),
+		"- Fix crash\n- **api:** Fix timeout\n- **ui:** Fix flicker\n"), "unlabeled scopeless entry should lead:\n"+out.String())
+}
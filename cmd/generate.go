@@ -8,20 +8,46 @@ FLAGS
 	-i, --interactive       Review generated entries in a TUI
 	    --since <tag>       Generate changes since the given tag
 	-o, --output <path>     Write generated changelog to path
+	    --dry-run           Report what would be generated without writing files
+	    --diff              With --dry-run, list each entry as new, skipped, or updated
+	                        along with the files its commit renamed or copied
+	    --find-renames <n>  Similarity percentage for renames listed by --diff (0 disables)
+	    --consolidated <f>  Append entries to one YAML file instead of .changes/*.md
+	    --metadata-dir <d>  Store dedup metadata in d instead of .changes/data
+	    --no-metadata       Skip JSON metadata and dedup on entry frontmatter only
+	    --gitignore-metadata Append the metadata dir to .gitignore if not listed
+	    --ticket-pattern <r> Strip a leading ticket ID matching regex r from subjects
+	    --ticket-url <url>  Link captured tickets; {ticket} is replaced by the ID
+	    --with-body         Store each commit body, minus footers, below the frontmatter
+	    --attribute-authors Record commit authors and co-authors to thank in the changelog
+	    --new-contributors  Record first-time contributors for a New Contributors section
+	    --infer-scope       Infer missing scopes from the dominant changed directory
+	    --scope-map <p=s>   Map path prefix p to scope s when inferring (repeatable)
+	    --keep-fixups       Generate entries for fixup!/squash!/amend! commits
+	    --no-merges         Skip merge commits
+	    --first-parent      Follow only first parents; read PR merges by their title
+	    --no-cache          Compute every diff hash instead of reading .changes/cache
 	    --output-json       Output results as JSON
 	    --repo <path>       Path to the Git repository (default: .)
 */
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/plumbing/object"
 	"github.com/spf13/cobra"
+	"github.com/stormlightlabs/git-storm/internal/changelog"
 	"github.com/stormlightlabs/git-storm/internal/changeset"
+	"github.com/stormlightlabs/git-storm/internal/github"
 	"github.com/stormlightlabs/git-storm/internal/gitlog"
 	"github.com/stormlightlabs/git-storm/internal/style"
 	"github.com/stormlightlabs/git-storm/internal/tty"
@@ -29,9 +55,37 @@ import (
 )
 
 var (
-	interactive bool
-	sinceTag    string
-	outputJSON  bool
+	interactive      bool
+	sinceTag         string
+	outputJSON       bool
+	dryRun           bool
+	showPlan         bool
+	consolidatedPath string
+	metadataDir      string
+	noMetadata       bool
+	ticketPattern    string
+	ticketURL        string
+	inferScope       bool
+	scopeMaps        []string
+	ignoreMetadata   bool
+	keepFixups       bool
+	captureBody      bool
+	attributeAuthors bool
+	newContributors  bool
+	renameThreshold  int
+	commitRange      gitlog.RangeOptions
+	noCache          bool
+)
+
+// newContributorDepth bounds how many commits before the range
+// --new-contributors searches for an author's earlier work.
+const newContributorDepth = 10_000
+
+// Plan actions reported by generate --dry-run --diff.
+const (
+	planActionAdd    = "add"
+	planActionSkip   = "skip"
+	planActionUpdate = "update"
 )
 
 // GenerateOutput represents the JSON output structure for the generate command.
@@ -40,7 +94,52 @@ type GenerateOutput struct {
 	To           string                    `json:"to"`
 	TotalCommits int                       `json:"total_commits"`
 	Statistics   GenerateStatistics        `json:"statistics"`
+	DryRun       bool                      `json:"dry_run,omitempty"`
 	Entries      []changeset.EntryWithFile `json:"entries,omitempty"`
+	Plan         []GeneratePlanEntry       `json:"plan,omitempty"`
+	Rebased      []RebasedCommit           `json:"rebased,omitempty"`
+}
+
+// RebasedCommit records an existing entry whose commit hash was updated
+// because a rebased commit produced the same diff hash.
+type RebasedCommit struct {
+	PreviousCommit string `json:"previous_commit"`
+	CommitHash     string `json:"commit_hash"`
+	DiffHash       string `json:"diff_hash"`
+	Entry          string `json:"entry"`
+}
+
+// String describes the reconciliation, e.g. "updated abc1234 → def5678 for entry X".
+func (r RebasedCommit) String() string {
+	return fmt.Sprintf("updated %s → %s for entry %s", shortHash(r.PreviousCommit), shortHash(r.CommitHash), r.Entry)
+}
+
+func shortHash(hash string) string {
+	if len(hash) > gitlog.ShaLen {
+		return hash[:gitlog.ShaLen]
+	}
+	return hash
+}
+
+// GeneratePlanEntry describes what generate does with a single commit.
+//
+// Action is "add" for new entries, "skip" for duplicates of an existing entry,
+// and "update" for rebased commits whose diff matches an existing entry.
+type GeneratePlanEntry struct {
+	Action         string `json:"action"`
+	CommitHash     string `json:"commit_hash"`
+	PreviousCommit string `json:"previous_commit,omitempty"`
+	DiffHash       string `json:"diff_hash"`
+	Filename       string `json:"filename,omitempty"`
+	Type           string `json:"type"`
+	Scope          string `json:"scope,omitempty"`
+	Package        string `json:"package,omitempty"`
+	Summary        string `json:"summary"`
+	Breaking       bool   `json:"breaking,omitempty"`
+	// Renames lists the files the commit renamed or copied as "old → new".
+	Renames []string `json:"renames,omitempty"`
+
+	meta changeset.Metadata
 }
 
 // GenerateStatistics holds counts of generated, skipped, duplicate, and rebased entries.
@@ -51,41 +150,10 @@ type GenerateStatistics struct {
 	Rebased    int `json:"rebased"`
 }
 
-// TODO(determinism): Add deduplication logic using diff-based identity
-//
-// Currently generates duplicate .changes/*.md files when:
-// 1. Running generate multiple times on the same range
-// 2. History is rewritten (rebase/amend) but commit content unchanged
-//
-// Implementation:
-//
-//  1. Before processing commits, load existing entries:
-//     existingEntries := changeset.LoadExisting(".changes/data")
-//     // Returns map[diffHash]Metadata for O(1) lookups
-//
-//  2. For each selected commit:
-//     a. Compute diff hash: diffHash := changeset.ComputeDiffHash(repo, commit)
-//     b. Check if exists: if meta, exists := existingEntries[diffHash]; exists {
-//     - Same commit hash → true duplicate, skip
-//     - Different commit hash → rebased/cherry-picked
-//     * If --update-rebased: update metadata.CommitHash in JSON
-//     * If --skip-rebased: skip (default)
-//     * If --warn-rebased: print warning and skip
-//     }
-//     c. If not exists: create new entry with diff hash as filename
-//
-// 3. Report statistics:
-//   - N new entries created
-//   - M duplicates skipped (same commit)
-//   - K rebased commits detected (same diff, different commit)
-//
-// Flags to add:
-// --update-rebased    Update commit hash for rebased entries
-// --skip-rebased      Skip rebased commits (default)
-// --warn-rebased      Print warnings for rebased commits
-// --force             Regenerate all entries (ignore existing)
-//
-// Related: See internal/changeset/changeset.go TODO for implementation details
+// generateCmd drafts entries from the commits in a range. Commits are matched
+// to existing entries by diff hash: an entry with the same commit is skipped,
+// and one recorded under a different commit (rebased, amended, or
+// cherry-picked) has its commit hash updated instead of being duplicated.
 func generateCmd() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "generate [from] [to]",
@@ -103,6 +171,10 @@ interactive review mode.`,
 				return fmt.Errorf("--interactive and --output-json cannot be used together")
 			}
 
+			if showPlan && !dryRun {
+				return fmt.Errorf("--diff requires --dry-run")
+			}
+
 			var from, to string
 
 			if sinceTag != "" {
@@ -118,12 +190,12 @@ interactive review mode.`,
 				from, to = gitlog.ParseRefArgs(args)
 			}
 
-			repo, err := git.PlainOpen(repoPath)
+			repo, err := gitlog.OpenRepo(repoPath)
 			if err != nil {
 				return fmt.Errorf("failed to open repository: %w", err)
 			}
 
-			commits, err := gitlog.GetCommitRange(repo, from, to)
+			commits, err := gitlog.GetCommitRange(repo, from, to, commitRange)
 			if err != nil {
 				return err
 			}
@@ -133,132 +205,107 @@ interactive review mode.`,
 				return nil
 			}
 
-			parser := &gitlog.ConventionalParser{}
-			var selectedItems []ui.CommitItem
-
-			if interactive {
-				model := ui.NewCommitSelectorModel(commits, from, to, parser)
-				p := tea.NewProgram(model, tea.WithAltScreen())
-
-				finalModel, err := p.Run()
-				if err != nil {
-					return fmt.Errorf("failed to run interactive selector: %w", err)
-				}
-
-				selectorModel, ok := finalModel.(ui.CommitSelectorModel)
-				if !ok {
-					return fmt.Errorf("unexpected model type")
+			sources := planSources{OpenRepo: openRepoPath}
+			if newContributors {
+				if sources.NewAuthors, err = gitlog.NewAuthors(repo, from, commits, newContributorDepth); err != nil {
+					return err
 				}
+			}
 
-				if selectorModel.IsCancelled() {
-					style.Headline("Operation cancelled")
-					return nil
+			parser := newConventionalParser()
+			parser.KeepFixups = keepFixups
+			parser.MergeTitles = commitRange.FirstParent
+			if ticketPattern != "" {
+				pattern, err := regexp.Compile(ticketPattern)
+				if err != nil {
+					return fmt.Errorf("invalid --ticket-pattern: %w", err)
 				}
+				parser.TicketPattern = pattern
+			}
 
-				selectedItems = selectorModel.GetSelectedItems()
-
+			if !interactive {
+				style.Headlinef("Found %d commits between %s and %s", len(commits), from, to)
+			}
+			selectedItems, cancelled, err := selectCommitItems(commits, from, to, parser, interactive)
+			if err != nil {
+				return err
+			}
+			if cancelled {
+				style.Headline("Operation cancelled")
+				return nil
+			}
+			if interactive {
 				if len(selectedItems) == 0 {
 					style.Headline("No commits selected")
 					return nil
 				}
-
 				style.Headlinef("Generating entries for %d selected commits", len(selectedItems))
-			} else {
-				style.Headlinef("Found %d commits between %s and %s", len(commits), from, to)
+			}
 
-				for _, commit := range commits {
-					subject := commit.Message
-					body := ""
-					lines := strings.Split(commit.Message, "\n")
-					if len(lines) > 0 {
-						subject = lines[0]
-						if len(lines) > 1 {
-							body = strings.Join(lines[1:], "\n")
-						}
-					}
-
-					meta, err := parser.Parse(commit.Hash.String(), subject, body, commit.Author.When)
-					if err != nil {
-						style.Println("Warning: failed to parse commit %s: %v", commit.Hash.String()[:gitlog.ShaLen], err)
-						continue
-					}
-
-					category := parser.Categorize(meta)
-					if category == "" {
-						continue
-					}
-
-					selectedItems = append(selectedItems, ui.CommitItem{
-						Commit:   commit,
-						Meta:     meta,
-						Category: category,
-						Selected: true,
-					})
+			infer := projectConfig.InferScope
+			if cmd.Flags().Changed("infer-scope") {
+				infer = inferScope
+			}
+			if infer {
+				mapping, err := scopeMapping(scopeMaps)
+				if err != nil {
+					return err
 				}
+				applyInferredScopes(selectedItems, mapping)
 			}
 
-			changesDir := ".changes"
-			existingMetadata, err := changeset.LoadExistingMetadata(changesDir)
+			metaConfig := changeset.MetadataConfig{Dir: metadataDir, Disabled: noMetadata}
+			store := openGenerateStore(changesDir, consolidatedPath, metaConfig)
+			existingMetadata, err := existingGenerated(store)
 			if err != nil {
-				return fmt.Errorf("failed to load existing metadata: %w", err)
+				return err
 			}
 
-			created := 0
-			skipped := 0
-			duplicates := 0
-			rebased := 0
-
-			for _, item := range selectedItems {
-				if item.Category == "" {
-					skipped++
-					continue
-				}
-
-				diffHash, err := changeset.ComputeDiffHash(item.Commit)
+			if ignoreMetadata && !noMetadata && !dryRun {
+				added, err := metaConfig.IgnoreInGitignore(changesDir, ".gitignore")
 				if err != nil {
-					style.Println("Warning: failed to compute diff hash for commit %s: %v", item.Commit.Hash.String()[:7], err)
-					skipped++
-					continue
+					return err
 				}
-
-				if existing, exists := existingMetadata[diffHash]; exists {
-					if existing.CommitHash == item.Commit.Hash.String() {
-						duplicates++
-						continue
-					} else {
-						if err := changeset.UpdateMetadata(changesDir, diffHash, item.Commit.Hash.String()); err != nil {
-							style.Println("Warning: failed to update metadata for rebased commit: %v", err)
-							continue
-						}
-						style.Println("  Updated rebased commit %s (was %s)", item.Commit.Hash.String()[:7], existing.CommitHash[:7])
-						rebased++
-						continue
-					}
+				if added {
+					style.Println("Added metadata directory to .gitignore")
 				}
+			}
 
-				meta := changeset.Metadata{
-					CommitHash: item.Commit.Hash.String(),
-					DiffHash:   diffHash,
-					Type:       item.Category,
-					Scope:      item.Meta.Scope,
-					Summary:    item.Meta.Description,
-					Breaking:   item.Meta.Breaking,
-					Author:     item.Commit.Author.Name,
-					Date:       item.Commit.Author.When,
+			if attributeAuthors {
+				if sources.Logins, err = newLoginResolver(); err != nil {
+					return err
 				}
+			}
 
-				filePath, err := changeset.WriteWithMetadata(changesDir, meta)
-				if err != nil {
-					fmt.Printf("Error: failed to write entry: %v\n", err)
-					skipped++
-					continue
+			// The cache sits beside the entries, so only a changes directory
+			// store gets one.
+			if _, inChangesDir := store.(*changeset.FSStore); inChangesDir && !noCache {
+				sources.Cache = openCommitCache()
+			}
+
+			plan, skipped := planGenerate(selectedItems, existingMetadata, sources)
+			if sources.Logins != nil && !dryRun {
+				if err := sources.Logins.Cache.Save(); err != nil {
+					style.Println("Warning: %v", err)
+				}
+			}
+			if !dryRun {
+				if err := sources.Cache.Save(); err != nil {
+					style.Println("Warning: %v", err)
 				}
-				style.Addedf("✓ Created %s", filePath)
-				created++
+			}
+			stats, rebasedCommits, err := applyGeneratePlan(plan, store, dryRun, true)
+			if err != nil {
+				return err
+			}
+			stats.Skipped += skipped
+
+			if dryRun {
+				return outputGeneratePlan(from, to, len(commits), stats, plan)
 			}
 
 			if outputJSON {
-				entries, err := changeset.List(changesDir)
+				entries, err := store.List()
 				if err != nil {
 					return fmt.Errorf("failed to list generated entries: %w", err)
 				}
@@ -267,13 +314,9 @@ interactive review mode.`,
 					From:         from,
 					To:           to,
 					TotalCommits: len(commits),
-					Statistics: GenerateStatistics{
-						Created:    created,
-						Skipped:    skipped,
-						Duplicates: duplicates,
-						Rebased:    rebased,
-					},
-					Entries: entries,
+					Statistics:   stats,
+					Entries:      entries,
+					Rebased:      rebasedCommits,
 				}
 
 				jsonBytes, err := json.MarshalIndent(output, "", "  ")
@@ -285,15 +328,15 @@ interactive review mode.`,
 			}
 
 			style.Newline()
-			style.Headlinef("Generated %d new changelog entries", created)
-			if duplicates > 0 {
-				style.Println("  Skipped %d duplicates", duplicates)
+			style.Headlinef("Generated %d new changelog entries", stats.Created)
+			if stats.Duplicates > 0 {
+				style.Println("  Skipped %d duplicates", stats.Duplicates)
 			}
-			if rebased > 0 {
-				style.Println("  Updated %d rebased commits", rebased)
+			if stats.Rebased > 0 {
+				style.Println("  Updated %d rebased commits", stats.Rebased)
 			}
-			if skipped > 0 {
-				style.Println("  Skipped %d commits (reverts or non-matching types)", skipped)
+			if stats.Skipped > 0 {
+				style.Println("  Skipped %d commits (reverts or non-matching types)", stats.Skipped)
 			}
 
 			return nil
@@ -303,5 +346,446 @@ interactive review mode.`,
 	c.Flags().BoolVarP(&interactive, "interactive", "i", false, "Review changes interactively in a TUI")
 	c.Flags().StringVar(&sinceTag, "since", "", "Generate changes since the given tag")
 	c.Flags().BoolVar(&outputJSON, "output-json", false, "Output results as JSON")
+	c.Flags().BoolVar(&dryRun, "dry-run", false, "Report what would be generated without writing files")
+	c.Flags().StringVar(&consolidatedPath, "consolidated", "", "Append entries to a single YAML file (e.g. news.yaml) instead of .changes/*.md")
+	c.Flags().StringVar(&metadataDir, "metadata-dir", "", "Directory for deduplication metadata (default: .changes/data)")
+	c.Flags().BoolVar(&noMetadata, "no-metadata", false, "Skip JSON metadata and deduplicate using entry frontmatter only")
+	c.Flags().BoolVar(&ignoreMetadata, "gitignore-metadata", false, "Append the metadata directory to .gitignore so JSON metadata stays untracked")
+	c.Flags().StringVar(&ticketPattern, "ticket-pattern", "", "Regex matching a leading ticket ID to strip from subjects before parsing")
+	c.Flags().StringVar(&ticketURL, "ticket-url", "", "URL template linking captured tickets; {ticket} is replaced by the ID")
+	c.Flags().BoolVar(&captureBody, "with-body", false, "Store each commit body, minus footers, below the entry frontmatter")
+	c.Flags().BoolVar(&attributeAuthors, "attribute-authors", false, "Record commit authors and Co-authored-by co-authors to thank in the changelog, as GitHub @logins when found")
+	c.Flags().BoolVar(&newContributors, "new-contributors", false, "Record authors with no commit before the range as first-time contributors for the changelog")
+	c.Flags().BoolVar(&keepFixups, "keep-fixups", false, "Generate entries for fixup!/squash!/amend! commits instead of skipping them")
+	c.Flags().BoolVar(&inferScope, "infer-scope", false, "Infer missing scopes from the dominant directory of changed files (default: the config's infer_scope)")
+	c.Flags().StringArrayVar(&scopeMaps, "scope-map", nil, "Path prefix to scope mapping as prefix=scope, used with --infer-scope; overrides the config's scope_map (repeatable)")
+	c.Flags().BoolVar(&showPlan, "diff", false, "With --dry-run, list entries that would be added, skipped, or updated")
+	c.Flags().IntVar(&renameThreshold, "find-renames", gitlog.DefaultRenameThreshold, "Similarity percentage for detecting the renames --diff lists (0 disables)")
+	c.Flags().BoolVar(&noCache, "no-cache", false, "Compute every commit's diff hash instead of reading .changes/cache (always with --consolidated)")
+	addRangeFlags(c, &commitRange)
 	return c
 }
+
+// planSources is what planGenerate reads besides the commits. The zero value
+// hashes every commit one at a time and names authors as git records them.
+type planSources struct {
+	// Cache remembers diff hashes between runs; nil computes every hash.
+	Cache *changeset.CommitCache
+	// OpenRepo opens a repository for each concurrent diff hashing worker;
+	// nil hashes serially.
+	OpenRepo func() (*git.Repository, error)
+	// Logins resolves GitHub logins for --attribute-authors; nil when the
+	// origin isn't on GitHub, so authors are thanked by name.
+	Logins *github.LoginResolver
+	// NewAuthors holds the lowercased emails of authors new to the repository
+	// in the range; planGenerate flags the first entry of each and removes them.
+	NewAuthors map[string]bool
+}
+
+// planGenerate classifies selected commits against existing metadata by diff hash.
+//
+// Returns the plan along with the number of commits that could not be planned.
+func planGenerate(items []ui.CommitItem, existing map[string]changeset.Metadata, sources planSources) ([]GeneratePlanEntry, int) {
+	var plan []GeneratePlanEntry
+	skipped := 0
+
+	var commits []*object.Commit
+	for _, item := range items {
+		if item.Category != "" {
+			commits = append(commits, item.Commit)
+		}
+	}
+	diffHashes, errs := sources.Cache.DiffHashes(commits, sources.OpenRepo)
+
+	hashed := 0
+	for _, item := range items {
+		if item.Category == "" {
+			skipped++
+			continue
+		}
+
+		diffHash, err := diffHashes[hashed], errs[hashed]
+		hashed++
+		if err != nil {
+			style.Println("Warning: failed to compute diff hash for commit %s: %v", item.Commit.Hash.String()[:7], err)
+			skipped++
+			continue
+		}
+
+		pkg := commitPackage(item.Commit)
+		entry := GeneratePlanEntry{
+			Action:     planActionAdd,
+			CommitHash: item.Commit.Hash.String(),
+			DiffHash:   diffHash,
+			Type:       item.Category,
+			Scope:      item.Meta.Scope,
+			Package:    pkg,
+			Summary:    item.Meta.Description,
+			Breaking:   item.Meta.Breaking,
+			meta: changeset.Metadata{
+				CommitHash: item.Commit.Hash.String(),
+				DiffHash:   diffHash,
+				Type:       item.Category,
+				Scope:      item.Meta.Scope,
+				Package:    pkg,
+				Summary:    item.Meta.Description,
+				Breaking:   item.Meta.Breaking,
+				Author:     item.Commit.Author.Name,
+				Date:       item.Commit.Author.When,
+				Links:      ticketLinks(item.Meta.Ticket, ticketURL),
+				References: gitlog.ParseReferences(item.Meta.Description + "\n" + item.Meta.Body),
+			},
+		}
+		if captureBody {
+			entry.meta.Body = gitlog.StripFooters(item.Meta.Body)
+		}
+		if attributeAuthors {
+			entry.meta.Authors = commitAuthors(sources.Logins, item.Commit, item.Meta.Body)
+		}
+		if email := strings.ToLower(item.Commit.Author.Email); sources.NewAuthors[email] {
+			entry.meta.NewContributors = []string{authorName(sources.Logins, item.Commit.Author)}
+			delete(sources.NewAuthors, email)
+		}
+
+		if showPlan {
+			entry.Renames = commitRenames(item.Commit)
+		}
+
+		if meta, exists := existing[diffHash]; exists {
+			entry.Filename = meta.Filename
+			if meta.CommitHash == entry.CommitHash {
+				entry.Action = planActionSkip
+			} else {
+				entry.Action = planActionUpdate
+				entry.PreviousCommit = meta.CommitHash
+			}
+		}
+
+		plan = append(plan, entry)
+	}
+
+	return plan, skipped
+}
+
+// openRepoPath opens the repository at [repoPath], for work that needs its own
+// handle such as concurrent diff hashing.
+func openRepoPath() (*git.Repository, error) {
+	return gitlog.OpenRepo(repoPath)
+}
+
+// openCommitCache loads the commit cache in the changes directory, or returns
+// nil after a warning when it can't be read.
+func openCommitCache() *changeset.CommitCache {
+	cache, err := changeset.LoadCommitCache(changeset.CommitCachePath(changesDir))
+	if err != nil {
+		style.Println("Warning: %v", err)
+		return nil
+	}
+	return cache
+}
+
+// newLoginResolver returns a login resolver backed by the user's cache of
+// looked-up emails, or nil when the origin isn't on GitHub. Lookups use
+// GITHUB_TOKEN when set.
+func newLoginResolver() (*github.LoginResolver, error) {
+	remote, err := changelog.RemoteRepo(repoPath, configuredHosts())
+	if err != nil || remote.Forge != changelog.ForgeGitHub {
+		return nil, nil
+	}
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, nil
+	}
+	cache, err := github.LoadLoginCache(filepath.Join(cacheDir, "storm", "github-logins.json"))
+	if err != nil {
+		return nil, err
+	}
+	client := github.NewClient(os.Getenv("GITHUB_API_URL"), os.Getenv("GITHUB_TOKEN"))
+	return &github.LoginResolver{Client: client, Cache: cache}, nil
+}
+
+// commitAuthors lists the people to thank for commit: its author, then the
+// co-authors in body's trailers, each named by [authorName].
+func commitAuthors(logins *github.LoginResolver, commit *object.Commit, body string) []string {
+	people := append([]object.Signature{commit.Author}, gitlog.CoAuthors(body)...)
+	var authors []string
+	seen := make(map[string]bool, len(people))
+	for _, person := range people {
+		if seen[strings.ToLower(person.Email)] {
+			continue
+		}
+		seen[strings.ToLower(person.Email)] = true
+		authors = append(authors, authorName(logins, person))
+	}
+	return authors
+}
+
+// authorName names person as "@login" when logins finds one, and by name
+// otherwise. A failed lookup stops further API lookups.
+func authorName(logins *github.LoginResolver, person object.Signature) string {
+	if logins == nil {
+		return person.Name
+	}
+	login, err := logins.Login(context.Background(), person.Email)
+	if err != nil {
+		style.Println("Warning: %v; skipping further GitHub lookups", err)
+		logins.Client = nil
+	}
+	if login == "" {
+		return person.Name
+	}
+	return "@" + login
+}
+
+// commitRenames lists the files commit renamed or copied as "old → new",
+// detected at --find-renames similarity.
+func commitRenames(commit *object.Commit) []string {
+	if renameThreshold <= 0 {
+		return nil
+	}
+
+	changes, err := gitlog.CommitFileChanges(commit)
+	if err != nil {
+		style.Println("Warning: failed to list changed files for commit %s: %v", commit.Hash.String()[:gitlog.ShaLen], err)
+		return nil
+	}
+
+	var renames []string
+	for _, change := range gitlog.DetectRenames(changes, gitlog.RenameOptions{Threshold: renameThreshold, Copies: true}) {
+		if change.OldPath != "" {
+			renames = append(renames, change.DisplayPath())
+		}
+	}
+	return renames
+}
+
+// existingGenerated loads the metadata of entries already in store, keyed by diff hash.
+func existingGenerated(store changeset.Store) (map[string]changeset.Metadata, error) {
+	existing, err := store.LoadMetadata()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load existing metadata: %w", err)
+	}
+	return existing, nil
+}
+
+// addRangeFlags registers the flags choosing which commits of a range are read.
+func addRangeFlags(c *cobra.Command, opts *gitlog.RangeOptions) {
+	c.Flags().BoolVar(&opts.NoMerges, "no-merges", false, "Skip merge commits")
+	c.Flags().BoolVar(&opts.FirstParent, "first-parent", false, "Follow only the first parent of merges, reading each merged pull request as one commit titled by the pull request")
+}
+
+// selectCommitItems parses commits into categorized items, either all of them
+// or those picked in the interactive selector. cancelled reports that the
+// selector was dismissed.
+func selectCommitItems(commits []*object.Commit, from, to string, parser *gitlog.ConventionalParser, interactive bool) (items []ui.CommitItem, cancelled bool, err error) {
+	if interactive {
+		model := ui.NewCommitSelectorModel(commits, from, to, parser)
+		p := tea.NewProgram(model, tea.WithAltScreen())
+
+		finalModel, err := p.Run()
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to run interactive selector: %w", err)
+		}
+
+		selectorModel, ok := finalModel.(ui.CommitSelectorModel)
+		if !ok {
+			return nil, false, fmt.Errorf("unexpected model type")
+		}
+		if selectorModel.IsCancelled() {
+			return nil, true, nil
+		}
+		return selectorModel.GetSelectedItems(), false, nil
+	}
+
+	for _, commit := range commits {
+		subject, body, _ := strings.Cut(commit.Message, "\n")
+
+		meta, err := parser.Parse(commit.Hash.String(), subject, body, commit.Author.When)
+		if err != nil {
+			style.Println("Warning: failed to parse commit %s: %v", commit.Hash.String()[:gitlog.ShaLen], err)
+			continue
+		}
+
+		category := parser.Categorize(meta)
+		if category == "" {
+			continue
+		}
+
+		items = append(items, ui.CommitItem{
+			Commit:   commit,
+			Meta:     meta,
+			Category: category,
+			Selected: true,
+		})
+	}
+	return items, false, nil
+}
+
+// applyGeneratePlan writes new entries and reconciles rebased ones in store,
+// returning the resulting statistics. With dryRun nothing is written but the
+// counts are the same. report prints each created or updated entry.
+func applyGeneratePlan(plan []GeneratePlanEntry, store changeset.Store, dryRun, report bool) (GenerateStatistics, []RebasedCommit, error) {
+	var stats GenerateStatistics
+	var rebasedCommits []RebasedCommit
+
+	for _, entry := range plan {
+		switch entry.Action {
+		case planActionSkip:
+			stats.Duplicates++
+		case planActionUpdate:
+			if !dryRun {
+				reconciled, err := reconcileRebased(store, entry)
+				if err != nil {
+					style.Println("Warning: failed to update metadata for rebased commit: %v", err)
+					continue
+				}
+				if report {
+					style.Println("  %s", reconciled)
+				}
+				rebasedCommits = append(rebasedCommits, reconciled)
+			}
+			stats.Rebased++
+		case planActionAdd:
+			if !dryRun {
+				id, err := store.WriteGenerated(entry.meta)
+				if err != nil {
+					fmt.Printf("Error: failed to write entry: %v\n", err)
+					stats.Skipped++
+					continue
+				}
+				if report {
+					style.Addedf("✓ Created %s", id)
+				}
+			}
+			stats.Created++
+		}
+	}
+	return stats, rebasedCommits, nil
+}
+
+// reconcileRebased points an existing entry at a rebased commit with the same
+// diff hash, returning a record of the update.
+func reconcileRebased(store changeset.Store, entry GeneratePlanEntry) (RebasedCommit, error) {
+	if err := store.UpdateCommit(entry.DiffHash, entry.CommitHash); err != nil {
+		return RebasedCommit{}, err
+	}
+
+	name := entry.Filename
+	if name == "" {
+		name = fmt.Sprintf("%q", entry.Summary)
+	}
+	return RebasedCommit{
+		PreviousCommit: entry.PreviousCommit,
+		CommitHash:     entry.CommitHash,
+		DiffHash:       entry.DiffHash,
+		Entry:          name,
+	}, nil
+}
+
+// parseScopeMap parses prefix=scope pairs as accepted by --scope-map.
+func parseScopeMap(pairs []string) (map[string]string, error) {
+	mapping := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		prefix, scope, ok := strings.Cut(pair, "=")
+		prefix, scope = strings.TrimSpace(prefix), strings.TrimSpace(scope)
+		if !ok || prefix == "" {
+			return nil, fmt.Errorf("invalid --scope-map %q: expected prefix=scope", pair)
+		}
+		mapping[prefix] = scope
+	}
+	return mapping, nil
+}
+
+// applyInferredScopes fills in the scope of scopeless items from the paths
+// their commits changed. Items that already have a scope are left untouched.
+func applyInferredScopes(items []ui.CommitItem, mapping map[string]string) {
+	for i := range items {
+		if items[i].Meta.Scope != "" {
+			continue
+		}
+
+		paths, err := gitlog.CommitPaths(items[i].Commit)
+		if err != nil {
+			style.Println("Warning: failed to list changed files for commit %s: %v", items[i].Commit.Hash.String()[:gitlog.ShaLen], err)
+			continue
+		}
+		items[i].Meta.Scope = gitlog.InferScope(paths, mapping)
+	}
+}
+
+// commitPackage returns the configured package owning most of the files
+// commit changed, or "" when none does or no packages are configured.
+func commitPackage(commit *object.Commit) string {
+	if len(projectConfig.Packages) == 0 {
+		return ""
+	}
+	paths, err := gitlog.CommitPaths(commit)
+	if err != nil {
+		style.Println("Warning: failed to list changed files for commit %s: %v", commit.Hash.String()[:gitlog.ShaLen], err)
+		return ""
+	}
+	return projectConfig.PackageFor(paths)
+}
+
+// ticketLinks returns a link to ticket built from urlTemplate, or nil when
+// either is empty.
+func ticketLinks(ticket, urlTemplate string) changeset.Links {
+	if ticket == "" || urlTemplate == "" {
+		return nil
+	}
+	return changeset.Links{{Name: ticket, URL: strings.ReplaceAll(urlTemplate, "{ticket}", ticket)}}
+}
+
+// outputGeneratePlan reports a dry run as styled text or JSON.
+func outputGeneratePlan(from, to string, totalCommits int, stats GenerateStatistics, plan []GeneratePlanEntry) error {
+	if outputJSON {
+		output := GenerateOutput{
+			From:         from,
+			To:           to,
+			TotalCommits: totalCommits,
+			Statistics:   stats,
+			DryRun:       true,
+		}
+		if showPlan {
+			output.Plan = plan
+		}
+
+		jsonBytes, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal output to JSON: %w", err)
+		}
+		fmt.Println(string(jsonBytes))
+		return nil
+	}
+
+	if showPlan {
+		style.Newline()
+		for _, entry := range plan {
+			short := entry.CommitHash[:gitlog.ShaLen]
+			switch entry.Action {
+			case planActionAdd:
+				style.Addedf("+ add     %s %s: %s", short, entry.Type, entry.Summary)
+			case planActionSkip:
+				style.Println("= skip    %s %s: %s (%s)", short, entry.Type, entry.Summary, entry.Filename)
+			case planActionUpdate:
+				style.Warningf("~ update  %s %s: %s (was %s)", short, entry.Type, entry.Summary, entry.PreviousCommit[:gitlog.ShaLen])
+			}
+			for _, rename := range entry.Renames {
+				style.Println("          %s", rename)
+			}
+		}
+	}
+
+	style.Newline()
+	style.Headlinef("Dry run: would generate %d new changelog entries", stats.Created)
+	if stats.Duplicates > 0 {
+		style.Println("  Would skip %d duplicates", stats.Duplicates)
+	}
+	if stats.Rebased > 0 {
+		style.Println("  Would update %d rebased commits", stats.Rebased)
+	}
+	if stats.Skipped > 0 {
+		style.Println("  Would skip %d commits (reverts or non-matching types)", stats.Skipped)
+	}
+	return nil
+}
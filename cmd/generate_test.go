@@ -1,12 +1,20 @@
 package main
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/plumbing/object"
+	"github.com/stormlightlabs/git-storm/internal/changeset"
+	"github.com/stormlightlabs/git-storm/internal/github"
 	"github.com/stormlightlabs/git-storm/internal/gitlog"
 	"github.com/stormlightlabs/git-storm/internal/testutils"
+	"github.com/stormlightlabs/git-storm/internal/ui"
 )
 
 func TestGetCommitRange(t *testing.T) {
@@ -24,7 +32,7 @@ func TestGetCommitRange(t *testing.T) {
 	testutils.AddCommit(t, repo, "d.txt", "content d", "feat: add d feature")
 	testutils.AddCommit(t, repo, "e.txt", "content e", "fix: fix e bug")
 
-	rangeCommits, err := gitlog.GetCommitRange(repo, "v1.0.0", "HEAD")
+	rangeCommits, err := gitlog.GetCommitRange(repo, "v1.0.0", "HEAD", gitlog.RangeOptions{})
 	if err != nil {
 		t.Fatalf("gitlog.GetCommitRange() error = %v", err)
 	}
@@ -43,7 +51,7 @@ func TestGetCommitRange(t *testing.T) {
 func TestGetCommitRange_SameRef(t *testing.T) {
 	repo := testutils.SetupTestRepo(t)
 
-	rangeCommits, err := gitlog.GetCommitRange(repo, "HEAD", "HEAD")
+	rangeCommits, err := gitlog.GetCommitRange(repo, "HEAD", "HEAD", gitlog.RangeOptions{})
 	if err != nil {
 		t.Fatalf("gitlog.GetCommitRange() error = %v", err)
 	}
@@ -56,7 +64,7 @@ func TestGetCommitRange_SameRef(t *testing.T) {
 func TestGetCommitRange_InvalidRef(t *testing.T) {
 	repo := testutils.SetupTestRepo(t)
 
-	_, err := gitlog.GetCommitRange(repo, "invalid-ref", "HEAD")
+	_, err := gitlog.GetCommitRange(repo, "invalid-ref", "HEAD", gitlog.RangeOptions{})
 	if err == nil {
 		t.Errorf("Expected error for invalid ref, got nil")
 	}
@@ -82,22 +90,12 @@ func TestGenerateCmd_JSONOutput(t *testing.T) {
 	testutils.AddCommit(t, repo, "feat.txt", "content", "feat: add new feature")
 	testutils.AddCommit(t, repo, "fix.txt", "content", "fix: fix bug")
 
-	repoPath = worktree.Filesystem.Root()
+	chdirRepo(t, worktree.Filesystem.Root())
 	outputJSON = true
-
-	oldWd, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("Failed to get current directory: %v", err)
-	}
 	defer func() {
-		os.Chdir(oldWd)
 		outputJSON = false
 	}()
 
-	if err := os.Chdir(repoPath); err != nil {
-		t.Fatalf("Failed to change to temp directory: %v", err)
-	}
-
 	cmd := generateCmd()
 	cmd.SetArgs([]string{"v1.0.0", "HEAD"})
 
@@ -141,3 +139,538 @@ func TestGenerateCmd_InteractiveAndJSONConflict(t *testing.T) {
 		}
 	}
 }
+
+func TestPlanGenerate_Classification(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.AddCommit(t, repo, "new.txt", "content new", "feat: add new feature")
+	testutils.AddCommit(t, repo, "dup.txt", "content dup", "fix: duplicate fix")
+	testutils.AddCommit(t, repo, "moved.txt", "content moved", "feat: rebased feature")
+
+	commits := testutils.GetCommitHistory(t, repo)
+	parser := &gitlog.ConventionalParser{}
+
+	var items []ui.CommitItem
+	for _, commit := range commits[:3] {
+		meta, err := parser.Parse(commit.Hash.String(), strings.Split(commit.Message, "\n")[0], "", commit.Author.When)
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+		items = append(items, ui.CommitItem{Commit: commit, Meta: meta, Category: parser.Categorize(meta)})
+	}
+
+	hashes := make(map[string]string)
+	for _, item := range items {
+		diffHash, err := changeset.ComputeDiffHash(item.Commit)
+		if err != nil {
+			t.Fatalf("ComputeDiffHash() error = %v", err)
+		}
+		hashes[item.Meta.Description] = diffHash
+	}
+
+	existing := map[string]changeset.Metadata{
+		hashes["duplicate fix"]: {
+			CommitHash: commits[1].Hash.String(),
+			DiffHash:   hashes["duplicate fix"],
+			Filename:   "dup.md",
+		},
+		hashes["rebased feature"]: {
+			CommitHash: strings.Repeat("a", 40),
+			DiffHash:   hashes["rebased feature"],
+			Filename:   "moved.md",
+		},
+	}
+
+	plan, skipped := planGenerate(items, existing, planSources{})
+	testutils.Expect.Equal(t, skipped, 0)
+	testutils.Expect.Equal(t, len(plan), 3)
+
+	actions := make(map[string]GeneratePlanEntry)
+	for _, entry := range plan {
+		actions[entry.Summary] = entry
+	}
+
+	testutils.Expect.Equal(t, actions["add new feature"].Action, planActionAdd)
+	testutils.Expect.Equal(t, actions["duplicate fix"].Action, planActionSkip)
+	testutils.Expect.Equal(t, actions["duplicate fix"].Filename, "dup.md")
+	testutils.Expect.Equal(t, actions["rebased feature"].Action, planActionUpdate)
+	testutils.Expect.Equal(t, actions["rebased feature"].PreviousCommit, strings.Repeat("a", 40))
+}
+
+func TestPlanGenerate_Renames(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.RenameCommit(t, repo, "a.txt", "docs/a.txt", "docs: move a")
+	commit := testutils.GetCommitHistory(t, repo)[0]
+
+	oldShow, oldThreshold := showPlan, renameThreshold
+	t.Cleanup(func() { showPlan, renameThreshold = oldShow, oldThreshold })
+	items := []ui.CommitItem{{Commit: commit, Meta: gitlog.CommitMeta{Type: "docs", Description: "move a"}, Category: "changed"}}
+
+	showPlan, renameThreshold = true, gitlog.DefaultRenameThreshold
+	plan, _ := planGenerate(items, nil, planSources{})
+	testutils.Expect.Equal(t, len(plan), 1)
+	testutils.Expect.Equal(t, plan[0].Renames, []string{"a.txt → docs/a.txt"})
+
+	renameThreshold = 0
+	plan, _ = planGenerate(items, nil, planSources{})
+	testutils.Expect.Equal(t, len(plan[0].Renames), 0)
+}
+
+func TestGenerateCmd_DiffRequiresDryRun(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+
+	repoPath = worktree.Filesystem.Root()
+	defer func() { showPlan = false }()
+
+	cmd := generateCmd()
+	cmd.SetArgs([]string{"--diff", "HEAD~1", "HEAD"})
+
+	err = cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--diff requires --dry-run") {
+		t.Errorf("Expected --diff without --dry-run to fail, got %v", err)
+	}
+}
+
+func TestGenerateCmd_ConsolidatedRelease(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+
+	testutils.CreateTag(t, repo, "v0.1.0")
+	testutils.AddCommit(t, repo, "feat.txt", "content", "feat: add consolidated storage")
+	testutils.AddCommit(t, repo, "fix.txt", "content", "fix: handle missing news file")
+
+	chdirRepo(t, worktree.Filesystem.Root())
+	output = "CHANGELOG.md"
+	defer func() {
+		consolidatedPath = ""
+	}()
+
+	for range 2 {
+		cmd := generateCmd()
+		cmd.SetArgs([]string{"--consolidated", "news.yaml", "v0.1.0", "HEAD"})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("generateCmd() error = %v", err)
+		}
+	}
+
+	entries, err := changeset.ReadConsolidated("news.yaml")
+	if err != nil {
+		t.Fatalf("ReadConsolidated() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 2, "Re-running generate should not duplicate entries")
+
+	if _, err := os.Stat(".changes"); !os.IsNotExist(err) {
+		t.Errorf("Consolidated mode should not write .changes/*.md files")
+	}
+
+	release := releaseCmd()
+	release.SetArgs([]string{"--version", "0.2.0", "--date", "2025-01-15", "--consolidated", "news.yaml", "--clear-changes"})
+	if err := release.Execute(); err != nil {
+		t.Fatalf("releaseCmd() error = %v", err)
+	}
+
+	content, err := os.ReadFile("CHANGELOG.md")
+	if err != nil {
+		t.Fatalf("Failed to read CHANGELOG.md: %v", err)
+	}
+	testutils.Expect.True(t, strings.Contains(string(content), "## [0.2.0] - 2025-01-15"))
+	testutils.Expect.True(t, strings.Contains(string(content), "- add consolidated storage"))
+	testutils.Expect.True(t, strings.Contains(string(content), "- handle missing news file"))
+
+	remaining, err := changeset.ReadConsolidated("news.yaml")
+	if err != nil {
+		t.Fatalf("ReadConsolidated() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(remaining), 0, "--clear-changes should empty the consolidated file")
+}
+
+func TestGenerateCmd_NoMetadataDedup(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+
+	testutils.CreateTag(t, repo, "v0.1.0")
+	testutils.AddCommit(t, repo, "feat.txt", "content", "feat: frontmatter dedup")
+
+	chdirRepo(t, worktree.Filesystem.Root())
+	defer func() {
+		noMetadata = false
+	}()
+
+	for range 2 {
+		cmd := generateCmd()
+		cmd.SetArgs([]string{"--no-metadata", "v0.1.0", "HEAD"})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("generateCmd() error = %v", err)
+		}
+	}
+
+	entries, err := changeset.List(".changes")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 1, "Re-running generate should dedupe on frontmatter diff hash")
+
+	if _, err := os.Stat(".changes/data"); !os.IsNotExist(err) {
+		t.Errorf("--no-metadata should not write .changes/data")
+	}
+}
+
+func TestGenerateCmd_RebasedCommit(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+
+	testutils.CreateTag(t, repo, "v0.1.0")
+	testutils.AddCommit(t, repo, "feat.txt", "content", "feat: add search")
+
+	chdirRepo(t, worktree.Filesystem.Root())
+
+	generate := func() {
+		t.Helper()
+		cmd := generateCmd()
+		cmd.SetArgs([]string{"--since", "v0.1.0"})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("generateCmd() error = %v", err)
+		}
+	}
+
+	generate()
+
+	// Amend the commit: the same diff lands under a new commit hash.
+	if _, err := worktree.Commit("feat: add search (reworded)", &git.CommitOptions{
+		Amend:  true,
+		Author: &object.Signature{Name: "Test Author", Email: "test@example.com", When: time.Now()},
+	}); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	head := testutils.GetCommitHistory(t, repo)[0]
+
+	generate()
+
+	entries, err := changeset.List(".changes")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 1, "A rebased commit should not produce a second entry")
+
+	metadata, err := changeset.LoadExistingMetadata(".changes")
+	if err != nil {
+		t.Fatalf("LoadExistingMetadata() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(metadata), 1)
+	for _, meta := range metadata {
+		testutils.Expect.Equal(t, meta.CommitHash, head.Hash.String(), "Metadata should follow the rewritten commit")
+	}
+}
+
+func TestTicketLinks(t *testing.T) {
+	links := ticketLinks("ABC-1", "https://tracker.example.com/browse/{ticket}")
+	testutils.Expect.Equal(t, len(links), 1)
+	testutils.Expect.Equal(t, links[0].Name, "ABC-1")
+	testutils.Expect.Equal(t, links[0].URL, "https://tracker.example.com/browse/ABC-1")
+
+	testutils.Expect.Equal(t, len(ticketLinks("", "https://tracker.example.com/{ticket}")), 0)
+	testutils.Expect.Equal(t, len(ticketLinks("ABC-1", "")), 0)
+}
+
+func TestReconcileRebased(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.AddCommit(t, repo, "feat.txt", "content", "feat: rebased feature")
+	head := testutils.GetCommitHistory(t, repo)[0]
+
+	diffHash, err := changeset.ComputeDiffHash(head)
+	if err != nil {
+		t.Fatalf("ComputeDiffHash() error = %v", err)
+	}
+
+	changesDir := filepath.Join(t.TempDir(), ".changes")
+	oldCommit := strings.Repeat("a", 40)
+	if _, err := changeset.WriteWithMetadata(changesDir, changeset.Metadata{
+		CommitHash: oldCommit,
+		DiffHash:   diffHash,
+		Type:       "added",
+		Summary:    "rebased feature",
+	}); err != nil {
+		t.Fatalf("WriteWithMetadata() error = %v", err)
+	}
+
+	existing, err := changeset.LoadExistingMetadata(changesDir)
+	if err != nil {
+		t.Fatalf("LoadExistingMetadata() error = %v", err)
+	}
+
+	items := []ui.CommitItem{{Commit: head, Meta: gitlog.CommitMeta{Description: "rebased feature"}, Category: "added"}}
+	plan, _ := planGenerate(items, existing, planSources{})
+	testutils.Expect.Equal(t, len(plan), 1)
+	testutils.Expect.Equal(t, plan[0].Action, planActionUpdate)
+
+	reconciled, err := reconcileRebased(changeset.NewFSStore(changesDir), plan[0])
+	if err != nil {
+		t.Fatalf("reconcileRebased() error = %v", err)
+	}
+	testutils.Expect.Equal(t, reconciled.PreviousCommit, oldCommit)
+	testutils.Expect.Equal(t, reconciled.CommitHash, head.Hash.String())
+	testutils.Expect.Equal(t, reconciled.String(),
+		fmt.Sprintf("updated aaaaaaa → %s for entry %s-rebased-feature.md", head.Hash.String()[:7], diffHash[:7]))
+
+	updated, err := changeset.LoadExistingMetadata(changesDir)
+	if err != nil {
+		t.Fatalf("LoadExistingMetadata() error = %v", err)
+	}
+	testutils.Expect.Equal(t, updated[diffHash].CommitHash, head.Hash.String())
+}
+
+func TestApplyInferredScopes(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.AddFilesCommit(t, repo, map[string]string{
+		"internal/ui/view.go":  "package ui",
+		"internal/ui/model.go": "package ui",
+	}, "feat: add view")
+	testutils.AddFilesCommit(t, repo, map[string]string{
+		"internal/ui/extra.go": "package ui",
+		"cmd/main.go":          "package main",
+	}, "fix: mixed change")
+	testutils.AddCommit(t, repo, "internal/diff/diff.go", "package diff", "fix(core): scoped change")
+
+	history := testutils.GetCommitHistory(t, repo)
+	items := []ui.CommitItem{
+		{Commit: history[2], Meta: gitlog.CommitMeta{Description: "add view"}, Category: "added"},
+		{Commit: history[1], Meta: gitlog.CommitMeta{Description: "mixed change"}, Category: "fixed"},
+		{Commit: history[0], Meta: gitlog.CommitMeta{Scope: "core", Description: "scoped change"}, Category: "fixed"},
+	}
+
+	applyInferredScopes(items, nil)
+	testutils.Expect.Equal(t, items[0].Meta.Scope, "ui")
+	testutils.Expect.Equal(t, items[1].Meta.Scope, "", "mixed directories should not infer a scope")
+	testutils.Expect.Equal(t, items[2].Meta.Scope, "core", "explicit scopes are kept")
+
+	mapping, err := parseScopeMap([]string{"internal/ui/=tui"})
+	if err != nil {
+		t.Fatalf("parseScopeMap() error = %v", err)
+	}
+	items[0].Meta.Scope = ""
+	applyInferredScopes(items[:1], mapping)
+	testutils.Expect.Equal(t, items[0].Meta.Scope, "tui")
+
+	if _, err := parseScopeMap([]string{"no-equals"}); err == nil {
+		t.Error("parseScopeMap() expected error for malformed pair")
+	}
+}
+
+func TestScopeMapping(t *testing.T) {
+	previous := projectConfig
+	t.Cleanup(func() { projectConfig = previous })
+	projectConfig.ScopeMap = map[string]string{"internal/ui/": "tui", "cmd/": "cli"}
+
+	mapping, err := scopeMapping([]string{"internal/ui/=view"})
+	if err != nil {
+		t.Fatalf("scopeMapping() error = %v", err)
+	}
+	testutils.Expect.Equal(t, mapping, map[string]string{"internal/ui/": "view", "cmd/": "cli"}, "--scope-map should override the config")
+}
+
+func TestGenerateCmd_ConfiguredScopeInference(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+	chdirRepo(t, worktree.Filesystem.Root())
+	previous := projectConfig
+	t.Cleanup(func() { projectConfig = previous })
+	projectConfig.InferScope = true
+	projectConfig.ScopeMap = map[string]string{"internal/diff/": "differ"}
+
+	testutils.AddCommit(t, repo, "internal/diff/myers.go", "package diff", "fix: handle empty hunks")
+
+	cmd := generateCmd()
+	cmd.SetArgs([]string{"HEAD~1", "HEAD"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("generate error = %v", err)
+	}
+
+	entries, err := changeset.List(".changes")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 1)
+	testutils.Expect.Equal(t, entries[0].Entry.Scope, "differ")
+}
+
+func TestGenerateCmd_SkipsFixups(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+
+	testutils.CreateTag(t, repo, "v0.1.0")
+	testutils.AddCommit(t, repo, "feat.txt", "content", "feat: add search")
+	testutils.AddCommit(t, repo, "feat.txt", "content\nmore", "fixup! feat: add search")
+
+	chdirRepo(t, worktree.Filesystem.Root())
+	defer func() {
+		keepFixups = false
+	}()
+
+	cmd := generateCmd()
+	cmd.SetArgs([]string{"v0.1.0", "HEAD"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("generateCmd() error = %v", err)
+	}
+
+	entries, err := changeset.List(".changes")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 1, "fixup! commit should not produce an entry")
+	testutils.Expect.Equal(t, entries[0].Entry.Summary, "add search")
+
+	cmd = generateCmd()
+	cmd.SetArgs([]string{"--keep-fixups", "v0.1.0", "HEAD"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("generateCmd() --keep-fixups error = %v", err)
+	}
+
+	entries, err = changeset.List(".changes")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 2, "--keep-fixups should generate the fixup commit")
+}
+
+func TestGenerateCmd_WithBody(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+	chdirRepo(t, worktree.Filesystem.Root())
+	defer func() {
+		captureBody = false
+	}()
+
+	testutils.AddCommit(t, repo, "retry.go", "package retry", "fix: retry uploads\n\nRequests retry on 503.\n\nRefs: #42")
+
+	cmd := generateCmd()
+	cmd.SetArgs([]string{"--with-body", "HEAD~1", "HEAD"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("generate error = %v", err)
+	}
+
+	entries, err := changeset.List(".changes")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 1)
+	testutils.Expect.Equal(t, entries[0].Entry.Summary, "retry uploads")
+	testutils.Expect.Equal(t, entries[0].Entry.Body, "Requests retry on 503.", "footers are stripped from the body")
+}
+
+func TestGenerateCmd_References(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+	chdirRepo(t, worktree.Filesystem.Root())
+
+	testutils.AddCommit(t, repo, "upload.go", "package upload", "fix: retry uploads (#12)\n\nCloses #12\nRefs GH-40")
+
+	cmd := generateCmd()
+	cmd.SetArgs([]string{"HEAD~1", "HEAD"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("generate error = %v", err)
+	}
+
+	entries, err := changeset.List(".changes")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 1)
+	testutils.Expect.Equal(t, entries[0].Entry.References, []int{12, 40})
+}
+
+func TestGenerateCmd_AttributeAuthors(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+	chdirRepo(t, worktree.Filesystem.Root())
+	defer func() {
+		attributeAuthors = false
+	}()
+
+	testutils.AddCommit(t, repo, "pair.go", "package pair", "feat: add pairing\n\nCo-authored-by: Jane Doe <jane@example.com>\nCo-authored-by: Test Author <test@example.com>")
+
+	cmd := generateCmd()
+	cmd.SetArgs([]string{"--attribute-authors", "HEAD~1", "HEAD"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("generate error = %v", err)
+	}
+
+	entries, err := changeset.List(".changes")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 1)
+	testutils.Expect.Equal(t, entries[0].Entry.Authors, []string{"Test Author", "Jane Doe"}, "without a GitHub origin authors are thanked by name")
+}
+
+func TestCommitAuthors_Logins(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	history := testutils.GetCommitHistory(t, repo)
+
+	cache, err := github.LoadLoginCache(filepath.Join(t.TempDir(), "logins.json"))
+	if err != nil {
+		t.Fatalf("LoadLoginCache() error = %v", err)
+	}
+	cache.Store("test@example.com", "tester")
+	logins := &github.LoginResolver{Cache: cache}
+
+	got := commitAuthors(logins, history[0], "Co-authored-by: Octo <1+octocat@users.noreply.github.com>\nCo-authored-by: Jane <jane@example.com>")
+	testutils.Expect.Equal(t, got, []string{"@tester", "@octocat", "Jane"})
+}
+
+func TestGenerateCmd_NewContributors(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+	chdirRepo(t, worktree.Filesystem.Root())
+
+	testutils.CreateTag(t, repo, "v1.0.0")
+	testutils.AddCommit(t, repo, "a.go", "package a", "feat: add a")
+	testutils.AddAuthoredCommit(t, repo, "Jane Doe", "jane@example.com", "b.go", "package b", "feat: add b")
+	testutils.AddAuthoredCommit(t, repo, "Jane Doe", "jane@example.com", "c.go", "package c", "fix: fix c")
+
+	cmd := generateCmd()
+	cmd.SetArgs([]string{"--new-contributors", "v1.0.0", "HEAD"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("generate error = %v", err)
+	}
+
+	entries, err := changeset.List(".changes")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 3)
+	contributors := make(map[string][]string)
+	for _, e := range entries {
+		contributors[e.Entry.Summary] = e.Entry.NewContributors
+	}
+	testutils.Expect.Equal(t, contributors, map[string][]string{"add a": nil, "add b": {"Jane Doe"}, "fix c": nil})
+}
@@ -0,0 +1,250 @@
+/*
+USAGE
+
+	storm hooks install [options]
+
+FLAGS
+
+	--uninstall    Remove the hooks storm installed instead
+	--force        Replace existing hooks and ignore hook managers
+	--repo <path>  Path to the Git repository (default: .)
+
+Writes commit-msg and pre-push hooks into the repository's hooks directory,
+or core.hooksPath when it is set. The commit-msg hook lints the message with
+storm check --commit-msg; the pre-push hook lints the commits being pushed
+with storm check --lint and validates entries with storm check changes.
+
+Existing hooks that storm didn't write are left alone, and nothing is
+installed when husky or lefthook manages the repository's hooks; the storm
+commands to add to their config are printed instead. --uninstall removes only
+hooks storm wrote.
+*/
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stormlightlabs/git-storm/internal/gitlog"
+	"github.com/stormlightlabs/git-storm/internal/style"
+)
+
+// releaseHookEnv describes the release to a hook through STORM_* variables.
+type releaseHookEnv struct {
+	Version       string
+	Tag           string
+	Date          string
+	ChangelogPath string
+}
+
+// environ returns the current environment with the STORM_* variables added.
+func (e releaseHookEnv) environ() []string {
+	return append(os.Environ(),
+		"STORM_VERSION="+e.Version,
+		"STORM_TAG="+e.Tag,
+		"STORM_DATE="+e.Date,
+		"STORM_CHANGELOG_PATH="+e.ChangelogPath,
+	)
+}
+
+// runHook runs command through the shell in dir, writing its output to w.
+// An empty command does nothing; name labels errors, e.g. "pre_release".
+func runHook(name, command, dir string, env releaseHookEnv, w io.Writer) error {
+	if command == "" {
+		return nil
+	}
+
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	cmd := exec.Command(shell, flag, command)
+	cmd.Dir = dir
+	cmd.Env = env.environ()
+	cmd.Stdout = w
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s hook %q failed: %w", name, command, err)
+	}
+	return nil
+}
+
+// gitHookMarker identifies hooks written by storm hooks install.
+const gitHookMarker = "# Installed by storm hooks install."
+
+// gitHook is a git hook storm installs.
+type gitHook struct {
+	Name string
+	// Command is what to add to a hook manager's config instead.
+	Command string
+	Script  string
+}
+
+// gitHooks are the hooks storm hooks install writes.
+var gitHooks = []gitHook{
+	{
+		Name:    "commit-msg",
+		Command: `storm check --commit-msg "$1"`,
+		Script: `#!/bin/sh
+` + gitHookMarker + `
+exec storm check --commit-msg "$1"
+`,
+	},
+	{
+		Name:    "pre-push",
+		Command: "storm check changes",
+		Script: `#!/bin/sh
+` + gitHookMarker + `
+# Lint the commits being pushed, then validate the unreleased entries.
+while read -r local_ref local_sha remote_ref remote_sha; do
+	case $local_sha in *[!0]*) ;; *) continue ;; esac
+	case $remote_sha in *[!0]*) ;; *) continue ;; esac
+	git cat-file -e "$remote_sha" 2>/dev/null || continue
+	storm check "$remote_sha" "$local_sha" --lint || exit 1
+done
+exec storm check changes
+`,
+	},
+}
+
+// lefthookConfigs are the config files lefthook reads.
+var lefthookConfigs = []string{
+	"lefthook.yml", ".lefthook.yml", "lefthook.yaml", ".lefthook.yaml",
+	"lefthook.toml", ".lefthook.toml", "lefthook.json", ".lefthook.json",
+}
+
+// hookManager names the hook manager set up in the worktree at root, husky
+// or lefthook, or returns "" when there is none.
+func hookManager(root string) string {
+	if info, err := os.Stat(filepath.Join(root, ".husky")); err == nil && info.IsDir() {
+		return "husky"
+	}
+	for _, name := range lefthookConfigs {
+		if _, err := os.Stat(filepath.Join(root, name)); err == nil {
+			return "lefthook"
+		}
+	}
+	return ""
+}
+
+// installGitHooks writes [gitHooks] into hooksDir and returns their names.
+// Hooks storm didn't write are only replaced with force; otherwise nothing
+// is written.
+func installGitHooks(hooksDir string, force bool) ([]string, error) {
+	if !force {
+		for _, hook := range gitHooks {
+			data, err := os.ReadFile(filepath.Join(hooksDir, hook.Name))
+			if err == nil && !strings.Contains(string(data), gitHookMarker) {
+				return nil, fmt.Errorf("a %s hook already exists in %s; use --force to replace it", hook.Name, hooksDir)
+			}
+		}
+	}
+
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+	var names []string
+	for _, hook := range gitHooks {
+		if err := os.WriteFile(filepath.Join(hooksDir, hook.Name), []byte(hook.Script), 0755); err != nil {
+			return nil, fmt.Errorf("failed to write %s hook: %w", hook.Name, err)
+		}
+		names = append(names, hook.Name)
+	}
+	return names, nil
+}
+
+// uninstallGitHooks removes the hooks storm wrote to hooksDir and returns
+// their names. Other hooks are kept.
+func uninstallGitHooks(hooksDir string) ([]string, error) {
+	var names []string
+	for _, hook := range gitHooks {
+		path := filepath.Join(hooksDir, hook.Name)
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s hook: %w", hook.Name, err)
+		}
+		if !strings.Contains(string(data), gitHookMarker) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove %s hook: %w", hook.Name, err)
+		}
+		names = append(names, hook.Name)
+	}
+	return names, nil
+}
+
+func hooksCmd() *cobra.Command {
+	var uninstall bool
+	var force bool
+
+	install := &cobra.Command{
+		Use:   "install",
+		Short: "Install commit-msg and pre-push hooks that run storm check",
+		Long: `Writes a commit-msg hook running storm check --commit-msg and a pre-push hook
+running storm check --lint and storm check changes into the hooks directory
+(core.hooksPath when set). Existing hooks and hook managers such as husky and
+lefthook are left alone unless --force is given.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repo, err := gitlog.OpenRepo(repoPath)
+			if err != nil {
+				return fmt.Errorf("failed to open repository: %w", err)
+			}
+			worktree, err := repo.Worktree()
+			if err != nil {
+				return fmt.Errorf("failed to get worktree: %w", err)
+			}
+			hooksDir, err := gitlog.HooksDir(repo)
+			if err != nil {
+				return err
+			}
+
+			if uninstall {
+				removed, err := uninstallGitHooks(hooksDir)
+				if err != nil {
+					return err
+				}
+				if len(removed) == 0 {
+					style.Println("No storm hooks installed in %s", hooksDir)
+					return nil
+				}
+				style.Addedf("✓ Removed %s hooks from %s", strings.Join(removed, " and "), hooksDir)
+				return nil
+			}
+
+			if manager := hookManager(worktree.Filesystem.Root()); manager != "" && !force {
+				style.Println("%s manages this repository's hooks. Add storm to its config instead:", manager)
+				for _, hook := range gitHooks {
+					style.Println("  %s: %s", hook.Name, hook.Command)
+				}
+				return fmt.Errorf("hooks not installed: %s is in use (use --force to install anyway)", manager)
+			}
+
+			installed, err := installGitHooks(hooksDir, force)
+			if err != nil {
+				return err
+			}
+			style.Addedf("✓ Installed %s hooks in %s", strings.Join(installed, " and "), hooksDir)
+			return nil
+		},
+	}
+	install.Flags().BoolVar(&uninstall, "uninstall", false, "Remove the hooks storm installed")
+	install.Flags().BoolVar(&force, "force", false, "Replace existing hooks and install even when husky or lefthook is in use")
+
+	root := &cobra.Command{
+		Use:   "hooks",
+		Short: "Manage git hooks that run storm check",
+	}
+	root.AddCommand(install)
+	return root
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/git-storm/internal/changeset"
+	"github.com/stormlightlabs/git-storm/internal/config"
+	"github.com/stormlightlabs/git-storm/internal/testutils"
+)
+
+func TestRunHook(t *testing.T) {
+	dir := t.TempDir()
+	env := releaseHookEnv{Version: "1.2.0", Tag: "v1.2.0", Date: "2025-02-01", ChangelogPath: "CHANGELOG.md"}
+
+	var out bytes.Buffer
+	if err := runHook("post_release", `echo "$STORM_VERSION $STORM_TAG $STORM_DATE $STORM_CHANGELOG_PATH"; pwd`, dir, env, &out); err != nil {
+		t.Fatalf("runHook() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	testutils.Expect.Equal(t, lines[0], "1.2.0 v1.2.0 2025-02-01 CHANGELOG.md")
+	testutils.Expect.True(t, strings.HasSuffix(lines[1], dir), "hooks should run in the repository")
+
+	if err := runHook("pre_release", "", dir, env, &out); err != nil {
+		t.Errorf("an empty hook should be skipped, got %v", err)
+	}
+	err := runHook("pre_release", "exit 3", dir, env, &out)
+	if err == nil || !strings.Contains(err.Error(), `pre_release hook "exit 3" failed`) {
+		t.Errorf("runHook() error = %v, want the failing hook", err)
+	}
+}
+
+func TestReleaseCmd_Hooks(t *testing.T) {
+	dir := t.TempDir()
+	chdirRepo(t, dir)
+	output = "CHANGELOG.md"
+	previous := projectConfig
+	t.Cleanup(func() { projectConfig = previous })
+
+	initial := "# Changelog\n\n## [1.0.0] - 2025-01-01\n\n### Added\n\n- Initial release\n"
+	writeFile(t, "CHANGELOG.md", initial)
+	if _, err := changeset.Write(".changes", changeset.Entry{Type: "fixed", Summary: "Fix crash"}); err != nil {
+		t.Fatalf("Failed to write entry: %v", err)
+	}
+
+	projectConfig.Hooks = config.Hooks{PreRelease: "test -f .ready"}
+	cmd := releaseCmd()
+	cmd.SetArgs([]string{"--bump", "patch", "--date", "2025-02-01"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "release aborted") {
+		t.Fatalf("a failing pre_release hook should abort, got %v", err)
+	}
+	content, err := os.ReadFile("CHANGELOG.md")
+	if err != nil {
+		t.Fatalf("Failed to read changelog: %v", err)
+	}
+	testutils.Expect.Equal(t, string(content), initial, "an aborted release should not touch the changelog")
+
+	writeFile(t, ".ready", "")
+	projectConfig.Hooks.PostRelease = `echo "$STORM_TAG" > released.txt`
+	cmd = releaseCmd()
+	cmd.SetArgs([]string{"--bump", "patch", "--date", "2025-02-01"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("releaseCmd() error = %v", err)
+	}
+	released, err := os.ReadFile("released.txt")
+	if err != nil {
+		t.Fatalf("post_release hook should have run: %v", err)
+	}
+	testutils.Expect.Equal(t, string(released), "v1.0.1\n")
+}
+
+func TestInstallGitHooks(t *testing.T) {
+	hooksDir := filepath.Join(t.TempDir(), "hooks")
+
+	installed, err := installGitHooks(hooksDir, false)
+	if err != nil {
+		t.Fatalf("installGitHooks() error = %v", err)
+	}
+	testutils.Expect.Equal(t, installed, []string{"commit-msg", "pre-push"})
+	info, err := os.Stat(filepath.Join(hooksDir, "commit-msg"))
+	if err != nil {
+		t.Fatalf("commit-msg hook missing: %v", err)
+	}
+	testutils.Expect.True(t, info.Mode()&0100 != 0, "hooks must be executable")
+
+	if _, err := installGitHooks(hooksDir, false); err != nil {
+		t.Errorf("reinstalling storm's own hooks should succeed, got %v", err)
+	}
+
+	writeFile(t, filepath.Join(hooksDir, "pre-push"), "#!/bin/sh\nmake lint\n")
+	if _, err := installGitHooks(hooksDir, false); err == nil {
+		t.Error("installGitHooks() should not replace a foreign hook without force")
+	}
+
+	removed, err := uninstallGitHooks(hooksDir)
+	if err != nil {
+		t.Fatalf("uninstallGitHooks() error = %v", err)
+	}
+	testutils.Expect.Equal(t, removed, []string{"commit-msg"}, "foreign hooks are kept")
+	content, err := os.ReadFile(filepath.Join(hooksDir, "pre-push"))
+	if err != nil {
+		t.Fatalf("foreign hook removed: %v", err)
+	}
+	testutils.Expect.Equal(t, string(content), "#!/bin/sh\nmake lint\n")
+
+	if _, err := installGitHooks(hooksDir, true); err != nil {
+		t.Fatalf("installGitHooks() with force error = %v", err)
+	}
+	content, err = os.ReadFile(filepath.Join(hooksDir, "pre-push"))
+	if err != nil {
+		t.Fatalf("Failed to read hook: %v", err)
+	}
+	testutils.Expect.True(t, strings.Contains(string(content), "storm check changes"))
+}
+
+func TestHookManager(t *testing.T) {
+	root := t.TempDir()
+	testutils.Expect.Equal(t, hookManager(root), "")
+
+	writeFile(t, filepath.Join(root, "lefthook.yml"), "pre-commit:\n")
+	testutils.Expect.Equal(t, hookManager(root), "lefthook")
+
+	if err := os.Mkdir(filepath.Join(root, ".husky"), 0755); err != nil {
+		t.Fatalf("Failed to create .husky: %v", err)
+	}
+	testutils.Expect.Equal(t, hookManager(root), "husky")
+}
@@ -7,12 +7,26 @@ import (
 	"github.com/charmbracelet/fang"
 	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
+	"github.com/stormlightlabs/git-storm/internal/changeset"
+	"github.com/stormlightlabs/git-storm/internal/config"
 	"github.com/stormlightlabs/git-storm/internal/style"
 )
 
 var (
 	repoPath string
 	output   string
+	verbose  bool
+	noColor  bool
+	// noCompress is the default expansion state for diff views; --expanded overrides it per view.
+	noCompress bool
+	// changesDir holds unreleased entries; .storm.yaml's changes_dir overrides it.
+	changesDir = ".changes"
+	// projectConfig holds the defaults loaded from .storm.yaml or .storm.toml.
+	projectConfig config.Config
+	// typeFlags are the raw --types values; see changeTypes.
+	typeFlags []string
+	// changeTypes is the change type registry from --types or the config.
+	changeTypes = changeset.DefaultRegistry()
 )
 
 // TODO: use ldflags
@@ -29,21 +43,45 @@ func versionCmd() *cobra.Command {
 	}
 }
 
-func main() {
-	ctx := context.Background()
+// rootCmd builds the storm command tree.
+func rootCmd() *cobra.Command {
 	root := &cobra.Command{
 		Use:   "storm",
 		Short: "A Git-aware changelog manager for Go projects",
 		Long: `storm is a modern changelog generator inspired by Towncrier.
 It manages .changes/ entries, generates Keep a Changelog sections,
-and can review commits interactively through a TUI.`,
+and can review commits interactively through a TUI.
+
+Project defaults for these flags, the .changes directory, change types, the
+changelog header, and commit categories can be set in .storm.yaml or .storm.toml.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// Commands that parse their own flags run this once they have.
+			if cmd.DisableFlagParsing {
+				return nil
+			}
+			if verbose {
+				log.SetLevel(log.DebugLevel)
+			}
+			if noColor {
+				style.SetColorMode(style.ColorNever)
+			}
+			return applyProjectConfig(cmd)
+		},
 	}
 
 	root.PersistentFlags().StringVar(&repoPath, "repo", ".", "Path to the Git repository")
 	root.PersistentFlags().StringVarP(&output, "output", "o", "CHANGELOG.md", "Output changelog file path")
-	root.AddCommand(generateCmd(), unreleasedCmd(), releaseCmd(), bumpCmd(), diffCmd(), checkCmd(), versionCmd())
+	root.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (also honors NO_COLOR)")
+	root.PersistentFlags().BoolVar(&verbose, "verbose", false, "Log diagnostic details to stderr")
+	root.PersistentFlags().BoolVar(&noCompress, "no-compress", false, "Show unchanged lines in diffs by default instead of compressing them")
+	root.PersistentFlags().StringSliceVar(&typeFlags, "types", nil, "Change types in section order, as name or name=Title (overrides the config)")
+	root.AddCommand(generateCmd(), unreleasedCmd(), releaseCmd(), bumpCmd(), diffCmd(), checkCmd(), hooksCmd(), changelogCmd(), exportCmd(), traceCmd(), versionCmd())
+	return root
+}
 
-	if err := fang.Execute(ctx, root, fang.WithColorSchemeFunc(style.NewColorScheme)); err != nil {
+func main() {
+	ctx := context.Background()
+	if err := fang.Execute(ctx, rootCmd(), fang.WithColorSchemeFunc(style.NewColorScheme)); err != nil {
 		log.Fatalf("Execution failed: %v", err)
 	}
 }
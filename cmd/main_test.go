@@ -0,0 +1,22 @@
+package main
+
+import "testing"
+
+// chdirTemp switches into a fresh temp directory for the rest of the test.
+func chdirTemp(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Chdir(dir)
+	return dir
+}
+
+// chdirRepo points repoPath at dir and switches into it for the rest of the
+// test. repoPath and output are restored when the test ends, so tests may
+// set output as well.
+func chdirRepo(t *testing.T, dir string) {
+	t.Helper()
+	oldRepo, oldOutput := repoPath, output
+	t.Cleanup(func() { repoPath, output = oldRepo, oldOutput })
+	repoPath = dir
+	t.Chdir(dir)
+}
@@ -2,15 +2,39 @@
 USAGE
 
 	storm release --version <X.Y.Z> [options]
+	storm release yank <X.Y.Z> [--reason <text>]
 
 FLAGS
 
 	--version <X.Y.Z>     Semantic version for the new release (required)
-	--bump <type>         Automatically bump the previous version (major|minor|patch)
+	--bump <type>         Automatically bump the previous version (major|minor|patch|auto)
+	--bump-from-commits   Infer the bump from conventional commits since the last release tag
+	--bump-from-entries   Infer the bump from pending entries (removed/breaking: major, added/deprecated: minor)
+	--package <name>      Release one package from the config to its changelog and <name>/vX.Y.Z tag
+	--since <ref>         Generate deduplicated entries for <ref>..HEAD first
+	-i, --interactive     With --since, select commits in a TUI before building
 	--date <YYYY-MM-DD>   Release date (default: today)
 	--clear-changes       Delete .changes/*.md files after successful release
+	--consolidated <f>    Read entries from one YAML file instead of .changes/*.md
+	--snapshot            Archive the release notes to .changes/released/<version>.md
 	--dry-run             Preview changes without writing files
+	--validate-only       Check the release would succeed without writing anything
 	--tag                 Create an annotated Git tag with release notes
+	--sign                With --tag, sign the tag with your configured GPG or SSH key
+	--push                With --tag, push the tag to the remote
+	--push-branch         With --push, also push the current branch
+	--remote <name>       Remote to push to (default: origin)
+	--github-release      With --push, create a GitHub Release (token from GITHUB_TOKEN)
+	--draft               With --github-release, create the release as a draft
+	--prerelease          With --github-release, mark the release as a prerelease
+	--with-hash           Append the short commit hash to each entry
+	--format <profile>    Output profile: keepachangelog or common-changelog
+	--section-order <t>   Comma-separated section order (overrides the profile)
+	--date-format <fmt>   Go time layout for version dates (overrides the profile)
+	--breaking-section    Collect breaking entries into their own section
+	--group-by-scope      Render entries sharing a scope together within each section
+	--scopeless-label <l> Label for scopeless entries when grouping (default: General)
+	--changelog-template <f> Render the whole changelog with the text/template in f
 	--toolchain <value>   Update toolchain manifests (path/type or 'interactive')
 	--output-json         Output results as JSON
 	--repo <path>         Path to the Git repository (default: .)
@@ -19,20 +43,29 @@ FLAGS
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/plumbing"
 	"github.com/go-git/go-git/v6/plumbing/object"
 	"github.com/spf13/cobra"
 	"github.com/stormlightlabs/git-storm/internal/changelog"
 	"github.com/stormlightlabs/git-storm/internal/changeset"
+	"github.com/stormlightlabs/git-storm/internal/config"
+	"github.com/stormlightlabs/git-storm/internal/github"
+	"github.com/stormlightlabs/git-storm/internal/gitlog"
 	"github.com/stormlightlabs/git-storm/internal/shared"
 	"github.com/stormlightlabs/git-storm/internal/style"
+	"github.com/stormlightlabs/git-storm/internal/toolchain"
+	"github.com/stormlightlabs/git-storm/internal/tty"
 	"github.com/stormlightlabs/git-storm/internal/versioning"
 )
 
@@ -44,9 +77,12 @@ type ReleaseOutput struct {
 	ChangelogPath     string             `json:"changelog_path"`
 	TagCreated        bool               `json:"tag_created"`
 	TagName           string             `json:"tag_name,omitempty"`
+	PushedRefs        []string           `json:"pushed_refs,omitempty"`
+	GitHubReleaseURL  string             `json:"github_release_url,omitempty"`
 	ChangesCleared    bool               `json:"changes_cleared"`
 	DeletedCount      int                `json:"deleted_count,omitempty"`
 	ToolchainsUpdated []string           `json:"toolchains_updated,omitempty"`
+	SnapshotPath      string             `json:"snapshot_path,omitempty"`
 	DryRun            bool               `json:"dry_run"`
 	VersionData       *changelog.Version `json:"version_data"`
 }
@@ -59,18 +95,158 @@ func releaseCmd() *cobra.Command {
 		clearChanges bool
 		dryRun       bool
 		tag          bool
+		sign         bool
+		push         bool
+		pushBranch   bool
+		remote       string
+		ghRelease    bool
+		draft        bool
+		prerelease   bool
 		toolchains   []string
 		outputJSON   bool
+		withHash     bool
+		validateOnly bool
+		consolidated string
+		snapshot     bool
+		format       string
+		sectionOrder []string
+		dateFormat   string
+		breakingSect bool
+		fromCommits  bool
+		fromEntries  bool
+		templatePath string
+		since        string
+		interactive  bool
+		groupByScope bool
+		scopeless    string
+		packageName  string
 	)
 
 	c := &cobra.Command{
 		Use:   "release",
 		Short: "Promote unreleased changes into a new changelog version",
 		Long: `Merges all .changes entries into CHANGELOG.md under a new version header.
-Optionally creates a Git tag and clears the .changes directory.`,
+Optionally creates a Git tag and clears the .changes directory.
+
+With --since <ref>, entries for <ref>..HEAD are generated (and deduplicated)
+first, so one command goes from commits to a tagged release. Add --push to
+push the tag (and, with --push-branch, the current branch) to origin using
+the SSH agent or your git credential helpers. --github-release then creates
+a GitHub Release for the pushed tag with the version's sections as its notes,
+using the token in GITHUB_TOKEN.
+
+With --package, only that package's entries are released, into its own
+changelog and under a <package>/vX.Y.Z tag. Without it, entries that belong
+to a package are left for that package's release.
+
+With --bump-from-entries (or --bump auto), the bump is inferred from the
+pending entries, including those --since generates: removals and breaking
+changes are major, additions and deprecations are minor, and everything else
+is patch.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			changelogPath := filepath.Join(repoPath, output)
-			existingChangelog, err := changelog.Parse(changelogPath)
+			var pkg config.Package
+			if packageName != "" {
+				var ok bool
+				if pkg, ok = projectConfig.Package(packageName); !ok {
+					return fmt.Errorf("unknown package %q: add it to the config's packages", packageName)
+				}
+				changelogPath = filepath.Join(repoPath, pkg.ChangelogPath())
+			}
+
+			if interactive && since == "" {
+				return fmt.Errorf("--interactive requires --since")
+			}
+			if interactive && !tty.IsInteractive() {
+				return tty.ErrorInteractiveFlag("--interactive")
+			}
+
+			if sign && !tag {
+				return fmt.Errorf("--sign requires --tag")
+			}
+			if push && !tag {
+				return fmt.Errorf("--push requires --tag")
+			}
+			if pushBranch && !push {
+				return fmt.Errorf("--push-branch requires --push")
+			}
+			if ghRelease && !tag {
+				return fmt.Errorf("--github-release requires --tag")
+			}
+			if ghRelease && !push {
+				return fmt.Errorf("--github-release requires --push so the release is created for the pushed tag")
+			}
+			if (draft || prerelease) && !ghRelease {
+				return fmt.Errorf("--draft and --prerelease require --github-release")
+			}
+			if ghRelease && !dryRun && os.Getenv("GITHUB_TOKEN") == "" {
+				return fmt.Errorf("--github-release requires GITHUB_TOKEN to be set")
+			}
+
+			if fromCommits && fromEntries {
+				return fmt.Errorf("--bump-from-commits cannot be used with --bump-from-entries")
+			}
+
+			if fromCommits {
+				if version != "" || bumpKind != "" {
+					return fmt.Errorf("--bump-from-commits cannot be used with --version or --bump")
+				}
+				kind, count, err := inferBumpFromCommits(repoPath, changelogPath, packageName)
+				if err != nil {
+					return err
+				}
+				if !outputJSON {
+					style.Println("Inferred %s bump from %d commits", kind, count)
+				}
+				bumpKind = string(kind)
+			}
+
+			// Generated entries are planned up front so --bump-from-entries sees
+			// them, and written only once the pre-release hook passes.
+			var generation *releaseGeneration
+			if since != "" {
+				planned, err := planForRelease(since, consolidated, interactive)
+				if err != nil {
+					return err
+				}
+				generation = planned
+			}
+
+			if strings.EqualFold(bumpKind, versioning.BumpAuto) {
+				if fromEntries {
+					return fmt.Errorf("--bump auto cannot be used with --bump-from-entries")
+				}
+				bumpKind, fromEntries = "", true
+			}
+
+			if fromEntries {
+				if version != "" || bumpKind != "" {
+					return fmt.Errorf("--bump-from-entries cannot be used with --version or --bump")
+				}
+				kind, reason, count, err := inferBumpFromEntries(consolidated, packageName, generation.entries())
+				if err != nil {
+					return err
+				}
+				if !outputJSON {
+					style.Println("Inferred %s bump from %d entries: %s", kind, count, reason)
+				}
+				bumpKind = string(kind)
+			}
+
+			if validateOnly {
+				resolved, problems := validateRelease(repoPath, changelogPath, changesDir, consolidated, version, bumpKind, date, toolchains)
+				if len(problems) > 0 {
+					style.Warningf("✗ Release validation failed")
+					for _, problem := range problems {
+						style.Println("  - %s", problem)
+					}
+					return fmt.Errorf("release validation failed with %d problem(s)", len(problems))
+				}
+				style.Successf("✓ Release %s is valid", resolved)
+				return nil
+			}
+
+			existingChangelog, err := changelog.ParseCached(changelogPath)
 			if err != nil {
 				return fmt.Errorf("failed to parse changelog: %w", err)
 			}
@@ -95,14 +271,51 @@ Optionally creates a Git tag and clears the .changes directory.`,
 				style.Newline()
 			}
 
-			changesDir := ".changes"
-			entries, err := changeset.List(changesDir)
+			// Hook output goes to stderr with --output-json to keep stdout parseable.
+			hookOut := io.Writer(os.Stdout)
+			if outputJSON {
+				hookOut = os.Stderr
+			}
+			tagName := releaseTagName(pkg, version)
+			hookEnv := releaseHookEnv{
+				Version:       version,
+				Tag:           tagName,
+				Date:          releaseDate,
+				ChangelogPath: changelogPath,
+			}
+			hooks := projectConfig.Hooks
+			if dryRun {
+				if (hooks.PreRelease != "" || hooks.PostRelease != "") && !outputJSON {
+					style.Warningf("Skipping release hooks (--dry-run)")
+				}
+			} else if err := runHook("pre_release", hooks.PreRelease, repoPath, hookEnv, hookOut); err != nil {
+				return fmt.Errorf("release aborted: %w", err)
+			}
+
+			var planned []changeset.Entry
+			if dryRun {
+				planned = generation.entries()
+			} else if err := generation.apply(outputJSON); err != nil {
+				return err
+			}
+
+			source := changesDir
+			if consolidated != "" {
+				source = consolidated
+			}
+			store := openStore(changesDir, consolidated)
+			entries, err := store.List()
 			if err != nil {
-				return fmt.Errorf("failed to read .changes directory: %w", err)
+				return fmt.Errorf("failed to read unreleased entries: %w", err)
 			}
 
+			for _, entry := range planned {
+				entries = append(entries, changeset.EntryWithFile{Entry: entry})
+			}
+			entries, held := entriesForPackage(entries, packageName)
+
 			if len(entries) == 0 {
-				return fmt.Errorf("no unreleased changes found in %s", changesDir)
+				return fmt.Errorf("no unreleased changes found in %s", source)
 			}
 
 			if !outputJSON {
@@ -115,7 +328,32 @@ Optionally creates a Git tag and clears the .changes directory.`,
 				entryList = append(entryList, e.Entry)
 			}
 
-			newVersion, err := changelog.Build(entryList, version, releaseDate)
+			buildOpts, err := changelog.ProfileOptions(format)
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("section-order") {
+				buildOpts.SectionOrder = sectionOrder
+			}
+			if cmd.Flags().Changed("date-format") {
+				buildOpts.DateFormat = dateFormat
+			}
+			if cmd.Flags().Changed("breaking-section") {
+				buildOpts.BreakingSection = breakingSect
+			}
+			buildOpts.GroupByScope = groupByScope
+			buildOpts.ScopelessLabel = scopeless
+			buildOpts.Types = changeTypes
+			if buildOpts.Template, err = changelogTemplate(templatePath); err != nil {
+				return err
+			}
+			buildOpts.WithHash = withHash
+			buildOpts.Hosts = configuredHosts()
+			if remote, err := changelog.RemoteRepo(repoPath, buildOpts.Hosts); err == nil {
+				buildOpts.RepoURL, buildOpts.Forge = remote.URL, remote.Forge
+			}
+
+			newVersion, err := changelog.BuildWithOptions(entryList, version, releaseDate, buildOpts)
 			if err != nil {
 				return fmt.Errorf("failed to build version: %w", err)
 			}
@@ -152,7 +390,8 @@ Optionally creates a Git tag and clears the .changes directory.`,
 				return nil
 			}
 
-			if err := changelog.Write(changelogPath, existingChangelog, repoPath); err != nil {
+			applyConfiguredHeader(existingChangelog)
+			if err := changelog.WriteWithOptions(changelogPath, existingChangelog, repoPath, buildOpts); err != nil {
 				return fmt.Errorf("failed to write CHANGELOG.md: %w", err)
 			}
 
@@ -160,13 +399,41 @@ Optionally creates a Git tag and clears the .changes directory.`,
 				style.Addedf("✓ Updated %s", changelogPath)
 			}
 
-			if clearChanges {
+			if snapshot {
+				snapshotDir := filepath.Join(changesDir, "released", pkg.Name)
+				snapshotPath, created, err := changelog.WriteSnapshot(snapshotDir, newVersion, buildOpts)
+				if err != nil {
+					return err
+				}
+				releaseOutput.SnapshotPath = snapshotPath
+				if !outputJSON {
+					if created {
+						style.Addedf("✓ Archived release notes to %s", snapshotPath)
+					} else {
+						style.Warningf("Snapshot %s already exists; leaving it unchanged", snapshotPath)
+					}
+				}
+			}
+
+			if clearChanges && consolidated != "" {
+				var kept []changeset.Entry
+				for _, e := range held {
+					kept = append(kept, e.Entry)
+				}
+				if err := changeset.WriteConsolidated(consolidated, kept); err != nil {
+					return err
+				}
+				releaseOutput.ChangesCleared = true
+				releaseOutput.DeletedCount = len(entries)
+				if !outputJSON {
+					style.Println("✓ Cleared %d entries from %s", len(entries), consolidated)
+				}
+			} else if clearChanges {
 				deletedCount := 0
 				for _, entry := range entries {
-					filePath := filepath.Join(changesDir, entry.Filename)
-					if err := os.Remove(filePath); err != nil {
+					if err := store.Delete(entry.Filename); err != nil {
 						if !outputJSON {
-							style.Println("Warning: failed to delete %s: %v", filePath, err)
+							style.Println("Warning: failed to delete %s: %v", filepath.Join(changesDir, entry.Filename), err)
 						}
 						continue
 					}
@@ -195,10 +462,9 @@ Optionally creates a Git tag and clears the .changes directory.`,
 			}
 
 			if tag {
-				if err := createReleaseTag(repoPath, version, newVersion); err != nil {
+				if err := createReleaseTag(repoPath, tagName, version, newVersion, sign); err != nil {
 					return fmt.Errorf("failed to create Git tag: %w", err)
 				}
-				tagName := fmt.Sprintf("v%s", version)
 				releaseOutput.TagCreated = true
 				releaseOutput.TagName = tagName
 				if !outputJSON {
@@ -207,6 +473,41 @@ Optionally creates a Git tag and clears the .changes directory.`,
 				}
 			}
 
+			if push {
+				pushed, err := pushRelease(repoPath, remote, releaseOutput.TagName, pushBranch)
+				if err != nil {
+					return err
+				}
+				releaseOutput.PushedRefs = pushed
+				if !outputJSON {
+					for _, ref := range pushed {
+						style.Addedf("✓ Pushed %s to %s", ref, remote)
+					}
+				}
+			}
+
+			if ghRelease {
+				release := github.Release{
+					TagName:    releaseOutput.TagName,
+					Name:       releaseOutput.TagName,
+					Body:       changelog.RenderSections(newVersion, buildOpts),
+					Draft:      draft,
+					Prerelease: prerelease,
+				}
+				releaseURL, err := createGitHubRelease(cmd.Context(), repoPath, release)
+				if err != nil {
+					return err
+				}
+				releaseOutput.GitHubReleaseURL = releaseURL
+				if !outputJSON {
+					style.Addedf("✓ Created GitHub Release %s", releaseURL)
+				}
+			}
+
+			if err := runHook("post_release", hooks.PostRelease, repoPath, hookEnv, hookOut); err != nil {
+				return err
+			}
+
 			if outputJSON {
 				jsonBytes, err := json.MarshalIndent(releaseOutput, "", "  ")
 				if err != nil {
@@ -224,17 +525,158 @@ Optionally creates a Git tag and clears the .changes directory.`,
 	}
 
 	c.Flags().StringVar(&version, "version", "", "Semantic version for the new release (e.g., 1.3.0)")
-	c.Flags().StringVar(&bumpKind, "bump", "", "Automatically bump the previous version (major, minor, or patch)")
+	c.Flags().StringVar(&bumpKind, "bump", "", "Automatically bump the previous version (major, minor, patch, or auto to infer it from pending entries)")
+	c.Flags().BoolVar(&fromCommits, "bump-from-commits", false, "Infer the bump from conventional commits since the last release tag")
+	c.Flags().BoolVar(&fromEntries, "bump-from-entries", false, "Infer the bump from pending entries: removed/breaking is major, added/deprecated is minor")
+	c.Flags().StringVar(&packageName, "package", "", "Release only this package from the config's packages, to its changelog and <package>/vX.Y.Z tag")
+	c.Flags().StringVar(&since, "since", "", "Generate entries for <ref>..HEAD before releasing, like storm generate --since")
+	c.Flags().BoolVarP(&interactive, "interactive", "i", false, "With --since, pick the commits to include in a TUI")
 	c.Flags().StringVar(&date, "date", "", "Release date in YYYY-MM-DD format (default: today)")
+	c.Flags().StringVar(&consolidated, "consolidated", "", "Read entries from a single YAML file (e.g. news.yaml) instead of .changes/*.md")
 	c.Flags().BoolVar(&clearChanges, "clear-changes", false, "Delete .changes/*.md files after successful release")
+	c.Flags().BoolVar(&snapshot, "snapshot", false, "Archive the rendered release notes to .changes/released/<version>.md")
 	c.Flags().BoolVar(&dryRun, "dry-run", false, "Preview changes without writing files")
+	c.Flags().BoolVar(&validateOnly, "validate-only", false, "Validate the release without writing files; exits non-zero on problems")
 	c.Flags().BoolVar(&tag, "tag", false, "Create an annotated Git tag with release notes")
+	c.Flags().BoolVar(&sign, "sign", false, "With --tag, sign the tag with your configured GPG or SSH key (like git tag -s)")
+	c.Flags().BoolVar(&push, "push", false, "With --tag, push the tag to the remote")
+	c.Flags().BoolVar(&pushBranch, "push-branch", false, "With --push, also push the current branch")
+	c.Flags().StringVar(&remote, "remote", "origin", "Remote to push the release to")
+	c.Flags().BoolVar(&ghRelease, "github-release", false, "With --push, create a GitHub Release using the token in GITHUB_TOKEN")
+	c.Flags().BoolVar(&draft, "draft", false, "With --github-release, create the release as a draft")
+	c.Flags().BoolVar(&prerelease, "prerelease", false, "With --github-release, mark the release as a prerelease")
+	c.Flags().BoolVar(&withHash, "with-hash", false, "Append the short commit hash to each changelog entry")
+	c.Flags().StringVar(&format, "format", changelog.ProfileKeepAChangelog, "Changelog output profile: keepachangelog or common-changelog")
+	c.Flags().StringSliceVar(&sectionOrder, "section-order", nil, "Comma-separated section order, e.g. security,fixed,added (overrides --format)")
+	c.Flags().StringVar(&dateFormat, "date-format", "", "Go time layout for version dates, e.g. 'January 2, 2006' (overrides --format)")
+	c.Flags().StringVar(&templatePath, "changelog-template", "", "Go text/template file controlling the whole CHANGELOG.md layout")
+	c.Flags().BoolVar(&breakingSect, "breaking-section", false, "Collect breaking entries into a dedicated section (overrides --format)")
+	c.Flags().BoolVar(&groupByScope, "group-by-scope", false, "Order entries within each section by scope so shared scopes render together")
+	c.Flags().StringVar(&scopeless, "scopeless-label", "General", "With --group-by-scope, scope label for entries without one; empty lists them first unlabeled")
 	c.Flags().StringSliceVar(&toolchains, "toolchain", nil, "Toolchain manifests to update (paths, types, or 'interactive')")
 	c.Flags().BoolVar(&outputJSON, "output-json", false, "Output results as JSON")
 
+	c.AddCommand(releaseYankCmd())
 	return c
 }
 
+// releaseYankCmd marks a released version as yanked in the changelog.
+func releaseYankCmd() *cobra.Command {
+	var reason string
+
+	c := &cobra.Command{
+		Use:   "yank <X.Y.Z>",
+		Short: "Mark a released version as yanked",
+		Long: `Marks a version's heading in CHANGELOG.md as "[YANKED]", following Keep a
+Changelog, for releases pulled because of a serious bug or security issue.
+With --reason, the explanation is added under a "Yanked" section. Tags and
+.changes are left untouched.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			changelogPath := filepath.Join(repoPath, output)
+			existing, err := changelog.Parse(changelogPath)
+			if err != nil {
+				return fmt.Errorf("failed to parse changelog: %w", err)
+			}
+
+			version, err := changelog.Yank(existing, args[0], reason)
+			if err != nil {
+				return err
+			}
+
+			opts, err := writeOptions()
+			if err != nil {
+				return err
+			}
+			applyConfiguredHeader(existing)
+			if err := changelog.WriteWithOptions(changelogPath, existing, repoPath, opts); err != nil {
+				return fmt.Errorf("failed to write changelog: %w", err)
+			}
+
+			style.Warningf("✓ Marked %s as yanked in %s", version.Number, changelogPath)
+			return nil
+		},
+	}
+	c.Flags().StringVar(&reason, "reason", "", "Why the version was yanked, added as an entry of a Yanked section")
+	return c
+}
+
+// validateRelease runs the release pipeline without writing anything.
+//
+// Returns the resolved version and every problem found; an empty slice means the release would succeed.
+func validateRelease(repoDir, changelogPath, changesDir, consolidated, versionFlag, bumpFlag, date string, toolchains []string) (string, []string) {
+	var problems []string
+
+	existing, err := changelog.ParseCached(changelogPath)
+	if err != nil {
+		return "", []string{fmt.Sprintf("failed to parse changelog: %v", err)}
+	}
+
+	version, err := resolveReleaseVersion(versionFlag, bumpFlag, existing)
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	if version != "" {
+		for _, v := range existing.Versions {
+			if v.Number == version {
+				problems = append(problems, fmt.Sprintf("version %s already exists in %s", version, filepath.Base(changelogPath)))
+				break
+			}
+		}
+	}
+
+	releaseDate := date
+	if releaseDate == "" {
+		releaseDate = time.Now().Format("2006-01-02")
+	} else if err := changelog.ValidateDate(releaseDate); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	source := changesDir
+	if consolidated != "" {
+		source = consolidated
+	}
+	entries, err := openStore(changesDir, consolidated).List()
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("failed to read %s: %v", source, err))
+	} else if len(entries) == 0 {
+		problems = append(problems, fmt.Sprintf("no unreleased changes found in %s", source))
+	}
+
+	var entryList []changeset.Entry
+	for _, e := range entries {
+		if e.Entry.Type == "" || strings.TrimSpace(e.Entry.Summary) == "" {
+			problems = append(problems, fmt.Sprintf("%s is missing a type or summary", e.Filename))
+			continue
+		}
+		entryList = append(entryList, e.Entry)
+	}
+
+	if version != "" && len(problems) == 0 {
+		if _, err := changelog.BuildWithOptions(entryList, version, releaseDate, changelog.Options{Types: changeTypes}); err != nil {
+			problems = append(problems, fmt.Sprintf("failed to build version: %v", err))
+		}
+	}
+
+	if len(toolchains) > 0 {
+		selected, _, _, err := toolchain.ResolveTargets(repoDir, toolchains)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("failed to resolve toolchains: %v", err))
+		}
+
+		versions := make(map[string]bool)
+		for _, manifest := range dedupeManifests(selected) {
+			versions[manifest.Version] = true
+		}
+		if len(versions) > 1 {
+			problems = append(problems, "toolchain manifests disagree on the current version")
+		}
+	}
+
+	return version, problems
+}
+
 func resolveReleaseVersion(versionFlag, bumpFlag string, existing *changelog.Changelog) (string, error) {
 	if bumpFlag == "" {
 		if versionFlag == "" {
@@ -263,9 +705,208 @@ func resolveReleaseVersion(versionFlag, bumpFlag string, existing *changelog.Cha
 	return versioning.Next(current, kind)
 }
 
+// inferBumpFromCommits infers the bump level from the conventional commits since
+// the latest release's v<version> tag, or from the full history when that tag is missing.
+// For a package, its <package>/v<version> tag is used and only commits
+// attributed to it count.
+func inferBumpFromCommits(repoDir, changelogPath, packageName string) (versioning.BumpType, int, error) {
+	repo, err := gitlog.OpenRepo(repoDir)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	existing, err := changelog.ParseCached(changelogPath)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to parse changelog: %w", err)
+	}
+
+	var commits []*object.Commit
+	latest, ok := versioning.LatestVersion(existing)
+	pkg, _ := projectConfig.Package(packageName)
+	latestTag := releaseTagName(pkg, latest)
+	if _, tagErr := repo.Tag(latestTag); ok && tagErr == nil {
+		commits, err = gitlog.GetCommitRange(repo, latestTag, "HEAD", gitlog.RangeOptions{})
+	} else {
+		commits, err = allCommits(repo)
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	if pkg.Name != "" {
+		commits = slices.DeleteFunc(commits, func(commit *object.Commit) bool {
+			return commitPackage(commit) != pkg.Name
+		})
+	}
+	if len(commits) == 0 {
+		return "", 0, fmt.Errorf("no commits found since the last release")
+	}
+
+	parser := newConventionalParser()
+	metas := make([]gitlog.CommitMeta, 0, len(commits))
+	for _, commit := range commits {
+		subject, body, _ := strings.Cut(commit.Message, "\n")
+		meta, err := parser.Parse(commit.Hash.String(), subject, body, commit.Author.When)
+		if err != nil {
+			continue
+		}
+		metas = append(metas, meta)
+	}
+
+	return versioning.InferBumpFromCommits(metas), len(commits), nil
+}
+
+// inferBumpFromEntries infers the bump level from the pending .changes entries,
+// or from the consolidated file when one is given, plus the planned entries not
+// written yet, and explains the choice.
+func inferBumpFromEntries(consolidated, packageName string, planned []changeset.Entry) (versioning.BumpType, string, int, error) {
+	source := changesDir
+	if consolidated != "" {
+		source = consolidated
+	}
+	entries, err := openStore(changesDir, consolidated).List()
+	if err != nil {
+		return "", "", 0, fmt.Errorf("failed to read unreleased entries: %w", err)
+	}
+	for _, entry := range planned {
+		entries = append(entries, changeset.EntryWithFile{Entry: entry})
+	}
+	entries, _ = entriesForPackage(entries, packageName)
+	if len(entries) == 0 {
+		return "", "", 0, fmt.Errorf("no unreleased changes found in %s", source)
+	}
+
+	entryList := make([]changeset.Entry, 0, len(entries))
+	for _, e := range entries {
+		entryList = append(entryList, e.Entry)
+	}
+	kind, reason := versioning.InferBump(entryList)
+	return kind, reason, len(entries), nil
+}
+
+// releaseTagName returns the tag of a release: v<version>, or
+// <package>/v<version> when releasing a package.
+func releaseTagName(pkg config.Package, version string) string {
+	if pkg.Name != "" {
+		return pkg.TagName(version)
+	}
+	return "v" + version
+}
+
+// entriesForPackage splits entries into those released with packageName,
+// which are the entries without a package when it's empty, and the rest.
+func entriesForPackage(entries []changeset.EntryWithFile, packageName string) (selected, rest []changeset.EntryWithFile) {
+	for _, e := range entries {
+		if e.Entry.Package == packageName {
+			selected = append(selected, e)
+		} else {
+			rest = append(rest, e)
+		}
+	}
+	return selected, rest
+}
+
+// allCommits returns every commit reachable from HEAD.
+func allCommits(repo *git.Repository) ([]*object.Commit, error) {
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	iter, err := repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	var commits []*object.Commit
+	err = iter.ForEach(func(c *object.Commit) error {
+		commits = append(commits, c)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate history: %w", err)
+	}
+	return commits, nil
+}
+
+// releaseGeneration holds the entries planned for since..HEAD by
+// [planForRelease]. A nil *releaseGeneration plans nothing.
+type releaseGeneration struct {
+	since string
+	plan  []GeneratePlanEntry
+	store changeset.Store
+}
+
+// planForRelease plans deduplicated entries for since..HEAD the way
+// `storm generate --since` does, without writing anything.
+func planForRelease(since, consolidated string, interactive bool) (*releaseGeneration, error) {
+	repo, err := gitlog.OpenRepo(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	commits, err := gitlog.GetCommitRange(repo, since, "HEAD", gitlog.RangeOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	items, cancelled, err := selectCommitItems(commits, since, "HEAD", newConventionalParser(), interactive)
+	if err != nil {
+		return nil, err
+	}
+	if cancelled {
+		return nil, fmt.Errorf("release cancelled")
+	}
+	if projectConfig.InferScope {
+		mapping, err := scopeMapping(nil)
+		if err != nil {
+			return nil, err
+		}
+		applyInferredScopes(items, mapping)
+	}
+
+	store := openGenerateStore(changesDir, consolidated, changeset.MetadataConfig{})
+	existing, err := existingGenerated(store)
+	if err != nil {
+		return nil, err
+	}
+	plan, _ := planGenerate(items, existing, planSources{OpenRepo: openRepoPath})
+	return &releaseGeneration{since: since, plan: plan, store: store}, nil
+}
+
+// entries returns the entries the plan would create.
+func (g *releaseGeneration) entries() []changeset.Entry {
+	if g == nil {
+		return nil
+	}
+	var planned []changeset.Entry
+	for _, entry := range g.plan {
+		if entry.Action == planActionAdd {
+			planned = append(planned, entry.meta.Entry())
+		}
+	}
+	return planned
+}
+
+// apply writes the planned entries so the release picks them up.
+func (g *releaseGeneration) apply(quiet bool) error {
+	if g == nil {
+		return nil
+	}
+	stats, _, err := applyGeneratePlan(g.plan, g.store, false, !quiet)
+	if err != nil {
+		return err
+	}
+	if !quiet {
+		style.Println("Generated %d entries from %s..HEAD", stats.Created, g.since)
+		style.Newline()
+	}
+	return nil
+}
+
 // createReleaseTag creates an annotated Git tag for the release with changelog entries as the message.
-func createReleaseTag(repoPath, version string, versionData *changelog.Version) error {
-	repo, err := git.PlainOpen(repoPath)
+// With sign, the tag is signed with the user's configured key, as git tag -s does.
+func createReleaseTag(repoPath, tagName, version string, versionData *changelog.Version, sign bool) error {
+	repo, err := gitlog.OpenRepo(repoPath)
 	if err != nil {
 		return fmt.Errorf("failed to open repository: %w", err)
 	}
@@ -275,22 +916,36 @@ func createReleaseTag(repoPath, version string, versionData *changelog.Version)
 		return fmt.Errorf("failed to get HEAD: %w", err)
 	}
 
-	tagName := fmt.Sprintf("v%s", version)
-
 	_, err = repo.Tag(tagName)
 	if err == nil {
 		return fmt.Errorf("tag %s already exists", tagName)
 	}
 
 	tagMessage := buildTagMessage(version, versionData)
+	tagger := object.Signature{
+		Name:  "storm",
+		Email: "noreply@storm",
+		When:  time.Now(),
+	}
+
+	if sign {
+		signing, err := gitlog.LoadSigningConfig(repo)
+		if err != nil {
+			return err
+		}
+		// Like git tag -s, sign as the configured user.
+		if signing.Name != "" && signing.Email != "" {
+			tagger.Name, tagger.Email = signing.Name, signing.Email
+		}
+		if _, err := gitlog.CreateSignedTag(repo, tagName, head.Hash(), tagMessage, tagger, signing); err != nil {
+			return fmt.Errorf("failed to create signed tag: %w", err)
+		}
+		return nil
+	}
 
 	_, err = repo.CreateTag(tagName, head.Hash(), &git.CreateTagOptions{
 		Message: tagMessage,
-		Tagger: &object.Signature{
-			Name:  "storm",
-			Email: "noreply@storm",
-			When:  time.Now(),
-		},
+		Tagger:  &tagger,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create tag: %w", err)
@@ -298,6 +953,60 @@ func createReleaseTag(repoPath, version string, versionData *changelog.Version)
 	return nil
 }
 
+// pushRelease pushes tagName, and the current branch when branch is set, to
+// remote. It returns the pushed refs.
+func pushRelease(repoPath, remote, tagName string, branch bool) ([]string, error) {
+	repo, err := gitlog.OpenRepo(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	refs := []string{plumbing.NewTagReferenceName(tagName).String()}
+	if branch {
+		head, err := repo.Head()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get HEAD: %w", err)
+		}
+		if !head.Name().IsBranch() {
+			return nil, fmt.Errorf("--push-branch requires a checked-out branch, HEAD is detached")
+		}
+		refs = append(refs, head.Name().String())
+	}
+
+	refspecs := make([]string, len(refs))
+	for i, ref := range refs {
+		refspecs[i] = ref + ":" + ref
+	}
+	if err := gitlog.PushRefs(repo, remote, refspecs); err != nil {
+		return nil, err
+	}
+	return refs, nil
+}
+
+// createGitHubRelease creates release in the GitHub repository behind the
+// origin remote and returns its URL. The API root is derived from the remote's
+// host, so GitHub Enterprise hosts mapped to github in the config's hosts work
+// too. GITHUB_API_URL overrides it, as it does in GitHub Actions.
+func createGitHubRelease(ctx context.Context, repoPath string, release github.Release) (string, error) {
+	remote, err := changelog.RemoteRepo(repoPath, configuredHosts())
+	if err != nil {
+		return "", fmt.Errorf("cannot create GitHub Release: %w", err)
+	}
+	if remote.Forge != changelog.ForgeGitHub {
+		return "", fmt.Errorf("cannot create GitHub Release: origin %s is on %s, not GitHub", remote.URL, remote.Forge)
+	}
+	host, owner, name, err := github.ParseRepoURL(remote.URL)
+	if err != nil {
+		return "", err
+	}
+	baseURL := os.Getenv("GITHUB_API_URL")
+	if baseURL == "" {
+		baseURL = github.APIURL(host)
+	}
+	client := github.NewClient(baseURL, os.Getenv("GITHUB_TOKEN"))
+	return client.CreateRelease(ctx, owner, name, release)
+}
+
 // buildTagMessage formats the version's changelog entries into a tag message.
 func buildTagMessage(version string, versionData *changelog.Version) string {
 	var builder strings.Builder
@@ -332,17 +1041,17 @@ func displayVersionPreview(version *changelog.Version) {
 		var sectionTitle string
 		switch section.Type {
 		case "added":
-			sectionTitle = style.StyleAdded.Render("### Added")
+			sectionTitle = style.Render(style.StyleAdded, "### Added")
 		case "changed":
-			sectionTitle = style.StyleChanged.Render("### Changed")
+			sectionTitle = style.Render(style.StyleChanged, "### Changed")
 		case "deprecated":
 			sectionTitle = "### Deprecated"
 		case "removed":
-			sectionTitle = style.StyleRemoved.Render("### Removed")
+			sectionTitle = style.Render(style.StyleRemoved, "### Removed")
 		case "fixed":
-			sectionTitle = style.StyleFixed.Render("### Fixed")
+			sectionTitle = style.Render(style.StyleFixed, "### Fixed")
 		case "security":
-			sectionTitle = style.StyleSecurity.Render("### Security")
+			sectionTitle = style.Render(style.StyleSecurity, "### Security")
 		default:
 			sectionTitle = fmt.Sprintf("### %s", section.Type)
 		}
@@ -1,12 +1,24 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
+	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/config"
 	"github.com/stormlightlabs/git-storm/internal/changelog"
+	"github.com/stormlightlabs/git-storm/internal/changeset"
+	stormconfig "github.com/stormlightlabs/git-storm/internal/config"
+	"github.com/stormlightlabs/git-storm/internal/github"
 	"github.com/stormlightlabs/git-storm/internal/testutils"
+	"github.com/stormlightlabs/git-storm/internal/versioning"
 )
 
 func TestCreateReleaseTag(t *testing.T) {
@@ -37,7 +49,7 @@ func TestCreateReleaseTag(t *testing.T) {
 		},
 	}
 
-	err = createReleaseTag(repoPath, "1.0.0", version)
+	err = createReleaseTag(repoPath, "v1.0.0", "1.0.0", version, false)
 	if err != nil {
 		t.Fatalf("createReleaseTag() error = %v", err)
 	}
@@ -86,12 +98,12 @@ func TestCreateReleaseTag_DuplicateTag(t *testing.T) {
 		},
 	}
 
-	err = createReleaseTag(repoPath, "1.0.0", version)
+	err = createReleaseTag(repoPath, "v1.0.0", "1.0.0", version, false)
 	if err != nil {
 		t.Fatalf("First createReleaseTag() error = %v", err)
 	}
 
-	err = createReleaseTag(repoPath, "1.0.0", version)
+	err = createReleaseTag(repoPath, "v1.0.0", "1.0.0", version, false)
 	if err == nil {
 		t.Error("Expected error when creating duplicate tag, got nil")
 	}
@@ -129,7 +141,7 @@ func TestCreateReleaseTag_TagNameFormat(t *testing.T) {
 				},
 			}
 
-			err = createReleaseTag(repoPath, tt.version, version)
+			err = createReleaseTag(repoPath, "v"+tt.version, tt.version, version, false)
 			if err != nil {
 				t.Fatalf("createReleaseTag() error = %v", err)
 			}
@@ -300,3 +312,523 @@ func TestReleaseOutput_DryRunJSON(t *testing.T) {
 	testutils.Expect.True(t, strings.Contains(string(jsonBytes), `"tag_created": false`))
 	testutils.Expect.True(t, strings.Contains(string(jsonBytes), `"changes_cleared": false`))
 }
+
+func TestValidateRelease(t *testing.T) {
+	tmpDir := t.TempDir()
+	changelogPath := filepath.Join(tmpDir, "CHANGELOG.md")
+	changesDir := filepath.Join(tmpDir, ".changes")
+
+	existing := &changelog.Changelog{
+		Header:   "# Changelog",
+		Versions: []changelog.Version{{Number: "1.0.0", Date: "2024-01-15", Sections: []changelog.Section{{Type: "added", Entries: []string{"Initial release"}}}}},
+	}
+	if err := changelog.Write(changelogPath, existing, tmpDir); err != nil {
+		t.Fatalf("Failed to write changelog: %v", err)
+	}
+	if _, err := changeset.Write(changesDir, changeset.Entry{Type: "fixed", Summary: "Bug fix"}); err != nil {
+		t.Fatalf("Failed to write entry: %v", err)
+	}
+
+	t.Run("valid release passes", func(t *testing.T) {
+		version, problems := validateRelease(tmpDir, changelogPath, changesDir, "", "1.1.0", "", "2024-02-01", nil)
+		testutils.Expect.Equal(t, version, "1.1.0")
+		testutils.Expect.Equal(t, len(problems), 0, "Expected no problems")
+	})
+
+	t.Run("bump resolves from changelog", func(t *testing.T) {
+		version, problems := validateRelease(tmpDir, changelogPath, changesDir, "", "", "patch", "", nil)
+		testutils.Expect.Equal(t, version, "1.0.1")
+		testutils.Expect.Equal(t, len(problems), 0, "Expected no problems")
+	})
+
+	t.Run("duplicate version fails", func(t *testing.T) {
+		_, problems := validateRelease(tmpDir, changelogPath, changesDir, "", "1.0.0", "", "", nil)
+		testutils.Expect.Equal(t, len(problems), 1)
+		testutils.Expect.True(t, strings.Contains(problems[0], "already exists"), "Problem should mention the duplicate version")
+	})
+
+	t.Run("invalid date and missing changes fail", func(t *testing.T) {
+		_, problems := validateRelease(tmpDir, changelogPath, filepath.Join(tmpDir, "missing"), "", "1.1.0", "", "2024-13-45", nil)
+		testutils.Expect.Equal(t, len(problems), 2)
+	})
+
+	t.Run("consolidated entries are read", func(t *testing.T) {
+		consolidated := filepath.Join(tmpDir, "news.yaml")
+		if err := changeset.WriteConsolidated(consolidated, []changeset.Entry{{Type: "added", Summary: "Feature"}}); err != nil {
+			t.Fatalf("Failed to write consolidated file: %v", err)
+		}
+		version, problems := validateRelease(tmpDir, changelogPath, filepath.Join(tmpDir, "missing"), consolidated, "1.1.0", "", "2024-02-01", nil)
+		testutils.Expect.Equal(t, version, "1.1.0")
+		testutils.Expect.Equal(t, len(problems), 0, "entries should be read from the consolidated file")
+	})
+}
+
+func TestReleaseCmd_Snapshot(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+
+	chdirRepo(t, worktree.Filesystem.Root())
+	output = "CHANGELOG.md"
+
+	release := func(version, summary string) {
+		t.Helper()
+		if _, err := changeset.Write(".changes", changeset.Entry{Type: "added", Summary: summary}); err != nil {
+			t.Fatalf("Failed to write entry: %v", err)
+		}
+		cmd := releaseCmd()
+		cmd.SetArgs([]string{"--version", version, "--date", "2025-02-01", "--snapshot", "--clear-changes"})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("releaseCmd() error = %v", err)
+		}
+	}
+
+	release("1.0.0", "First release")
+
+	snapshotPath := filepath.Join(".changes", "released", "1.0.0.md")
+	first, err := os.ReadFile(snapshotPath)
+	if err != nil {
+		t.Fatalf("Snapshot should exist: %v", err)
+	}
+	testutils.Expect.Equal(t, string(first), "## [1.0.0] - 2025-02-01\n\n### Added\n\n- First release\n")
+
+	release("1.1.0", "Second release")
+
+	after, err := os.ReadFile(snapshotPath)
+	if err != nil {
+		t.Fatalf("Failed to re-read snapshot: %v", err)
+	}
+	testutils.Expect.Equal(t, string(after), string(first), "Earlier snapshot should not change on later releases")
+
+	second, err := os.ReadFile(filepath.Join(".changes", "released", "1.1.0.md"))
+	if err != nil {
+		t.Fatalf("Second snapshot should exist: %v", err)
+	}
+	testutils.Expect.True(t, strings.Contains(string(second), "- Second release"))
+	testutils.Expect.False(t, strings.Contains(string(second), "First release"))
+}
+
+func TestInferBumpFromCommits(t *testing.T) {
+	cases := []struct {
+		name    string
+		commits [][2]string
+		want    versioning.BumpType
+	}{
+		{
+			name: "breaking footer",
+			commits: [][2]string{
+				{"fix.txt", "fix: tidy output"},
+				{"api.txt", "refactor: rework API\n\nBREAKING CHANGE: removes the v1 endpoints"},
+			},
+			want: versioning.BumpMajor,
+		},
+		{
+			name:    "only feats",
+			commits: [][2]string{{"one.txt", "feat: add one"}, {"two.txt", "feat(cli): add two"}},
+			want:    versioning.BumpMinor,
+		},
+		{
+			name:    "only fixes",
+			commits: [][2]string{{"one.txt", "fix: correct one"}, {"two.txt", "fix: correct two"}},
+			want:    versioning.BumpPatch,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := testutils.SetupTestRepo(t)
+			worktree, err := repo.Worktree()
+			if err != nil {
+				t.Fatalf("Failed to get worktree: %v", err)
+			}
+			dir := worktree.Filesystem.Root()
+
+			changelogPath := filepath.Join(dir, "CHANGELOG.md")
+			writeFile(t, changelogPath, "# Changelog\n\n## [1.0.0] - 2025-01-01\n\n### Added\n\n- Initial release\n")
+			testutils.CreateTag(t, repo, "v1.0.0")
+
+			for _, c := range tc.commits {
+				testutils.AddCommit(t, repo, c[0], c[1], c[1])
+			}
+
+			kind, count, err := inferBumpFromCommits(dir, changelogPath, "")
+			if err != nil {
+				t.Fatalf("inferBumpFromCommits() error = %v", err)
+			}
+			testutils.Expect.Equal(t, kind, tc.want)
+			testutils.Expect.Equal(t, count, len(tc.commits), "Only commits after the release tag should be scanned")
+		})
+	}
+}
+
+func TestReleaseCmd_BumpFromCommitsConflicts(t *testing.T) {
+	for _, args := range [][]string{
+		{"--bump-from-commits", "--version", "1.2.0"},
+		{"--bump-from-commits", "--bump", "minor"},
+		{"--bump-from-entries", "--version", "1.2.0"},
+		{"--bump-from-entries", "--bump-from-commits"},
+		{"--bump", "auto", "--bump-from-entries"},
+		{"--bump", "auto", "--version", "1.2.0"},
+	} {
+		cmd := releaseCmd()
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "cannot be used with") {
+			t.Errorf("%v: expected conflict error, got %v", args, err)
+		}
+	}
+}
+
+func TestReleaseCmd_BumpFromEntries(t *testing.T) {
+	cases := []struct {
+		name    string
+		entries []changeset.Entry
+		want    string
+	}{
+		{"deprecation is minor", []changeset.Entry{{Type: "deprecated", Summary: "Deprecate --legacy"}, {Type: "fixed", Summary: "Fix crash"}}, "1.1.0"},
+		{"removal is major", []changeset.Entry{{Type: "deprecated", Summary: "Deprecate --old"}, {Type: "removed", Summary: "Remove --legacy"}}, "2.0.0"},
+		{"breaking is major", []changeset.Entry{{Type: "changed", Summary: "Rework config", Breaking: true}}, "2.0.0"},
+		{"fixes are patch", []changeset.Entry{{Type: "fixed", Summary: "Fix crash"}}, "1.0.1"},
+	}
+
+	for i, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			chdirRepo(t, dir)
+			output = "CHANGELOG.md"
+
+			writeFile(t, "CHANGELOG.md", "# Changelog\n\n## [1.0.0] - 2025-01-01\n\n### Added\n\n- Initial release\n")
+			for _, entry := range tc.entries {
+				if _, err := changeset.Write(".changes", entry); err != nil {
+					t.Fatalf("Failed to write entry: %v", err)
+				}
+			}
+
+			// --bump auto is an alias for --bump-from-entries.
+			args := []string{"--bump-from-entries", "--date", "2025-02-01"}
+			if i%2 == 1 {
+				args = []string{"--bump", "auto", "--date", "2025-02-01"}
+			}
+			cmd := releaseCmd()
+			cmd.SetArgs(args)
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("releaseCmd() error = %v", err)
+			}
+
+			content, err := os.ReadFile("CHANGELOG.md")
+			if err != nil {
+				t.Fatalf("Failed to read changelog: %v", err)
+			}
+			testutils.Expect.True(t, strings.Contains(string(content), "## ["+tc.want+"] - 2025-02-01"), "expected version "+tc.want+":\n"+string(content))
+		})
+	}
+}
+
+func TestReleaseCmd_ChangelogTemplate(t *testing.T) {
+	dir := t.TempDir()
+	chdirRepo(t, dir)
+	output = "CHANGELOG.md"
+
+	if _, err := changeset.Write(".changes", changeset.Entry{Type: "fixed", Summary: "Patch the thing"}); err != nil {
+		t.Fatalf("Failed to write entry: %v", err)
+	}
+	writeFile(t, "notes.tmpl", "RELEASES\n{{range .Versions}}{{.Number}}:{{range sections .}} {{.Type}}={{len .Entries}}{{end}}\n{{end}}")
+
+	cmd := releaseCmd()
+	cmd.SetArgs([]string{"--version", "2.0.0", "--date", "2025-03-01", "--changelog-template", "notes.tmpl"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("releaseCmd() error = %v", err)
+	}
+
+	content, err := os.ReadFile("CHANGELOG.md")
+	if err != nil {
+		t.Fatalf("Failed to read changelog: %v", err)
+	}
+	testutils.Expect.Equal(t, string(content), "RELEASES\n2.0.0: fixed=1\n")
+}
+
+func TestReleaseCmd_Since(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+
+	chdirRepo(t, worktree.Filesystem.Root())
+	output = "CHANGELOG.md"
+
+	writeFile(t, "CHANGELOG.md", "# Changelog\n\n## [1.1.0] - 2025-01-01\n\n### Added\n\n- Earlier work\n")
+	testutils.CreateTag(t, repo, "v1.1.0")
+	testutils.AddCommit(t, repo, "search.go", "package search", "feat: add search")
+	testutils.AddCommit(t, repo, "crash.go", "package crash", "fix: avoid crash on empty input")
+
+	cmd := releaseCmd()
+	cmd.SetArgs([]string{"--since", "v1.1.0", "--bump", "minor", "--date", "2025-02-01", "--tag", "--clear-changes"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("releaseCmd() error = %v", err)
+	}
+
+	content, err := os.ReadFile("CHANGELOG.md")
+	if err != nil {
+		t.Fatalf("Failed to read changelog: %v", err)
+	}
+	for _, want := range []string{"## [1.2.0] - 2025-02-01", "- add search", "- avoid crash on empty input", "- Earlier work"} {
+		testutils.Expect.True(t, strings.Contains(string(content), want), "changelog should contain "+want)
+	}
+
+	if _, err := repo.Tag("v1.2.0"); err != nil {
+		t.Errorf("Expected tag v1.2.0: %v", err)
+	}
+
+	entries, err := changeset.List(".changes")
+	if err != nil {
+		t.Fatalf("Failed to list entries: %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 0, "--clear-changes should remove generated entries")
+}
+
+func TestReleaseCmd_SinceBumpAuto(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+
+	chdirRepo(t, worktree.Filesystem.Root())
+	output = "CHANGELOG.md"
+
+	writeFile(t, "CHANGELOG.md", "# Changelog\n\n## [1.1.0] - 2025-01-01\n\n### Added\n\n- Earlier work\n")
+	testutils.CreateTag(t, repo, "v1.1.0")
+	testutils.AddCommit(t, repo, "search.go", "package search", "feat: add search")
+
+	cmd := releaseCmd()
+	cmd.SetArgs([]string{"--since", "v1.1.0", "--bump", "auto", "--date", "2025-02-01"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("releaseCmd() error = %v", err)
+	}
+
+	content, err := os.ReadFile("CHANGELOG.md")
+	if err != nil {
+		t.Fatalf("Failed to read changelog: %v", err)
+	}
+	testutils.Expect.True(t, strings.Contains(string(content), "## [1.2.0] - 2025-02-01"), "the bump should be inferred from the generated entries")
+	testutils.Expect.True(t, strings.Contains(string(content), "- add search"), "changelog should contain the generated entry")
+}
+
+func TestReleaseCmd_Package(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+
+	chdirRepo(t, worktree.Filesystem.Root())
+	output = "CHANGELOG.md"
+	previous := projectConfig
+	t.Cleanup(func() { projectConfig = previous })
+	projectConfig.Packages = []stormconfig.Package{{Name: "api", Paths: []string{"services/api"}}}
+
+	testutils.AddCommit(t, repo, "services/api/main.go", "package main", "feat: add users endpoint")
+	testutils.AddCommit(t, repo, "docs/guide.md", "# Guide", "docs: add guide")
+	if _, err := changeset.Write(".changes", changeset.Entry{Type: "fixed", Summary: "Root fix"}); err != nil {
+		t.Fatalf("Failed to write entry: %v", err)
+	}
+
+	cmd := releaseCmd()
+	cmd.SetArgs([]string{"--package", "api", "--since", "HEAD~2", "--version", "1.1.0", "--date", "2025-02-01", "--tag", "--clear-changes"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("releaseCmd() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join("services", "api", "CHANGELOG.md"))
+	if err != nil {
+		t.Fatalf("Package changelog should be written: %v", err)
+	}
+	testutils.Expect.True(t, strings.Contains(string(content), "## [1.1.0] - 2025-02-01"), string(content))
+	testutils.Expect.True(t, strings.Contains(string(content), "- add users endpoint"), string(content))
+	testutils.Expect.False(t, strings.Contains(string(content), "Root fix"), "root entries belong to the root release")
+
+	if _, err := repo.Tag("api/v1.1.0"); err != nil {
+		t.Errorf("Expected tag api/v1.1.0: %v", err)
+	}
+	_, err = os.Stat("CHANGELOG.md")
+	testutils.Expect.True(t, os.IsNotExist(err), "the root changelog should be left alone")
+
+	entries, err := changeset.List(".changes")
+	if err != nil {
+		t.Fatalf("Failed to list entries: %v", err)
+	}
+	var kept []string
+	for _, e := range entries {
+		kept = append(kept, e.Entry.Summary)
+	}
+	slices.Sort(kept)
+	testutils.Expect.Equal(t, kept, []string{"Root fix", "add guide"}, "only the package's entries should be cleared")
+
+	cmd = releaseCmd()
+	cmd.SetArgs([]string{"--package", "web", "--version", "1.0.0"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "unknown package") {
+		t.Errorf("expected unknown package error, got %v", err)
+	}
+}
+
+func TestReleaseCmd_InteractiveRequiresSince(t *testing.T) {
+	cmd := releaseCmd()
+	cmd.SetArgs([]string{"--bump", "minor", "--interactive"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--interactive requires --since") {
+		t.Fatalf("expected --interactive requires --since error, got %v", err)
+	}
+}
+
+func TestPushRelease(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+	repoPath := worktree.Filesystem.Root()
+
+	remoteDir := t.TempDir()
+	remoteRepo, err := git.PlainInit(remoteDir, true)
+	if err != nil {
+		t.Fatalf("Failed to init remote: %v", err)
+	}
+	if _, err := repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remoteDir}}); err != nil {
+		t.Fatalf("Failed to add remote: %v", err)
+	}
+
+	if err := createReleaseTag(repoPath, "v1.0.0", "1.0.0", &changelog.Version{Number: "1.0.0"}, false); err != nil {
+		t.Fatalf("createReleaseTag() error = %v", err)
+	}
+	pushed, err := pushRelease(repoPath, "origin", "v1.0.0", true)
+	if err != nil {
+		t.Fatalf("pushRelease() error = %v", err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("Failed to get HEAD: %v", err)
+	}
+	testutils.Expect.Equal(t, pushed, []string{"refs/tags/v1.0.0", head.Name().String()})
+
+	if _, err := remoteRepo.Tag("v1.0.0"); err != nil {
+		t.Errorf("remote should have tag v1.0.0: %v", err)
+	}
+	branch, err := remoteRepo.Reference(head.Name(), true)
+	if err != nil {
+		t.Fatalf("remote should have branch %s: %v", head.Name(), err)
+	}
+	testutils.Expect.Equal(t, branch.Hash(), head.Hash(), "the pushed branch should point at HEAD")
+
+	if _, err := pushRelease(repoPath, "origin", "v1.0.0", false); err != nil {
+		t.Errorf("pushing an existing tag again should succeed, got %v", err)
+	}
+	if _, err := pushRelease(repoPath, "upstream", "v1.0.0", false); err == nil {
+		t.Error("pushing to a missing remote should fail")
+	}
+}
+
+func TestReleaseCmd_PushRequiresTag(t *testing.T) {
+	for want, args := range map[string][]string{
+		"--push requires --tag":         {"--push"},
+		"--push-branch requires --push": {"--tag", "--push-branch"},
+	} {
+		cmd := releaseCmd()
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%v: expected %q, got %v", args, want, err)
+		}
+	}
+}
+
+func TestReleaseCmd_GitHubReleaseFlags(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	for want, args := range map[string][]string{
+		"--github-release requires --tag":        {"--github-release"},
+		"--draft and --prerelease require":       {"--draft"},
+		"--github-release requires --push":       {"--tag", "--github-release"},
+		"--github-release requires GITHUB_TOKEN": {"--tag", "--push", "--github-release"},
+	} {
+		cmd := releaseCmd()
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%v: expected %q, got %v", args, want, err)
+		}
+	}
+}
+
+func TestCreateGitHubRelease(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+	repoPath := worktree.Filesystem.Root()
+
+	var got github.Release
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testutils.Expect.Equal(t, r.URL.Path, "/repos/owner/app/releases")
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"html_url": "https://github.com/owner/app/releases/tag/v1.0.0"}`))
+	}))
+	defer server.Close()
+	t.Setenv("GITHUB_API_URL", server.URL)
+	t.Setenv("GITHUB_TOKEN", "tok")
+
+	release := github.Release{TagName: "v1.0.0", Name: "v1.0.0", Body: "### Fixed\n\n- Bug\n", Prerelease: true}
+	if _, err := createGitHubRelease(context.Background(), repoPath, release); err == nil {
+		t.Error("a repository without a GitHub origin should fail")
+	}
+
+	if _, err := repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"git@gitlab.com:owner/app.git"}}); err != nil {
+		t.Fatalf("Failed to add remote: %v", err)
+	}
+	if _, err := createGitHubRelease(context.Background(), repoPath, release); err == nil || !strings.Contains(err.Error(), "not GitHub") {
+		t.Errorf("a GitLab origin should fail, got %v", err)
+	}
+	if err := repo.DeleteRemote("origin"); err != nil {
+		t.Fatalf("Failed to remove remote: %v", err)
+	}
+
+	if _, err := repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"git@github.com:owner/app.git"}}); err != nil {
+		t.Fatalf("Failed to add remote: %v", err)
+	}
+	url, err := createGitHubRelease(context.Background(), repoPath, release)
+	if err != nil {
+		t.Fatalf("createGitHubRelease() error = %v", err)
+	}
+	testutils.Expect.Equal(t, url, "https://github.com/owner/app/releases/tag/v1.0.0")
+	testutils.Expect.Equal(t, got, release)
+}
+
+func TestReleaseYankCmd(t *testing.T) {
+	dir := t.TempDir()
+	chdirRepo(t, dir)
+	output = "CHANGELOG.md"
+	writeFile(t, "CHANGELOG.md", "# Changelog\n\n## [1.1.0] - 2025-02-01\n\n### Fixed\n\n- Fix crash\n\n## [1.0.0] - 2025-01-01\n\n### Added\n\n- Initial release\n")
+
+	cmd := releaseCmd()
+	cmd.SetArgs([]string{"yank", "1.1.0", "--reason", "Breaks config loading"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("release yank error = %v", err)
+	}
+
+	content, err := os.ReadFile("CHANGELOG.md")
+	if err != nil {
+		t.Fatalf("Failed to read changelog: %v", err)
+	}
+	text := string(content)
+	testutils.Expect.True(t, strings.Contains(text, "## [1.1.0] - 2025-02-01 [YANKED]\n"), "1.1.0 should be marked yanked:\n"+text)
+	testutils.Expect.True(t, strings.Contains(text, "### Yanked\n\n- Breaks config loading\n"), "the reason should be recorded:\n"+text)
+	testutils.Expect.True(t, strings.Contains(text, "## [1.0.0] - 2025-01-01\n"), "other versions should be untouched:\n"+text)
+
+	cmd = releaseCmd()
+	cmd.SetArgs([]string{"yank", "1.1.0"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "already yanked") {
+		t.Errorf("yanking twice should fail, got %v", err)
+	}
+}
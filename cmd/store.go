@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/stormlightlabs/git-storm/internal/changeset"
+	"github.com/stormlightlabs/git-storm/internal/config"
+)
+
+// openStore returns the entry store commands read and write through: the
+// consolidated file when one is given, otherwise the changes directory in
+// the configured format.
+//
+// Tests replace it to run command flows against a [changeset.MemoryStore].
+var openStore = func(changesDir, consolidated string) changeset.Store {
+	if consolidated != "" {
+		return changeset.NewConsolidatedStore(consolidated)
+	}
+	if projectConfig.Format == config.FormatChangesets {
+		return changeset.NewChangesetsStore(changesDir)
+	}
+	return changeset.NewFSStore(changesDir)
+}
+
+// openGenerateStore returns the store generated entries are written to. When
+// they live in a changes directory, their metadata is kept as cfg describes.
+func openGenerateStore(changesDir, consolidated string, cfg changeset.MetadataConfig) changeset.Store {
+	store := openStore(changesDir, consolidated)
+	if fs, ok := store.(*changeset.FSStore); ok {
+		fs.Metadata = cfg
+	}
+	return store
+}
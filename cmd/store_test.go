@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/git-storm/internal/changeset"
+	"github.com/stormlightlabs/git-storm/internal/style"
+	"github.com/stormlightlabs/git-storm/internal/testutils"
+)
+
+// useMemoryStore routes every command's entry storage to an in-memory store
+// seeded with entries, restoring [openStore] when the test ends.
+func useMemoryStore(t *testing.T, entries ...changeset.Entry) *changeset.MemoryStore {
+	t.Helper()
+	store := changeset.NewMemoryStore(entries...)
+	previous := openStore
+	openStore = func(string, string) changeset.Store { return store }
+	t.Cleanup(func() { openStore = previous })
+	return store
+}
+
+func TestMemoryStore_UnreleasedAddAndList(t *testing.T) {
+	chdirTemp(t)
+	store := useMemoryStore(t, changeset.Entry{Type: "fixed", Summary: "Existing fix"})
+
+	var buf bytes.Buffer
+	style.SetWriter(&buf)
+	defer style.SetWriter(nil)
+
+	cmd := unreleasedCmd()
+	cmd.SetArgs([]string{"add", "--type", "added", "--scope", "cli", "--summary", "In-memory entry"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unreleased add error = %v", err)
+	}
+
+	entries, _ := store.List()
+	testutils.Expect.Equal(t, len(entries), 2)
+	testutils.Expect.Equal(t, entries[1].Entry.Summary, "In-memory entry")
+	if _, err := os.Stat(".changes"); !os.IsNotExist(err) {
+		t.Error("add should write through the store, not to .changes")
+	}
+
+	buf.Reset()
+	cmd = unreleasedCmd()
+	cmd.SetArgs([]string{"list"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unreleased list error = %v", err)
+	}
+	out := buf.String()
+	testutils.Expect.True(t, strings.Contains(out, "Found 2 unreleased change(s)"), out)
+	testutils.Expect.True(t, strings.Contains(out, "In-memory entry"), out)
+	testutils.Expect.True(t, strings.Contains(out, "Existing fix"), out)
+}
+
+func TestMemoryStore_Export(t *testing.T) {
+	chdirTemp(t)
+	useMemoryStore(t,
+		changeset.Entry{Type: "added", Summary: "New feature"},
+		changeset.Entry{Type: "security", Summary: "Patch token leak"},
+	)
+
+	var out bytes.Buffer
+	cmd := exportCmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--sections", "security"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("exportCmd() error = %v", err)
+	}
+	testutils.Expect.True(t, strings.Contains(out.String(), "### Security\n\n- Patch token leak"), out.String())
+	testutils.Expect.False(t, strings.Contains(out.String(), "New feature"), out.String())
+}
+
+func TestMemoryStore_ReleaseClearsEntries(t *testing.T) {
+	chdirRepo(t, t.TempDir())
+	output = "CHANGELOG.md"
+	store := useMemoryStore(t,
+		changeset.Entry{Type: "deprecated", Summary: "Deprecate --legacy"},
+		changeset.Entry{Type: "fixed", Summary: "Fix crash"},
+	)
+	writeFile(t, "CHANGELOG.md", "# Changelog\n\n## [1.0.0] - 2025-01-01\n\n### Added\n\n- Initial release\n")
+
+	var buf bytes.Buffer
+	style.SetWriter(&buf)
+	defer style.SetWriter(nil)
+
+	cmd := releaseCmd()
+	cmd.SetArgs([]string{"--bump-from-entries", "--date", "2025-02-01", "--clear-changes"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("releaseCmd() error = %v", err)
+	}
+
+	content, err := os.ReadFile("CHANGELOG.md")
+	if err != nil {
+		t.Fatalf("Failed to read changelog: %v", err)
+	}
+	testutils.Expect.True(t, strings.Contains(string(content), "## [1.1.0] - 2025-02-01\n\n### Deprecated\n\n- Deprecate --legacy"), string(content))
+
+	remaining, _ := store.List()
+	testutils.Expect.Equal(t, len(remaining), 0, "--clear-changes should delete entries through the store")
+}
+
+func TestMemoryStore_GenerateAndReleaseSince(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.CreateTag(t, repo, "v0.1.0")
+	testutils.AddCommit(t, repo, "feat.txt", "content", "feat: add stored feature")
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	chdirRepo(t, wt.Filesystem.Root())
+	output = "CHANGELOG.md"
+	store := useMemoryStore(t)
+
+	var buf bytes.Buffer
+	style.SetWriter(&buf)
+	defer style.SetWriter(nil)
+
+	for range 2 {
+		cmd := generateCmd()
+		cmd.SetArgs([]string{"v0.1.0", "HEAD"})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("generateCmd() error = %v", err)
+		}
+	}
+	entries, _ := store.List()
+	testutils.Expect.Equal(t, len(entries), 1, "re-running generate should deduplicate against the store")
+	testutils.Expect.Equal(t, entries[0].Entry.Summary, "add stored feature")
+	if _, err := os.Stat(".changes"); !os.IsNotExist(err) {
+		t.Error("generate should write through the store, not to .changes")
+	}
+
+	testutils.AddCommit(t, repo, "fix.txt", "content", "fix: handle stored crash")
+	writeFile(t, "CHANGELOG.md", "# Changelog\n\n## [0.1.0] - 2025-01-01\n\n### Added\n\n- Initial release\n")
+
+	cmd := releaseCmd()
+	cmd.SetArgs([]string{"--version", "0.2.0", "--date", "2025-02-01", "--since", "v0.1.0"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("release --since error = %v", err)
+	}
+	entries, _ = store.List()
+	testutils.Expect.Equal(t, len(entries), 2, "release --since should add the new commit's entry to the store")
+
+	content, err := os.ReadFile("CHANGELOG.md")
+	if err != nil {
+		t.Fatalf("failed to read changelog: %v", err)
+	}
+	testutils.Expect.True(t, strings.Contains(string(content), "- handle stored crash"), string(content))
+	testutils.Expect.True(t, strings.Contains(string(content), "- add stored feature"), string(content))
+}
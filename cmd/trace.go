@@ -0,0 +1,250 @@
+/*
+USAGE
+
+	storm trace <version> [options]
+
+FLAGS
+
+	--json              Output the trace as JSON
+	--metadata-dir <d>  Read commit metadata from d instead of .changes/data
+	--output <path>     Changelog file path (default: CHANGELOG.md)
+	--repo <path>       Path to the Git repository (default: .)
+
+# DESCRIPTION
+
+Lists each bullet of a released version in CHANGELOG.md alongside the
+commit(s) that produced it, so release notes can be audited back to code.
+
+Bullets are joined to the JSON metadata that generate leaves in .changes/data,
+which survives release. A bullet ending in a commit reference such as
+"(abc1234)" (see release --with-hash) is matched by hash; otherwise its
+summary, without scope, breaking label, and trailing links, is compared with
+the recorded summaries. Bullets that match neither way are reported as
+"unknown source", which is expected for entries added by hand.
+*/
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stormlightlabs/git-storm/internal/changelog"
+	"github.com/stormlightlabs/git-storm/internal/changeset"
+	"github.com/stormlightlabs/git-storm/internal/shared"
+	"github.com/stormlightlabs/git-storm/internal/style"
+)
+
+// TraceOutput is the JSON form of a version trace.
+type TraceOutput struct {
+	Version string       `json:"version"`
+	Date    string       `json:"date"`
+	Entries []TraceEntry `json:"entries"`
+}
+
+// TraceEntry maps one changelog bullet to the commits that produced it.
+// Commits is empty when the bullet has no traceable source.
+type TraceEntry struct {
+	Section string        `json:"section"`
+	Text    string        `json:"text"`
+	Commits []TraceCommit `json:"commits"`
+}
+
+// TraceCommit is a commit credited with a changelog bullet.
+type TraceCommit struct {
+	Hash   string `json:"hash"`
+	Author string `json:"author,omitempty"`
+	// Match is "hash" when found via an appended commit reference, "summary" otherwise.
+	Match string `json:"match"`
+}
+
+var (
+	// traceHashRegex matches a trailing commit reference rendered by --with-hash,
+	// either "(abc1234)" or "([abc1234](.../commit/<hash>))".
+	traceHashRegex = regexp.MustCompile(`\(\[?([0-9a-f]{7,40})\]?(?:\([^()]*\))?\)\s*$`)
+	// traceGroupRegex matches a trailing parenthesized group such as rendered links.
+	traceGroupRegex = regexp.MustCompile(`\s*\((?:[^()]|\([^()]*\))*\)\s*$`)
+	// traceLabelRegex matches leading bold labels like "**cli:** " or "**BREAKING:** ".
+	traceLabelRegex = regexp.MustCompile(`^(?:\*\*[^*]+:\*\*\s+)+`)
+)
+
+func traceCmd() *cobra.Command {
+	var (
+		outputJSON  bool
+		metadataDir string
+	)
+
+	c := &cobra.Command{
+		Use:   "trace <version>",
+		Short: "Map a released version's changelog entries back to commits",
+		Long: `Lists each changelog bullet of a released version alongside the commit(s)
+that produced it, using the metadata generate records in .changes/data.
+Bullets without traceable metadata are listed as "unknown source".`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			number := strings.TrimPrefix(args[0], "v")
+
+			existing, err := changelog.Parse(filepath.Join(repoPath, output))
+			if err != nil {
+				return fmt.Errorf("failed to parse changelog: %w", err)
+			}
+
+			var version *changelog.Version
+			for i := range existing.Versions {
+				if existing.Versions[i].Number == number {
+					version = &existing.Versions[i]
+					break
+				}
+			}
+			if version == nil {
+				return fmt.Errorf("version %s not found in %s", number, output)
+			}
+
+			metadata, err := changeset.MetadataConfig{Dir: metadataDir}.Load(changesDir)
+			if err != nil {
+				return fmt.Errorf("failed to load existing metadata: %w", err)
+			}
+
+			trace := TraceOutput{Version: version.Number, Date: version.Date, Entries: traceVersion(*version, metadata)}
+
+			if outputJSON {
+				jsonBytes, err := json.MarshalIndent(trace, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal trace to JSON: %w", err)
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(jsonBytes))
+				return nil
+			}
+
+			displayTrace(trace)
+			return nil
+		},
+	}
+
+	c.Flags().BoolVar(&outputJSON, "json", false, "Output the trace as JSON")
+	c.Flags().StringVar(&metadataDir, "metadata-dir", "", "Directory for commit metadata (default: .changes/data)")
+	return c
+}
+
+// traceVersion joins every bullet in version to the metadata that produced it.
+func traceVersion(version changelog.Version, metadata map[string]changeset.Metadata) []TraceEntry {
+	metas := make([]changeset.Metadata, 0, len(metadata))
+	for _, meta := range metadata {
+		metas = append(metas, meta)
+	}
+	sort.Slice(metas, func(i, j int) bool {
+		if !metas[i].Date.Equal(metas[j].Date) {
+			return metas[i].Date.Before(metas[j].Date)
+		}
+		return metas[i].CommitHash < metas[j].CommitHash
+	})
+
+	var entries []TraceEntry
+	for _, section := range version.Sections {
+		if section.Type == changelog.ContributorsSection {
+			continue
+		}
+		for _, text := range section.Entries {
+			entries = append(entries, TraceEntry{
+				Section: section.Type,
+				Text:    text,
+				Commits: traceBullet(section.Type, text, metas),
+			})
+		}
+	}
+	return entries
+}
+
+// traceBullet finds the commits behind a single bullet, preferring an appended
+// commit reference over a summary match.
+func traceBullet(sectionType, text string, metas []changeset.Metadata) []TraceCommit {
+	// Body sub-bullets follow the entry line; only the line itself is matched.
+	text, _, _ = strings.Cut(text, "\n")
+	if match := traceHashRegex.FindStringSubmatch(text); match != nil {
+		short := match[1]
+		for _, meta := range metas {
+			if strings.HasPrefix(meta.CommitHash, short) {
+				return []TraceCommit{{Hash: meta.CommitHash, Author: meta.Author, Match: "hash"}}
+			}
+		}
+		return []TraceCommit{{Hash: short, Match: "hash"}}
+	}
+
+	candidates := traceSummaries(text)
+	var matched []changeset.Metadata
+	for _, meta := range metas {
+		if candidates[meta.Summary] {
+			matched = append(matched, meta)
+		}
+	}
+
+	if len(matched) > 1 {
+		var sameType []changeset.Metadata
+		for _, meta := range matched {
+			if meta.Type == sectionType {
+				sameType = append(sameType, meta)
+			}
+		}
+		if len(sameType) > 0 {
+			matched = sameType
+		}
+	}
+
+	commits := make([]TraceCommit, 0, len(matched))
+	for _, meta := range matched {
+		commits = append(commits, TraceCommit{Hash: meta.CommitHash, Author: meta.Author, Match: "summary"})
+	}
+	return commits
+}
+
+// traceSummaries returns the summaries a bullet may have been rendered from:
+// the text without leading labels, with and without trailing parenthesized groups.
+func traceSummaries(text string) map[string]bool {
+	text = traceLabelRegex.ReplaceAllString(strings.TrimSpace(text), "")
+
+	candidates := map[string]bool{text: true}
+	for {
+		stripped := traceGroupRegex.ReplaceAllString(text, "")
+		if stripped == text || stripped == "" {
+			break
+		}
+		text = stripped
+		candidates[text] = true
+	}
+	return candidates
+}
+
+// displayTrace prints a trace grouped by section.
+func displayTrace(trace TraceOutput) {
+	style.Headlinef("Trace for %s (%s)", trace.Version, trace.Date)
+
+	currentSection := ""
+	for _, entry := range trace.Entries {
+		if entry.Section != currentSection {
+			currentSection = entry.Section
+			style.Newline()
+			style.Println("### %s", shared.TitleCase(currentSection))
+		}
+
+		style.Println("- %s", entry.Text)
+		if len(entry.Commits) == 0 {
+			style.Println("    %s", style.Render(style.StyleRemoved, "unknown source"))
+			continue
+		}
+		for _, commit := range entry.Commits {
+			hash := commit.Hash
+			if len(hash) > 7 {
+				hash = hash[:7]
+			}
+			if commit.Author != "" {
+				style.Println("    %s %s", hash, commit.Author)
+			} else {
+				style.Println("    %s", hash)
+			}
+		}
+	}
+}
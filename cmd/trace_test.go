@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stormlightlabs/git-storm/internal/changelog"
+	"github.com/stormlightlabs/git-storm/internal/changeset"
+	"github.com/stormlightlabs/git-storm/internal/style"
+	"github.com/stormlightlabs/git-storm/internal/testutils"
+)
+
+func TestTraceVersion(t *testing.T) {
+	now := time.Now()
+	metadata := map[string]changeset.Metadata{
+		"d1": {CommitHash: "1111111aaaaaaa", DiffHash: "d1", Type: "added", Scope: "cli", Summary: "Add --trace flag", Author: "Ada", Date: now},
+		"d2": {CommitHash: "2222222bbbbbbb", DiffHash: "d2", Type: "fixed", Summary: "Fix crash on empty repo", Author: "Grace", Date: now},
+		"d3": {CommitHash: "3333333ccccccc", DiffHash: "d3", Type: "changed", Summary: "Rework parser", Author: "Linus", Date: now},
+	}
+	version := changelog.Version{
+		Number: "1.2.0",
+		Date:   "2025-01-01",
+		Sections: []changelog.Section{
+			{Type: "added", Entries: []string{"**cli:** Add --trace flag ([PR](https://example.com/pull/7))"}},
+			{Type: "changed", Entries: []string{"**BREAKING:** Rework parser"}},
+			{Type: "fixed", Entries: []string{
+				"Fix crash on empty repo ([2222222](https://github.com/o/r/commit/2222222bbbbbbb))",
+				"Hand-written note",
+				"Old fix (9999999)",
+			}},
+		},
+	}
+
+	entries := traceVersion(version, metadata)
+	testutils.Expect.Equal(t, len(entries), 5)
+
+	want := []struct {
+		hash, author, match string
+	}{
+		{"1111111aaaaaaa", "Ada", "summary"},
+		{"3333333ccccccc", "Linus", "summary"},
+		{"2222222bbbbbbb", "Grace", "hash"},
+		{},
+		{"9999999", "", "hash"},
+	}
+	for i, w := range want {
+		if w.hash == "" {
+			testutils.Expect.Equal(t, len(entries[i].Commits), 0, entries[i].Text+" should have no source")
+			continue
+		}
+		if len(entries[i].Commits) != 1 {
+			t.Fatalf("%q: expected 1 commit, got %+v", entries[i].Text, entries[i].Commits)
+		}
+		got := entries[i].Commits[0]
+		testutils.Expect.Equal(t, got.Hash, w.hash, entries[i].Text)
+		testutils.Expect.Equal(t, got.Author, w.author, entries[i].Text)
+		testutils.Expect.Equal(t, got.Match, w.match, entries[i].Text)
+	}
+}
+
+func TestTraceCmd(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFile(t, filepath.Join(tmpDir, "CHANGELOG.md"), `# Changelog
+
+## [1.2.0] - 2025-01-01
+
+### Added
+
+- **cli:** Add --trace flag
+
+### Fixed
+
+- Manual bullet
+`)
+	if err := changeset.SaveMetadata(filepath.Join(tmpDir, ".changes"), changeset.Metadata{
+		CommitHash: "abcdef0123456789",
+		DiffHash:   "diff1",
+		Type:       "added",
+		Scope:      "cli",
+		Summary:    "Add --trace flag",
+		Author:     "Ada Lovelace",
+		Date:       time.Now(),
+	}); err != nil {
+		t.Fatalf("SaveMetadata() error = %v", err)
+	}
+
+	chdirRepo(t, tmpDir)
+	output = "CHANGELOG.md"
+
+	var buf bytes.Buffer
+	style.SetWriter(&buf)
+	defer style.SetWriter(nil)
+
+	cmd := traceCmd()
+	cmd.SetArgs([]string{"v1.2.0"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("trace error = %v", err)
+	}
+
+	out := buf.String()
+	testutils.Expect.True(t, strings.Contains(out, "- **cli:** Add --trace flag\n    abcdef0 Ada Lovelace"), "traced bullet should list its commit:\n"+out)
+	testutils.Expect.True(t, strings.Contains(out, "- Manual bullet\n    unknown source"), "manual bullet should be an unknown source:\n"+out)
+
+	var jsonOut bytes.Buffer
+	cmd = traceCmd()
+	cmd.SetOut(&jsonOut)
+	cmd.SetArgs([]string{"1.2.0", "--json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("trace --json error = %v", err)
+	}
+
+	var trace TraceOutput
+	if err := json.Unmarshal(jsonOut.Bytes(), &trace); err != nil {
+		t.Fatalf("failed to decode trace JSON: %v\n%s", err, jsonOut.String())
+	}
+	testutils.Expect.Equal(t, trace.Version, "1.2.0")
+	testutils.Expect.Equal(t, len(trace.Entries), 2)
+	testutils.Expect.Equal(t, trace.Entries[0].Commits[0].Hash, "abcdef0123456789")
+	testutils.Expect.Equal(t, len(trace.Entries[1].Commits), 0)
+
+	cmd = traceCmd()
+	cmd.SetArgs([]string{"9.9.9"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error for a version missing from the changelog")
+	}
+}
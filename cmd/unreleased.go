@@ -9,6 +9,8 @@ SUBCOMMANDS
 	list        List all unreleased changes
 	review      Review unreleased changes interactively
 	partial     Create entry linked to a specific commit
+	import      Create partial entries for every commit in a range
+	validate    Check a single entry file for problems
 
 USAGE
 
@@ -16,9 +18,12 @@ USAGE
 
 FLAGS
 
-	--type <type>       Change type (added, changed, fixed, removed, security)
+	--type <type>       Change type from the registry (default: Keep a Changelog types)
 	--scope <scope>     Optional subsystem or module name
+	--package <name>    Monorepo package the change belongs to
 	--summary <text>    Short description of the change
+	--link <name=url>   Named link rendered after the entry (repeatable)
+	--edit              Open the new entry in $EDITOR, then validate it
 	--repo <path>       Path to the repository (default: .)
 
 USAGE
@@ -28,6 +33,7 @@ USAGE
 FLAGS
 
 	--json              Output as JSON
+	--show-meta         Show author and relative date from generate metadata
 	--repo <path>       Path to the repository (default: .)
 
 USAGE
@@ -38,6 +44,13 @@ FLAGS
 
 	--repo <path>       Path to the repository (default: .)
 	--output <file>     Optional file to export reviewed notes
+	--rename-on-edit    Rename timestamp-named files to match an edited summary
+
+In the review list, t and T cycle an entry through the change types; the new
+type is written to the entry's file on confirm.
+
+In the entry editor, ctrl+o opens the entry's file in $VISUAL or $EDITOR; the
+file is validated and the form refilled from it when the editor exits.
 
 USAGE
 
@@ -48,22 +61,44 @@ FLAGS
 	--type <type>       Override change type (auto-detected from commit message)
 	--summary <text>    Override summary (auto-detected from commit message)
 	--scope <scope>     Optional subsystem or module name
+	--with-body         Keep the commit body (without footers) below the frontmatter
+	--repo <path>       Path to the repository (default: .)
+
+USAGE
+
+	storm unreleased import <from>..<to> [options]
+	storm unreleased import <base> [options]
+
+FLAGS
+
+	--type <type>       Default change type for commits that can't be categorized
+	--scope <scope>     Default scope for commits without one
 	--repo <path>       Path to the repository (default: .)
+
+USAGE
+
+	storm unreleased validate <file>
+
+Reports bad frontmatter, invalid types, empty summaries, and diff hashes shared
+with another entry in the same directory. Exits non-zero on any problem.
 */
 package main
 
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/go-git/go-git/v6"
 	"github.com/go-git/go-git/v6/plumbing"
+	"github.com/go-git/go-git/v6/plumbing/object"
 	"github.com/spf13/cobra"
 	"github.com/stormlightlabs/git-storm/internal/changeset"
 	"github.com/stormlightlabs/git-storm/internal/gitlog"
+	"github.com/stormlightlabs/git-storm/internal/shared"
 	"github.com/stormlightlabs/git-storm/internal/style"
 	"github.com/stormlightlabs/git-storm/internal/tty"
 	"github.com/stormlightlabs/git-storm/internal/ui"
@@ -73,47 +108,89 @@ func unreleasedCmd() *cobra.Command {
 	var (
 		changeType string
 		scope      string
+		pkg        string
 		summary    string
 		outputJSON bool
+		links      []string
+		renameEdit bool
+		withBody   bool
+		showMeta   bool
+		edit       bool
 	)
 
-	changesDir := ".changes"
-	validTypes := []string{"added", "changed", "fixed", "removed", "security"}
-
 	add := &cobra.Command{
 		Use:   "add",
 		Short: "Add a new unreleased change entry",
 		Long: `Creates a new .changes/<date>-<summary>.md file with the specified type,
-scope, and summary.`,
+scope, and summary.
+
+With --edit the new file is opened in $VISUAL or $EDITOR (default vi) to add
+a body or other details, and its frontmatter is validated once the editor
+exits.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if !slices.Contains(validTypes, changeType) {
-				return fmt.Errorf("invalid type %q: must be one of %s", changeType, strings.Join(validTypes, ", "))
+			if err := changeTypes.Validate(changeType); err != nil {
+				return err
+			}
+			store := openStore(changesDir, "")
+			fsStore, isFS := store.(*changeset.FSStore)
+			if edit {
+				if !isFS {
+					return fmt.Errorf("--edit needs entries stored as files in a changes directory")
+				}
+				if !tty.IsInteractive() {
+					return tty.ErrorInteractiveFlag("--edit")
+				}
+			}
+			if _, ok := projectConfig.Package(pkg); pkg != "" && !ok {
+				return fmt.Errorf("unknown package %q: add it to the config's packages", pkg)
+			}
+
+			var entryLinks changeset.Links
+			for _, raw := range links {
+				link, err := changeset.ParseLink(raw)
+				if err != nil {
+					return err
+				}
+				entryLinks = append(entryLinks, link)
 			}
 
-			if filePath, err := changeset.Write(changesDir, changeset.Entry{
+			id, err := store.Write(changeset.Entry{
 				Type:    changeType,
 				Scope:   scope,
+				Package: pkg,
 				Summary: summary,
-			}); err != nil {
+				Links:   entryLinks,
+			})
+			if err != nil {
 				return fmt.Errorf("failed to create changelog entry: %w", err)
-			} else {
-				style.Addedf("Created %s", filePath)
-				return nil
 			}
+			style.Addedf("Created %s", filepath.Join(changesDir, id))
+			if edit {
+				return editEntryFile(fsStore.Path(id))
+			}
+			return nil
 		},
 	}
-	add.Flags().StringVar(&changeType, "type", "", "Type of change (added, changed, fixed, removed, security)")
+	add.Flags().StringVar(&changeType, "type", "", "Type of change (added, changed, deprecated, removed, fixed, security, or a type set by --types)")
 	add.Flags().StringVar(&scope, "scope", "", "Optional scope or subsystem name")
+	add.Flags().StringVar(&pkg, "package", "", "Monorepo package the change belongs to, from the config's packages")
 	add.Flags().StringVar(&summary, "summary", "", "Short summary of the change")
+	add.Flags().StringArrayVar(&links, "link", nil, "Named link as name=url, rendered after the entry (repeatable)")
+	add.Flags().BoolVar(&edit, "edit", false, "Open the new entry in $EDITOR, then validate it")
 	add.MarkFlagRequired("type")
 	add.MarkFlagRequired("summary")
 
 	list := &cobra.Command{
 		Use:   "list",
 		Short: "List all unreleased changes",
-		Long:  "Prints all pending .changes entries to stdout. Supports JSON output.",
+		Long: `Prints all pending .changes entries to stdout. Supports JSON output.
+
+Use --show-meta to add the commit author and relative date recorded in
+.changes/data for entries created by generate. Entries added by hand have no
+metadata and are shown unchanged.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			entries, err := changeset.List(changesDir)
+			store := openStore(changesDir, "")
+			entries, err := store.List()
 			if err != nil {
 				return fmt.Errorf("failed to list changelog entries: %w", err)
 			}
@@ -132,23 +209,39 @@ scope, and summary.`,
 				return nil
 			}
 
+			var metadata map[string]changeset.Metadata
+			if showMeta {
+				metadata, err = store.LoadMetadata()
+				if err != nil {
+					return fmt.Errorf("failed to load entry metadata: %w", err)
+				}
+			}
+
 			style.Headlinef("Found %d unreleased change(s):", len(entries))
 			style.Newline()
 
 			for _, e := range entries {
-				displayEntry(e)
+				displayEntry(e, entryMetadata(e.Entry, metadata))
 			}
 
 			return nil
 		},
 	}
 	list.Flags().BoolVar(&outputJSON, "json", false, "Output results as JSON")
+	list.Flags().BoolVar(&showMeta, "show-meta", false, "Show the author and relative date of entries with generate metadata")
 
 	review := &cobra.Command{
 		Use:   "review",
 		Short: "Review unreleased changes interactively",
 		Long: `Launches an interactive Bubble Tea TUI to review, edit, or categorize
-unreleased entries before final release.`,
+unreleased entries before final release.
+
+While editing an entry, ctrl+o suspends the form and opens the entry's file
+in $VISUAL or $EDITOR. When the editor exits the file is validated and the
+form refilled from it, or its problems are listed above the form.
+
+Press t (or T to go back) to move an entry to another change type without
+opening the editor, e.g. to fix a miscategorized generated entry.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !tty.IsInteractive() {
 				return tty.ErrorInteractiveRequired("storm unreleased review", []string{
@@ -157,7 +250,8 @@ unreleased entries before final release.`,
 				})
 			}
 
-			entries, err := changeset.List(changesDir)
+			store := openStore(changesDir, "")
+			entries, err := store.List()
 			if err != nil {
 				return fmt.Errorf("failed to list changelog entries: %w", err)
 			}
@@ -167,7 +261,11 @@ unreleased entries before final release.`,
 				return nil
 			}
 
-			model := ui.NewChangesetReviewModel(entries)
+			model := ui.NewChangesetReviewModelWithTypes(entries, changeTypes.Names())
+			// Metadata only adds authors and dates to filter and sort by.
+			if metadata, err := store.LoadMetadata(); err == nil {
+				model.SetMetadata(metadata)
+			}
 			p := tea.NewProgram(model, tea.WithAltScreen())
 
 			finalModel, err := p.Run()
@@ -191,7 +289,7 @@ unreleased entries before final release.`,
 
 			for _, item := range items {
 				if item.Action == ui.ActionDelete {
-					if err := changeset.Delete(changesDir, item.Entry.Filename); err != nil {
+					if err := store.Delete(item.Entry.Filename); err != nil {
 						return fmt.Errorf("failed to delete %s: %w", item.Entry.Filename, err)
 					}
 					deleteCount++
@@ -199,9 +297,20 @@ unreleased entries before final release.`,
 				}
 			}
 
+			retypeCount, err := applyRetypes(store, items)
+			if err != nil {
+				return err
+			}
+
+			scopes := scopeSuggestions(entries)
 			for _, item := range items {
 				if item.Action == ui.ActionEdit {
-					editorModel := ui.NewEntryEditorModel(item.Entry)
+					item.Entry.Entry.Type = item.Type()
+					editorModel := ui.NewEntryEditorModelWithTypes(item.Entry, changeTypes.Names())
+					editorModel.SetScopeSuggestions(scopes)
+					if fsStore, ok := store.(*changeset.FSStore); ok {
+						editorModel.SetFile(fsStore.Path(item.Entry.Filename), changeTypes)
+					}
 					p := tea.NewProgram(editorModel, tea.WithAltScreen())
 
 					finalModel, err := p.Run()
@@ -215,13 +324,36 @@ unreleased entries before final release.`,
 					}
 
 					if editor.IsCancelled() {
-						style.Warningf("Skipped editing: %s", item.Entry.Filename)
+						switch problems := editor.FileProblems(); {
+						case len(problems) > 0:
+							style.Warningf("Left %s with problems:", item.Entry.Filename)
+							for _, p := range problems {
+								style.Println("  - %s", p)
+							}
+						case editor.EditedExternally():
+							style.Warningf("Kept the $EDITOR changes to %s", item.Entry.Filename)
+						default:
+							style.Warningf("Skipped editing: %s", item.Entry.Filename)
+						}
 						continue
 					}
 
 					if editor.IsConfirmed() {
 						editedEntry := editor.GetEditedEntry()
-						if err := changeset.Update(changesDir, item.Entry.Filename, editedEntry); err != nil {
+						if fsStore, ok := store.(*changeset.FSStore); ok && renameEdit {
+							filename, err := fsStore.UpdateAndRename(item.Entry.Filename, editedEntry)
+							if err != nil {
+								return fmt.Errorf("failed to update %s: %w", item.Entry.Filename, err)
+							}
+							editCount++
+							if filename != item.Entry.Filename {
+								style.Successf("Updated: %s (renamed to %s)", item.Entry.Filename, filename)
+							} else {
+								style.Successf("Updated: %s", item.Entry.Filename)
+							}
+							continue
+						}
+						if err := store.Update(item.Entry.Filename, editedEntry); err != nil {
 							return fmt.Errorf("failed to update %s: %w", item.Entry.Filename, err)
 						}
 						editCount++
@@ -230,16 +362,18 @@ unreleased entries before final release.`,
 				}
 			}
 
-			if deleteCount == 0 && editCount == 0 {
+			if deleteCount == 0 && editCount == 0 && retypeCount == 0 {
 				style.Headline("No changes requested")
 				return nil
 			}
 
-			style.Headlinef("Review completed: %d deleted, %d edited", deleteCount, editCount)
+			style.Headlinef("Review completed: %d deleted, %d edited, %d retyped", deleteCount, editCount, retypeCount)
 			return nil
 		},
 	}
 
+	review.Flags().BoolVar(&renameEdit, "rename-on-edit", false, "Rename timestamp-named entry files to match an edited summary")
+
 	partial := &cobra.Command{
 		Use:   "partial <commit-ref>",
 		Short: "Create entry linked to a specific commit",
@@ -249,7 +383,7 @@ Auto-detects type and summary from conventional commit format, with optional ove
 		RunE: func(cmd *cobra.Command, args []string) error {
 			commitRef := args[0]
 
-			repo, err := git.PlainOpen(repoPath)
+			repo, err := gitlog.OpenRepo(repoPath)
 			if err != nil {
 				return fmt.Errorf("failed to open repository: %w", err)
 			}
@@ -264,7 +398,7 @@ Auto-detects type and summary from conventional commit format, with optional ove
 				return fmt.Errorf("failed to get commit object: %w", err)
 			}
 
-			parser := &gitlog.ConventionalParser{}
+			parser := newConventionalParser()
 			subject := commit.Message
 			body := ""
 			lines := strings.Split(commit.Message, "\n")
@@ -283,8 +417,8 @@ Auto-detects type and summary from conventional commit format, with optional ove
 			category := parser.Categorize(meta)
 
 			if changeType != "" {
-				if !slices.Contains(validTypes, changeType) {
-					return fmt.Errorf("invalid type %q: must be one of %s", changeType, strings.Join(validTypes, ", "))
+				if err := changeTypes.Validate(changeType); err != nil {
+					return err
 				}
 				category = changeType
 			} else if category == "" {
@@ -311,6 +445,9 @@ Auto-detects type and summary from conventional commit format, with optional ove
 				Breaking:   meta.Breaking,
 				CommitHash: hash.String(),
 			}
+			if withBody {
+				entry.Body = gitlog.StripFooters(meta.Body)
+			}
 
 			if _, err := changeset.WritePartial(changesDir, filename, entry); err != nil {
 				return fmt.Errorf("failed to create changelog entry: %w", err)
@@ -323,6 +460,83 @@ Auto-detects type and summary from conventional commit format, with optional ove
 	partial.Flags().StringVar(&changeType, "type", "", "Override change type (auto-detected from commit)")
 	partial.Flags().StringVar(&scope, "scope", "", "Optional scope or subsystem name")
 	partial.Flags().StringVar(&summary, "summary", "", "Override summary (auto-detected from commit)")
+	partial.Flags().BoolVar(&withBody, "with-body", false, "Store the commit body, minus footers, below the entry frontmatter")
+
+	importCmd := &cobra.Command{
+		Use:   "import <from>..<to> | import <base>",
+		Short: "Create partial entries for every commit in a range",
+		Long: `Creates a .changes/<sha7>.<type>.md file for each commit in the range,
+skipping commits that already have an entry. Pass a base branch to import
+the commits on HEAD that aren't on the base.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if changeType != "" {
+				if err := changeTypes.Validate(changeType); err != nil {
+					return err
+				}
+			}
+
+			from, to := gitlog.ParseRefArgs(args)
+
+			repo, err := gitlog.OpenRepo(repoPath)
+			if err != nil {
+				return fmt.Errorf("failed to open repository: %w", err)
+			}
+
+			commits, err := gitlog.GetCommitRange(repo, from, to, gitlog.RangeOptions{})
+			if err != nil {
+				return err
+			}
+
+			if len(commits) == 0 {
+				style.Headlinef("No commits found between %s and %s", from, to)
+				return nil
+			}
+
+			created, skipped, err := importCommits(changesDir, commits, changeType, scope)
+			if err != nil {
+				return err
+			}
+
+			for _, filePath := range created {
+				style.Addedf("Created %s", filePath)
+			}
+
+			style.Newline()
+			style.Headlinef("Imported %d of %d commits", len(created), len(commits))
+			if skipped > 0 {
+				style.Println("  Skipped %d commits (existing entries or no detectable type)", skipped)
+			}
+			return nil
+		},
+	}
+	importCmd.Flags().StringVar(&changeType, "type", "", "Default change type for commits that can't be categorized")
+	importCmd.Flags().StringVar(&scope, "scope", "", "Default scope for commits without one")
+
+	validate := &cobra.Command{
+		Use:   "validate <file>",
+		Short: "Check a single entry file for problems",
+		Long: `Parses one .changes entry and reports bad frontmatter, invalid types,
+empty summaries, and diff hashes that collide with another entry. Intended
+for editor integrations and pre-commit hooks.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			violations, err := changeset.ValidateFile(args[0], changeTypes)
+			if err != nil {
+				return err
+			}
+
+			if len(violations) == 0 {
+				style.Addedf("✓ %s is valid", args[0])
+				return nil
+			}
+
+			for _, v := range violations {
+				style.Println("  - %s: %s", args[0], v.Message)
+			}
+			return fmt.Errorf("%s has %d problem(s)", args[0], len(violations))
+		},
+	}
 
 	root := &cobra.Command{
 		Use:   "unreleased",
@@ -330,24 +544,172 @@ Auto-detects type and summary from conventional commit format, with optional ove
 		Long: `Work with unreleased change notes. Supports adding, listing,
 and reviewing pending entries before release.`,
 	}
-	root.AddCommand(add, list, review, partial)
+	root.AddCommand(add, list, review, partial, importCmd, validate)
 	return root
 }
 
+// importCommits writes a partial entry for each commit, skipping commits that
+// already have an entry and those whose type can't be determined.
+//
+// defaultType and defaultScope fill in for commits without a detectable type or scope.
+func importCommits(changesDir string, commits []*object.Commit, defaultType, defaultScope string) ([]string, int, error) {
+	existing, err := openStore(changesDir, "").List()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list existing entries: %w", err)
+	}
+
+	imported := make(map[string]bool, len(existing))
+	for _, e := range existing {
+		if e.Entry.CommitHash != "" {
+			imported[e.Entry.CommitHash] = true
+		}
+	}
+
+	parser := newConventionalParser()
+	var created []string
+	skipped := 0
+
+	for _, commit := range commits {
+		hash := commit.Hash.String()
+		if imported[hash] {
+			skipped++
+			continue
+		}
+
+		subject, body, _ := strings.Cut(commit.Message, "\n")
+		meta, err := parser.Parse(hash, subject, body, commit.Author.When)
+		if err != nil {
+			style.Println("Warning: failed to parse commit %s: %v", hash[:gitlog.ShaLen], err)
+			skipped++
+			continue
+		}
+
+		category := parser.Categorize(meta)
+		if category == "" {
+			category = defaultType
+		}
+		if category == "" {
+			skipped++
+			continue
+		}
+
+		entryScope := meta.Scope
+		if entryScope == "" {
+			entryScope = defaultScope
+		}
+
+		filename := fmt.Sprintf("%s.%s.md", hash[:gitlog.ShaLen], category)
+		if _, err := os.Stat(filepath.Join(changesDir, filename)); err == nil {
+			skipped++
+			continue
+		}
+
+		filePath, err := changeset.WritePartial(changesDir, filename, changeset.Entry{
+			Type:       category,
+			Scope:      entryScope,
+			Summary:    meta.Description,
+			Breaking:   meta.Breaking,
+			CommitHash: hash,
+		})
+		if err != nil {
+			return created, skipped, fmt.Errorf("failed to create changelog entry: %w", err)
+		}
+		created = append(created, filePath)
+	}
+
+	return created, skipped, nil
+}
+
+// applyRetypes writes the new type of each kept entry retyped during review
+// and returns how many were updated. Entries marked for editing get their new
+// type through the editor instead.
+func applyRetypes(store changeset.Store, items []ui.ReviewItem) (int, error) {
+	count := 0
+	for _, item := range items {
+		if item.Action != ui.ActionKeep || !item.Retyped() {
+			continue
+		}
+
+		entry := item.Entry.Entry
+		entry.Type = item.NewType
+		if err := store.Update(item.Entry.Filename, entry); err != nil {
+			return count, fmt.Errorf("failed to update %s: %w", item.Entry.Filename, err)
+		}
+		count++
+		style.Successf("Retyped: %s (%s → %s)", item.Entry.Filename, item.Entry.Entry.Type, item.NewType)
+	}
+	return count, nil
+}
+
+// editEntryFile opens the entry file at path in $VISUAL or $EDITOR and
+// validates it once the editor exits, listing any problems.
+func editEntryFile(path string) error {
+	if err := tty.EditorCommand(path).Run(); err != nil {
+		return fmt.Errorf("editor %q failed: %w", tty.Editor(), err)
+	}
+
+	violations, err := changeset.ValidateFile(path, changeTypes)
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		for _, v := range violations {
+			style.Println("  - %s: %s", path, v.Message)
+		}
+		return fmt.Errorf("%s has %d problem(s); fix it and run 'storm unreleased validate %s'", path, len(violations), path)
+	}
+
+	style.Addedf("✓ %s is valid", path)
+	return nil
+}
+
+// scopeSuggestionDepth is how many commits of history scopeSuggestions reads.
+const scopeSuggestionDepth = 500
+
+// scopeSuggestions returns the scopes offered by the entry editor: those of
+// recent commits, most used first, then any other scopes entries use. The
+// history is skipped when the repository can't be read.
+func scopeSuggestions(entries []changeset.EntryWithFile) []string {
+	var scopes []string
+	if repo, err := gitlog.OpenRepo(repoPath); err == nil {
+		scopes, _ = gitlog.HistoryScopes(repo, scopeSuggestionDepth)
+	}
+	for _, e := range entries {
+		if e.Entry.Scope != "" && !slices.Contains(scopes, e.Entry.Scope) {
+			scopes = append(scopes, e.Entry.Scope)
+		}
+	}
+	return scopes
+}
+
+// entryMetadata returns the metadata recorded for entry, joined by diff hash.
+// Entries without a diff hash, or without recorded metadata, yield nil.
+func entryMetadata(entry changeset.Entry, metadata map[string]changeset.Metadata) *changeset.Metadata {
+	if entry.DiffHash == "" {
+		return nil
+	}
+	meta, ok := metadata[entry.DiffHash]
+	if !ok {
+		return nil
+	}
+	return &meta
+}
+
 // displayEntry formats and prints a single changelog entry with color-coded type.
-func displayEntry(e changeset.EntryWithFile) {
+// When meta is non-nil its author and relative date are printed below the file.
+func displayEntry(e changeset.EntryWithFile, meta *changeset.Metadata) {
 	var typeLabel string
 	switch e.Entry.Type {
 	case "added":
-		typeLabel = style.StyleAdded.Render(fmt.Sprintf("[%s]", e.Entry.Type))
+		typeLabel = style.Render(style.StyleAdded, fmt.Sprintf("[%s]", e.Entry.Type))
 	case "changed":
-		typeLabel = style.StyleChanged.Render(fmt.Sprintf("[%s]", e.Entry.Type))
+		typeLabel = style.Render(style.StyleChanged, fmt.Sprintf("[%s]", e.Entry.Type))
 	case "fixed":
-		typeLabel = style.StyleFixed.Render(fmt.Sprintf("[%s]", e.Entry.Type))
+		typeLabel = style.Render(style.StyleFixed, fmt.Sprintf("[%s]", e.Entry.Type))
 	case "removed":
-		typeLabel = style.StyleRemoved.Render(fmt.Sprintf("[%s]", e.Entry.Type))
+		typeLabel = style.Render(style.StyleRemoved, fmt.Sprintf("[%s]", e.Entry.Type))
 	case "security":
-		typeLabel = style.StyleSecurity.Render(fmt.Sprintf("[%s]", e.Entry.Type))
+		typeLabel = style.Render(style.StyleSecurity, fmt.Sprintf("[%s]", e.Entry.Type))
 	default:
 		typeLabel = fmt.Sprintf("[%s]", e.Entry.Type)
 	}
@@ -359,8 +721,14 @@ func displayEntry(e changeset.EntryWithFile) {
 
 	style.Println("%s %s%s", typeLabel, scopePart, e.Entry.Summary)
 	style.Println("  File: %s", e.Filename)
+	if meta != nil && meta.Author != "" {
+		style.Println("  Author: %s", meta.Author)
+	}
+	if meta != nil && !meta.Date.IsZero() {
+		style.Println("  Date: %s", shared.TimeAgo(meta.Date))
+	}
 	if e.Entry.Breaking {
-		style.Println("  Breaking: %s\n", style.StyleRemoved.Render("YES"))
+		style.Println("  Breaking: %s\n", style.Render(style.StyleRemoved, "YES"))
 	}
 	style.Newline()
 }
@@ -1,12 +1,19 @@
 package main
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stormlightlabs/git-storm/internal/changeset"
+	"github.com/stormlightlabs/git-storm/internal/gitlog"
+	"github.com/stormlightlabs/git-storm/internal/style"
 	"github.com/stormlightlabs/git-storm/internal/testutils"
+	"github.com/stormlightlabs/git-storm/internal/ui"
 )
 
 func TestUnreleasedReviewWorkflow_Delete(t *testing.T) {
@@ -102,6 +109,72 @@ func TestUnreleasedReviewWorkflow_Edit(t *testing.T) {
 	testutils.Expect.Equal(t, entries[0].Entry.CommitHash, "abc123", "CommitHash should be preserved")
 }
 
+func TestApplyRetypes(t *testing.T) {
+	var buf bytes.Buffer
+	style.SetWriter(&buf)
+	defer style.SetWriter(os.Stdout)
+
+	changesDir := filepath.Join(t.TempDir(), ".changes")
+	store := changeset.NewFSStore(changesDir)
+	var items []ui.ReviewItem
+	for _, entry := range []changeset.Entry{
+		{Type: "added", Scope: "cli", Summary: "Actually a fix"},
+		{Type: "added", Summary: "Deleted anyway"},
+		{Type: "changed", Summary: "Unchanged"},
+	} {
+		id, err := store.Write(entry)
+		if err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		items = append(items, ui.ReviewItem{Entry: changeset.EntryWithFile{Entry: entry, Filename: id}})
+	}
+	items[0].NewType = "fixed"
+	items[1].NewType = "fixed"
+	items[1].Action = ui.ActionDelete
+
+	count, err := applyRetypes(store, items)
+	if err != nil {
+		t.Fatalf("applyRetypes() error = %v", err)
+	}
+	testutils.Expect.Equal(t, count, 1)
+
+	types := make(map[string]string)
+	entries, err := store.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	for _, e := range entries {
+		types[e.Entry.Summary] = e.Entry.Type
+		if e.Entry.Summary == "Actually a fix" {
+			testutils.Expect.Equal(t, e.Entry.Scope, "cli", "other fields are kept")
+		}
+	}
+	testutils.Expect.Equal(t, types["Actually a fix"], "fixed")
+	testutils.Expect.Equal(t, types["Deleted anyway"], "added", "entries marked for deletion are not retyped")
+	testutils.Expect.Equal(t, types["Unchanged"], "changed")
+	testutils.Expect.True(t, strings.Contains(buf.String(), "added → fixed"), buf.String())
+}
+
+func TestScopeSuggestions(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+	testutils.AddCommit(t, repo, "a.txt", "1", "feat(cli): add flag")
+	chdirRepo(t, worktree.Filesystem.Root())
+
+	entries := []changeset.EntryWithFile{
+		{Entry: changeset.Entry{Type: "added", Scope: "cli", Summary: "Known scope"}},
+		{Entry: changeset.Entry{Type: "fixed", Scope: "docs", Summary: "Entry-only scope"}},
+		{Entry: changeset.Entry{Type: "fixed", Summary: "No scope"}},
+	}
+	testutils.Expect.Equal(t, scopeSuggestions(entries), []string{"cli", "docs"})
+
+	chdirRepo(t, t.TempDir())
+	testutils.Expect.Equal(t, scopeSuggestions(entries), []string{"cli", "docs"}, "without a repository, entry scopes are still offered")
+}
+
 func TestUnreleasedReviewWorkflow_DeleteAndEdit(t *testing.T) {
 	tmpDir := t.TempDir()
 	changesDir := filepath.Join(tmpDir, ".changes")
@@ -190,3 +263,196 @@ func TestUnreleasedReviewWorkflow_EmptyChanges(t *testing.T) {
 
 	testutils.Expect.Equal(t, len(entries), 0, "Should have no entries")
 }
+
+func TestImportCommits(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.CreateTag(t, repo, "base")
+
+	testutils.AddCommit(t, repo, "a.txt", "a", "feat(cli): add import command")
+	testutils.AddCommit(t, repo, "b.txt", "b", "fix: handle empty ranges")
+	testutils.AddCommit(t, repo, "c.txt", "c", "Update contributing notes")
+
+	commits, err := gitlog.GetCommitRange(repo, "base", "HEAD", gitlog.RangeOptions{})
+	if err != nil {
+		t.Fatalf("GetCommitRange() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(commits), 3)
+
+	changesDir := filepath.Join(t.TempDir(), ".changes")
+	created, skipped, err := importCommits(changesDir, commits, "changed", "docs")
+	if err != nil {
+		t.Fatalf("importCommits() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(created), 3)
+	testutils.Expect.Equal(t, skipped, 0)
+
+	wantTypes := []string{"added", "fixed", "changed"}
+	for i, commit := range commits {
+		filename := commit.Hash.String()[:7] + "." + wantTypes[i] + ".md"
+		if _, err := os.Stat(filepath.Join(changesDir, filename)); err != nil {
+			t.Errorf("Expected partial file %s: %v", filename, err)
+		}
+	}
+
+	entries, err := changeset.List(changesDir)
+	if err != nil {
+		t.Fatalf("Failed to list entries: %v", err)
+	}
+	for _, e := range entries {
+		switch e.Entry.Type {
+		case "added":
+			testutils.Expect.Equal(t, e.Entry.Scope, "cli", "Commit scope should be kept")
+		case "changed":
+			testutils.Expect.Equal(t, e.Entry.Scope, "docs", "Default scope should fill in")
+		}
+	}
+
+	created, skipped, err = importCommits(changesDir, commits, "changed", "")
+	if err != nil {
+		t.Fatalf("second importCommits() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(created), 0, "Re-importing should not create duplicates")
+	testutils.Expect.Equal(t, skipped, 3)
+}
+
+func TestUnreleasedValidateCmd(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.md")
+	invalid := filepath.Join(dir, "invalid.md")
+	writeFile(t, valid, "---\ntype: added\nsummary: Fine\n---\n")
+	writeFile(t, invalid, "---\ntype: added\n---\n")
+
+	cmd := unreleasedCmd()
+	cmd.SetArgs([]string{"validate", valid})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("validate on valid file returned error: %v", err)
+	}
+
+	cmd = unreleasedCmd()
+	cmd.SetArgs([]string{"validate", invalid})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("validate on invalid file expected error")
+	}
+}
+
+func TestEditEntryFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell as the editor")
+	}
+	var buf bytes.Buffer
+	style.SetWriter(&buf)
+	defer style.SetWriter(os.Stdout)
+
+	path := filepath.Join(t.TempDir(), "entry.md")
+	writeFile(t, path, "---\ntype: added\nsummary: Before\n---\n")
+
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", `printf -- '---\ntype: fixed\nsummary: After\n---\n\nDetails.\n' >`)
+	if err := editEntryFile(path); err != nil {
+		t.Fatalf("editEntryFile() error = %v", err)
+	}
+	entry, err := changeset.Read(path)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	testutils.Expect.Equal(t, entry.Summary, "After")
+	testutils.Expect.Equal(t, entry.Body, "Details.")
+
+	t.Setenv("EDITOR", `printf -- '---\ntype: fixed\n---\n' >`)
+	err = editEntryFile(path)
+	if err == nil {
+		t.Fatal("editEntryFile() should report the missing summary")
+	}
+	testutils.Expect.True(t, strings.Contains(buf.String(), "summary"), buf.String())
+
+	t.Setenv("EDITOR", "false")
+	if err := editEntryFile(path); err == nil {
+		t.Fatal("editEntryFile() should fail when the editor does")
+	}
+}
+
+func TestUnreleasedPartial_WithBody(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.AddCommit(t, repo, "d.txt", "d", "feat(api): add retries\n\n"+
+		"Requests now retry on 503 responses.\n\n"+
+		"Backoff doubles each attempt, capped at 30s.\n\n"+
+		"Refs: #42\n"+
+		"Signed-off-by: Dev <dev@example.com>\n")
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+
+	chdirRepo(t, worktree.Filesystem.Root())
+
+	cmd := unreleasedCmd()
+	cmd.SetArgs([]string{"partial", "HEAD", "--with-body"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("partial --with-body error = %v", err)
+	}
+
+	entries, err := changeset.List(".changes")
+	if err != nil {
+		t.Fatalf("Failed to list entries: %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 1)
+	testutils.Expect.Equal(t, entries[0].Entry.Summary, "add retries")
+	testutils.Expect.Equal(t, entries[0].Entry.Body,
+		"Requests now retry on 503 responses.\n\nBackoff doubles each attempt, capped at 30s.")
+}
+
+func TestUnreleasedList_ShowMeta(t *testing.T) {
+	dir := t.TempDir()
+	changesDir := filepath.Join(dir, ".changes")
+
+	if _, err := changeset.WriteWithMetadata(changesDir, changeset.Metadata{
+		CommitHash: "abc1234",
+		DiffHash:   "deadbeef",
+		Type:       "added",
+		Summary:    "Generated entry",
+		Author:     "Ada Lovelace",
+		Date:       time.Now().Add(-3 * 24 * time.Hour),
+	}); err != nil {
+		t.Fatalf("WriteWithMetadata() error = %v", err)
+	}
+	if _, err := changeset.Write(changesDir, changeset.Entry{Type: "fixed", Summary: "Manual entry"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	t.Chdir(dir)
+
+	list := func(args ...string) string {
+		var buf bytes.Buffer
+		style.SetWriter(&buf)
+		defer style.SetWriter(nil)
+
+		cmd := unreleasedCmd()
+		cmd.SetArgs(append([]string{"list"}, args...))
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("list %v error = %v", args, err)
+		}
+		return buf.String()
+	}
+
+	block := func(out, summary string) string {
+		for b := range strings.SplitSeq(out, "\n\n") {
+			if strings.Contains(b, summary) {
+				return b
+			}
+		}
+		t.Fatalf("no entry %q in output:\n%s", summary, out)
+		return ""
+	}
+
+	out := list("--show-meta")
+	generated, manual := block(out, "Generated entry"), block(out, "Manual entry")
+	testutils.Expect.True(t, strings.Contains(generated, "Author: Ada Lovelace"), "metadata-backed entry should show its author")
+	testutils.Expect.True(t, strings.Contains(generated, "Date: 3d ago"), "metadata-backed entry should show a relative date")
+	testutils.Expect.False(t, strings.Contains(manual, "Author:"), "manual entry should not show an author")
+	testutils.Expect.False(t, strings.Contains(manual, "Date:"), "manual entry should not show a date")
+
+	if plain := list(); strings.Contains(plain, "Author:") {
+		t.Errorf("list without --show-meta should not show metadata:\n%s", plain)
+	}
+}
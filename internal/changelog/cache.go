@@ -0,0 +1,80 @@
+package changelog
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// parseFile parses a changelog from disk. Overridden in tests to count parses.
+var parseFile = Parse
+
+// cachedParse is a parsed changelog along with the file state it was read from.
+type cachedParse struct {
+	modTime   time.Time
+	size      int64
+	changelog *Changelog
+}
+
+// parseCache holds parsed changelogs by path for the life of the process.
+var parseCache = struct {
+	sync.Mutex
+	entries map[string]cachedParse
+}{entries: make(map[string]cachedParse)}
+
+// ParseCached parses path like [Parse], reusing an earlier parse while the
+// file's modification time and size are unchanged.
+//
+// Each call returns an independent copy, so callers may modify the result.
+// Missing files are not cached.
+func ParseCached(path string) (*Changelog, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return parseFile(path)
+	}
+
+	parseCache.Lock()
+	cached, ok := parseCache.entries[path]
+	parseCache.Unlock()
+	if ok && cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+		return cloneChangelog(cached.changelog), nil
+	}
+
+	parsed, err := parseFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	parseCache.Lock()
+	parseCache.entries[path] = cachedParse{modTime: info.ModTime(), size: info.Size(), changelog: parsed}
+	parseCache.Unlock()
+	return cloneChangelog(parsed), nil
+}
+
+// forgetCached drops any cached parse of path, e.g. after it is rewritten.
+func forgetCached(path string) {
+	parseCache.Lock()
+	delete(parseCache.entries, path)
+	parseCache.Unlock()
+}
+
+// cloneChangelog deep-copies a changelog so cached parses can't be mutated.
+func cloneChangelog(c *Changelog) *Changelog {
+	clone := &Changelog{
+		Header: c.Header,
+		Links:  append([]string(nil), c.Links...),
+	}
+	if c.Versions != nil {
+		clone.Versions = make([]Version, len(c.Versions))
+	}
+	for i, v := range c.Versions {
+		clone.Versions[i] = Version{Number: v.Number, Date: v.Date}
+		if v.Sections != nil {
+			clone.Versions[i].Sections = make([]Section, len(v.Sections))
+		}
+		for j, s := range v.Sections {
+			clone.Versions[i].Sections[j] = Section{Type: s.Type, Entries: append([]string(nil), s.Entries...)}
+		}
+	}
+	return clone
+}
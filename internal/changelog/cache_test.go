@@ -0,0 +1,97 @@
+package changelog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseCached(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	content := "# Changelog\n\n## [1.0.0] - 2024-01-01\n\n### Added\n\n- Initial release\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write changelog: %v", err)
+	}
+
+	parses := 0
+	original := parseFile
+	parseFile = func(path string) (*Changelog, error) {
+		parses++
+		return original(path)
+	}
+	t.Cleanup(func() {
+		parseFile = original
+		forgetCached(path)
+	})
+
+	first, err := ParseCached(path)
+	if err != nil {
+		t.Fatalf("ParseCached() error = %v", err)
+	}
+	second, err := ParseCached(path)
+	if err != nil {
+		t.Fatalf("ParseCached() error = %v", err)
+	}
+	if parses != 1 {
+		t.Fatalf("expected 1 parse for an unchanged file, got %d", parses)
+	}
+	if len(second.Versions) != 1 || second.Versions[0].Number != "1.0.0" {
+		t.Fatalf("unexpected cached result: %+v", second.Versions)
+	}
+
+	first.Versions[0].Sections[0].Entries[0] = "mutated"
+	third, err := ParseCached(path)
+	if err != nil {
+		t.Fatalf("ParseCached() error = %v", err)
+	}
+	if third.Versions[0].Sections[0].Entries[0] != "Initial release" {
+		t.Error("mutating a returned changelog should not affect the cache")
+	}
+
+	updated := content + "\n## [0.9.0] - 2023-01-01\n\n### Fixed\n\n- Beta fix\n"
+	if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+		t.Fatalf("failed to update changelog: %v", err)
+	}
+	later := time.Now().Add(time.Second)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatalf("failed to touch changelog: %v", err)
+	}
+
+	fresh, err := ParseCached(path)
+	if err != nil {
+		t.Fatalf("ParseCached() error = %v", err)
+	}
+	if parses != 2 {
+		t.Errorf("expected a re-parse after the file changed, got %d parses", parses)
+	}
+	if len(fresh.Versions) != 2 {
+		t.Errorf("expected 2 versions after update, got %d", len(fresh.Versions))
+	}
+}
+
+func TestParseCached_WriteInvalidates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	cl := &Changelog{Header: defaultHeader(), Versions: []Version{{Number: "1.0.0", Date: "2024-01-01"}}}
+	if err := Write(path, cl, t.TempDir()); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	if _, err := ParseCached(path); err != nil {
+		t.Fatalf("ParseCached() error = %v", err)
+	}
+	t.Cleanup(func() { forgetCached(path) })
+
+	cl.Versions = append([]Version{{Number: "1.1.0", Date: "2024-02-01"}}, cl.Versions...)
+	if err := Write(path, cl, t.TempDir()); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	parsed, err := ParseCached(path)
+	if err != nil {
+		t.Fatalf("ParseCached() error = %v", err)
+	}
+	if len(parsed.Versions) != 2 {
+		t.Errorf("expected Write to invalidate the cache, got %d versions", len(parsed.Versions))
+	}
+}
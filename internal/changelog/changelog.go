@@ -5,16 +5,20 @@ package changelog
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
-	"github.com/go-git/go-git/v6"
 	"github.com/stormlightlabs/git-storm/internal/changeset"
+	"github.com/stormlightlabs/git-storm/internal/gitlog"
 )
 
 // Changelog represents the entire CHANGELOG.md file structure.
@@ -28,6 +32,7 @@ type Changelog struct {
 type Version struct {
 	Number   string    // Semantic version (e.g., "1.2.0")
 	Date     string    // ISO date (YYYY-MM-DD) or "Unreleased"
+	Yanked   bool      // Pulled after release; rendered as a trailing "[YANKED]"
 	Sections []Section // Category sections (Added, Changed, etc.)
 }
 
@@ -48,10 +53,118 @@ var sectionTitles = map[string]string{
 	"removed":    "Removed",
 	"fixed":      "Fixed",
 	"security":   "Security",
+	"breaking":   "Breaking Changes",
+
+	ContributorsSection: "New Contributors",
+}
+
+// ContributorsSection is the type of the section listing first-time
+// contributors, which always comes last.
+const ContributorsSection = "contributors"
+
+// Options customizes how versions are built and written.
+type Options struct {
+	// SectionOrder overrides the Keep a Changelog section ordering.
+	// Types not listed are appended afterwards in alphabetical order.
+	SectionOrder []string
+	// WithHash appends the short commit hash to entries that carry one.
+	WithHash bool
+	// RepoURL is the repository's web URL used to link hashes and issue
+	// references; plain hashes and references are used when empty.
+	RepoURL string
+	// Forge lays out the links under RepoURL; GitHub's layout is used when empty.
+	Forge Forge
+	// Hosts maps self-hosted hostnames to their forge so comparison links can
+	// be generated for them. See [RemoteRepo].
+	Hosts map[string]Forge
+	// SectionTitles overrides the heading rendered for a section type.
+	SectionTitles map[string]string
+	// DateFormat is a Go time layout for version dates; YYYY-MM-DD is used when empty.
+	DateFormat string
+	// BreakingSection collects breaking entries into a dedicated "breaking" section
+	// instead of prefixing them within their own type.
+	BreakingSection bool
+	// BreakingLabel is the bold prefix for breaking entries; "BREAKING" when empty.
+	BreakingLabel string
+	// Sections restricts built versions to these section types; all types are
+	// included when empty. Filtered entries are omitted, not removed from .changes.
+	Sections []string
+	// Template is a whole-document text/template rendered by [WriteWithOptions];
+	// [DefaultTemplate] is used when empty. See [RenderTemplate].
+	Template string
+	// GroupByScope orders entries within each section by scope so entries sharing
+	// a scope render together, each keeping its "**scope:**" prefix.
+	GroupByScope bool
+	// ScopelessLabel, with GroupByScope, is the scope shown for entries without one
+	// (e.g. "General"), sorted among the named scopes. When empty, scopeless
+	// entries are listed first without a prefix.
+	ScopelessLabel string
+	// Types is the project's change type registry. When set, building rejects
+	// entries of unregistered types, new sections follow the registry's order
+	// unless SectionOrder is given, and its titles are used for headings.
+	Types *changeset.Registry
+}
+
+// sectionOrder returns the configured order, led by the breaking section when enabled.
+func (o Options) sectionOrder() []string {
+	if !o.BreakingSection {
+		return o.SectionOrder
+	}
+	return append([]string{"breaking"}, resolveSectionOrder[struct{}](o.SectionOrder, nil)...)
+}
+
+// buildOrder returns the section order for newly built versions: the configured
+// order, or the registry's type order when none is set.
+func (o Options) buildOrder() []string {
+	if len(o.SectionOrder) == 0 && o.Types != nil {
+		o.SectionOrder = o.Types.Names()
+	}
+	return o.sectionOrder()
+}
+
+// validateTypes checks every entry's type against the registry, if one is set.
+func (o Options) validateTypes(entries []changeset.Entry) error {
+	if o.Types == nil {
+		return nil
+	}
+	for _, entry := range entries {
+		if err := o.Types.Validate(entry.Type); err != nil {
+			return fmt.Errorf("entry %q: %w", entry.Summary, err)
+		}
+	}
+	return nil
 }
 
-// versionHeaderRegex matches version headers like "## [1.2.0] - 2025-01-15" or "## [Unreleased]"
-var versionHeaderRegex = regexp.MustCompile(`^##\s+\[([^\]]+)\](?:\s+-\s+(.+))?$`)
+// Output profiles accepted by [ProfileOptions].
+const (
+	ProfileKeepAChangelog  = "keepachangelog"
+	ProfileCommonChangelog = "common-changelog"
+)
+
+// ProfileOptions returns the Options preset for a named output profile.
+//
+// keepachangelog follows https://keepachangelog.com and is the default.
+// common-changelog follows https://common-changelog.org: Changed, Added,
+// Removed, Fixed ordering with breaking entries marked "Breaking". Either
+// can be adjusted further with the individual options.
+func ProfileOptions(name string) (Options, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", ProfileKeepAChangelog:
+		return Options{}, nil
+	case ProfileCommonChangelog:
+		return Options{
+			SectionOrder:  []string{"changed", "added", "removed", "fixed"},
+			BreakingLabel: "Breaking",
+		}, nil
+	default:
+		return Options{}, fmt.Errorf("unknown changelog format %q: expected %s or %s",
+			name, ProfileKeepAChangelog, ProfileCommonChangelog)
+	}
+}
+
+// versionHeaderRegex matches version headers like "## [1.2.0] - 2025-01-15",
+// "## [1.1.0] - 2025-01-02 [YANKED]", or "## [Unreleased]"
+var versionHeaderRegex = regexp.MustCompile(`^##\s+\[([^\]]+)\](?:\s+-\s+(.+?))?(\s+(?i:\[yanked\]))?$`)
 
 // sectionHeaderRegex matches section headers like "### Added"
 var sectionHeaderRegex = regexp.MustCompile(`^###\s+(.+)$`)
@@ -59,6 +172,9 @@ var sectionHeaderRegex = regexp.MustCompile(`^###\s+(.+)$`)
 // entryRegex matches changelog entries like "- Entry text"
 var entryRegex = regexp.MustCompile(`^-\s+(.+)$`)
 
+// subEntryRegex matches the indented body bullets below an entry, like "  - Detail"
+var subEntryRegex = regexp.MustCompile(`^\s+-\s+(.+)$`)
+
 // semanticVersionRegex validates semantic versioning (X.Y.Z)
 var semanticVersionRegex = regexp.MustCompile(`^\d+\.\d+\.\d+$`)
 
@@ -111,6 +227,7 @@ func Parse(path string) (*Changelog, error) {
 
 			currentVersion = &Version{
 				Number: versionMatch[1],
+				Yanked: versionMatch[3] != "",
 			}
 			if len(versionMatch) > 2 && versionMatch[2] != "" {
 				currentVersion.Date = versionMatch[2]
@@ -144,6 +261,14 @@ func Parse(path string) (*Changelog, error) {
 			continue
 		}
 
+		if subMatch := subEntryRegex.FindStringSubmatch(line); subMatch != nil {
+			if currentSection != nil && len(currentSection.Entries) > 0 {
+				last := len(currentSection.Entries) - 1
+				currentSection.Entries[last] += "\n  - " + subMatch[1]
+			}
+			continue
+		}
+
 		if currentVersion == nil {
 			headerLines = append(headerLines, line)
 		}
@@ -172,6 +297,13 @@ func Parse(path string) (*Changelog, error) {
 //
 // Entries are grouped by type, sorted, and formatted with breaking change prefixes.
 func Build(entries []changeset.Entry, version, date string) (*Version, error) {
+	return BuildWithOptions(entries, version, date, Options{})
+}
+
+// BuildWithOptions creates a new Version like [Build], ordering sections by opts.
+//
+// When opts.Types is set, entries whose type isn't registered are rejected.
+func BuildWithOptions(entries []changeset.Entry, version, date string, opts Options) (*Version, error) {
 	if err := ValidateVersion(version); err != nil {
 		return nil, err
 	}
@@ -180,27 +312,92 @@ func Build(entries []changeset.Entry, version, date string) (*Version, error) {
 		return nil, err
 	}
 
-	grouped := make(map[string][]string)
+	if err := opts.validateTypes(entries); err != nil {
+		return nil, err
+	}
+
+	return &Version{
+		Number:   version,
+		Date:     date,
+		Sections: buildSections(entries, opts),
+	}, nil
+}
+
+// BuildUnreleased creates an "Unreleased" Version from changeset entries,
+// ordering and filtering sections by opts. Unlike [BuildWithOptions] it never
+// rejects entries, so previews can show entries of unregistered types.
+func BuildUnreleased(entries []changeset.Entry, opts Options) *Version {
+	return &Version{
+		Number:   "Unreleased",
+		Date:     "Unreleased",
+		Sections: buildSections(entries, opts),
+	}
+}
+
+// buildSections groups entries into sorted sections ordered by opts.
+func buildSections(entries []changeset.Entry, opts Options) []Section {
+	breakingLabel := opts.BreakingLabel
+	if breakingLabel == "" {
+		breakingLabel = "BREAKING"
+	}
+
+	grouped := make(map[string][]builtEntry)
 	for _, entry := range entries {
+		typ := entry.Type
 		text := entry.Summary
-		if entry.Scope != "" {
-			text = fmt.Sprintf("**%s:** %s", entry.Scope, text)
+		scope := entry.Scope
+		if scope == "" && opts.GroupByScope {
+			scope = opts.ScopelessLabel
+		}
+		if scope != "" {
+			text = fmt.Sprintf("**%s:** %s", scope, text)
+		}
+		if entry.Breaking && opts.BreakingSection {
+			typ = "breaking"
+		} else if entry.Breaking {
+			text = fmt.Sprintf("**%s:** %s", breakingLabel, text)
+		}
+		remote := Remote{Forge: opts.Forge, URL: opts.RepoURL}
+		text = linkReferences(text, entryReferences(entry), remote)
+		if len(entry.Authors) > 0 {
+			text = fmt.Sprintf("%s (thanks %s)", text, strings.Join(entry.Authors, ", "))
 		}
-		if entry.Breaking {
-			text = fmt.Sprintf("**BREAKING:** %s", text)
+		if len(entry.Links) > 0 {
+			text = fmt.Sprintf("%s (%s)", text, formatLinks(entry.Links))
+		}
+		if opts.WithHash && entry.CommitHash != "" {
+			text = fmt.Sprintf("%s (%s)", text, formatCommitRef(entry.CommitHash, remote))
+		}
+		text += formatBody(entry.Body)
+
+		if len(opts.Sections) > 0 && !slices.Contains(opts.Sections, typ) {
+			continue
 		}
 
-		grouped[entry.Type] = append(grouped[entry.Type], text)
+		built := builtEntry{text: text, key: entry.CommitHash + entry.DiffHash}
+		if opts.GroupByScope {
+			built.group = strings.ToLower(scope)
+		}
+		grouped[typ] = append(grouped[typ], built)
+	}
+
+	if len(opts.Sections) == 0 || slices.Contains(opts.Sections, ContributorsSection) {
+		if built := contributorEntries(entries, Remote{Forge: opts.Forge, URL: opts.RepoURL}); len(built) > 0 {
+			grouped[ContributorsSection] = built
+		}
 	}
 
 	for typ := range grouped {
-		sort.Strings(grouped[typ])
+		sortBuiltEntries(grouped[typ])
 	}
 
-	// Build sections in Keep a Changelog order
 	var sections []Section
-	for _, typ := range sectionOrder {
-		if entryList, exists := grouped[typ]; exists && len(entryList) > 0 {
+	for _, typ := range resolveSectionOrder(opts.buildOrder(), grouped) {
+		if built := grouped[typ]; len(built) > 0 {
+			entryList := make([]string, len(built))
+			for i, b := range built {
+				entryList[i] = b.text
+			}
 			sections = append(sections, Section{
 				Type:    typ,
 				Entries: entryList,
@@ -208,11 +405,219 @@ func Build(entries []changeset.Entry, version, date string) (*Version, error) {
 		}
 	}
 
-	return &Version{
-		Number:   version,
-		Date:     date,
-		Sections: sections,
-	}, nil
+	return sections
+}
+
+// contributorEntries lists each first-time contributor once, like GitHub's
+// release notes: "@jane made their first contribution in #12", pointing at
+// the entry's first reference or else its commit.
+func contributorEntries(entries []changeset.Entry, remote Remote) []builtEntry {
+	var built []builtEntry
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		where := ""
+		if refs := entryReferences(entry); len(refs) > 0 {
+			where = fmt.Sprintf("#%d", refs[0])
+			if remote.URL != "" {
+				where = fmt.Sprintf("[#%d](%s)", refs[0], remote.IssueURL(refs[0]))
+			}
+		} else if entry.CommitHash != "" {
+			where = formatCommitRef(entry.CommitHash, remote)
+		}
+
+		for _, name := range entry.NewContributors {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			text := name + " made their first contribution"
+			if where != "" {
+				text += " in " + where
+			}
+			built = append(built, builtEntry{text: text})
+		}
+	}
+	return built
+}
+
+// builtEntry is a rendered entry line with a tie-break key for identical text.
+type builtEntry struct {
+	text  string
+	key   string // commit hash + diff hash of the source entry
+	group string // lowercased scope when grouping by scope; "" sorts first
+}
+
+// sortBuiltEntries orders entries by scope group, then text, breaking ties by
+// source hashes so identical summaries render in the same order regardless of
+// input order.
+func sortBuiltEntries(entries []builtEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].group != entries[j].group {
+			return entries[i].group < entries[j].group
+		}
+		if entries[i].text != entries[j].text {
+			return entries[i].text < entries[j].text
+		}
+		return entries[i].key < entries[j].key
+	})
+}
+
+// formatBody renders each paragraph of an entry body as an indented
+// sub-bullet on its own line, joining wrapped lines with spaces.
+func formatBody(body string) string {
+	var b strings.Builder
+	for paragraph := range strings.SplitSeq(strings.ReplaceAll(strings.TrimSpace(body), "\r\n", "\n"), "\n\n") {
+		if text := strings.Join(strings.Fields(paragraph), " "); text != "" {
+			b.WriteString("\n  - " + text)
+		}
+	}
+	return b.String()
+}
+
+// entryReferences returns the issue numbers recorded on entry along with any
+// mentioned in its summary.
+func entryReferences(entry changeset.Entry) []int {
+	refs := slices.Clone(entry.References)
+	for _, n := range gitlog.ParseReferences(entry.Summary) {
+		if !slices.Contains(refs, n) {
+			refs = append(refs, n)
+		}
+	}
+	return refs
+}
+
+// linkReferences turns the references to refs mentioned in text into links
+// to their issues on remote, then appends the ones text doesn't mention.
+// Without a remote URL, mentions are left as they are and the rest appended
+// as plain "#123".
+func linkReferences(text string, refs []int, remote Remote) string {
+	if len(refs) == 0 {
+		return text
+	}
+
+	mentioned := gitlog.ParseReferences(text)
+	if remote.URL != "" {
+		text = gitlog.ReferenceRegex.ReplaceAllStringFunc(text, func(match string) string {
+			m := gitlog.ReferenceRegex.FindStringSubmatch(match)
+			n, _ := strconv.Atoi(m[3])
+			if !slices.Contains(refs, n) {
+				return match
+			}
+			return fmt.Sprintf("%s[%s%s](%s)", m[1], m[2], m[3], remote.IssueURL(n))
+		})
+	}
+
+	var rest []string
+	for _, n := range refs {
+		if slices.Contains(mentioned, n) {
+			continue
+		}
+		if remote.URL != "" {
+			rest = append(rest, fmt.Sprintf("[#%d](%s)", n, remote.IssueURL(n)))
+		} else {
+			rest = append(rest, fmt.Sprintf("#%d", n))
+		}
+	}
+	if len(rest) > 0 {
+		text = fmt.Sprintf("%s (%s)", text, strings.Join(rest, ", "))
+	}
+	return text
+}
+
+// formatLinks renders named links as comma-separated markdown links.
+func formatLinks(links changeset.Links) string {
+	parts := make([]string, len(links))
+	for i, link := range links {
+		parts[i] = fmt.Sprintf("[%s](%s)", link.Name, link.URL)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatCommitRef renders a short commit hash, linked to the commit when remote has a URL.
+func formatCommitRef(hash string, remote Remote) string {
+	short := hash
+	if len(short) > 7 {
+		short = short[:7]
+	}
+	if remote.URL == "" {
+		return short
+	}
+	return fmt.Sprintf("[%s](%s)", short, remote.CommitURL(hash))
+}
+
+// resolveSectionOrder returns the order sections should render in.
+//
+// Uses the Keep a Changelog order when order is empty. Types present in grouped but
+// missing from the order are appended alphabetically so no section is dropped.
+func resolveSectionOrder[T any](order []string, grouped map[string]T) []string {
+	if len(order) == 0 {
+		order = sectionOrder
+	}
+
+	seen := make(map[string]bool, len(order))
+	resolved := make([]string, 0, len(order))
+	for _, typ := range order {
+		typ = strings.ToLower(strings.TrimSpace(typ))
+		if typ == "" || seen[typ] {
+			continue
+		}
+		seen[typ] = true
+		resolved = append(resolved, typ)
+	}
+
+	var extra []string
+	for typ := range grouped {
+		if !seen[typ] && typ != ContributorsSection {
+			extra = append(extra, typ)
+		}
+	}
+	sort.Strings(extra)
+	resolved = append(resolved, extra...)
+
+	if _, ok := grouped[ContributorsSection]; ok && !seen[ContributorsSection] {
+		resolved = append(resolved, ContributorsSection)
+	}
+	return resolved
+}
+
+// orderSections returns sections sorted by the given order, preserving unlisted ones.
+func orderSections(sections []Section, order []string) []Section {
+	byType := make(map[string][]Section, len(sections))
+	for _, section := range sections {
+		byType[section.Type] = append(byType[section.Type], section)
+	}
+
+	ordered := make([]Section, 0, len(sections))
+	for _, typ := range resolveSectionOrder(order, byType) {
+		ordered = append(ordered, byType[typ]...)
+	}
+	return ordered
+}
+
+// mergeSections consolidates sections of the same type into the first occurrence.
+//
+// Entries of merged sections are concatenated and re-sorted; sections that appear
+// only once are returned untouched.
+func mergeSections(sections []Section) []Section {
+	index := make(map[string]int, len(sections))
+	merged := make([]Section, 0, len(sections))
+	duplicated := make(map[string]bool)
+	for _, section := range sections {
+		typ := strings.ToLower(section.Type)
+		if i, ok := index[typ]; ok {
+			merged[i].Entries = append(merged[i].Entries, section.Entries...)
+			duplicated[typ] = true
+			continue
+		}
+		index[typ] = len(merged)
+		section.Entries = append([]string(nil), section.Entries...)
+		merged = append(merged, section)
+	}
+
+	for typ := range duplicated {
+		sort.Strings(merged[index[typ]].Entries)
+	}
+	return merged
 }
 
 // Merge inserts a new version into the changelog at the top (below Unreleased if present).
@@ -229,95 +634,337 @@ func Merge(changelog *Changelog, version *Version) {
 	changelog.Versions = versions
 }
 
+// Insert places a version at its chronological position, keeping versions
+// ordered newest first by semantic version below any Unreleased section.
+//
+// Returns an error if the version already exists.
+func Insert(changelog *Changelog, version *Version) error {
+	insertIndex := len(changelog.Versions)
+	for i, existing := range changelog.Versions {
+		if existing.Number == version.Number {
+			return fmt.Errorf("version %s already exists", version.Number)
+		}
+		if strings.ToLower(existing.Number) == "unreleased" {
+			continue
+		}
+		if insertIndex == len(changelog.Versions) && compareVersions(version.Number, existing.Number) > 0 {
+			insertIndex = i
+		}
+	}
+
+	versions := make([]Version, 0, len(changelog.Versions)+1)
+	versions = append(versions, changelog.Versions[:insertIndex]...)
+	versions = append(versions, *version)
+	versions = append(versions, changelog.Versions[insertIndex:]...)
+	changelog.Versions = versions
+	return nil
+}
+
+// YankedSection is the section type holding the reason a version was yanked.
+const YankedSection = "yanked"
+
+// Yank marks a released version as yanked, following Keep a Changelog. A
+// non-empty reason is added as an entry of a "Yanked" section.
+//
+// Returns an error if the version doesn't exist, is unreleased, or is already yanked.
+func Yank(changelog *Changelog, version, reason string) (*Version, error) {
+	for i := range changelog.Versions {
+		v := &changelog.Versions[i]
+		if v.Number != version {
+			continue
+		}
+		if strings.ToLower(v.Number) == "unreleased" {
+			return nil, fmt.Errorf("only released versions can be yanked")
+		}
+		if v.Yanked {
+			return nil, fmt.Errorf("version %s is already yanked", version)
+		}
+		v.Yanked = true
+		if reason = strings.TrimSpace(reason); reason != "" {
+			v.Sections = append(v.Sections, Section{Type: YankedSection, Entries: []string{reason}})
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("version %s not found", version)
+}
+
+// Promote relabels the changelog's Unreleased section as version, released on
+// date, and leaves a fresh empty Unreleased section above it.
+//
+// Returns the promoted version, or an error if there is nothing unreleased or
+// the version already exists.
+func Promote(changelog *Changelog, version, date string) (*Version, error) {
+	if err := ValidateVersion(version); err != nil {
+		return nil, err
+	}
+	if err := ValidateDate(date); err != nil {
+		return nil, err
+	}
+
+	index := -1
+	for i, existing := range changelog.Versions {
+		if strings.ToLower(existing.Number) == "unreleased" {
+			index = i
+			break
+		}
+	}
+	if index == -1 || len(changelog.Versions[index].Sections) == 0 {
+		return nil, fmt.Errorf("no unreleased changes to promote")
+	}
+
+	promoted := &Version{
+		Number:   version,
+		Date:     date,
+		Sections: changelog.Versions[index].Sections,
+	}
+
+	previous := changelog.Versions[index]
+	changelog.Versions[index] = Version{Number: "Unreleased", Date: "Unreleased"}
+	if err := Insert(changelog, promoted); err != nil {
+		changelog.Versions[index] = previous
+		return nil, err
+	}
+	return promoted, nil
+}
+
+// SortVersions orders versions newest first by semantic version, keeping
+// Unreleased on top. Non-semver versions sort after semver ones, newest date first.
+func SortVersions(versions []Version) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versionLess(versions[i], versions[j])
+	})
+}
+
+// VersionsSorted reports whether versions are already in [SortVersions] order.
+func VersionsSorted(versions []Version) bool {
+	return sort.SliceIsSorted(versions, func(i, j int) bool {
+		return versionLess(versions[i], versions[j])
+	})
+}
+
+// versionLess reports whether a belongs before b in changelog order.
+func versionLess(a, b Version) bool {
+	aUnreleased := strings.ToLower(a.Number) == "unreleased"
+	bUnreleased := strings.ToLower(b.Number) == "unreleased"
+	if aUnreleased || bUnreleased {
+		return aUnreleased && !bUnreleased
+	}
+
+	_, aSemver := parseVersionParts(a.Number)
+	_, bSemver := parseVersionParts(b.Number)
+	switch {
+	case aSemver && bSemver:
+		return compareVersions(a.Number, b.Number) > 0
+	case aSemver != bSemver:
+		return aSemver
+	default:
+		return a.Date > b.Date
+	}
+}
+
+// compareVersions compares two X.Y.Z versions, returning -1, 0, or 1.
+//
+// Versions that don't parse sort below those that do.
+func compareVersions(a, b string) int {
+	pa, okA := parseVersionParts(a)
+	pb, okB := parseVersionParts(b)
+	switch {
+	case !okA && !okB:
+		return 0
+	case !okA:
+		return -1
+	case !okB:
+		return 1
+	}
+
+	for i := range pa {
+		if pa[i] != pb[i] {
+			if pa[i] < pb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// parseVersionParts splits an X.Y.Z version into its numeric components.
+func parseVersionParts(v string) ([3]int, bool) {
+	var parts [3]int
+	if !semanticVersionRegex.MatchString(v) {
+		return parts, false
+	}
+	for i, field := range strings.Split(v, ".") {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
+
 // Write writes the changelog to a file with proper Keep a Changelog formatting.
 //
 // Generates version comparison links if a git remote is available.
 func Write(path string, changelog *Changelog, repoPath string) error {
+	return WriteWithOptions(path, changelog, repoPath, Options{})
+}
+
+// WriteWithOptions writes the changelog like [Write].
+//
+// Sections sharing a type within a version are merged into one before rendering.
+// opts.Template replaces the whole-document layout; see [RenderTemplate].
+// When opts.SectionOrder is set, each version's sections are reordered to match it,
+// and section titles and dates follow opts.SectionTitles and opts.DateFormat.
+func WriteWithOptions(path string, changelog *Changelog, repoPath string, opts Options) error {
+	defer forgetCached(path)
+
 	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	file, err := os.Create(path)
-	if err != nil {
-		return fmt.Errorf("failed to create changelog: %w", err)
+	// Render before touching the file so a broken template leaves it intact.
+	var buf bytes.Buffer
+	if err := RenderTemplate(&buf, opts.Template, changelog, repoPath, opts); err != nil {
+		return err
 	}
-	defer file.Close()
 
-	w := bufio.NewWriter(file)
-	defer w.Flush()
-
-	if changelog.Header != "" {
-		fmt.Fprintf(w, "%s\n\n", changelog.Header)
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to create changelog: %w", err)
 	}
+	return nil
+}
 
-	for i, version := range changelog.Versions {
-		if i > 0 {
+// writeVersion renders a single version header and its sections.
+func writeVersion(w io.Writer, version Version, opts Options) {
+	fmt.Fprintf(w, "%s\n\n", versionHeading(version, opts))
+	writeSections(w, version, opts)
+}
+
+// writeSections renders the sections of version without its header.
+func writeSections(w io.Writer, version Version, opts Options) {
+	for j, section := range orderedSections(version, opts) {
+		if j > 0 {
 			fmt.Fprintln(w)
 		}
+		fmt.Fprintf(w, "### %s\n\n", sectionTitle(section.Type, opts))
 
-		if version.Date == "" || strings.ToLower(version.Date) == "unreleased" {
-			fmt.Fprintf(w, "## [%s]\n\n", version.Number)
-		} else {
-			fmt.Fprintf(w, "## [%s] - %s\n\n", version.Number, version.Date)
+		for _, entry := range section.Entries {
+			fmt.Fprintf(w, "- %s\n", entry)
 		}
+	}
+}
 
-		for j, section := range version.Sections {
-			if j > 0 {
-				fmt.Fprintln(w)
-			}
+// versionHeading returns the "## [X.Y.Z] - date" heading for version, marked
+// "[YANKED]" when the version was pulled.
+func versionHeading(version Version, opts Options) string {
+	heading := fmt.Sprintf("## [%s]", version.Number)
+	if version.Date != "" && strings.ToLower(version.Date) != "unreleased" {
+		heading += " - " + formatDate(version.Date, opts.DateFormat)
+	}
+	if version.Yanked {
+		heading += " [YANKED]"
+	}
+	return heading
+}
 
-			title := sectionTitles[section.Type]
-			if title == "" {
-				if len(section.Type) > 0 {
-					title = strings.ToUpper(section.Type[:1]) + section.Type[1:]
-				} else {
-					title = section.Type
-				}
-			}
-			fmt.Fprintf(w, "### %s\n\n", title)
+// orderedSections merges duplicate sections of version and applies the configured order.
+func orderedSections(version Version, opts Options) []Section {
+	sections := mergeSections(version.Sections)
+	if order := opts.sectionOrder(); len(order) > 0 {
+		sections = orderSections(sections, order)
+	}
+	return sections
+}
 
-			for _, entry := range section.Entries {
-				fmt.Fprintf(w, "- %s\n", entry)
+// sectionTitle returns the heading for a section type, preferring opts overrides.
+func sectionTitle(sectionType string, opts Options) string {
+	if title := opts.SectionTitles[sectionType]; title != "" {
+		return title
+	}
+	if opts.Types != nil {
+		for _, t := range opts.Types.Types() {
+			// Parsed changelogs key custom sections by their lowercased title.
+			if t.Name == sectionType || strings.ToLower(t.Title) == sectionType {
+				return t.Title
 			}
 		}
 	}
+	if title := sectionTitles[sectionType]; title != "" {
+		return title
+	}
+	if len(sectionType) > 0 {
+		return strings.ToUpper(sectionType[:1]) + sectionType[1:]
+	}
+	return sectionType
+}
 
-	links, err := GenerateLinks(repoPath, changelog.Versions)
-	if err == nil && len(links) > 0 {
-		fmt.Fprintln(w)
-		for _, link := range links {
-			fmt.Fprintln(w, link)
-		}
-	} else if len(changelog.Links) > 0 {
-		fmt.Fprintln(w)
-		for _, link := range changelog.Links {
-			fmt.Fprintln(w, link)
-		}
+// formatDate renders an ISO date with layout, leaving dates that aren't
+// YYYY-MM-DD (or an empty layout) unchanged.
+func formatDate(date, layout string) string {
+	if layout == "" {
+		return date
+	}
+	parsed, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
 	}
+	return parsed.Format(layout)
+}
 
-	return nil
+// RenderVersion renders a single version exactly as [WriteWithOptions] writes it.
+func RenderVersion(version *Version, opts Options) string {
+	var b strings.Builder
+	writeVersion(&b, *version, opts)
+	return b.String()
 }
 
-// GenerateLinks creates version comparison links for GitHub repositories.
-func GenerateLinks(repoPath string, versions []Version) ([]string, error) {
-	repo, err := git.PlainOpen(repoPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open repository: %w", err)
+// RenderSections renders version's sections without the version heading,
+// e.g. as the body of a hosted release.
+func RenderSections(version *Version, opts Options) string {
+	var b strings.Builder
+	writeSections(&b, *version, opts)
+	return b.String()
+}
+
+// WriteSnapshot writes the rendered version to <dir>/<version>.md as an
+// immutable record of the release.
+//
+// Existing snapshots are never overwritten; created reports whether a file was written.
+func WriteSnapshot(dir string, version *Version, opts Options) (path string, created bool, err error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", false, fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	remote, err := repo.Remote("origin")
+	path = filepath.Join(dir, version.Number+".md")
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
 	if err != nil {
-		return nil, fmt.Errorf("no origin remote configured: %w", err)
+		if os.IsExist(err) {
+			return path, false, nil
+		}
+		return "", false, fmt.Errorf("failed to create snapshot: %w", err)
 	}
+	defer file.Close()
 
-	if len(remote.Config().URLs) == 0 {
-		return nil, fmt.Errorf("no remote URL configured")
+	if _, err := file.WriteString(RenderVersion(version, opts)); err != nil {
+		return "", false, fmt.Errorf("failed to write snapshot: %w", err)
 	}
+	return path, true, nil
+}
+
+// GenerateLinks creates version comparison links for repositories hosted on
+// a public forge.
+func GenerateLinks(repoPath string, versions []Version) ([]string, error) {
+	return GenerateLinksWithOptions(repoPath, versions, Options{})
+}
 
-	remoteURL := remote.Config().URLs[0]
-	baseURL := parseGitHubURL(remoteURL)
-	if baseURL == "" {
-		return nil, fmt.Errorf("not a GitHub repository")
+// GenerateLinksWithOptions creates version comparison links like
+// [GenerateLinks], also recognizing the self-hosted forges in opts.Hosts.
+func GenerateLinksWithOptions(repoPath string, versions []Version, opts Options) ([]string, error) {
+	remote, err := RemoteRepo(repoPath, opts.Hosts)
+	if err != nil {
+		return nil, err
 	}
 
 	var links []string
@@ -325,15 +972,16 @@ func GenerateLinks(repoPath string, versions []Version) ([]string, error) {
 		var link string
 		if strings.ToLower(version.Number) == "unreleased" {
 			if len(versions) > 1 {
-				link = fmt.Sprintf("[Unreleased]: %s/compare/v%s...HEAD", baseURL, versions[1].Number)
+				link = fmt.Sprintf("[Unreleased]: %s", remote.CompareURL("v"+versions[1].Number, "HEAD"))
 			} else {
-				link = fmt.Sprintf("[Unreleased]: %s/compare/HEAD", baseURL)
+				link = fmt.Sprintf("[Unreleased]: %s", remote.CommitsURL())
 			}
 		} else {
+			tag := "v" + version.Number
 			if i+1 < len(versions) && strings.ToLower(versions[i+1].Number) != "unreleased" {
-				link = fmt.Sprintf("[%s]: %s/compare/v%s...v%s", version.Number, baseURL, versions[i+1].Number, version.Number)
+				link = fmt.Sprintf("[%s]: %s", version.Number, remote.CompareURL("v"+versions[i+1].Number, tag))
 			} else {
-				link = fmt.Sprintf("[%s]: %s/releases/tag/v%s", version.Number, baseURL, version.Number)
+				link = fmt.Sprintf("[%s]: %s", version.Number, remote.TagURL(tag))
 			}
 		}
 		links = append(links, link)
@@ -359,22 +1007,6 @@ func ValidateDate(date string) error {
 	return nil
 }
 
-// parseGitHubURL extracts the base GitHub URL from a git remote URL.
-//
-// Handles both HTTPS and SSH formats.
-func parseGitHubURL(remoteURL string) string {
-	remoteURL = strings.TrimSuffix(remoteURL, ".git")
-
-	if strings.HasPrefix(remoteURL, "https://github.com/") {
-		return remoteURL
-	}
-
-	if parts, ok := strings.CutPrefix(remoteURL, "git@github.com:"); ok {
-		return "https://github.com/" + parts
-	}
-	return ""
-}
-
 // findSectionType converts a section title to its internal type.
 func findSectionType(title string) string {
 	titleLower := strings.ToLower(strings.TrimSpace(title))
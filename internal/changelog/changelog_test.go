@@ -6,6 +6,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/config"
 	"github.com/stormlightlabs/git-storm/internal/changeset"
 )
 
@@ -217,6 +219,215 @@ func TestBuild(t *testing.T) {
 	}
 }
 
+func TestBuild_IdenticalSummariesAreStable(t *testing.T) {
+	entries := []changeset.Entry{
+		{Type: "fixed", Scope: "cli", Summary: "Handle empty input", CommitHash: "bbbbbbb"},
+		{Type: "fixed", Summary: "Another fix", CommitHash: "ccccccc"},
+		{Type: "fixed", Scope: "cli", Summary: "Handle empty input", CommitHash: "aaaaaaa"},
+	}
+	reversed := []changeset.Entry{entries[2], entries[1], entries[0]}
+
+	want := RenderVersion(mustBuild(t, entries), Options{})
+	for range 5 {
+		for _, input := range [][]changeset.Entry{entries, reversed} {
+			if got := RenderVersion(mustBuild(t, input), Options{}); got != want {
+				t.Fatalf("unstable output:\ngot:\n%s\nwant:\n%s", got, want)
+			}
+		}
+	}
+
+	built := []builtEntry{{text: "same", key: "b"}, {text: "other", key: "z"}, {text: "same", key: "a"}}
+	sortBuiltEntries(built)
+	if built[0].text != "other" || built[1].key != "a" || built[2].key != "b" {
+		t.Errorf("sortBuiltEntries() = %+v, want identical text ordered by key", built)
+	}
+}
+
+func mustBuild(t *testing.T, entries []changeset.Entry) *Version {
+	t.Helper()
+	version, err := Build(entries, "1.0.0", "2025-01-01")
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	return version
+}
+
+func TestBuildSectionsFilter(t *testing.T) {
+	entries := []changeset.Entry{
+		{Type: "added", Summary: "New feature"},
+		{Type: "security", Summary: "Patch CVE-2025-0001"},
+		{Type: "fixed", Summary: "Bug fix"},
+	}
+
+	version, err := BuildWithOptions(entries, "1.0.0", "2025-01-01", Options{Sections: []string{"security"}})
+	if err != nil {
+		t.Fatalf("BuildWithOptions() error = %v", err)
+	}
+	if len(version.Sections) != 1 || version.Sections[0].Type != "security" {
+		t.Fatalf("Sections = %+v, want only security", version.Sections)
+	}
+
+	rendered := RenderVersion(version, Options{})
+	if !strings.Contains(rendered, "### Security") || strings.Contains(rendered, "### Added") {
+		t.Errorf("rendered output should contain only the Security section:\n%s", rendered)
+	}
+
+	empty := BuildUnreleased(entries, Options{Sections: []string{"deprecated"}})
+	if len(empty.Sections) != 0 {
+		t.Errorf("Sections = %+v, want none for unmatched filter", empty.Sections)
+	}
+	if got := RenderVersion(empty, Options{}); got != "## [Unreleased]\n\n" {
+		t.Errorf("RenderVersion() of empty version = %q", got)
+	}
+}
+
+func TestRenderSections(t *testing.T) {
+	version := mustBuild(t, []changeset.Entry{
+		{Type: "fixed", Summary: "Bug fix"},
+		{Type: "added", Summary: "New feature"},
+	})
+	want := "### Added\n\n- New feature\n\n### Fixed\n\n- Bug fix\n"
+	if got := RenderSections(version, Options{}); got != want {
+		t.Errorf("RenderSections() = %q, want %q", got, want)
+	}
+	if rendered := RenderVersion(version, Options{}); !strings.HasSuffix(rendered, want) {
+		t.Errorf("RenderVersion() should end with the sections, got %q", rendered)
+	}
+}
+
+func TestBuildWithOptions_TypeRegistry(t *testing.T) {
+	registry, err := changeset.NewRegistry([]changeset.ChangeType{
+		{Name: "fixed"},
+		{Name: "perf", Title: "Performance Improvements"},
+		{Name: "added"},
+	})
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+	opts := Options{Types: registry}
+
+	version, err := BuildWithOptions([]changeset.Entry{
+		{Type: "added", Summary: "Add cache"},
+		{Type: "perf", Summary: "Speed up diffing"},
+		{Type: "fixed", Summary: "Fix crash"},
+	}, "1.1.0", "2025-01-01", opts)
+	if err != nil {
+		t.Fatalf("BuildWithOptions() error = %v", err)
+	}
+
+	var order []string
+	for _, section := range version.Sections {
+		order = append(order, section.Type)
+	}
+	if got := strings.Join(order, ","); got != "fixed,perf,added" {
+		t.Errorf("sections should follow the registry order, got %s", got)
+	}
+
+	rendered := RenderVersion(version, opts)
+	if !strings.Contains(rendered, "### Performance Improvements\n\n- Speed up diffing") {
+		t.Errorf("registry titles should be used for headings:\n%s", rendered)
+	}
+
+	changelogPath := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	if err := os.WriteFile(changelogPath, []byte("# Changelog\n\n"+rendered), 0644); err != nil {
+		t.Fatalf("Failed to write changelog: %v", err)
+	}
+	parsed, err := Parse(changelogPath)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := RenderVersion(&parsed.Versions[0], opts); got != rendered {
+		t.Errorf("custom titles should survive a parse round trip:\n%s", got)
+	}
+
+	_, err = BuildWithOptions([]changeset.Entry{{Type: "security", Summary: "Patch leak"}}, "1.1.0", "2025-01-01", opts)
+	if err == nil || !strings.Contains(err.Error(), "must be one of fixed, perf, added") {
+		t.Errorf("unregistered types should be rejected, got %v", err)
+	}
+
+	unreleased := BuildUnreleased([]changeset.Entry{{Type: "security", Summary: "Patch leak"}}, opts)
+	if len(unreleased.Sections) != 1 {
+		t.Errorf("previews should keep unregistered types, got %d sections", len(unreleased.Sections))
+	}
+}
+
+func TestBuildWithOptions_GroupByScope(t *testing.T) {
+	entries := []changeset.Entry{
+		{Type: "added", Scope: "ui", Summary: "Add dark mode"},
+		{Type: "added", Summary: "Add retries"},
+		{Type: "added", Scope: "api", Summary: "Add pagination"},
+		{Type: "added", Summary: "Add config file"},
+		{Type: "added", Scope: "api", Summary: "Add filtering"},
+		{Type: "added", Scope: "db", Summary: "Add migrations"},
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+		want []string
+	}{
+		{
+			name: "labeled General sorts among scopes",
+			opts: Options{GroupByScope: true, ScopelessLabel: "General"},
+			want: []string{
+				"**api:** Add filtering",
+				"**api:** Add pagination",
+				"**db:** Add migrations",
+				"**General:** Add config file",
+				"**General:** Add retries",
+				"**ui:** Add dark mode",
+			},
+		},
+		{
+			name: "labeled Core sorts before later scopes",
+			opts: Options{GroupByScope: true, ScopelessLabel: "Core"},
+			want: []string{
+				"**api:** Add filtering",
+				"**api:** Add pagination",
+				"**Core:** Add config file",
+				"**Core:** Add retries",
+				"**db:** Add migrations",
+				"**ui:** Add dark mode",
+			},
+		},
+		{
+			name: "omitted label leads with ungrouped entries",
+			opts: Options{GroupByScope: true},
+			want: []string{
+				"Add config file",
+				"Add retries",
+				"**api:** Add filtering",
+				"**api:** Add pagination",
+				"**db:** Add migrations",
+				"**ui:** Add dark mode",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := BuildWithOptions(entries, "1.0.0", "2025-01-01", tt.opts)
+			if err != nil {
+				t.Fatalf("BuildWithOptions() error = %v", err)
+			}
+			if len(version.Sections) != 1 {
+				t.Fatalf("Sections = %+v, want one", version.Sections)
+			}
+			got := version.Sections[0].Entries
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Entries =\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+
+	ungrouped := BuildUnreleased(entries, Options{ScopelessLabel: "General"})
+	for _, entry := range ungrouped.Sections[0].Entries {
+		if strings.Contains(entry, "General") {
+			t.Errorf("ScopelessLabel should only apply with GroupByScope, got %q", entry)
+		}
+	}
+}
+
 func TestBuildInvalidVersion(t *testing.T) {
 	entries := []changeset.Entry{{Type: "added", Summary: "Test"}}
 
@@ -308,6 +519,178 @@ func TestMerge(t *testing.T) {
 	}
 }
 
+func TestInsert(t *testing.T) {
+	changelog := &Changelog{
+		Versions: []Version{
+			{Number: "Unreleased", Date: "Unreleased"},
+			{Number: "1.2.0", Date: "2024-06-01"},
+			{Number: "0.8.0", Date: "2022-01-01"},
+		},
+	}
+
+	if err := Insert(changelog, &Version{Number: "0.10.0", Date: "2023-01-01"}); err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+
+	want := []string{"Unreleased", "1.2.0", "0.10.0", "0.8.0"}
+	if len(changelog.Versions) != len(want) {
+		t.Fatalf("Expected %d versions, got %d", len(want), len(changelog.Versions))
+	}
+	for i, number := range want {
+		if changelog.Versions[i].Number != number {
+			t.Errorf("Version %d = %s, want %s", i, changelog.Versions[i].Number, number)
+		}
+	}
+
+	if err := Insert(changelog, &Version{Number: "0.1.0", Date: "2021-01-01"}); err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+	if last := changelog.Versions[len(changelog.Versions)-1].Number; last != "0.1.0" {
+		t.Errorf("Oldest version should be last, got %s", last)
+	}
+
+	if err := Insert(changelog, &Version{Number: "1.2.0", Date: "2024-06-01"}); err == nil {
+		t.Error("Expected error inserting a duplicate version")
+	}
+}
+
+func TestPromote(t *testing.T) {
+	cl := &Changelog{
+		Versions: []Version{
+			{Number: "Unreleased", Date: "Unreleased", Sections: []Section{{Type: "added", Entries: []string{"Feature"}}}},
+			{Number: "1.0.0", Date: "2024-01-01", Sections: []Section{{Type: "fixed", Entries: []string{"Fix"}}}},
+		},
+	}
+
+	promoted, err := Promote(cl, "1.1.0", "2024-02-01")
+	if err != nil {
+		t.Fatalf("Promote() error = %v", err)
+	}
+	if promoted.Number != "1.1.0" || len(promoted.Sections) != 1 {
+		t.Fatalf("Promote() = %+v, want 1.1.0 with the unreleased sections", promoted)
+	}
+	if len(cl.Versions) != 3 {
+		t.Fatalf("expected 3 versions, got %d", len(cl.Versions))
+	}
+	if cl.Versions[0].Number != "Unreleased" || len(cl.Versions[0].Sections) != 0 {
+		t.Errorf("expected fresh empty Unreleased first, got %+v", cl.Versions[0])
+	}
+	if cl.Versions[1].Number != "1.1.0" || cl.Versions[1].Sections[0].Entries[0] != "Feature" {
+		t.Errorf("expected promoted 1.1.0 second, got %+v", cl.Versions[1])
+	}
+
+	if _, err := Promote(cl, "1.2.0", "2024-03-01"); err == nil {
+		t.Error("Promote() expected error when Unreleased is empty")
+	}
+
+	cl.Versions[0].Sections = []Section{{Type: "added", Entries: []string{"Again"}}}
+	if _, err := Promote(cl, "1.0.0", "2024-03-01"); err == nil {
+		t.Error("Promote() expected error for an existing version")
+	}
+	if len(cl.Versions[0].Sections) != 1 {
+		t.Error("failed Promote() should leave Unreleased untouched")
+	}
+}
+
+func TestYank(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "CHANGELOG.md")
+	content := `# Changelog
+
+## [Unreleased]
+
+## [1.1.0] - 2024-02-01
+
+### Added
+
+- Feature
+
+## [1.0.0] - 2024-01-01 [YANKED]
+
+### Fixed
+
+- Fix
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write changelog: %v", err)
+	}
+
+	cl, err := Parse(path)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !cl.Versions[2].Yanked || cl.Versions[2].Date != "2024-01-01" {
+		t.Fatalf("1.0.0 should parse as yanked with its date, got %+v", cl.Versions[2])
+	}
+
+	if _, err := Yank(cl, "1.0.0", ""); err == nil {
+		t.Error("Yank() expected error for an already yanked version")
+	}
+	if _, err := Yank(cl, "Unreleased", ""); err == nil {
+		t.Error("Yank() expected error for Unreleased")
+	}
+	if _, err := Yank(cl, "2.0.0", ""); err == nil {
+		t.Error("Yank() expected error for a missing version")
+	}
+	if _, err := Yank(cl, "1.1.0", "Corrupts the cache on upgrade"); err != nil {
+		t.Fatalf("Yank() error = %v", err)
+	}
+
+	if err := Write(path, cl, dir); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	written, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read changelog: %v", err)
+	}
+	for _, want := range []string{
+		"## [1.1.0] - 2024-02-01 [YANKED]\n\n### Added\n\n- Feature\n\n### Yanked\n\n- Corrupts the cache on upgrade\n",
+		"## [1.0.0] - 2024-01-01 [YANKED]\n",
+	} {
+		if !strings.Contains(string(written), want) {
+			t.Errorf("written changelog should contain %q:\n%s", want, written)
+		}
+	}
+
+	reparsed, err := Parse(path)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	yanked := reparsed.Versions[1]
+	if !yanked.Yanked || len(yanked.Sections) != 2 || yanked.Sections[1].Type != YankedSection {
+		t.Errorf("yanked version should round-trip, got %+v", yanked)
+	}
+}
+
+func TestSortVersions(t *testing.T) {
+	versions := []Version{
+		{Number: "0.9.0", Date: "2023-06-01"},
+		{Number: "legacy", Date: "2019-01-01"},
+		{Number: "1.10.0", Date: "2024-09-01"},
+		{Number: "Unreleased", Date: "Unreleased"},
+		{Number: "1.2.0", Date: "2024-03-01"},
+		{Number: "beta", Date: "2020-05-01"},
+		{Number: "1.9.3", Date: "2024-08-01"},
+	}
+
+	if VersionsSorted(versions) {
+		t.Error("Scrambled versions should not report as sorted")
+	}
+
+	SortVersions(versions)
+
+	want := []string{"Unreleased", "1.10.0", "1.9.3", "1.2.0", "0.9.0", "beta", "legacy"}
+	for i, number := range want {
+		if versions[i].Number != number {
+			t.Errorf("Version %d = %s, want %s", i, versions[i].Number, number)
+		}
+	}
+
+	if !VersionsSorted(versions) {
+		t.Error("Sorted versions should report as sorted")
+	}
+}
+
 func TestWrite(t *testing.T) {
 	tmpDir := t.TempDir()
 	changelogPath := filepath.Join(tmpDir, "CHANGELOG.md")
@@ -418,56 +801,318 @@ func TestValidateDate(t *testing.T) {
 	}
 }
 
-func TestParseGitHubURL(t *testing.T) {
+func TestSectionOrdering(t *testing.T) {
+	entries := []changeset.Entry{
+		{Type: "security", Summary: "Security fix"},
+		{Type: "removed", Summary: "Removed feature"},
+		{Type: "fixed", Summary: "Bug fix"},
+		{Type: "changed", Summary: "Changed behavior"},
+		{Type: "added", Summary: "New feature"},
+	}
+
+	version, err := Build(entries, "1.0.0", "2025-01-15")
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	expectedOrder := []string{"added", "changed", "removed", "fixed", "security"}
+	if len(version.Sections) != len(expectedOrder) {
+		t.Fatalf("Expected %d sections, got %d", len(expectedOrder), len(version.Sections))
+	}
+
+	for i, expectedType := range expectedOrder {
+		if version.Sections[i].Type != expectedType {
+			t.Errorf("Section %d: got type %s, want %s", i, version.Sections[i].Type, expectedType)
+		}
+	}
+}
+
+func TestBuildWithOptions_SectionOrder(t *testing.T) {
+	entries := []changeset.Entry{
+		{Type: "added", Summary: "New feature"},
+		{Type: "fixed", Summary: "Bug fix"},
+		{Type: "security", Summary: "Patched CVE"},
+		{Type: "performance", Summary: "Faster startup"},
+		{Type: "docs", Summary: "Updated README"},
+	}
+
+	version, err := BuildWithOptions(entries, "1.0.0", "2025-01-15", Options{
+		SectionOrder: []string{"security", "fixed", "added"},
+	})
+	if err != nil {
+		t.Fatalf("BuildWithOptions() error = %v", err)
+	}
+
+	expectedOrder := []string{"security", "fixed", "added", "docs", "performance"}
+	if len(version.Sections) != len(expectedOrder) {
+		t.Fatalf("Expected %d sections, got %d", len(expectedOrder), len(version.Sections))
+	}
+
+	for i, expectedType := range expectedOrder {
+		if version.Sections[i].Type != expectedType {
+			t.Errorf("Section %d: got type %s, want %s", i, version.Sections[i].Type, expectedType)
+		}
+	}
+}
+
+func TestBuild_UnlistedTypesAppended(t *testing.T) {
+	entries := []changeset.Entry{
+		{Type: "performance", Summary: "Faster startup"},
+		{Type: "added", Summary: "New feature"},
+	}
+
+	version, err := Build(entries, "1.0.0", "2025-01-15")
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if len(version.Sections) != 2 {
+		t.Fatalf("Expected 2 sections, got %d", len(version.Sections))
+	}
+	if version.Sections[0].Type != "added" || version.Sections[1].Type != "performance" {
+		t.Errorf("Unexpected section order: %s, %s", version.Sections[0].Type, version.Sections[1].Type)
+	}
+}
+
+func TestWriteWithOptions_SectionOrder(t *testing.T) {
+	tmpDir := t.TempDir()
+	changelogPath := filepath.Join(tmpDir, "CHANGELOG.md")
+
+	changelog := &Changelog{
+		Header: "# Changelog",
+		Versions: []Version{
+			{
+				Number: "1.0.0",
+				Date:   "2025-01-15",
+				Sections: []Section{
+					{Type: "added", Entries: []string{"New feature"}},
+					{Type: "fixed", Entries: []string{"Bug fix"}},
+					{Type: "security", Entries: []string{"Patched CVE"}},
+				},
+			},
+		},
+	}
+
+	err := WriteWithOptions(changelogPath, changelog, tmpDir, Options{SectionOrder: []string{"security", "fixed"}})
+	if err != nil {
+		t.Fatalf("WriteWithOptions() error = %v", err)
+	}
+
+	content, err := os.ReadFile(changelogPath)
+	if err != nil {
+		t.Fatalf("Failed to read CHANGELOG.md: %v", err)
+	}
+
+	contentStr := string(content)
+	security := strings.Index(contentStr, "### Security")
+	fixed := strings.Index(contentStr, "### Fixed")
+	added := strings.Index(contentStr, "### Added")
+	if security < 0 || fixed < 0 || added < 0 {
+		t.Fatalf("Missing sections in output:\n%s", contentStr)
+	}
+	if !(security < fixed && fixed < added) {
+		t.Errorf("Sections not rendered in requested order:\n%s", contentStr)
+	}
+}
+
+func TestWrite_MergesDuplicateSections(t *testing.T) {
+	tmpDir := t.TempDir()
+	changelogPath := filepath.Join(tmpDir, "CHANGELOG.md")
+
+	changelog := &Changelog{
+		Header: "# Changelog",
+		Versions: []Version{
+			{
+				Number: "1.0.0",
+				Date:   "2025-01-15",
+				Sections: []Section{
+					{Type: "added", Entries: []string{"Zebra support"}},
+					{Type: "fixed", Entries: []string{"Bug fix"}},
+					{Type: "added", Entries: []string{"Apple support"}},
+				},
+			},
+		},
+	}
+
+	if err := Write(changelogPath, changelog, tmpDir); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	content, err := os.ReadFile(changelogPath)
+	if err != nil {
+		t.Fatalf("Failed to read CHANGELOG.md: %v", err)
+	}
+
+	contentStr := string(content)
+	if count := strings.Count(contentStr, "### Added"); count != 1 {
+		t.Errorf("Expected a single ### Added section, got %d:\n%s", count, contentStr)
+	}
+
+	apple := strings.Index(contentStr, "- Apple support")
+	zebra := strings.Index(contentStr, "- Zebra support")
+	fixed := strings.Index(contentStr, "### Fixed")
+	if apple < 0 || zebra < 0 || fixed < 0 {
+		t.Fatalf("Missing entries in output:\n%s", contentStr)
+	}
+	if !(apple < zebra && zebra < fixed) {
+		t.Errorf("Merged entries not sorted within the first Added section:\n%s", contentStr)
+	}
+
+	if len(changelog.Versions[0].Sections) != 3 {
+		t.Errorf("Write should not mutate the input version, got %d sections", len(changelog.Versions[0].Sections))
+	}
+}
+
+func TestBuildWithOptions_WithHash(t *testing.T) {
+	hash := "abc1234def5678abc1234def5678abc1234def56"
+	entries := []changeset.Entry{
+		{Type: "added", Summary: "Add thing", CommitHash: hash},
+		{Type: "fixed", Summary: "Manual entry"},
+	}
+
 	tests := []struct {
 		name      string
-		remoteURL string
-		want      string
+		opts      Options
+		wantAdded string
 	}{
 		{
-			name:      "https format",
-			remoteURL: "https://github.com/user/repo.git",
-			want:      "https://github.com/user/repo",
+			name:      "disabled",
+			opts:      Options{},
+			wantAdded: "Add thing",
 		},
 		{
-			name:      "https without .git",
-			remoteURL: "https://github.com/user/repo",
-			want:      "https://github.com/user/repo",
+			name:      "plain hash",
+			opts:      Options{WithHash: true},
+			wantAdded: "Add thing (abc1234)",
 		},
 		{
-			name:      "ssh format",
-			remoteURL: "git@github.com:user/repo.git",
-			want:      "https://github.com/user/repo",
+			name:      "github link",
+			opts:      Options{WithHash: true, RepoURL: "https://github.com/user/repo"},
+			wantAdded: "Add thing ([abc1234](https://github.com/user/repo/commit/" + hash + "))",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := BuildWithOptions(entries, "1.0.0", "2025-01-15", tt.opts)
+			if err != nil {
+				t.Fatalf("BuildWithOptions() error = %v", err)
+			}
+
+			if got := version.Sections[0].Entries[0]; got != tt.wantAdded {
+				t.Errorf("Added entry = %q, want %q", got, tt.wantAdded)
+			}
+			if got := version.Sections[1].Entries[0]; got != "Manual entry" {
+				t.Errorf("Entry without hash = %q, want %q", got, "Manual entry")
+			}
+		})
+	}
+}
+
+func TestRepoURL(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo, err := git.PlainInit(tmpDir, false)
+	if err != nil {
+		t.Fatalf("PlainInit() error = %v", err)
+	}
+
+	if _, err := RepoURL(tmpDir); err == nil {
+		t.Errorf("Expected error without origin remote")
+	}
+
+	_, err = repo.CreateRemote(&config.RemoteConfig{
+		Name: "origin",
+		URLs: []string{"git@github.com:user/repo.git"},
+	})
+	if err != nil {
+		t.Fatalf("CreateRemote() error = %v", err)
+	}
+
+	got, err := RepoURL(tmpDir)
+	if err != nil {
+		t.Fatalf("RepoURL() error = %v", err)
+	}
+	if got != "https://github.com/user/repo" {
+		t.Errorf("RepoURL() = %s, want https://github.com/user/repo", got)
+	}
+}
+
+func TestBuild_EntryLinks(t *testing.T) {
+	entries := []changeset.Entry{
+		{
+			Type:    "fixed",
+			Summary: "Fix crash",
+			Links: changeset.Links{
+				{Name: "PR", URL: "https://example.com/pr/42"},
+				{Name: "Issue", URL: "https://example.com/issues/7"},
+			},
 		},
+		{Type: "fixed", Summary: "Plain fix"},
+	}
+
+	version, err := Build(entries, "1.0.0", "2025-01-15")
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	got := version.Sections[0].Entries
+	want := []string{
+		"Fix crash ([PR](https://example.com/pr/42), [Issue](https://example.com/issues/7))",
+		"Plain fix",
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Entry %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestBuildWithOptions_References(t *testing.T) {
+	entries := []changeset.Entry{
+		{Type: "fixed", Summary: "Fix crash (#12)", References: []int{12, 30}},
+		{Type: "fixed", Summary: "Handle GH-7 and #8"},
+		{Type: "fixed", Summary: "Plain fix"},
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+		want []string
+	}{
 		{
-			name:      "ssh without .git",
-			remoteURL: "git@github.com:user/repo",
-			want:      "https://github.com/user/repo",
+			name: "no remote",
+			want: []string{"Fix crash (#12) (#30)", "Handle GH-7 and #8", "Plain fix"},
 		},
 		{
-			name:      "non-github url",
-			remoteURL: "https://gitlab.com/user/repo.git",
-			want:      "",
+			name: "github",
+			opts: Options{RepoURL: "https://github.com/user/repo"},
+			want: []string{
+				"Fix crash ([#12](https://github.com/user/repo/issues/12)) ([#30](https://github.com/user/repo/issues/30))",
+				"Handle [GH-7](https://github.com/user/repo/issues/7) and [#8](https://github.com/user/repo/issues/8)",
+				"Plain fix",
+			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := parseGitHubURL(tt.remoteURL)
-			if got != tt.want {
-				t.Errorf("parseGitHubURL(%s) = %s, want %s", tt.remoteURL, got, tt.want)
+			version, err := BuildWithOptions(entries, "1.0.0", "2025-01-15", tt.opts)
+			if err != nil {
+				t.Fatalf("BuildWithOptions() error = %v", err)
+			}
+			got := version.Sections[0].Entries
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("Entry %d = %q, want %q", i, got[i], tt.want[i])
+				}
 			}
 		})
 	}
 }
 
-func TestSectionOrdering(t *testing.T) {
+func TestBuild_EntryAuthors(t *testing.T) {
 	entries := []changeset.Entry{
-		{Type: "security", Summary: "Security fix"},
-		{Type: "removed", Summary: "Removed feature"},
-		{Type: "fixed", Summary: "Bug fix"},
-		{Type: "changed", Summary: "Changed behavior"},
-		{Type: "added", Summary: "New feature"},
+		{Type: "added", Summary: "Add pairing", Authors: []string{"@jane", "Bob"}, Links: changeset.Links{{Name: "PR", URL: "https://example.com/pr/3"}}},
 	}
 
 	version, err := Build(entries, "1.0.0", "2025-01-15")
@@ -475,15 +1120,75 @@ func TestSectionOrdering(t *testing.T) {
 		t.Fatalf("Build() error = %v", err)
 	}
 
-	expectedOrder := []string{"added", "changed", "removed", "fixed", "security"}
-	if len(version.Sections) != len(expectedOrder) {
-		t.Fatalf("Expected %d sections, got %d", len(expectedOrder), len(version.Sections))
+	want := "Add pairing (thanks @jane, Bob) ([PR](https://example.com/pr/3))"
+	if got := version.Sections[0].Entries[0]; got != want {
+		t.Errorf("Entry = %q, want %q", got, want)
 	}
+}
 
-	for i, expectedType := range expectedOrder {
-		if version.Sections[i].Type != expectedType {
-			t.Errorf("Section %d: got type %s, want %s", i, version.Sections[i].Type, expectedType)
-		}
+func TestBuildWithOptions_NewContributors(t *testing.T) {
+	entries := []changeset.Entry{
+		{Type: "added", Summary: "Add pairing", References: []int{12}, NewContributors: []string{"@jane"}},
+		{Type: "fixed", Summary: "Fix typo", CommitHash: "abc1234def", NewContributors: []string{"Bob", "@jane"}},
+		{Type: "fixed", Summary: "Plain fix"},
+	}
+
+	version, err := BuildWithOptions(entries, "1.0.0", "2025-01-15", Options{RepoURL: "https://github.com/user/repo"})
+	if err != nil {
+		t.Fatalf("BuildWithOptions() error = %v", err)
+	}
+
+	last := version.Sections[len(version.Sections)-1]
+	if last.Type != ContributorsSection {
+		t.Fatalf("last section = %q, want %q", last.Type, ContributorsSection)
+	}
+	want := []string{
+		"@jane made their first contribution in [#12](https://github.com/user/repo/issues/12)",
+		"Bob made their first contribution in [abc1234](https://github.com/user/repo/commit/abc1234def)",
+	}
+	if strings.Join(last.Entries, "|") != strings.Join(want, "|") {
+		t.Errorf("entries = %q, want %q", last.Entries, want)
+	}
+	if got := RenderSections(version, Options{}); !strings.HasSuffix(got, "### New Contributors\n\n- "+want[0]+"\n- "+want[1]+"\n") {
+		t.Errorf("RenderSections() = %q, want a trailing New Contributors section", got)
+	}
+}
+
+func TestBuild_EntryBody(t *testing.T) {
+	entries := []changeset.Entry{
+		{Type: "fixed", Summary: "Retry uploads", Body: "Requests retry on 503\nwith backoff.\n\nThe limit is configurable."},
+		{Type: "fixed", Summary: "Plain fix"},
+	}
+
+	version, err := Build(entries, "1.0.0", "2025-01-15")
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := "Retry uploads\n  - Requests retry on 503 with backoff.\n  - The limit is configurable."
+	if got := version.Sections[0].Entries[1]; got != want {
+		t.Fatalf("Entry = %q, want %q", got, want)
+	}
+
+	tmpDir := t.TempDir()
+	changelogPath := filepath.Join(tmpDir, "CHANGELOG.md")
+	if err := Write(changelogPath, &Changelog{Header: defaultHeader(), Versions: []Version{*version}}, tmpDir); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	content, err := os.ReadFile(changelogPath)
+	if err != nil {
+		t.Fatalf("Failed to read CHANGELOG.md: %v", err)
+	}
+	if !strings.Contains(string(content), "- Plain fix\n- Retry uploads\n  - Requests retry on 503 with backoff.\n  - The limit is configurable.\n") {
+		t.Errorf("Body not rendered as sub-bullets:\n%s", content)
+	}
+
+	parsed, err := Parse(changelogPath)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := parsed.Versions[0].Sections[0].Entries; len(got) != 2 || got[1] != want {
+		t.Errorf("Parsed entries = %q, want body kept with %q", got, want)
 	}
 }
 
@@ -534,3 +1239,132 @@ func TestScopeFormatting(t *testing.T) {
 		t.Errorf("Entry should contain formatted scope, got: %s", entry)
 	}
 }
+
+func TestWriteSnapshot(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "released")
+	version := &Version{
+		Number:   "1.2.0",
+		Date:     "2025-03-01",
+		Sections: []Section{{Type: "added", Entries: []string{"Snapshots"}}},
+	}
+
+	path, created, err := WriteSnapshot(dir, version, Options{})
+	if err != nil {
+		t.Fatalf("WriteSnapshot() error = %v", err)
+	}
+	if !created {
+		t.Fatal("Expected snapshot to be created")
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read snapshot: %v", err)
+	}
+	expected := "## [1.2.0] - 2025-03-01\n\n### Added\n\n- Snapshots\n"
+	if string(content) != expected {
+		t.Errorf("Snapshot content = %q, want %q", content, expected)
+	}
+
+	version.Sections[0].Entries = []string{"Edited later"}
+	if _, created, err = WriteSnapshot(dir, version, Options{}); err != nil {
+		t.Fatalf("WriteSnapshot() second call error = %v", err)
+	}
+	if created {
+		t.Error("Existing snapshot should not be overwritten")
+	}
+
+	content, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read snapshot: %v", err)
+	}
+	if string(content) != expected {
+		t.Errorf("Snapshot was modified: %q", content)
+	}
+}
+
+func TestProfileOptions_Render(t *testing.T) {
+	entries := []changeset.Entry{
+		{Type: "added", Summary: "Add export command"},
+		{Type: "fixed", Summary: "Fix crash on empty input"},
+		{Type: "changed", Summary: "Rename config keys", Breaking: true},
+		{Type: "removed", Summary: "Remove legacy flag"},
+	}
+
+	render := func(t *testing.T, profile string) string {
+		t.Helper()
+		opts, err := ProfileOptions(profile)
+		if err != nil {
+			t.Fatalf("ProfileOptions(%q) error = %v", profile, err)
+		}
+		version, err := BuildWithOptions(entries, "2.0.0", "2025-04-01", opts)
+		if err != nil {
+			t.Fatalf("BuildWithOptions() error = %v", err)
+		}
+		return RenderVersion(version, opts)
+	}
+
+	headingOrder := func(content string, headings ...string) bool {
+		last := -1
+		for _, heading := range headings {
+			idx := strings.Index(content, heading)
+			if idx <= last {
+				return false
+			}
+			last = idx
+		}
+		return true
+	}
+
+	keep := render(t, ProfileKeepAChangelog)
+	if !headingOrder(keep, "### Added", "### Changed", "### Removed", "### Fixed") {
+		t.Errorf("keepachangelog sections out of order:\n%s", keep)
+	}
+	if !strings.Contains(keep, "- **BREAKING:** Rename config keys") {
+		t.Errorf("keepachangelog should use the BREAKING label:\n%s", keep)
+	}
+
+	common := render(t, ProfileCommonChangelog)
+	if !headingOrder(common, "### Changed", "### Added", "### Removed", "### Fixed") {
+		t.Errorf("common-changelog sections out of order:\n%s", common)
+	}
+	if !strings.Contains(common, "- **Breaking:** Rename config keys") {
+		t.Errorf("common-changelog should use the Breaking label:\n%s", common)
+	}
+
+	for _, name := range []string{"nonsense", "custom"} {
+		if _, err := ProfileOptions(name); err == nil {
+			t.Errorf("Expected error for unknown profile %q", name)
+		}
+	}
+}
+
+func TestOptions_BreakingSectionAndDateFormat(t *testing.T) {
+	entries := []changeset.Entry{
+		{Type: "added", Summary: "Add export command"},
+		{Type: "changed", Summary: "Rename config keys", Breaking: true},
+	}
+
+	opts := Options{BreakingSection: true, DateFormat: "January 2, 2006"}
+	version, err := BuildWithOptions(entries, "2.0.0", "2025-04-01", opts)
+	if err != nil {
+		t.Fatalf("BuildWithOptions() error = %v", err)
+	}
+
+	if version.Sections[0].Type != "breaking" || version.Sections[0].Entries[0] != "Rename config keys" {
+		t.Errorf("Breaking entries should lead in their own section, got %+v", version.Sections)
+	}
+
+	content := RenderVersion(version, opts)
+	if !strings.HasPrefix(content, "## [2.0.0] - April 1, 2025\n") {
+		t.Errorf("Date should use the configured layout:\n%s", content)
+	}
+	if !strings.Contains(content, "### Breaking Changes\n\n- Rename config keys") {
+		t.Errorf("Missing breaking section:\n%s", content)
+	}
+	if strings.Contains(content, "### Changed") {
+		t.Errorf("Changed section should be empty once breaking entries move out:\n%s", content)
+	}
+	if findSectionType("Breaking Changes") != "breaking" {
+		t.Error("Breaking Changes heading should parse back to the breaking type")
+	}
+}
@@ -0,0 +1,186 @@
+package changelog
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/stormlightlabs/git-storm/internal/gitlog"
+)
+
+// Forge is a git hosting service. It decides how compare, tag, and commit
+// links are laid out.
+type Forge string
+
+const (
+	ForgeGitHub    Forge = "github"
+	ForgeGitLab    Forge = "gitlab"
+	ForgeBitbucket Forge = "bitbucket"
+	// ForgeGitea also covers Forgejo instances such as Codeberg.
+	ForgeGitea Forge = "gitea"
+)
+
+// Forges lists every supported forge.
+var Forges = []Forge{ForgeGitHub, ForgeGitLab, ForgeBitbucket, ForgeGitea}
+
+// knownHosts maps public hosts to their forge. Self-hosted instances are
+// added through the hosts argument of [RemoteRepo].
+var knownHosts = map[string]Forge{
+	"github.com":    ForgeGitHub,
+	"gitlab.com":    ForgeGitLab,
+	"bitbucket.org": ForgeBitbucket,
+	"codeberg.org":  ForgeGitea,
+	"gitea.com":     ForgeGitea,
+}
+
+// ParseForge validates a forge name such as "gitlab".
+func ParseForge(name string) (Forge, error) {
+	for _, forge := range Forges {
+		if string(forge) == strings.ToLower(name) {
+			return forge, nil
+		}
+	}
+	return "", fmt.Errorf("unknown forge %q: must be one of github, gitlab, bitbucket, or gitea", name)
+}
+
+// Remote is a repository's web address on a forge, e.g.
+// {ForgeGitLab, "https://gitlab.com/group/project"}.
+type Remote struct {
+	Forge Forge
+	URL   string
+}
+
+// CompareURL links the changes between two refs.
+func (r Remote) CompareURL(from, to string) string {
+	switch r.Forge {
+	case ForgeGitLab:
+		return fmt.Sprintf("%s/-/compare/%s...%s", r.URL, from, to)
+	case ForgeBitbucket:
+		return fmt.Sprintf("%s/branches/compare/%s%%0D%s", r.URL, to, from)
+	}
+	return fmt.Sprintf("%s/compare/%s...%s", r.URL, from, to)
+}
+
+// TagURL links a tag's release or source page.
+func (r Remote) TagURL(tag string) string {
+	switch r.Forge {
+	case ForgeGitLab:
+		return fmt.Sprintf("%s/-/tags/%s", r.URL, tag)
+	case ForgeBitbucket:
+		return fmt.Sprintf("%s/src/%s", r.URL, tag)
+	}
+	return fmt.Sprintf("%s/releases/tag/%s", r.URL, tag)
+}
+
+// CommitURL links a single commit.
+func (r Remote) CommitURL(hash string) string {
+	switch r.Forge {
+	case ForgeGitLab:
+		return fmt.Sprintf("%s/-/commit/%s", r.URL, hash)
+	case ForgeBitbucket:
+		return fmt.Sprintf("%s/commits/%s", r.URL, hash)
+	}
+	return fmt.Sprintf("%s/commit/%s", r.URL, hash)
+}
+
+// IssueURL links an issue by number. GitHub and Gitea redirect issue links to
+// pull requests with the same number.
+func (r Remote) IssueURL(number int) string {
+	if r.Forge == ForgeGitLab {
+		return fmt.Sprintf("%s/-/issues/%d", r.URL, number)
+	}
+	return fmt.Sprintf("%s/issues/%d", r.URL, number)
+}
+
+// CommitsURL links the commit history up to HEAD.
+func (r Remote) CommitsURL() string {
+	switch r.Forge {
+	case ForgeGitLab:
+		return r.URL + "/-/commits/HEAD"
+	case ForgeGitHub:
+		return r.URL + "/commits/HEAD"
+	}
+	return r.URL + "/commits"
+}
+
+// RepoURL returns the web URL for the repository's origin remote on any
+// public forge in [Forges].
+func RepoURL(repoPath string) (string, error) {
+	remote, err := RemoteRepo(repoPath, nil)
+	if err != nil {
+		return "", err
+	}
+	return remote.URL, nil
+}
+
+// RemoteRepo locates the repository's origin remote on its forge. hosts maps
+// self-hosted hostnames (e.g. "git.example.com") to their forge and takes
+// precedence over the public hosts.
+func RemoteRepo(repoPath string, hosts map[string]Forge) (Remote, error) {
+	repo, err := gitlog.OpenRepo(repoPath)
+	if err != nil {
+		return Remote{}, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	origin, err := repo.Remote("origin")
+	if err != nil {
+		return Remote{}, fmt.Errorf("no origin remote configured: %w", err)
+	}
+
+	if len(origin.Config().URLs) == 0 {
+		return Remote{}, fmt.Errorf("no remote URL configured")
+	}
+
+	remote, ok := parseRemote(origin.Config().URLs[0], hosts)
+	if !ok {
+		return Remote{}, fmt.Errorf("origin is not on a known forge; map its host in the config's hosts")
+	}
+	return remote, nil
+}
+
+// parseRemote converts an HTTPS, SSH, or scp-like git remote URL into the
+// repository's web address.
+func parseRemote(remoteURL string, hosts map[string]Forge) (Remote, bool) {
+	remoteURL = strings.TrimSuffix(strings.TrimSuffix(remoteURL, "/"), ".git")
+
+	scheme, host, webHost, path := "https", "", "", ""
+	if strings.Contains(remoteURL, "://") {
+		u, err := url.Parse(remoteURL)
+		if err != nil {
+			return Remote{}, false
+		}
+		switch u.Scheme {
+		case "http", "https":
+			scheme, webHost = u.Scheme, u.Host
+		case "ssh", "git+ssh", "ssh+git", "git":
+			// SSH ports aren't the web server's, so drop them.
+			webHost = u.Hostname()
+		default:
+			return Remote{}, false
+		}
+		host, path = u.Hostname(), u.Path
+	} else {
+		userHost, p, ok := strings.Cut(remoteURL, ":")
+		if !ok {
+			return Remote{}, false
+		}
+		_, h, hasUser := strings.Cut(userHost, "@")
+		if !hasUser {
+			h = userHost
+		}
+		host, webHost, path = h, h, p
+	}
+
+	host, path = strings.ToLower(host), strings.Trim(path, "/")
+	if host == "" || path == "" {
+		return Remote{}, false
+	}
+	forge, ok := hosts[host]
+	if !ok {
+		forge, ok = knownHosts[host]
+	}
+	if !ok {
+		return Remote{}, false
+	}
+	return Remote{Forge: forge, URL: fmt.Sprintf("%s://%s/%s", scheme, webHost, path)}, true
+}
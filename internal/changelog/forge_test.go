@@ -0,0 +1,105 @@
+package changelog
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/config"
+)
+
+func TestParseRemote(t *testing.T) {
+	hosts := map[string]Forge{"git.example.com": ForgeGitLab}
+	tests := []struct {
+		name      string
+		remoteURL string
+		want      Remote
+	}{
+		{"github https", "https://github.com/user/repo.git", Remote{ForgeGitHub, "https://github.com/user/repo"}},
+		{"github https without .git", "https://github.com/user/repo", Remote{ForgeGitHub, "https://github.com/user/repo"}},
+		{"github ssh", "git@github.com:user/repo.git", Remote{ForgeGitHub, "https://github.com/user/repo"}},
+		{"github ssh without .git", "git@github.com:user/repo", Remote{ForgeGitHub, "https://github.com/user/repo"}},
+		{"gitlab subgroup", "git@gitlab.com:group/sub/project.git", Remote{ForgeGitLab, "https://gitlab.com/group/sub/project"}},
+		{"bitbucket with user", "https://jane@bitbucket.org/team/repo.git", Remote{ForgeBitbucket, "https://bitbucket.org/team/repo"}},
+		{"codeberg", "ssh://git@codeberg.org/user/repo.git", Remote{ForgeGitea, "https://codeberg.org/user/repo"}},
+		{"self-hosted gitlab over ssh port", "ssh://git@git.example.com:2222/team/app.git", Remote{ForgeGitLab, "https://git.example.com/team/app"}},
+		{"self-hosted gitlab on http port", "http://git.example.com:8080/team/app", Remote{ForgeGitLab, "http://git.example.com:8080/team/app"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRemote(tt.remoteURL, hosts)
+			if !ok || got != tt.want {
+				t.Errorf("parseRemote(%s) = %+v, %v; want %+v", tt.remoteURL, got, ok, tt.want)
+			}
+		})
+	}
+
+	for _, remoteURL := range []string{"https://git.unknown.org/user/repo", "/srv/git/repo.git", "https://github.com/"} {
+		if got, ok := parseRemote(remoteURL, hosts); ok {
+			t.Errorf("parseRemote(%s) = %+v, want no forge", remoteURL, got)
+		}
+	}
+}
+
+func TestRemoteLinks(t *testing.T) {
+	tests := []struct {
+		forge                           Forge
+		compare, tag, commit, at, issue string
+	}{
+		{ForgeGitHub, "/compare/v1.0.0...v1.1.0", "/releases/tag/v1.1.0", "/commit/abc", "/commits/HEAD", "/issues/12"},
+		{ForgeGitLab, "/-/compare/v1.0.0...v1.1.0", "/-/tags/v1.1.0", "/-/commit/abc", "/-/commits/HEAD", "/-/issues/12"},
+		{ForgeBitbucket, "/branches/compare/v1.1.0%0Dv1.0.0", "/src/v1.1.0", "/commits/abc", "/commits", "/issues/12"},
+		{ForgeGitea, "/compare/v1.0.0...v1.1.0", "/releases/tag/v1.1.0", "/commit/abc", "/commits", "/issues/12"},
+	}
+	for _, tt := range tests {
+		r := Remote{Forge: tt.forge, URL: "https://host/repo"}
+		got := []string{r.CompareURL("v1.0.0", "v1.1.0"), r.TagURL("v1.1.0"), r.CommitURL("abc"), r.CommitsURL(), r.IssueURL(12)}
+		for i, want := range []string{tt.compare, tt.tag, tt.commit, tt.at, tt.issue} {
+			if got[i] != "https://host/repo"+want {
+				t.Errorf("%s: got %s, want https://host/repo%s", tt.forge, got[i], want)
+			}
+		}
+	}
+}
+
+func TestGenerateLinksWithOptions_SelfHosted(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("PlainInit() error = %v", err)
+	}
+	if _, err := repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"git@git.example.com:team/app.git"}}); err != nil {
+		t.Fatalf("CreateRemote() error = %v", err)
+	}
+	versions := []Version{{Number: "Unreleased"}, {Number: "1.1.0"}, {Number: "1.0.0"}}
+
+	if _, err := GenerateLinks(dir, versions); err == nil {
+		t.Error("GenerateLinks() should fail for an unmapped host")
+	}
+
+	links, err := GenerateLinksWithOptions(dir, versions, Options{Hosts: map[string]Forge{"git.example.com": ForgeGitLab}})
+	if err != nil {
+		t.Fatalf("GenerateLinksWithOptions() error = %v", err)
+	}
+	want := []string{
+		"[Unreleased]: https://git.example.com/team/app/-/compare/v1.1.0...HEAD",
+		"[1.1.0]: https://git.example.com/team/app/-/compare/v1.0.0...v1.1.0",
+		"[1.0.0]: https://git.example.com/team/app/-/tags/v1.0.0",
+	}
+	if len(links) != len(want) {
+		t.Fatalf("links = %v, want %v", links, want)
+	}
+	for i := range want {
+		if links[i] != want[i] {
+			t.Errorf("links[%d] = %s, want %s", i, links[i], want[i])
+		}
+	}
+}
+
+func TestParseForge(t *testing.T) {
+	if forge, err := ParseForge("GitLab"); err != nil || forge != ForgeGitLab {
+		t.Errorf("ParseForge(GitLab) = %q, %v", forge, err)
+	}
+	if _, err := ParseForge("sourcehut"); err == nil {
+		t.Error("ParseForge(sourcehut) should fail")
+	}
+}
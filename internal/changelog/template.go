@@ -0,0 +1,50 @@
+package changelog
+
+import (
+	_ "embed"
+	"fmt"
+	"io"
+	"text/template"
+)
+
+// DefaultTemplate is the whole-document template used when
+// [Options.Template] is empty. It renders the Keep a Changelog layout.
+//
+//go:embed changelog.tmpl
+var DefaultTemplate string
+
+// RenderTemplate executes a whole-document changelog template against
+// changelog. The template receives the *Changelog and may call:
+//
+//	versionHeading <Version>  "## [X.Y.Z] - date" heading
+//	sections <Version>        merged sections in the configured order
+//	sectionTitle <type>       heading text for a section type
+//	links                     comparison link references for the footer
+//
+// An empty tmpl renders [DefaultTemplate].
+func RenderTemplate(w io.Writer, tmpl string, changelog *Changelog, repoPath string, opts Options) error {
+	if tmpl == "" {
+		tmpl = DefaultTemplate
+	}
+
+	funcs := template.FuncMap{
+		"versionHeading": func(v Version) string { return versionHeading(v, opts) },
+		"sections":       func(v Version) []Section { return orderedSections(v, opts) },
+		"sectionTitle":   func(t string) string { return sectionTitle(t, opts) },
+		"links": func() []string {
+			if links, err := GenerateLinksWithOptions(repoPath, changelog.Versions, opts); err == nil && len(links) > 0 {
+				return links
+			}
+			return changelog.Links
+		},
+	}
+
+	t, err := template.New("changelog").Funcs(funcs).Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("failed to parse changelog template: %w", err)
+	}
+	if err := t.Execute(w, changelog); err != nil {
+		return fmt.Errorf("failed to render changelog template: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package changelog
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func templateFixture() *Changelog {
+	return &Changelog{
+		Header: "# Changelog",
+		Versions: []Version{
+			{Number: "Unreleased", Date: "Unreleased"},
+			{
+				Number: "1.1.0",
+				Date:   "2025-02-01",
+				Sections: []Section{
+					{Type: "fixed", Entries: []string{"Fix crash"}},
+					{Type: "added", Entries: []string{"Add export", "Add promote"}},
+				},
+			},
+			{
+				Number:   "1.0.0",
+				Date:     "2025-01-01",
+				Sections: []Section{{Type: "added", Entries: []string{"Initial release"}}},
+			},
+		},
+		Links: []string{"[1.1.0]: https://example.com/compare/v1.0.0...v1.1.0"},
+	}
+}
+
+func TestRenderTemplate_Custom(t *testing.T) {
+	tmpl := `{{range .Versions}}{{if .Sections}}= {{.Number}} ({{.Date}})
+{{range sections .}}{{sectionTitle .Type}}: {{len .Entries}}
+{{end}}{{end}}{{end}}`
+
+	var buf bytes.Buffer
+	if err := RenderTemplate(&buf, tmpl, templateFixture(), t.TempDir(), Options{}); err != nil {
+		t.Fatalf("RenderTemplate() error = %v", err)
+	}
+
+	want := "= 1.1.0 (2025-02-01)\nFixed: 1\nAdded: 2\n= 1.0.0 (2025-01-01)\nAdded: 1\n"
+	if buf.String() != want {
+		t.Errorf("RenderTemplate() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestRenderTemplate_DefaultWhenUnset(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderTemplate(&buf, "", templateFixture(), t.TempDir(), Options{}); err != nil {
+		t.Fatalf("RenderTemplate() error = %v", err)
+	}
+
+	want := `# Changelog
+
+## [Unreleased]
+
+## [1.1.0] - 2025-02-01
+
+### Fixed
+
+- Fix crash
+
+### Added
+
+- Add export
+- Add promote
+
+## [1.0.0] - 2025-01-01
+
+### Added
+
+- Initial release
+
+[1.1.0]: https://example.com/compare/v1.0.0...v1.1.0
+`
+	if buf.String() != want {
+		t.Errorf("RenderTemplate() =\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
+
+func TestWriteWithOptions_Template(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "CHANGELOG.md")
+
+	opts := Options{Template: "{{range .Versions}}* {{.Number}}\n{{end}}"}
+	if err := WriteWithOptions(path, templateFixture(), dir, opts); err != nil {
+		t.Fatalf("WriteWithOptions() error = %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read changelog: %v", err)
+	}
+	if string(content) != "* Unreleased\n* 1.1.0\n* 1.0.0\n" {
+		t.Errorf("unexpected content:\n%s", content)
+	}
+
+	opts.Template = "{{.Missing"
+	if err := WriteWithOptions(path, templateFixture(), dir, opts); err == nil || !strings.Contains(err.Error(), "failed to parse changelog template") {
+		t.Fatalf("expected parse error, got %v", err)
+	}
+	unchanged, _ := os.ReadFile(path)
+	if string(unchanged) != string(content) {
+		t.Errorf("a broken template should leave the file untouched")
+	}
+}
@@ -0,0 +1,71 @@
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// versionRefRegex matches link reference names that refer to a version.
+var versionRefRegex = regexp.MustCompile(`(?i)^(unreleased|v?\d+\.\d+\.\d+\S*)$`)
+
+// Validate reports inconsistencies in a parsed changelog: versions out of
+// semantic order, dates not in dateLayout (YYYY-MM-DD when empty), versions
+// listed more than once, and version link references that don't resolve.
+//
+// Link references are only checked when the changelog has some, since storm
+// writes none for repositories without a known forge.
+func Validate(changelog *Changelog, dateLayout string) []string {
+	if dateLayout == "" {
+		dateLayout = "2006-01-02"
+	}
+
+	var problems []string
+	seen := make(map[string]bool, len(changelog.Versions))
+	for i, version := range changelog.Versions {
+		key := strings.ToLower(version.Number)
+		if seen[key] {
+			problems = append(problems, fmt.Sprintf("version %s is listed more than once", version.Number))
+		}
+		seen[key] = true
+
+		if key != "unreleased" {
+			if _, err := time.Parse(dateLayout, version.Date); err != nil {
+				problems = append(problems, fmt.Sprintf("version %s has date %q, want layout %s", version.Number, version.Date, dateLayout))
+			}
+		}
+
+		if i > 0 && versionLess(version, changelog.Versions[i-1]) {
+			problems = append(problems, fmt.Sprintf("version %s is listed below older version %s", version.Number, changelog.Versions[i-1].Number))
+		}
+	}
+
+	if len(changelog.Links) == 0 {
+		return problems
+	}
+	defined := make(map[string]bool, len(changelog.Links))
+	for _, line := range changelog.Links {
+		m := linkRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		name := strings.ToLower(m[1])
+		defined[name] = true
+		if !versionRefRegex.MatchString(name) {
+			continue
+		}
+		if !seen[name] {
+			problems = append(problems, fmt.Sprintf("link reference [%s] matches no version", m[1]))
+		}
+		if !strings.Contains(m[2], "://") {
+			problems = append(problems, fmt.Sprintf("link reference [%s] has no URL", m[1]))
+		}
+	}
+	for _, version := range changelog.Versions {
+		if !defined[strings.ToLower(version.Number)] {
+			problems = append(problems, fmt.Sprintf("version %s has no link reference", version.Number))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,97 @@
+package changelog
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		dateLayout string
+		want       []string
+	}{
+		{
+			name: "consistent",
+			content: `# Changelog
+
+## [Unreleased]
+
+## [1.1.0] - 2024-02-01
+
+## [1.0.0] - 2024-01-01
+
+[Unreleased]: https://github.com/acme/app/compare/v1.1.0...HEAD
+[1.1.0]: https://github.com/acme/app/compare/v1.0.0...v1.1.0
+[1.0.0]: https://github.com/acme/app/releases/tag/v1.0.0
+`,
+		},
+		{
+			name: "no link references",
+			content: `# Changelog
+
+## [1.0.0] - 2024-01-01
+`,
+		},
+		{
+			name: "order, dates, and duplicates",
+			content: `# Changelog
+
+## [1.0.0] - 2024-01-01
+
+## [1.1.0] - 01/02/2024
+
+## [1.0.0] - 2024-01-01
+`,
+			want: []string{
+				`version 1.1.0 has date "01/02/2024", want layout 2006-01-02`,
+				"version 1.1.0 is listed below older version 1.0.0",
+				"version 1.0.0 is listed more than once",
+			},
+		},
+		{
+			name: "custom date layout",
+			content: `# Changelog
+
+## [1.0.0] - January 2, 2024
+`,
+			dateLayout: "January 2, 2006",
+		},
+		{
+			name: "dangling links",
+			content: `# Changelog
+
+## [1.1.0] - 2024-02-01
+
+## [1.0.0] - 2024-01-01
+
+[1.1.0]: https://github.com/acme/app/compare/v1.0.0...v1.1.0
+[0.9.0]: https://github.com/acme/app/releases/tag/v0.9.0
+`,
+			want: []string{
+				"link reference [0.9.0] matches no version",
+				"version 1.0.0 has no link reference",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "CHANGELOG.md")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write changelog: %v", err)
+			}
+			changelog, err := Parse(path)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			if got := Validate(changelog, tt.dateLayout); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
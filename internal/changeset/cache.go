@@ -0,0 +1,172 @@
+package changeset
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/plumbing/object"
+)
+
+// commitCacheVersion is bumped whenever [ComputeDiffHash] changes, discarding
+// caches that hold hashes computed the old way.
+const commitCacheVersion = 2
+
+// CommitCachePath returns where the commit cache for changesDir is stored.
+func CommitCachePath(changesDir string) string {
+	return filepath.Join(changesDir, "cache", "commits.json")
+}
+
+// CommitCache remembers the diff hash of each commit, stored as JSON at Path,
+// so re-running generate or check over a large range doesn't recompute every
+// commit's patches. Commits never change, so cached hashes never go stale.
+//
+// Only diff hashes are cached: computing them is what dominates a run, while
+// parsing commit messages into metadata is cheap, so that is redone each run.
+//
+// A nil *CommitCache computes every hash.
+type CommitCache struct {
+	Path    string
+	hashes  map[string]string
+	changed bool
+}
+
+// commitCacheFile is the on-disk form of a [CommitCache].
+type commitCacheFile struct {
+	Version int               `json:"version"`
+	Hashes  map[string]string `json:"diff_hashes"`
+}
+
+// LoadCommitCache reads the cache at path. A missing or corrupt file, or one
+// written for another cache version, yields an empty cache that replaces it
+// when saved.
+func LoadCommitCache(path string) (*CommitCache, error) {
+	cache := &CommitCache{Path: path, hashes: make(map[string]string)}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cache, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit cache: %w", err)
+	}
+
+	var file commitCacheFile
+	if err := json.Unmarshal(data, &file); err == nil && file.Version == commitCacheVersion && file.Hashes != nil {
+		cache.hashes = file.Hashes
+	}
+	return cache, nil
+}
+
+// DiffHash returns the [ComputeDiffHash] of commit, computing and caching it
+// when it isn't cached yet.
+func (c *CommitCache) DiffHash(commit *object.Commit) (string, error) {
+	if c == nil {
+		return ComputeDiffHash(commit)
+	}
+	if hash, ok := c.hashes[commit.Hash.String()]; ok {
+		return hash, nil
+	}
+
+	hash, err := ComputeDiffHash(commit)
+	if err != nil {
+		return "", err
+	}
+	c.hashes[commit.Hash.String()] = hash
+	c.changed = true
+	return hash, nil
+}
+
+// DiffHashes returns the [ComputeDiffHash] of each commit, like
+// [CommitCache.DiffHash], computing the uncached ones concurrently on up to
+// GOMAXPROCS workers. hashes[i] and errs[i] belong to commits[i].
+//
+// A repository isn't safe for concurrent use, so each worker reads the commits
+// through its own repository from open. With a nil open, hashes are computed
+// one at a time from the commits as given.
+func (c *CommitCache) DiffHashes(commits []*object.Commit, open func() (*git.Repository, error)) (hashes []string, errs []error) {
+	hashes = make([]string, len(commits))
+	errs = make([]error, len(commits))
+
+	var missing []int
+	for i, commit := range commits {
+		if c != nil {
+			if hash, ok := c.hashes[commit.Hash.String()]; ok {
+				hashes[i] = hash
+				continue
+			}
+		}
+		missing = append(missing, i)
+	}
+
+	if open == nil {
+		for _, i := range missing {
+			hashes[i], errs[i] = ComputeDiffHash(commits[i])
+		}
+	} else {
+		jobs := make(chan int)
+		var wg sync.WaitGroup
+		for range min(runtime.GOMAXPROCS(0), len(missing)) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				repo, openErr := open()
+				for i := range jobs {
+					if openErr != nil {
+						errs[i] = openErr
+						continue
+					}
+					commit, err := repo.CommitObject(commits[i].Hash)
+					if err != nil {
+						errs[i] = fmt.Errorf("failed to read commit: %w", err)
+						continue
+					}
+					hashes[i], errs[i] = ComputeDiffHash(commit)
+				}
+			}()
+		}
+		for _, i := range missing {
+			jobs <- i
+		}
+		close(jobs)
+		wg.Wait()
+	}
+
+	if c != nil {
+		for _, i := range missing {
+			if errs[i] == nil {
+				c.hashes[commits[i].Hash.String()] = hashes[i]
+				c.changed = true
+			}
+		}
+	}
+	return hashes, errs
+}
+
+// Save writes the cache to its path when hashes were added since it was
+// loaded. The cache directory gets a .gitignore so the cache stays untracked.
+func (c *CommitCache) Save() error {
+	if c == nil || !c.changed {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(commitCacheFile{Version: commitCacheVersion, Hashes: c.hashes}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode commit cache: %w", err)
+	}
+	dir := filepath.Dir(c.Path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write commit cache: %w", err)
+	}
+	if err := os.WriteFile(c.Path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write commit cache: %w", err)
+	}
+	c.changed = false
+	return nil
+}
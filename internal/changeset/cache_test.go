@@ -0,0 +1,137 @@
+package changeset
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/plumbing/object"
+	"github.com/stormlightlabs/git-storm/internal/testutils"
+)
+
+func TestCommitCache(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	commit := testutils.GetCommitHistory(t, repo)[0]
+	path := CommitCachePath(filepath.Join(t.TempDir(), ".changes"))
+
+	original := changePatch
+	defer func() { changePatch = original }()
+	patches := 0
+	changePatch = func(c *object.Change) (*object.Patch, error) {
+		patches++
+		return original(c)
+	}
+
+	cache, err := LoadCommitCache(path)
+	if err != nil {
+		t.Fatalf("LoadCommitCache() error = %v", err)
+	}
+	want, err := ComputeDiffHash(commit)
+	if err != nil {
+		t.Fatalf("ComputeDiffHash() error = %v", err)
+	}
+	if err := cache.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Saving an unchanged cache should not write it")
+	}
+
+	got, err := cache.DiffHash(commit)
+	if err != nil {
+		t.Fatalf("DiffHash() error = %v", err)
+	}
+	testutils.Expect.Equal(t, got, want)
+	if err := cache.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	ignore, err := os.ReadFile(filepath.Join(filepath.Dir(path), ".gitignore"))
+	if err != nil {
+		t.Fatalf("Save() should write a .gitignore: %v", err)
+	}
+	testutils.Expect.Equal(t, string(ignore), "*\n")
+
+	reloaded, err := LoadCommitCache(path)
+	if err != nil {
+		t.Fatalf("LoadCommitCache() error = %v", err)
+	}
+	patches = 0
+	got, err = reloaded.DiffHash(commit)
+	if err != nil {
+		t.Fatalf("DiffHash() error = %v", err)
+	}
+	testutils.Expect.Equal(t, got, want)
+	testutils.Expect.Equal(t, patches, 0, "a cached hash should not recompute patches")
+
+	var none *CommitCache
+	got, err = none.DiffHash(commit)
+	if err != nil {
+		t.Fatalf("nil DiffHash() error = %v", err)
+	}
+	testutils.Expect.Equal(t, got, want)
+	testutils.Expect.Nil(t, none.Save())
+}
+
+func TestCommitCache_DiffHashes(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	for _, name := range []string{"d.txt", "e.txt", "f.txt"} {
+		testutils.AddCommit(t, repo, name, "content "+name, "feat: add "+name)
+	}
+	commits := testutils.GetCommitHistory(t, repo)
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree() error = %v", err)
+	}
+	open := func() (*git.Repository, error) { return git.PlainOpen(worktree.Filesystem.Root()) }
+
+	want := make([]string, len(commits))
+	for i, commit := range commits {
+		if want[i], err = ComputeDiffHash(commit); err != nil {
+			t.Fatalf("ComputeDiffHash() error = %v", err)
+		}
+	}
+
+	cache, err := LoadCommitCache(CommitCachePath(t.TempDir()))
+	if err != nil {
+		t.Fatalf("LoadCommitCache() error = %v", err)
+	}
+	if _, err := cache.DiffHash(commits[1]); err != nil {
+		t.Fatalf("DiffHash() error = %v", err)
+	}
+
+	hashes, errs := cache.DiffHashes(commits, open)
+	testutils.Expect.Equal(t, hashes, want, "hashes should line up with the commits")
+	testutils.Expect.Equal(t, errs, make([]error, len(commits)))
+	testutils.Expect.Equal(t, len(cache.hashes), len(commits), "computed hashes should be cached")
+
+	var none *CommitCache
+	hashes, _ = none.DiffHashes(commits, nil)
+	testutils.Expect.Equal(t, hashes, want)
+
+	failed := errors.New("no repository")
+	_, errs = none.DiffHashes(commits[:2], func() (*git.Repository, error) { return nil, failed })
+	testutils.Expect.ErrorIs(t, errs[0], failed)
+	testutils.Expect.ErrorIs(t, errs[1], failed)
+}
+
+func TestLoadCommitCache_Discarded(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"corrupt":     "{not json",
+		"old version": `{"version": 1, "diff_hashes": {"abc": "def"}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, "commits.json")
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cache, err := LoadCommitCache(path)
+			if err != nil {
+				t.Fatalf("LoadCommitCache() error = %v", err)
+			}
+			testutils.Expect.Equal(t, len(cache.hashes), 0)
+		})
+	}
+}
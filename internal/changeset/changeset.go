@@ -1,82 +1,130 @@
-// TODO(determinism): Make changeset file generation deterministic using diff-based identity
+// Package changeset reads and writes unreleased changelog entries.
 //
-// Current implementation uses [time.Now] for filenames, causing duplicate entries
-// when generate is run multiple times on the same commit range.
-//
-// Store commit metadata in .changes/data/<diff-hash>.json:
-// - Compute hash of git diff content (not commit message)
-// - Use diff hash as stable identifier across rebases
-// - Store JSON metadata: {commit_hash, diff_hash, type, scope, summary, breaking, author, date}
-// - Generate .changes/<diff-hash-7>-<slug>.md from metadata
-//
-// Implementation:
-// 1. Add DiffHash field to Entry struct
-// 2. Add CommitHash field for tracking source (optional, for reference)
-// 3. Create ComputeDiffHash(commit) function:
-//   - Get commit.Tree() and parent.Tree()
-//   - Compute diff between trees
-//   - Hash the diff content (files changed + line changes)
-//   - Return hex string
-//
-// 4. Update Write() to:
-//   - Accept diff hash as parameter
-//   - Use format: .changes/<diff-hash-7>-<slug>.md
-//   - Write JSON to .changes/data/<diff-hash>.json
-//   - Check if diff hash exists before writing (deduplication)
-//
-// 5. Add Read() function to parse existing entries by diff hash
-//
-// Directory structure:
+// Entries generated from commits are identified by a hash of their diff
+// rather than the commit hash, so the same change survives rebases and
+// amends. Each entry lives in .changes/<diff-hash-7>-<slug>.md, with its full
+// metadata in .changes/data/<diff-hash>.json:
 //
 //	.changes/
-//	  a1b2c3d-add-authentication.md      # Human-readable entry
-//	  e5f6a7b-fix-memory-leak.md
+//	  a1b2c3d-add-authentication.md
 //	  data/
-//	    a1b2c3d4e5f6...json              # Full metadata
-//	    e5f6a7b8c9d0...json
-//
-// Related: See cmd/generate.go TODO for deduplication logic
+//	    a1b2c3d4e5f6...json
 package changeset
 
 import (
 	"bytes"
-	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/log"
 	"github.com/go-git/go-git/v6/plumbing/object"
 	"github.com/goccy/go-yaml"
+	"github.com/stormlightlabs/git-storm/internal/gitlog"
 )
 
 // Entry represents a single changelog entry to be written to .changes/*.md
 type Entry struct {
-	Type       string `yaml:"type"`                  // added, changed, fixed, removed, security
-	Scope      string `yaml:"scope"`                 // optional scope
-	Summary    string `yaml:"summary"`               // description
-	Breaking   bool   `yaml:"breaking"`              // true if breaking change
-	CommitHash string `yaml:"commit_hash,omitempty"` // source commit hash (for reference)
-	DiffHash   string `yaml:"diff_hash,omitempty"`   // hash of git diff content (for deduplication)
+	Type            string   `yaml:"type"`                       // added, changed, fixed, removed, security
+	Scope           string   `yaml:"scope"`                      // optional scope
+	Package         string   `yaml:"package,omitempty"`          // monorepo package the change belongs to
+	Summary         string   `yaml:"summary"`                    // description
+	Breaking        bool     `yaml:"breaking"`                   // true if breaking change
+	CommitHash      string   `yaml:"commit_hash,omitempty"`      // source commit hash (for reference)
+	DiffHash        string   `yaml:"diff_hash,omitempty"`        // hash of git diff content (for deduplication)
+	Links           Links    `yaml:"links,omitempty"`            // named links rendered after the entry
+	References      []int    `yaml:"references,omitempty"`       // issue and pull request numbers, linked in the changelog
+	Authors         []string `yaml:"authors,omitempty"`          // people thanked in the changelog: "@login" or a name
+	NewContributors []string `yaml:"new_contributors,omitempty"` // authors whose first contribution is this entry
+	Body            string   `yaml:"body,omitempty"`             // free-form details, stored below the frontmatter in .md files
+}
+
+// Link is a named URL attached to an entry, such as a pull request or issue.
+type Link struct {
+	Name string
+	URL  string
+}
+
+// Links is an ordered set of named links, stored in frontmatter as a YAML mapping.
+type Links []Link
+
+// MarshalYAML encodes links as a mapping, preserving their order.
+func (l Links) MarshalYAML() (any, error) {
+	items := make(yaml.MapSlice, 0, len(l))
+	for _, link := range l {
+		items = append(items, yaml.MapItem{Key: link.Name, Value: link.URL})
+	}
+	return items, nil
+}
+
+// UnmarshalYAML decodes a mapping of names to URLs in document order.
+func (l *Links) UnmarshalYAML(unmarshal func(any) error) error {
+	var items yaml.MapSlice
+	if err := unmarshal(&items); err != nil {
+		return fmt.Errorf("links must be a mapping of names to URLs: %w", err)
+	}
+
+	links := make(Links, 0, len(items))
+	for _, item := range items {
+		links = append(links, Link{Name: fmt.Sprint(item.Key), URL: fmt.Sprint(item.Value)})
+	}
+	*l = links
+	return nil
+}
+
+// ParseLink parses a "name=url" pair as accepted by --link.
+func ParseLink(s string) (Link, error) {
+	name, url, ok := strings.Cut(s, "=")
+	name, url = strings.TrimSpace(name), strings.TrimSpace(url)
+	if !ok || name == "" || url == "" {
+		return Link{}, fmt.Errorf("invalid link %q: expected name=url", s)
+	}
+	return Link{Name: name, URL: url}, nil
 }
 
 // Metadata stores complete entry information in .changes/data/*.json for deduplication
 type Metadata struct {
-	CommitHash string    `json:"commit_hash"` // current commit hash
-	DiffHash   string    `json:"diff_hash"`   // stable diff content hash
-	Filename   string    `json:"filename"`    // relative path to .md file
-	Type       string    `json:"type"`
-	Scope      string    `json:"scope"`
-	Summary    string    `json:"summary"`
-	Breaking   bool      `json:"breaking"`
-	Author     string    `json:"author"`
-	Date       time.Time `json:"date"`
+	CommitHash      string    `json:"commit_hash"` // current commit hash
+	DiffHash        string    `json:"diff_hash"`   // stable diff content hash
+	Filename        string    `json:"filename"`    // relative path to .md file
+	Type            string    `json:"type"`
+	Scope           string    `json:"scope"`
+	Package         string    `json:"package,omitempty"`
+	Summary         string    `json:"summary"`
+	Body            string    `json:"body,omitempty"`
+	Breaking        bool      `json:"breaking"`
+	Author          string    `json:"author"`
+	Date            time.Time `json:"date"`
+	Links           Links     `json:"links,omitempty"`
+	References      []int     `json:"references,omitempty"`
+	Authors         []string  `json:"authors,omitempty"`
+	NewContributors []string  `json:"new_contributors,omitempty"`
+}
+
+// Entry returns the changeset entry recorded for a generated commit.
+func (m Metadata) Entry() Entry {
+	return Entry{
+		Type:            m.Type,
+		Scope:           m.Scope,
+		Package:         m.Package,
+		Summary:         m.Summary,
+		Body:            m.Body,
+		Breaking:        m.Breaking,
+		CommitHash:      m.CommitHash,
+		DiffHash:        m.DiffHash,
+		Links:           m.Links,
+		References:      m.References,
+		Authors:         m.Authors,
+		NewContributors: m.NewContributors,
+	}
 }
 
 // Write creates a new .changes/<timestamp>-<slug>.md file with YAML frontmatter.
@@ -101,13 +149,11 @@ func Write(dir string, entry Entry) (string, error) {
 		counter++
 	}
 
-	yamlBytes, err := yaml.Marshal(entry)
+	content, err := marshalEntry(entry)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal entry to YAML: %w", err)
+		return "", err
 	}
 
-	content := fmt.Sprintf("---\n%s---\n", string(yamlBytes))
-
 	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
 		return "", fmt.Errorf("failed to write file %s: %w", filePath, err)
 	}
@@ -129,13 +175,11 @@ func WritePartial(dir string, filename string, entry Entry) (string, error) {
 		return "", fmt.Errorf("file %s already exists", filename)
 	}
 
-	yamlBytes, err := yaml.Marshal(entry)
+	content, err := marshalEntry(entry)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal entry to YAML: %w", err)
+		return "", err
 	}
 
-	content := fmt.Sprintf("---\n%s---\n", string(yamlBytes))
-
 	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
 		return "", fmt.Errorf("failed to write file %s: %w", filePath, err)
 	}
@@ -150,6 +194,12 @@ func WritePartial(dir string, filename string, entry Entry) (string, error) {
 // identification, while the JSON metadata file uses the full hash for
 // deduplication lookups.
 func WriteWithMetadata(dir string, meta Metadata) (string, error) {
+	return WriteWithMetadataConfig(dir, meta, MetadataConfig{})
+}
+
+// WriteWithMetadataConfig writes an entry like [WriteWithMetadata], storing
+// its metadata according to cfg.
+func WriteWithMetadataConfig(dir string, meta Metadata, cfg MetadataConfig) (string, error) {
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return "", fmt.Errorf("failed to create directory %s: %w", dir, err)
 	}
@@ -159,27 +209,16 @@ func WriteWithMetadata(dir string, meta Metadata) (string, error) {
 	filename := fmt.Sprintf("%s-%s.md", diffHashShort, slug)
 	filePath := filepath.Join(dir, filename)
 
-	entry := Entry{
-		Type:       meta.Type,
-		Scope:      meta.Scope,
-		Summary:    meta.Summary,
-		Breaking:   meta.Breaking,
-		CommitHash: meta.CommitHash,
-		DiffHash:   meta.DiffHash,
-	}
-
-	yamlBytes, err := yaml.Marshal(entry)
+	content, err := marshalEntry(meta.Entry())
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal entry to YAML: %w", err)
+		return "", err
 	}
-
-	content := fmt.Sprintf("---\n%s---\n", string(yamlBytes))
 	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
 		return "", fmt.Errorf("failed to write file %s: %w", filePath, err)
 	}
 
 	meta.Filename = filename
-	if err := SaveMetadata(dir, meta); err != nil {
+	if err := cfg.Save(dir, meta); err != nil {
 		return "", fmt.Errorf("failed to save metadata: %w", err)
 	}
 	return filePath, nil
@@ -208,7 +247,35 @@ type EntryWithFile struct {
 	Filename string
 }
 
-// List reads all .changes/*.md files and returns their parsed entries.
+// excludedDirs names .changes subdirectories that never hold entries: JSON
+// metadata, the commit cache, trashed entries, and entries archived by a
+// release.
+var excludedDirs = map[string]struct{}{
+	"data":     {},
+	"cache":    {},
+	".trash":   {},
+	"released": {},
+}
+
+// isEntryFile reports whether path, relative to the changes directory, names a
+// changeset entry. Only .md files outside [excludedDirs] qualify; every entry
+// scanner should filter through it.
+func isEntryFile(path string) bool {
+	if filepath.Ext(path) != ".md" {
+		return false
+	}
+	parts := strings.Split(filepath.ToSlash(path), "/")
+	for _, dir := range parts[:len(parts)-1] {
+		if _, excluded := excludedDirs[dir]; excluded {
+			return false
+		}
+	}
+	return true
+}
+
+// List reads all .changes/*.md files and returns their parsed entries. Files
+// may hold YAML frontmatter or be Towncrier fragments named
+// <issue>.<type>.md with a plain-text body; see [TowncrierTypes].
 func List(dir string) ([]EntryWithFile, error) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
@@ -221,7 +288,7 @@ func List(dir string) ([]EntryWithFile, error) {
 	var results []EntryWithFile
 
 	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+		if entry.IsDir() || !isEntryFile(entry.Name()) {
 			continue
 		}
 
@@ -231,7 +298,7 @@ func List(dir string) ([]EntryWithFile, error) {
 			return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
 		}
 
-		parsed, err := parseEntry(content)
+		parsed, err := parseEntryFile(entry.Name(), content)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse %s: %w", entry.Name(), err)
 		}
@@ -245,23 +312,134 @@ func List(dir string) ([]EntryWithFile, error) {
 	return results, nil
 }
 
+// Read parses the single entry file at path, which may be a Towncrier
+// fragment like those [List] reads.
+func Read(path string) (Entry, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Entry{}, fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+
+	entry, err := parseEntryFile(filepath.Base(path), content)
+	if err != nil {
+		return Entry{}, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+	return entry, nil
+}
+
 // parseEntry extracts YAML frontmatter from a markdown file and unmarshals it into an Entry.
 func parseEntry(content []byte) (Entry, error) {
 	var entry Entry
 
-	parts := bytes.Split(content, []byte("---"))
+	parts := bytes.SplitN(content, []byte("---"), 3)
 	if len(parts) < 3 {
 		return entry, fmt.Errorf("invalid frontmatter format: expected ---...--- delimiters")
 	}
 
 	yamlContent := parts[1]
 	if err := yaml.Unmarshal(yamlContent, &entry); err != nil {
+		if violations := ValidateFrontmatter(content, nil); len(violations) > 0 {
+			return entry, violations[0]
+		}
 		return entry, fmt.Errorf("failed to unmarshal YAML: %w", err)
 	}
 
+	if body := strings.TrimSpace(string(parts[2])); body != "" {
+		entry.Body = body
+	}
 	return entry, nil
 }
 
+// marshalEntry renders entry as an entry file: YAML frontmatter followed by
+// the body, if any.
+func marshalEntry(entry Entry) (string, error) {
+	body := strings.TrimSpace(entry.Body)
+	entry.Body = ""
+
+	yamlBytes, err := yaml.Marshal(entry)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal entry to YAML: %w", err)
+	}
+	return entryFile(yamlBytes, body), nil
+}
+
+// entryFile joins rendered frontmatter and an optional body into entry file content.
+func entryFile(frontmatter []byte, body string) string {
+	content := fmt.Sprintf("---\n%s---\n", string(frontmatter))
+	if body != "" {
+		content += "\n" + body + "\n"
+	}
+	return content
+}
+
+// entryKeys holds the frontmatter keys modeled by [Entry].
+var entryKeys = func() map[string]bool {
+	keys := make(map[string]bool)
+	t := reflect.TypeFor[Entry]()
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		keys[name] = true
+	}
+	return keys
+}()
+
+// mergeEntry renders entry like [marshalEntry] while keeping the frontmatter
+// keys in existing that [Entry] does not model, in their original order.
+//
+// Modeled keys keep their position and take entry's value, or are dropped when
+// that value is now empty; new keys are appended. Content whose frontmatter
+// cannot be parsed is replaced outright.
+func mergeEntry(existing []byte, entry Entry) (string, error) {
+	var current yaml.MapSlice
+	parts := bytes.SplitN(existing, []byte("---"), 3)
+	if len(parts) < 3 || yaml.UnmarshalWithOptions(parts[1], &current, yaml.UseOrderedMap()) != nil {
+		return marshalEntry(entry)
+	}
+
+	body := strings.TrimSpace(entry.Body)
+	entry.Body = ""
+
+	yamlBytes, err := yaml.Marshal(entry)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal entry to YAML: %w", err)
+	}
+	var updated yaml.MapSlice
+	if err := yaml.UnmarshalWithOptions(yamlBytes, &updated, yaml.UseOrderedMap()); err != nil {
+		return "", fmt.Errorf("failed to decode marshaled entry: %w", err)
+	}
+
+	values := make(map[string]any, len(updated))
+	for _, item := range updated {
+		values[fmt.Sprint(item.Key)] = item.Value
+	}
+
+	merged := make(yaml.MapSlice, 0, len(current)+len(updated))
+	seen := make(map[string]bool, len(updated))
+	for _, item := range current {
+		key := fmt.Sprint(item.Key)
+		if value, ok := values[key]; ok {
+			merged = append(merged, yaml.MapItem{Key: item.Key, Value: value})
+			seen[key] = true
+		} else if !entryKeys[key] {
+			merged = append(merged, item)
+		}
+	}
+	for _, item := range updated {
+		if !seen[fmt.Sprint(item.Key)] {
+			merged = append(merged, item)
+		}
+	}
+
+	frontmatter, err := yaml.Marshal(merged)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal entry to YAML: %w", err)
+	}
+	return entryFile(frontmatter, body), nil
+}
+
+// changePatch computes the textual patch for a change. Overridden in tests.
+var changePatch = (*object.Change).Patch
+
 // ComputeDiffHash calculates a stable hash of the commit's diff content. This
 // hash is independent of the commit hash, so rebased commits with identical
 // diffs will produce the same hash.
@@ -269,43 +447,22 @@ func parseEntry(content []byte) (Entry, error) {
 // The hash is computed from:
 //   - Sorted list of changed file paths
 //   - For each file: the full diff content (additions and deletions)
+//
+// Files whose patch cannot be computed (e.g. large or binary blobs) contribute
+// their from/to blob hashes instead, so one problematic file doesn't abort hashing.
 func ComputeDiffHash(commit *object.Commit) (string, error) {
-	tree, err := commit.Tree()
+	changes, err := hashedChanges(commit)
 	if err != nil {
-		return "", fmt.Errorf("failed to get commit tree: %w", err)
-	}
-
-	var parentTree *object.Tree
-	if commit.NumParents() > 0 {
-		parent, err := commit.Parent(0)
-		if err != nil {
-			return "", fmt.Errorf("failed to get parent commit: %w", err)
-		}
-		parentTree, err = parent.Tree()
-		if err != nil {
-			return "", fmt.Errorf("failed to get parent tree: %w", err)
-		}
-	}
-
-	var changes object.Changes
-	if parentTree != nil {
-		changes, err = parentTree.Diff(tree)
-		if err != nil {
-			return "", fmt.Errorf("failed to compute diff: %w", err)
-		}
-	} else {
-		emptyTree := &object.Tree{}
-		changes, err = object.DiffTreeWithOptions(context.TODO(), emptyTree, tree, &object.DiffTreeOptions{})
-		if err != nil {
-			return "", fmt.Errorf("failed to compute diff for initial commit: %w", err)
-		}
+		return "", err
 	}
 
 	var diffParts []string
 	for _, change := range changes {
-		patch, err := change.Patch()
+		patch, err := changePatch(change)
 		if err != nil {
-			return "", fmt.Errorf("failed to get patch for %s: %w", change.To.Name, err)
+			log.Debug("falling back to blob hashes for diff hash", "file", change.To.Name, "err", err)
+			diffParts = append(diffParts, fmt.Sprintf("FILE:%s\nBLOB:%s..%s", change.To.Name, change.From.TreeEntry.Hash, change.To.TreeEntry.Hash))
+			continue
 		}
 
 		diffParts = append(diffParts, fmt.Sprintf("FILE:%s\n%s", change.To.Name, patch.String()))
@@ -321,9 +478,60 @@ func ComputeDiffHash(commit *object.Commit) (string, error) {
 	return hex.EncodeToString(hasher.Sum(nil)), nil
 }
 
-// SaveMetadata writes metadata to .changes/data/<diffHash>.json
-func SaveMetadata(dir string, meta Metadata) error {
-	dataDir := filepath.Join(dir, "data")
+// hashedChanges enumerates the changes [ComputeDiffHash] covers. They must
+// not drift, or existing metadata stops matching: a commit is diffed against
+// its first parent with go-git's default rename detection, as Tree.Diff does,
+// and a root commit against an empty tree without it.
+func hashedChanges(commit *object.Commit) (object.Changes, error) {
+	if commit.NumParents() == 0 {
+		return gitlog.CommitChanges(commit)
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit tree: %w", err)
+	}
+	parent, err := commit.Parent(0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parent commit: %w", err)
+	}
+	parentTree, err := parent.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parent tree: %w", err)
+	}
+	changes, err := parentTree.Diff(tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute diff: %w", err)
+	}
+	return changes, nil
+}
+
+// MetadataConfig controls where deduplication metadata is stored.
+//
+// The zero value stores JSON metadata in <changes dir>/data.
+type MetadataConfig struct {
+	// Dir holds the <diffHash>.json files; defaults to <changes dir>/data when empty.
+	Dir string
+	// Disabled skips JSON metadata so deduplication relies on the diff_hash
+	// recorded in each entry's frontmatter.
+	Disabled bool
+}
+
+// dataDir returns the metadata directory for the given changes directory.
+func (c MetadataConfig) dataDir(changesDir string) string {
+	if c.Dir != "" {
+		return c.Dir
+	}
+	return filepath.Join(changesDir, "data")
+}
+
+// Save writes metadata to <dataDir>/<diffHash>.json. It is a no-op when metadata is disabled.
+func (c MetadataConfig) Save(changesDir string, meta Metadata) error {
+	if c.Disabled {
+		return nil
+	}
+
+	dataDir := c.dataDir(changesDir)
 	if err := os.MkdirAll(dataDir, 0755); err != nil {
 		return fmt.Errorf("failed to create data directory: %w", err)
 	}
@@ -341,10 +549,16 @@ func SaveMetadata(dir string, meta Metadata) error {
 	return nil
 }
 
-// LoadExistingMetadata reads all metadata files from .changes/data/*.json
-// and creates a map of diff hash -> metadata for O(1) lookups.
-func LoadExistingMetadata(dir string) (map[string]Metadata, error) {
-	dataDir := filepath.Join(dir, "data")
+// Load returns a map of diff hash -> metadata for O(1) lookups.
+//
+// When metadata is disabled the map is built from the frontmatter of the
+// entries in changesDir instead.
+func (c MetadataConfig) Load(changesDir string) (map[string]Metadata, error) {
+	if c.Disabled {
+		return MetadataFromEntries(changesDir)
+	}
+
+	dataDir := c.dataDir(changesDir)
 	result := make(map[string]Metadata)
 	entries, err := os.ReadDir(dataDir)
 	if err != nil {
@@ -375,11 +589,68 @@ func LoadExistingMetadata(dir string) (map[string]Metadata, error) {
 	return result, nil
 }
 
-// UpdateMetadata updates an existing metadata file with a new commit hash when
-// a rebased commit is detected (same diff, different commit hash).
-func UpdateMetadata(dir string, diffHash string, newCommitHash string) error {
-	dataDir := filepath.Join(dir, "data")
-	filePath := filepath.Join(dataDir, diffHash+".json")
+// Orphans returns the metadata files, relative to the metadata directory,
+// whose entry no longer exists in changesDir. It returns nothing when
+// metadata is disabled.
+func (c MetadataConfig) Orphans(changesDir string) ([]string, error) {
+	if c.Disabled {
+		return nil, nil
+	}
+
+	dataDir := c.dataDir(changesDir)
+	files, err := os.ReadDir(dataDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read data directory: %w", err)
+	}
+
+	var orphans []string
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dataDir, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read metadata file %s: %w", file.Name(), err)
+		}
+		var meta Metadata
+		if err := json.Unmarshal(data, &meta); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata from %s: %w", file.Name(), err)
+		}
+
+		if meta.Filename != "" {
+			if _, err := os.Stat(filepath.Join(changesDir, meta.Filename)); err == nil {
+				continue
+			}
+		}
+		orphans = append(orphans, file.Name())
+	}
+	return orphans, nil
+}
+
+// UpdateCommit records a new commit hash for a rebased commit (same diff,
+// different commit hash).
+//
+// When metadata is disabled the commit_hash in the entry's frontmatter is rewritten.
+func (c MetadataConfig) UpdateCommit(changesDir, diffHash, newCommitHash string) error {
+	if c.Disabled {
+		entries, err := List(changesDir)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if e.Entry.DiffHash == diffHash {
+				e.Entry.CommitHash = newCommitHash
+				return Update(changesDir, e.Filename, e.Entry)
+			}
+		}
+		return fmt.Errorf("no entry with diff hash %s", diffHash)
+	}
+
+	filePath := filepath.Join(c.dataDir(changesDir), diffHash+".json")
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to read existing metadata: %w", err)
@@ -403,6 +674,55 @@ func UpdateMetadata(dir string, diffHash string, newCommitHash string) error {
 	return nil
 }
 
+// MetadataFromEntries builds a diff hash -> metadata map by scanning the
+// frontmatter of .changes/*.md files. Entries without a diff_hash are ignored.
+func MetadataFromEntries(dir string) (map[string]Metadata, error) {
+	entries, err := List(dir)
+	if err != nil {
+		return nil, err
+	}
+	return metadataFromEntries(entries), nil
+}
+
+// metadataFromEntries indexes entries that carry a diff hash by that hash.
+func metadataFromEntries(entries []EntryWithFile) map[string]Metadata {
+	result := make(map[string]Metadata, len(entries))
+	for _, e := range entries {
+		if e.Entry.DiffHash == "" {
+			continue
+		}
+		result[e.Entry.DiffHash] = Metadata{
+			CommitHash: e.Entry.CommitHash,
+			DiffHash:   e.Entry.DiffHash,
+			Filename:   e.Filename,
+			Type:       e.Entry.Type,
+			Scope:      e.Entry.Scope,
+			Package:    e.Entry.Package,
+			Summary:    e.Entry.Summary,
+			Body:       e.Entry.Body,
+			Breaking:   e.Entry.Breaking,
+		}
+	}
+	return result
+}
+
+// SaveMetadata writes metadata to .changes/data/<diffHash>.json
+func SaveMetadata(dir string, meta Metadata) error {
+	return MetadataConfig{}.Save(dir, meta)
+}
+
+// LoadExistingMetadata reads all metadata files from .changes/data/*.json
+// and creates a map of diff hash -> metadata for O(1) lookups.
+func LoadExistingMetadata(dir string) (map[string]Metadata, error) {
+	return MetadataConfig{}.Load(dir)
+}
+
+// UpdateMetadata updates an existing metadata file with a new commit hash when
+// a rebased commit is detected (same diff, different commit hash).
+func UpdateMetadata(dir string, diffHash string, newCommitHash string) error {
+	return MetadataConfig{}.UpdateCommit(dir, diffHash, newCommitHash)
+}
+
 // Delete removes a changelog entry file from the .changes/ directory.
 func Delete(dir, filename string) error {
 	filePath := filepath.Join(dir, filename)
@@ -417,19 +737,24 @@ func Delete(dir, filename string) error {
 }
 
 // Update modifies an existing changelog entry file with new values.
+//
+// Frontmatter keys that [Entry] does not model, such as custom fields added by
+// hand, are preserved (see [mergeEntry]).
 func Update(dir, filename string, entry Entry) error {
 	filePath := filepath.Join(dir, filename)
 
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+	existing, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
 		return fmt.Errorf("file %s does not exist", filename)
 	}
-
-	yamlBytes, err := yaml.Marshal(entry)
 	if err != nil {
-		return fmt.Errorf("failed to marshal entry to YAML: %w", err)
+		return fmt.Errorf("failed to read file %s: %w", filename, err)
 	}
 
-	content := fmt.Sprintf("---\n%s---\n", string(yamlBytes))
+	content, err := mergeEntry(existing, entry)
+	if err != nil {
+		return err
+	}
 
 	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
 		return fmt.Errorf("failed to update file %s: %w", filename, err)
@@ -437,3 +762,98 @@ func Update(dir, filename string, entry Entry) error {
 
 	return nil
 }
+
+// timestampFilenameRegex matches filenames created by [Write]: <timestamp>-<slug>[-N].md
+var timestampFilenameRegex = regexp.MustCompile(`^(\d{8}-\d{6})-.*\.md$`)
+
+// UpdateAndRename updates an entry like [Update] and, for timestamp-style files
+// created by [Write], renames the file to match the new summary's slug.
+//
+// Commit- and diff-hash-based filenames are kept as-is. Collisions are resolved
+// with a numeric suffix like [Write], and the Filename recorded in the entry's
+// metadata is updated when one exists. Returns the entry's (possibly new) filename.
+func UpdateAndRename(dir, filename string, entry Entry) (string, error) {
+	if err := Update(dir, filename, entry); err != nil {
+		return "", err
+	}
+
+	match := timestampFilenameRegex.FindStringSubmatch(filename)
+	if match == nil {
+		return filename, nil
+	}
+
+	timestamp := match[1]
+	slug := slugify(entry.Summary)
+	newFilename := fmt.Sprintf("%s-%s.md", timestamp, slug)
+	for counter := 1; newFilename != filename; counter++ {
+		if _, err := os.Stat(filepath.Join(dir, newFilename)); os.IsNotExist(err) {
+			break
+		}
+		newFilename = fmt.Sprintf("%s-%s-%d.md", timestamp, slug, counter)
+	}
+	if newFilename == filename {
+		return filename, nil
+	}
+
+	if err := os.Rename(filepath.Join(dir, filename), filepath.Join(dir, newFilename)); err != nil {
+		return "", fmt.Errorf("failed to rename %s to %s: %w", filename, newFilename, err)
+	}
+
+	if entry.DiffHash != "" {
+		if err := updateMetadataFilename(dir, entry.DiffHash, newFilename); err != nil {
+			return "", err
+		}
+	}
+	return newFilename, nil
+}
+
+// updateMetadataFilename records a renamed entry file in its metadata, if any.
+func updateMetadataFilename(dir, diffHash, filename string) error {
+	filePath := filepath.Join(MetadataConfig{}.dataDir(dir), diffHash+".json")
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read existing metadata: %w", err)
+	}
+
+	var meta Metadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+	meta.Filename = filename
+
+	return MetadataConfig{}.Save(dir, meta)
+}
+
+// IgnoreInGitignore appends the metadata directory to the .gitignore at
+// gitignorePath so JSON metadata stays untracked. It reports whether the file
+// was changed; an existing matching pattern leaves it untouched.
+func (c MetadataConfig) IgnoreInGitignore(changesDir, gitignorePath string) (bool, error) {
+	pattern := strings.TrimPrefix(filepath.ToSlash(filepath.Clean(c.dataDir(changesDir))), "./") + "/"
+
+	existing, err := os.ReadFile(gitignorePath)
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to read %s: %w", gitignorePath, err)
+	}
+
+	for line := range strings.SplitSeq(string(existing), "\n") {
+		line = strings.TrimPrefix(strings.TrimSpace(line), "/")
+		if line == pattern || line+"/" == pattern {
+			return false, nil
+		}
+	}
+
+	var sb strings.Builder
+	sb.Write(existing)
+	if len(existing) > 0 && !strings.HasSuffix(string(existing), "\n") {
+		sb.WriteString("\n")
+	}
+	sb.WriteString(pattern + "\n")
+
+	if err := os.WriteFile(gitignorePath, []byte(sb.String()), 0644); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", gitignorePath, err)
+	}
+	return true, nil
+}
@@ -1,13 +1,19 @@
 package changeset
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-git/go-git/v6/plumbing/object"
 	"github.com/goccy/go-yaml"
 	"github.com/stormlightlabs/git-storm/internal/testutils"
 )
@@ -226,6 +232,35 @@ func TestComputeDiffHash_Stability(t *testing.T) {
 	testutils.Expect.Equal(t, len(hash1), 64, "Diff hash should be 64 characters (SHA256 hex)")
 }
 
+func TestComputeDiffHash_PatchErrorFallback(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.AddCommit(t, repo, "large.bin", "binary content", "feat: add large blob")
+	commits := testutils.GetCommitHistory(t, repo)
+
+	original := changePatch
+	defer func() { changePatch = original }()
+
+	changePatch = func(c *object.Change) (*object.Patch, error) {
+		if c.To.Name == "large.bin" {
+			return nil, errors.New("simulated patch failure")
+		}
+		return original(c)
+	}
+
+	hash1, err := ComputeDiffHash(commits[0])
+	if err != nil {
+		t.Fatalf("ComputeDiffHash() should fall back on patch errors, got %v", err)
+	}
+
+	hash2, err := ComputeDiffHash(commits[0])
+	if err != nil {
+		t.Fatalf("ComputeDiffHash() second call error = %v", err)
+	}
+
+	testutils.Expect.Equal(t, hash1, hash2, "Fallback hash should be stable across calls")
+	testutils.Expect.Equal(t, len(hash1), 64, "Fallback hash should be 64 characters (SHA256 hex)")
+}
+
 func TestComputeDiffHash_DifferentCommits(t *testing.T) {
 	repo := testutils.SetupTestRepo(t)
 
@@ -250,6 +285,48 @@ func TestComputeDiffHash_DifferentCommits(t *testing.T) {
 	testutils.Expect.NotEqual(t, hash1, hash2, "Different commits should have different diff hashes")
 }
 
+func TestComputeDiffHash_RenameKeepsBaselineHash(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.RenameCommit(t, repo, "a.txt", "docs/a.txt", "docs: move a")
+	commit := testutils.GetCommitHistory(t, repo)[0]
+
+	// Diff hashes have always covered Tree.Diff's changes, which pair the move
+	// up as a single rename.
+	parent, err := commit.Parent(0)
+	if err != nil {
+		t.Fatalf("Parent() error = %v", err)
+	}
+	parentTree, err := parent.Tree()
+	if err != nil {
+		t.Fatalf("Tree() error = %v", err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		t.Fatalf("Tree() error = %v", err)
+	}
+	changes, err := parentTree.Diff(tree)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	var parts []string
+	for _, change := range changes {
+		patch, err := change.Patch()
+		if err != nil {
+			t.Fatalf("Patch() error = %v", err)
+		}
+		parts = append(parts, fmt.Sprintf("FILE:%s\n%s", change.To.Name, patch.String()))
+	}
+	sort.Strings(parts)
+	sum := sha256.Sum256([]byte(strings.Join(parts, "")))
+
+	hash, err := ComputeDiffHash(commit)
+	if err != nil {
+		t.Fatalf("ComputeDiffHash() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(changes), 1)
+	testutils.Expect.Equal(t, hash, hex.EncodeToString(sum[:]), "a rename should keep the hash Tree.Diff gave it")
+}
+
 func TestWriteWithMetadata(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -421,6 +498,113 @@ func TestUpdateMetadata(t *testing.T) {
 	testutils.Expect.Equal(t, updated.Summary, meta.Summary, "Other fields should remain unchanged")
 }
 
+func TestMetadataConfig_CustomDir(t *testing.T) {
+	changesDir := t.TempDir()
+	metaDir := filepath.Join(t.TempDir(), "storm-metadata")
+	cfg := MetadataConfig{Dir: metaDir}
+
+	meta := Metadata{
+		CommitHash: "abc123",
+		DiffHash:   "custom1111111111111111111111111111111111111111111111111111111111",
+		Type:       "added",
+		Summary:    "Relocated metadata",
+	}
+
+	if _, err := WriteWithMetadataConfig(changesDir, meta, cfg); err != nil {
+		t.Fatalf("WriteWithMetadataConfig() error = %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(metaDir, meta.DiffHash+".json")); err != nil {
+		t.Errorf("Expected metadata in custom dir: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(changesDir, "data")); !os.IsNotExist(err) {
+		t.Errorf("Default data directory should not be created")
+	}
+
+	if err := cfg.UpdateCommit(changesDir, meta.DiffHash, "def456"); err != nil {
+		t.Fatalf("UpdateCommit() error = %v", err)
+	}
+
+	loaded, err := cfg.Load(changesDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	testutils.Expect.Equal(t, loaded[meta.DiffHash].CommitHash, "def456")
+}
+
+func TestMetadataConfig_Orphans(t *testing.T) {
+	changesDir := t.TempDir()
+	cfg := MetadataConfig{}
+
+	kept := Metadata{DiffHash: "kept111111111111111111111111111111111111111111111111111111111111", Type: "added", Summary: "Kept"}
+	gone := Metadata{DiffHash: "gone111111111111111111111111111111111111111111111111111111111111", Type: "fixed", Summary: "Gone"}
+	if _, err := WriteWithMetadataConfig(changesDir, kept, cfg); err != nil {
+		t.Fatalf("WriteWithMetadataConfig() error = %v", err)
+	}
+	path, err := WriteWithMetadataConfig(changesDir, gone, cfg)
+	if err != nil {
+		t.Fatalf("WriteWithMetadataConfig() error = %v", err)
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("Failed to remove entry: %v", err)
+	}
+
+	orphans, err := cfg.Orphans(changesDir)
+	if err != nil {
+		t.Fatalf("Orphans() error = %v", err)
+	}
+	testutils.Expect.Equal(t, orphans, []string{gone.DiffHash + ".json"})
+
+	orphans, err = MetadataConfig{Disabled: true}.Orphans(changesDir)
+	if err != nil {
+		t.Fatalf("Orphans() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(orphans), 0)
+}
+
+func TestMetadataConfig_Disabled(t *testing.T) {
+	changesDir := t.TempDir()
+	cfg := MetadataConfig{Disabled: true}
+
+	meta := Metadata{
+		CommitHash: "abc123",
+		DiffHash:   "nometa111111111111111111111111111111111111111111111111111111111",
+		Type:       "fixed",
+		Summary:    "Frontmatter only",
+	}
+
+	if _, err := WriteWithMetadataConfig(changesDir, meta, cfg); err != nil {
+		t.Fatalf("WriteWithMetadataConfig() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(changesDir, "data")); !os.IsNotExist(err) {
+		t.Errorf("No metadata directory should be created when disabled")
+	}
+
+	loaded, err := cfg.Load(changesDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	found, ok := loaded[meta.DiffHash]
+	if !ok {
+		t.Fatal("Expected entry to be found by frontmatter diff hash")
+	}
+	testutils.Expect.Equal(t, found.Summary, meta.Summary)
+	testutils.Expect.True(t, strings.HasSuffix(found.Filename, ".md"))
+
+	if err := cfg.UpdateCommit(changesDir, meta.DiffHash, "rebased789"); err != nil {
+		t.Fatalf("UpdateCommit() error = %v", err)
+	}
+	entries, err := List(changesDir)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	testutils.Expect.Equal(t, entries[0].Entry.CommitHash, "rebased789", "Frontmatter commit hash should be updated")
+
+	if err := cfg.UpdateCommit(changesDir, "unknown", "x"); err == nil {
+		t.Error("Expected error for unknown diff hash")
+	}
+}
+
 func TestDeduplication_SameCommit(t *testing.T) {
 	tmpDir := t.TempDir()
 	repo := testutils.SetupTestRepo(t)
@@ -706,6 +890,103 @@ func TestUpdate(t *testing.T) {
 	testutils.Expect.Equal(t, parsed.Summary, updatedEntry.Summary, "Summary should be updated")
 }
 
+func TestUpdate_PreservesUnknownKeys(t *testing.T) {
+	tmpDir := t.TempDir()
+	filename := "custom.md"
+	original := "---\n" +
+		"type: added\n" +
+		"reviewer: alice\n" +
+		"scope: cli\n" +
+		"summary: Original summary\n" +
+		"ticket:\n" +
+		"  id: 42\n" +
+		"  tracker: jira\n" +
+		"links:\n" +
+		"  PR: https://example.com/pr/1\n" +
+		"---\n\nSome details.\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, filename), []byte(original), 0644); err != nil {
+		t.Fatalf("Failed to write entry: %v", err)
+	}
+
+	err := Update(tmpDir, filename, Entry{
+		Type:     "changed",
+		Summary:  "Updated summary",
+		Breaking: true,
+		Body:     "Some details.",
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, filename))
+	if err != nil {
+		t.Fatalf("Failed to read updated file: %v", err)
+	}
+
+	want := "---\n" +
+		"type: changed\n" +
+		"reviewer: alice\n" +
+		"scope: \"\"\n" +
+		"summary: Updated summary\n" +
+		"ticket:\n" +
+		"  id: 42\n" +
+		"  tracker: jira\n" +
+		"breaking: true\n" +
+		"---\n\nSome details.\n"
+	testutils.Expect.Equal(t, string(content), want, "custom keys should survive in place while modeled keys are updated")
+
+	entry, err := parseEntry(content)
+	if err != nil {
+		t.Fatalf("parseEntry() error = %v", err)
+	}
+	testutils.Expect.Equal(t, entry.Scope, "", "cleared scope should be emptied")
+	testutils.Expect.Equal(t, len(entry.Links), 0, "cleared links should be removed")
+}
+
+func TestUpdate_UnparseableFrontmatterIsReplaced(t *testing.T) {
+	tmpDir := t.TempDir()
+	filename := "broken.md"
+	if err := os.WriteFile(filepath.Join(tmpDir, filename), []byte("no frontmatter here\n"), 0644); err != nil {
+		t.Fatalf("Failed to write entry: %v", err)
+	}
+
+	if err := Update(tmpDir, filename, Entry{Type: "fixed", Summary: "Repaired"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	entries, err := List(tmpDir)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 1)
+	testutils.Expect.Equal(t, entries[0].Entry.Summary, "Repaired")
+}
+
+func TestRead(t *testing.T) {
+	tmpDir := t.TempDir()
+	path, err := Write(tmpDir, Entry{Type: "added", Scope: "cli", Summary: "Read one entry", Body: "Details."})
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	entry, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	testutils.Expect.Equal(t, entry.Type, "added")
+	testutils.Expect.Equal(t, entry.Scope, "cli")
+	testutils.Expect.Equal(t, entry.Summary, "Read one entry")
+	testutils.Expect.Equal(t, entry.Body, "Details.")
+
+	broken := filepath.Join(tmpDir, "broken.md")
+	if err := os.WriteFile(broken, []byte("no frontmatter here\n"), 0644); err != nil {
+		t.Fatalf("Failed to write entry: %v", err)
+	}
+	if _, err := Read(broken); err == nil {
+		t.Error("Read() of a file without frontmatter should fail")
+	}
+}
+
 func TestUpdate_NonExistentFile(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -772,3 +1053,233 @@ func TestUpdate_PreserveMetadata(t *testing.T) {
 	testutils.Expect.Equal(t, parsed.DiffHash, updatedEntry.DiffHash, "DiffHash should be preserved")
 	testutils.Expect.Equal(t, parsed.Breaking, updatedEntry.Breaking, "Breaking should be updated")
 }
+
+func TestWrite_LinksRoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	entry := Entry{
+		Type:    "fixed",
+		Summary: "Fix crash on startup",
+		Links: Links{
+			{Name: "PR", URL: "https://example.com/pr/42"},
+			{Name: "Issue", URL: "https://example.com/issues/7"},
+			{Name: "Advisory", URL: "https://example.com/ghsa"},
+		},
+	}
+
+	if _, err := Write(tmpDir, entry); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	entries, err := List(tmpDir)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 1)
+	testutils.Expect.Equal(t, len(entries[0].Entry.Links), 3)
+	for i, link := range entry.Links {
+		testutils.Expect.Equal(t, entries[0].Entry.Links[i], link, "Links should keep insertion order")
+	}
+
+	plainPath, err := Write(tmpDir, Entry{Type: "added", Summary: "No links here"})
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	content, err := os.ReadFile(plainPath)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", plainPath, err)
+	}
+	testutils.Expect.False(t, strings.Contains(string(content), "links:"), "Entries without links should omit the key")
+}
+
+func TestParseLink(t *testing.T) {
+	link, err := ParseLink("PR=https://example.com/pr/1")
+	if err != nil {
+		t.Fatalf("ParseLink() error = %v", err)
+	}
+	testutils.Expect.Equal(t, link, Link{Name: "PR", URL: "https://example.com/pr/1"})
+
+	for _, invalid := range []string{"PR", "=https://example.com", "PR="} {
+		if _, err := ParseLink(invalid); err == nil {
+			t.Errorf("ParseLink(%q) expected error", invalid)
+		}
+	}
+}
+
+func TestUpdateAndRename(t *testing.T) {
+	t.Run("timestamp file is renamed and metadata updated", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		entry := Entry{Type: "added", Summary: "Old summary", DiffHash: "rename11111111111111111111111111111111111111111111111111111111111"}
+
+		oldPath, err := Write(tmpDir, entry)
+		if err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		oldFilename := filepath.Base(oldPath)
+		if err := SaveMetadata(tmpDir, Metadata{DiffHash: entry.DiffHash, Filename: oldFilename, Summary: entry.Summary}); err != nil {
+			t.Fatalf("SaveMetadata() error = %v", err)
+		}
+
+		entry.Summary = "Brand new summary"
+		newFilename, err := UpdateAndRename(tmpDir, oldFilename, entry)
+		if err != nil {
+			t.Fatalf("UpdateAndRename() error = %v", err)
+		}
+
+		testutils.Expect.Equal(t, newFilename, oldFilename[:15]+"-brand-new-summary.md")
+		if _, err := os.Stat(oldPath); !os.IsNotExist(err) {
+			t.Error("Old file should no longer exist")
+		}
+
+		entries, err := List(tmpDir)
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		testutils.Expect.Equal(t, len(entries), 1)
+		testutils.Expect.Equal(t, entries[0].Filename, newFilename)
+		testutils.Expect.Equal(t, entries[0].Entry.Summary, "Brand new summary")
+
+		meta, err := LoadExistingMetadata(tmpDir)
+		if err != nil {
+			t.Fatalf("LoadExistingMetadata() error = %v", err)
+		}
+		testutils.Expect.Equal(t, meta[entry.DiffHash].Filename, newFilename, "Metadata filename should follow the rename")
+	})
+
+	t.Run("collision gets a numeric suffix", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		if _, err := WritePartial(tmpDir, "20250101-120000-taken.md", Entry{Type: "fixed", Summary: "Taken"}); err != nil {
+			t.Fatalf("WritePartial() error = %v", err)
+		}
+		if _, err := WritePartial(tmpDir, "20250101-120000-original.md", Entry{Type: "fixed", Summary: "Original"}); err != nil {
+			t.Fatalf("WritePartial() error = %v", err)
+		}
+
+		newFilename, err := UpdateAndRename(tmpDir, "20250101-120000-original.md", Entry{Type: "fixed", Summary: "Taken"})
+		if err != nil {
+			t.Fatalf("UpdateAndRename() error = %v", err)
+		}
+		testutils.Expect.Equal(t, newFilename, "20250101-120000-taken-1.md")
+	})
+
+	t.Run("commit-hash file keeps its name", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		if _, err := WritePartial(tmpDir, "abc1234.added.md", Entry{Type: "added", Summary: "Before"}); err != nil {
+			t.Fatalf("WritePartial() error = %v", err)
+		}
+
+		newFilename, err := UpdateAndRename(tmpDir, "abc1234.added.md", Entry{Type: "added", Summary: "After"})
+		if err != nil {
+			t.Fatalf("UpdateAndRename() error = %v", err)
+		}
+		testutils.Expect.Equal(t, newFilename, "abc1234.added.md")
+	})
+}
+
+func TestIsEntryFile(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"20250101-add-feature.md", true},
+		{"nested/entry.md", true},
+		{"notes.txt", false},
+		{"data/abc123.json", false},
+		{"data/readme.md", false},
+		{".trash/old-entry.md", false},
+		{"released/1.0.0.md", false},
+		{"team/released/1.0.0.md", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			testutils.Expect.Equal(t, isEntryFile(tt.path), tt.want)
+		})
+	}
+}
+
+func TestList_SkipsMetadataDir(t *testing.T) {
+	changesDir := t.TempDir()
+	meta := Metadata{
+		CommitHash: "abc123",
+		DiffHash:   "listdata111111111111111111111111111111111111111111111111111111",
+		Type:       "added",
+		Summary:    "Tracked entry",
+	}
+	if _, err := WriteWithMetadata(changesDir, meta); err != nil {
+		t.Fatalf("WriteWithMetadata() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(changesDir, "data", "stray.md"), []byte("---\ntype: fixed\nsummary: Stray\n---\n"), 0644); err != nil {
+		t.Fatalf("failed to write stray file: %v", err)
+	}
+
+	entries, err := List(changesDir)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 1)
+	for _, e := range entries {
+		testutils.Expect.False(t, strings.HasSuffix(e.Filename, ".json"), "metadata JSON returned as entry")
+	}
+}
+
+func TestMetadataConfig_IgnoreInGitignore(t *testing.T) {
+	dir := t.TempDir()
+	gitignore := filepath.Join(dir, ".gitignore")
+	if err := os.WriteFile(gitignore, []byte("bin/"), 0644); err != nil {
+		t.Fatalf("failed to write .gitignore: %v", err)
+	}
+
+	added, err := MetadataConfig{}.IgnoreInGitignore(".changes", gitignore)
+	if err != nil {
+		t.Fatalf("IgnoreInGitignore() error = %v", err)
+	}
+	testutils.Expect.True(t, added)
+
+	added, err = MetadataConfig{}.IgnoreInGitignore(".changes", gitignore)
+	if err != nil {
+		t.Fatalf("IgnoreInGitignore() error = %v", err)
+	}
+	testutils.Expect.False(t, added, "pattern should only be appended once")
+
+	content, err := os.ReadFile(gitignore)
+	if err != nil {
+		t.Fatalf("failed to read .gitignore: %v", err)
+	}
+	testutils.Expect.Equal(t, string(content), "bin/\n.changes/data/\n")
+
+	added, err = MetadataConfig{Dir: "meta"}.IgnoreInGitignore(".changes", filepath.Join(dir, "missing.gitignore"))
+	if err != nil {
+		t.Fatalf("IgnoreInGitignore() error = %v", err)
+	}
+	testutils.Expect.True(t, added)
+}
+
+func TestWritePartial_BodyRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	entry := Entry{
+		Type:    "added",
+		Summary: "Add retries",
+		Body:    "Requests retry on 503.\n\nBackoff --- doubles each attempt.",
+	}
+
+	path, err := WritePartial(dir, "abc1234.added.md", entry)
+	if err != nil {
+		t.Fatalf("WritePartial() error = %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read entry: %v", err)
+	}
+	if strings.Contains(strings.SplitN(string(content), "---", 3)[1], "body") {
+		t.Errorf("body should be stored below the frontmatter:\n%s", content)
+	}
+
+	parsed, err := parseEntry(content)
+	if err != nil {
+		t.Fatalf("parseEntry() error = %v", err)
+	}
+	testutils.Expect.Equal(t, parsed.Body, entry.Body)
+	testutils.Expect.Equal(t, parsed.Summary, entry.Summary)
+}
@@ -0,0 +1,245 @@
+package changeset
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// Bumps recorded by the JS changesets tool, from least to most severe.
+const (
+	ChangesetsPatch = "patch"
+	ChangesetsMinor = "minor"
+	ChangesetsMajor = "major"
+)
+
+// changesetsIgnored names files in a .changeset directory that aren't entries.
+var changesetsIgnored = map[string]bool{"README.md": true}
+
+// ChangesetsStore stores entries in a .changeset directory in the format of
+// the JS changesets tool (@changesets/cli), so monorepos can keep their
+// files as they are. Each file maps package names to a bump in its
+// frontmatter, followed by the summary:
+//
+//	---
+//	"@acme/ui": minor
+//	"@acme/core": patch
+//	---
+//
+//	Add a dark theme
+//
+// The mapping is lossy, as changesets has no change types: a major bump
+// reads as a breaking "changed" entry, minor as "added", and patch as
+// "fixed". Written entries bump major when breaking, minor when added, and
+// patch otherwise. Their packages come from the scope, a comma-separated
+// list of package names.
+type ChangesetsStore struct {
+	Dir string
+}
+
+// NewChangesetsStore returns a store for the .changeset directory dir.
+func NewChangesetsStore(dir string) *ChangesetsStore {
+	return &ChangesetsStore{Dir: dir}
+}
+
+// Write creates a <slug>.md file for entry and returns its filename.
+func (s *ChangesetsStore) Write(entry Entry) (string, error) {
+	content, err := marshalChangeset(entry, nil)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory %s: %w", s.Dir, err)
+	}
+
+	slug := slugify(entry.Summary)
+	filename := slug + ".md"
+	for counter := 1; ; counter++ {
+		if _, err := os.Stat(filepath.Join(s.Dir, filename)); os.IsNotExist(err) && !changesetsIgnored[filename] {
+			break
+		}
+		filename = fmt.Sprintf("%s-%d.md", slug, counter)
+	}
+
+	path := filepath.Join(s.Dir, filename)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("failed to write file %s: %w", path, err)
+	}
+	return filename, nil
+}
+
+// List reads every changeset in the directory, skipping its README.md and
+// empty changesets.
+func (s *ChangesetsStore) List() ([]EntryWithFile, error) {
+	files, err := os.ReadDir(s.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []EntryWithFile{}, nil
+		}
+		return nil, fmt.Errorf("failed to read directory %s: %w", s.Dir, err)
+	}
+
+	var results []EntryWithFile
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".md" || changesetsIgnored[file.Name()] {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(s.Dir, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %w", file.Name(), err)
+		}
+		entry, packages, err := parseChangeset(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file.Name(), err)
+		}
+		if len(packages) == 0 {
+			// An empty changeset records that a change needs no release.
+			continue
+		}
+		results = append(results, EntryWithFile{Entry: entry, Filename: file.Name()})
+	}
+	return results, nil
+}
+
+// Delete removes the changeset named id.
+func (s *ChangesetsStore) Delete(id string) error {
+	return Delete(s.Dir, id)
+}
+
+// Update rewrites the changeset named id. Its packages are kept unless the
+// entry's scope names new ones.
+func (s *ChangesetsStore) Update(id string, entry Entry) error {
+	path := filepath.Join(s.Dir, id)
+	existing, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("file %s does not exist", id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", id, err)
+	}
+
+	_, packages, err := parseChangeset(existing)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", id, err)
+	}
+	content, err := marshalChangeset(entry, packages)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to update file %s: %w", id, err)
+	}
+	return nil
+}
+
+// LoadMetadata returns no metadata: changesets don't record commits or diff
+// hashes, so generated entries can't be deduplicated against them.
+func (s *ChangesetsStore) LoadMetadata() (map[string]Metadata, error) {
+	return map[string]Metadata{}, nil
+}
+
+// WriteGenerated writes the entry for meta like [ChangesetsStore.Write]; its
+// commit and diff hash are not kept.
+func (s *ChangesetsStore) WriteGenerated(meta Metadata) (string, error) {
+	return s.Write(meta.Entry())
+}
+
+// UpdateCommit always fails, as changesets don't record diff hashes.
+func (s *ChangesetsStore) UpdateCommit(diffHash, commitHash string) error {
+	return fmt.Errorf("no entry with diff hash %s", diffHash)
+}
+
+// Path returns the file path of the changeset with id.
+func (s *ChangesetsStore) Path(id string) string {
+	return filepath.Join(s.Dir, id)
+}
+
+// parseChangeset reads a changeset file into an Entry and its package names,
+// in frontmatter order. An empty changeset yields no packages.
+func parseChangeset(content []byte) (Entry, []string, error) {
+	parts := bytes.SplitN(content, []byte("---"), 3)
+	if len(parts) < 3 {
+		return Entry{}, nil, fmt.Errorf("invalid frontmatter format: expected ---...--- delimiters")
+	}
+
+	var bumps yaml.MapSlice
+	if err := yaml.UnmarshalWithOptions(parts[1], &bumps, yaml.UseOrderedMap()); err != nil {
+		return Entry{}, nil, fmt.Errorf("failed to unmarshal YAML: %w", err)
+	}
+
+	severity := map[string]int{ChangesetsPatch: 1, ChangesetsMinor: 2, ChangesetsMajor: 3}
+	packages := make([]string, 0, len(bumps))
+	highest := ""
+	for _, item := range bumps {
+		name, bump := fmt.Sprint(item.Key), fmt.Sprint(item.Value)
+		if severity[bump] == 0 {
+			return Entry{}, nil, fmt.Errorf("package %s: bump must be one of [patch, minor, major], got %q", name, bump)
+		}
+		if severity[bump] > severity[highest] {
+			highest = bump
+		}
+		packages = append(packages, name)
+	}
+
+	if len(packages) == 0 {
+		return Entry{}, nil, nil
+	}
+
+	summary, body, _ := strings.Cut(strings.TrimSpace(string(parts[2])), "\n")
+	summary = strings.TrimSpace(summary)
+	if summary == "" {
+		return Entry{}, nil, fmt.Errorf("summary is required")
+	}
+
+	entry := Entry{Type: "changed", Scope: strings.Join(packages, ", "), Summary: summary, Body: strings.TrimSpace(body)}
+	switch highest {
+	case ChangesetsMajor:
+		entry.Breaking = true
+	case ChangesetsMinor:
+		entry.Type = "added"
+	case ChangesetsPatch:
+		entry.Type = "fixed"
+	}
+	return entry, packages, nil
+}
+
+// marshalChangeset renders entry as a changeset file. The packages bumped
+// are those in entry's scope, or packages when the scope is empty.
+func marshalChangeset(entry Entry, packages []string) (string, error) {
+	if entry.Scope != "" {
+		packages = nil
+		for name := range strings.SplitSeq(entry.Scope, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				packages = append(packages, name)
+			}
+		}
+	}
+	if len(packages) == 0 {
+		return "", fmt.Errorf("changesets entries need a package: set the scope to the package name")
+	}
+
+	bump := ChangesetsPatch
+	switch {
+	case entry.Breaking:
+		bump = ChangesetsMajor
+	case entry.Type == "added":
+		bump = ChangesetsMinor
+	}
+
+	var b strings.Builder
+	b.WriteString("---\n")
+	for _, name := range packages {
+		fmt.Fprintf(&b, "%q: %s\n", name, bump)
+	}
+	b.WriteString("---\n\n")
+	b.WriteString(strings.TrimSpace(entry.Summary))
+	if body := strings.TrimSpace(entry.Body); body != "" {
+		b.WriteString("\n\n" + body)
+	}
+	b.WriteString("\n")
+	return b.String(), nil
+}
@@ -0,0 +1,102 @@
+package changeset
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stormlightlabs/git-storm/internal/testutils"
+)
+
+func TestChangesetsStore_List(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"README.md":          "# Changesets\n\nHello and welcome!\n",
+		"config.json":        "{}",
+		"brave-dogs-run.md":  "---\n\"@acme/ui\": minor\n\"@acme/core\": patch\n---\n\nAdd a dark theme\n\nColors follow the OS setting.\n",
+		"quiet-cats-nap.md":  "---\n\"@acme/core\": major\n---\n\nDrop Node 16\n",
+		"empty-owls-hoot.md": "---\n---\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	entries, err := NewChangesetsStore(dir).List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 2)
+	testutils.Expect.Equal(t, entries[0].Filename, "brave-dogs-run.md")
+	testutils.Expect.Equal(t, entries[0].Entry, Entry{
+		Type:    "added",
+		Scope:   "@acme/ui, @acme/core",
+		Summary: "Add a dark theme",
+		Body:    "Colors follow the OS setting.",
+	})
+	testutils.Expect.Equal(t, entries[1].Entry, Entry{Type: "changed", Scope: "@acme/core", Summary: "Drop Node 16", Breaking: true})
+}
+
+func TestChangesetsStore_ListErrors(t *testing.T) {
+	cases := map[string]string{
+		"bad-bump.md":   "---\n\"@acme/ui\": huge\n---\n\nSomething\n",
+		"no-summary.md": "---\n\"@acme/ui\": patch\n---\n",
+		"no-front.md":   "Just text\n",
+	}
+	for name, content := range cases {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		if _, err := NewChangesetsStore(dir).List(); err == nil {
+			t.Errorf("%s: List() expected error", name)
+		}
+	}
+}
+
+func TestChangesetsStore_WriteAndUpdate(t *testing.T) {
+	dir := t.TempDir()
+	store := NewChangesetsStore(dir)
+
+	_, err := store.Write(Entry{Type: "fixed", Summary: "No package"})
+	testutils.Expect.True(t, err != nil, "writing without a package should fail")
+
+	id, err := store.Write(Entry{Type: "added", Scope: "@acme/ui, @acme/core", Summary: "Add a dark theme", Body: "Details."})
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	testutils.Expect.Equal(t, id, "add-a-dark-theme.md")
+
+	content, err := os.ReadFile(store.Path(id))
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", id, err)
+	}
+	testutils.Expect.Equal(t, string(content), "---\n\"@acme/ui\": minor\n\"@acme/core\": minor\n---\n\nAdd a dark theme\n\nDetails.\n")
+
+	second, err := store.Write(Entry{Type: "fixed", Scope: "@acme/ui", Summary: "Add a dark theme"})
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	testutils.Expect.Equal(t, second, "add-a-dark-theme-1.md")
+
+	// Without a scope, the update keeps the changeset's packages.
+	if err := store.Update(id, Entry{Type: "changed", Summary: "Rework theming", Breaking: true}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	content, err = os.ReadFile(store.Path(id))
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", id, err)
+	}
+	testutils.Expect.Equal(t, string(content), "---\n\"@acme/ui\": major\n\"@acme/core\": major\n---\n\nRework theming\n")
+
+	if err := store.Delete(second); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	entries, err := store.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 1)
+	testutils.Expect.True(t, entries[0].Entry.Breaking)
+}
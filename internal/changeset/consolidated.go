@@ -0,0 +1,142 @@
+package changeset
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/goccy/go-yaml"
+)
+
+// consolidatedFile is the on-disk layout of a single-file entry store such as news.yaml.
+type consolidatedFile struct {
+	Entries []Entry `yaml:"entries"`
+}
+
+// ReadConsolidated reads every entry from a consolidated YAML file.
+// Returns no entries if the file doesn't exist.
+func ReadConsolidated(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Entry{}, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var file consolidatedFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return file.Entries, nil
+}
+
+// WriteConsolidated replaces the contents of a consolidated YAML file with entries.
+func WriteConsolidated(path string, entries []Entry) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+
+	data, err := yaml.Marshal(consolidatedFile{Entries: entries})
+	if err != nil {
+		return fmt.Errorf("failed to marshal entries to YAML: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// AppendConsolidated adds entries to a consolidated YAML file, skipping any
+// whose diff hash is already present. Returns the number of entries added.
+func AppendConsolidated(path string, entries []Entry) (int, error) {
+	existing, err := ReadConsolidated(path)
+	if err != nil {
+		return 0, err
+	}
+
+	seen := make(map[string]bool, len(existing))
+	for _, entry := range existing {
+		if entry.DiffHash != "" {
+			seen[entry.DiffHash] = true
+		}
+	}
+
+	added := 0
+	for _, entry := range entries {
+		if entry.DiffHash != "" && seen[entry.DiffHash] {
+			continue
+		}
+		if entry.DiffHash != "" {
+			seen[entry.DiffHash] = true
+		}
+		existing = append(existing, entry)
+		added++
+	}
+
+	if added == 0 {
+		return 0, nil
+	}
+	return added, WriteConsolidated(path, existing)
+}
+
+// ListConsolidated returns the entries of a consolidated file in the same shape as [List].
+//
+// Every entry reports the consolidated file's base name as its Filename.
+func ListConsolidated(path string) ([]EntryWithFile, error) {
+	entries, err := ReadConsolidated(path)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]EntryWithFile, 0, len(entries))
+	for _, entry := range entries {
+		results = append(results, EntryWithFile{Entry: entry, Filename: filepath.Base(path)})
+	}
+	return results, nil
+}
+
+// ConsolidatedMetadata indexes a consolidated file's entries by diff hash so
+// they can be deduplicated like .changes/data metadata.
+func ConsolidatedMetadata(path string) (map[string]Metadata, error) {
+	entries, err := ReadConsolidated(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]Metadata, len(entries))
+	for _, entry := range entries {
+		if entry.DiffHash == "" {
+			continue
+		}
+		result[entry.DiffHash] = Metadata{
+			CommitHash: entry.CommitHash,
+			DiffHash:   entry.DiffHash,
+			Filename:   filepath.Base(path),
+			Type:       entry.Type,
+			Scope:      entry.Scope,
+			Summary:    entry.Summary,
+			Breaking:   entry.Breaking,
+		}
+	}
+	return result, nil
+}
+
+// UpdateConsolidatedCommit points the entry with diffHash at a new commit, e.g. after a rebase.
+func UpdateConsolidatedCommit(path, diffHash, newCommitHash string) error {
+	entries, err := ReadConsolidated(path)
+	if err != nil {
+		return err
+	}
+
+	for i := range entries {
+		if entries[i].DiffHash == diffHash {
+			entries[i].CommitHash = newCommitHash
+			return WriteConsolidated(path, entries)
+		}
+	}
+	return fmt.Errorf("no entry with diff hash %s in %s", diffHash, path)
+}
@@ -0,0 +1,74 @@
+package changeset
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stormlightlabs/git-storm/internal/testutils"
+)
+
+func TestReadConsolidated_MissingFile(t *testing.T) {
+	entries, err := ReadConsolidated(filepath.Join(t.TempDir(), "news.yaml"))
+	if err != nil {
+		t.Fatalf("ReadConsolidated() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 0)
+}
+
+func TestAppendConsolidated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "news.yaml")
+
+	added, err := AppendConsolidated(path, []Entry{
+		{Type: "added", Summary: "First feature", DiffHash: "aaa", CommitHash: "111"},
+		{Type: "fixed", Summary: "First fix", DiffHash: "bbb", CommitHash: "222"},
+	})
+	if err != nil {
+		t.Fatalf("AppendConsolidated() error = %v", err)
+	}
+	testutils.Expect.Equal(t, added, 2)
+
+	added, err = AppendConsolidated(path, []Entry{
+		{Type: "fixed", Summary: "First fix", DiffHash: "bbb", CommitHash: "222"},
+		{Type: "changed", Summary: "Tweak", DiffHash: "ccc", CommitHash: "333"},
+	})
+	if err != nil {
+		t.Fatalf("AppendConsolidated() error = %v", err)
+	}
+	testutils.Expect.Equal(t, added, 1, "Duplicate diff hashes should be skipped")
+
+	listed, err := ListConsolidated(path)
+	if err != nil {
+		t.Fatalf("ListConsolidated() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(listed), 3)
+	testutils.Expect.Equal(t, listed[0].Entry.Summary, "First feature")
+	testutils.Expect.Equal(t, listed[2].Entry.Summary, "Tweak")
+	testutils.Expect.Equal(t, listed[0].Filename, "news.yaml")
+
+	meta, err := ConsolidatedMetadata(path)
+	if err != nil {
+		t.Fatalf("ConsolidatedMetadata() error = %v", err)
+	}
+	testutils.Expect.Equal(t, meta["ccc"].CommitHash, "333")
+}
+
+func TestUpdateConsolidatedCommit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "news.yaml")
+	if err := WriteConsolidated(path, []Entry{{Type: "added", Summary: "Feature", DiffHash: "aaa", CommitHash: "old"}}); err != nil {
+		t.Fatalf("WriteConsolidated() error = %v", err)
+	}
+
+	if err := UpdateConsolidatedCommit(path, "aaa", "new"); err != nil {
+		t.Fatalf("UpdateConsolidatedCommit() error = %v", err)
+	}
+
+	entries, err := ReadConsolidated(path)
+	if err != nil {
+		t.Fatalf("ReadConsolidated() error = %v", err)
+	}
+	testutils.Expect.Equal(t, entries[0].CommitHash, "new")
+
+	if err := UpdateConsolidatedCommit(path, "missing", "new"); err == nil {
+		t.Error("Expected error for unknown diff hash")
+	}
+}
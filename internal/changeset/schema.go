@@ -0,0 +1,235 @@
+package changeset
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// EntrySchema is the JSON Schema describing valid entry frontmatter.
+//
+//go:embed entry.schema.json
+var EntrySchema []byte
+
+// schemaProperty is the subset of JSON Schema keywords used by [EntrySchema].
+type schemaProperty struct {
+	Type      string   `json:"type"`
+	Enum      []string `json:"enum"`
+	MinLength int      `json:"minLength"`
+}
+
+// entrySchema is the parsed form of [EntrySchema].
+type entrySchema struct {
+	Required             []string                  `json:"required"`
+	AdditionalProperties *bool                     `json:"additionalProperties"`
+	Properties           map[string]schemaProperty `json:"properties"`
+}
+
+var parsedEntrySchema = mustParseSchema(EntrySchema)
+
+func mustParseSchema(data []byte) entrySchema {
+	var schema entrySchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		panic(fmt.Sprintf("invalid embedded entry schema: %v", err))
+	}
+	return schema
+}
+
+// SchemaViolation describes a single way an entry's frontmatter breaks [EntrySchema].
+type SchemaViolation struct {
+	Field   string
+	Message string
+}
+
+func (v SchemaViolation) Error() string { return v.Message }
+
+// ValidateFrontmatter checks a changeset file's frontmatter against [EntrySchema],
+// returning precise violations such as "summary is required" instead of
+// generic YAML decoding errors. A valid entry yields no violations.
+//
+// The type must be one of types' names; a nil registry uses the schema's enum,
+// which lists [DefaultTypes].
+func ValidateFrontmatter(content []byte, types *Registry) []SchemaViolation {
+	parts := bytes.Split(content, []byte("---"))
+	if len(parts) < 3 {
+		return []SchemaViolation{{Message: "invalid frontmatter format: expected ---...--- delimiters"}}
+	}
+
+	var fields map[string]any
+	if err := yaml.Unmarshal(parts[1], &fields); err != nil {
+		return []SchemaViolation{{Message: fmt.Sprintf("invalid YAML: %v", err)}}
+	}
+
+	schema := parsedEntrySchema
+	var violations []SchemaViolation
+
+	for _, name := range schema.Required {
+		if value, ok := fields[name]; !ok || value == nil {
+			violations = append(violations, SchemaViolation{Field: name, Message: name + " is required"})
+		}
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := fields[name]
+		prop, known := schema.Properties[name]
+		if !known {
+			if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+				violations = append(violations, SchemaViolation{Field: name, Message: fmt.Sprintf("unknown field %q", name)})
+			}
+			continue
+		}
+		if value == nil {
+			continue
+		}
+		if name == "type" && types != nil {
+			prop.Enum = types.Names()
+		}
+		if msg := checkProperty(name, prop, value); msg != "" {
+			violations = append(violations, SchemaViolation{Field: name, Message: msg})
+		}
+	}
+
+	return violations
+}
+
+// checkProperty validates a single value, returning a message on failure.
+func checkProperty(name string, prop schemaProperty, value any) string {
+	switch prop.Type {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return name + " must be a string"
+		}
+		if prop.MinLength > 0 && len(strings.TrimSpace(s)) < prop.MinLength {
+			return name + " is required"
+		}
+		if len(prop.Enum) > 0 && !containsString(prop.Enum, s) {
+			return fmt.Sprintf("%s must be one of [%s]", name, strings.Join(prop.Enum, ", "))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return name + " must be a boolean"
+		}
+	case "object":
+		if _, ok := value.(map[string]any); !ok {
+			return name + " must be a mapping"
+		}
+	case "array":
+		if _, ok := value.([]any); !ok {
+			return name + " must be a list"
+		}
+	}
+	return ""
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateDir validates every .changes/*.md file in dir against types (see
+// [ValidateFrontmatter]), returning violations keyed by filename. Towncrier
+// fragments only need a known type and a summary. Files without violations
+// are omitted.
+func ValidateDir(dir string, types *Registry) (map[string][]SchemaViolation, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string][]SchemaViolation{}, nil
+		}
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	result := make(map[string][]SchemaViolation)
+	for _, entry := range entries {
+		if entry.IsDir() || !isEntryFile(entry.Name()) {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %w", entry.Name(), err)
+		}
+
+		violations := ValidateFrontmatter(content, types)
+		if isTowncrierFragment(entry.Name(), content) {
+			violations = validateTowncrier(entry.Name(), content, types)
+		}
+		if len(violations) > 0 {
+			result[entry.Name()] = violations
+		}
+	}
+	return result, nil
+}
+
+// ValidateFile validates a single changeset file against [EntrySchema] and
+// types (see [ValidateFrontmatter]), and reports a violation when its diff_hash is shared by another entry in the
+// same directory. Sibling files that fail to parse are ignored here; they are
+// reported when validated themselves.
+func ValidateFile(path string, types *Registry) ([]SchemaViolation, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+	if name := filepath.Base(path); isTowncrierFragment(name, content) {
+		return validateTowncrier(name, content, types), nil
+	}
+
+	if violations := ValidateFrontmatter(content, types); len(violations) > 0 {
+		return violations, nil
+	}
+
+	entry, err := parseEntry(content)
+	if err != nil {
+		return []SchemaViolation{{Message: err.Error()}}, nil
+	}
+	if entry.DiffHash == "" {
+		return nil, nil
+	}
+
+	dir, name := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	siblings, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	var violations []SchemaViolation
+	for _, sibling := range siblings {
+		if sibling.IsDir() || sibling.Name() == name || !isEntryFile(sibling.Name()) {
+			continue
+		}
+
+		other, err := os.ReadFile(filepath.Join(dir, sibling.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %w", sibling.Name(), err)
+		}
+
+		if parsed, err := parseEntryFile(sibling.Name(), other); err == nil && parsed.DiffHash == entry.DiffHash {
+			violations = append(violations, SchemaViolation{
+				Field:   "diff_hash",
+				Message: fmt.Sprintf("diff_hash duplicates %s", sibling.Name()),
+			})
+		}
+	}
+	return violations, nil
+}
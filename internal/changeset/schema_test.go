@@ -0,0 +1,196 @@
+package changeset
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/git-storm/internal/testutils"
+)
+
+func TestValidateFrontmatter(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "valid entry",
+			content: "---\ntype: added\nsummary: New feature\nbreaking: false\nlinks:\n  docs: https://example.com\nreferences: [12, 34]\n---\n",
+		},
+		{
+			name:    "missing summary",
+			content: "---\ntype: fixed\n---\n",
+			want:    []string{"summary is required"},
+		},
+		{
+			name:    "empty summary",
+			content: "---\ntype: fixed\nsummary: \"  \"\n---\n",
+			want:    []string{"summary is required"},
+		},
+		{
+			name:    "invalid type",
+			content: "---\ntype: feature\nsummary: Something\n---\n",
+			want:    []string{"type must be one of [added, changed, deprecated, removed, fixed, security]"},
+		},
+		{
+			name:    "missing type and summary",
+			content: "---\nscope: cli\n---\n",
+			want:    []string{"type is required", "summary is required"},
+		},
+		{
+			name:    "non-boolean breaking",
+			content: "---\ntype: changed\nsummary: API change\nbreaking: maybe\n---\n",
+			want:    []string{"breaking must be a boolean"},
+		},
+		{
+			name:    "custom field",
+			content: "---\ntype: added\nsummary: Feature\nreviewer: someone\n---\n",
+		},
+		{
+			name:    "non-string body",
+			content: "---\ntype: added\nsummary: Feature\nbody: [a]\n---\n",
+			want:    []string{"body must be a string"},
+		},
+		{
+			name:    "non-list references",
+			content: "---\ntype: fixed\nsummary: Fix\nreferences: 12\n---\n",
+			want:    []string{"references must be a list"},
+		},
+		{
+			name:    "missing delimiters",
+			content: "type: added\n",
+			want:    []string{"invalid frontmatter format: expected ---...--- delimiters"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := ValidateFrontmatter([]byte(tt.content), nil)
+			var got []string
+			for _, v := range violations {
+				got = append(got, v.Message)
+			}
+			testutils.Expect.Equal(t, strings.Join(got, "|"), strings.Join(tt.want, "|"))
+		})
+	}
+}
+
+func TestValidateDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"good.md":   "---\ntype: added\nsummary: Fine\n---\n",
+		"bad.md":    "---\ntype: nope\nsummary: Broken\n---\n",
+		"notes.txt": "ignored",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	results, err := ValidateDir(dir, nil)
+	if err != nil {
+		t.Fatalf("ValidateDir() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(results), 1)
+	testutils.Expect.Equal(t, len(results["bad.md"]), 1)
+	testutils.Expect.Equal(t, results["bad.md"][0].Field, "type")
+}
+
+func TestList_ReportsSchemaViolation(t *testing.T) {
+	dir := t.TempDir()
+	content := "---\ntype: changed\nsummary: API change\nbreaking: maybe\n---\n"
+	if err := os.WriteFile(filepath.Join(dir, "entry.md"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write entry: %v", err)
+	}
+
+	_, err := List(dir)
+	if err == nil {
+		t.Fatal("List() expected error for invalid breaking value")
+	}
+	testutils.Expect.True(t, strings.Contains(err.Error(), "entry.md: breaking must be a boolean"))
+}
+
+func TestValidateFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"valid.md":   "---\ntype: added\nsummary: Fine\ndiff_hash: aaa111\n---\n",
+		"badtype.md": "---\ntype: feature\nsummary: Wrong type\n---\n",
+		"dupe.md":    "---\ntype: fixed\nsummary: Same diff\ndiff_hash: bbb222\n---\n",
+		"orig.md":    "---\ntype: fixed\nsummary: Original\ndiff_hash: bbb222\n---\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	t.Run("valid file", func(t *testing.T) {
+		violations, err := ValidateFile(filepath.Join(dir, "valid.md"), nil)
+		if err != nil {
+			t.Fatalf("ValidateFile() error = %v", err)
+		}
+		testutils.Expect.Equal(t, len(violations), 0)
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		violations, err := ValidateFile(filepath.Join(dir, "badtype.md"), nil)
+		if err != nil {
+			t.Fatalf("ValidateFile() error = %v", err)
+		}
+		testutils.Expect.Equal(t, len(violations), 1)
+		testutils.Expect.Equal(t, violations[0].Field, "type")
+	})
+
+	t.Run("duplicate diff hash", func(t *testing.T) {
+		violations, err := ValidateFile(filepath.Join(dir, "dupe.md"), nil)
+		if err != nil {
+			t.Fatalf("ValidateFile() error = %v", err)
+		}
+		testutils.Expect.Equal(t, len(violations), 1)
+		testutils.Expect.Equal(t, violations[0].Message, "diff_hash duplicates orig.md")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		if _, err := ValidateFile(filepath.Join(dir, "missing.md"), nil); err == nil {
+			t.Error("ValidateFile() expected error for missing file")
+		}
+	})
+}
+
+func TestValidateFrontmatter_Registry(t *testing.T) {
+	types, err := NewRegistry([]ChangeType{{Name: "added"}, {Name: "perf"}})
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+
+	perf := []byte("---\ntype: perf\nsummary: Faster\n---\n")
+	testutils.Expect.Equal(t, len(ValidateFrontmatter(perf, nil)), 1, "the schema enum only lists the default types")
+	testutils.Expect.Equal(t, len(ValidateFrontmatter(perf, types)), 0, "registered types should be accepted")
+
+	violations := ValidateFrontmatter([]byte("---\ntype: fixed\nsummary: Bug\n---\n"), types)
+	if len(violations) != 1 {
+		t.Fatalf("expected one violation, got %v", violations)
+	}
+	testutils.Expect.Equal(t, violations[0].Message, "type must be one of [added, perf]")
+}
+
+func TestValidateFile_UpdatePreservedKeys(t *testing.T) {
+	dir := t.TempDir()
+	original := "---\ntype: added\nsummary: Original\nreviewer: alice\nticket:\n  id: 42\n---\n"
+	if err := os.WriteFile(filepath.Join(dir, "custom.md"), []byte(original), 0644); err != nil {
+		t.Fatalf("failed to write entry: %v", err)
+	}
+
+	if err := Update(dir, "custom.md", Entry{Type: "fixed", Summary: "Updated", Body: "Details."}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	violations, err := ValidateFile(filepath.Join(dir, "custom.md"), nil)
+	if err != nil {
+		t.Fatalf("ValidateFile() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(violations), 0, "keys preserved by Update should pass validation")
+}
@@ -0,0 +1,288 @@
+package changeset
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Store is a backend that holds unreleased changeset entries.
+//
+// An entry's id is the Filename that [Store.List] reports for it and is what
+// [Store.Delete] and [Store.Update] accept.
+type Store interface {
+	// Write saves a new entry and returns its id.
+	Write(entry Entry) (string, error)
+	// List returns every stored entry.
+	List() ([]EntryWithFile, error)
+	// Delete removes the entry with id.
+	Delete(id string) error
+	// Update replaces the fields of the entry with id.
+	Update(id string, entry Entry) error
+	// LoadMetadata returns the known entries' metadata keyed by diff hash.
+	LoadMetadata() (map[string]Metadata, error)
+	// WriteGenerated saves the entry generated for a commit along with the
+	// metadata used to deduplicate it, and returns its id.
+	WriteGenerated(meta Metadata) (string, error)
+	// UpdateCommit points the entry generated with diffHash at a new commit,
+	// e.g. after a rebase.
+	UpdateCommit(diffHash, commitHash string) error
+}
+
+// FSStore stores entries as markdown files in a .changes directory, with
+// generate metadata under the directory described by Metadata.
+type FSStore struct {
+	Dir      string
+	Metadata MetadataConfig
+}
+
+// NewFSStore returns a store for the .changes-style directory dir.
+func NewFSStore(dir string) *FSStore {
+	return &FSStore{Dir: dir}
+}
+
+// Write creates a timestamped entry file (see [Write]) and returns its filename.
+func (s *FSStore) Write(entry Entry) (string, error) {
+	path, err := Write(s.Dir, entry)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Base(path), nil
+}
+
+// List reads every entry file in the directory.
+func (s *FSStore) List() ([]EntryWithFile, error) {
+	return List(s.Dir)
+}
+
+// Delete removes the entry file named id.
+func (s *FSStore) Delete(id string) error {
+	return Delete(s.Dir, id)
+}
+
+// Update rewrites the entry file named id, keeping unknown frontmatter keys.
+func (s *FSStore) Update(id string, entry Entry) error {
+	return Update(s.Dir, id, entry)
+}
+
+// UpdateAndRename updates the entry file named id and renames it to match
+// the new summary (see [UpdateAndRename]). Returns the entry's new id.
+func (s *FSStore) UpdateAndRename(id string, entry Entry) (string, error) {
+	return UpdateAndRename(s.Dir, id, entry)
+}
+
+// LoadMetadata reads the JSON metadata written by generate.
+func (s *FSStore) LoadMetadata() (map[string]Metadata, error) {
+	return s.Metadata.Load(s.Dir)
+}
+
+// WriteGenerated writes a <diffHash7>-<slug>.md entry file and its metadata
+// (see [WriteWithMetadataConfig]) and returns its filename.
+func (s *FSStore) WriteGenerated(meta Metadata) (string, error) {
+	path, err := WriteWithMetadataConfig(s.Dir, meta, s.Metadata)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Base(path), nil
+}
+
+// UpdateCommit records the new commit in the entry's metadata (see
+// [MetadataConfig.UpdateCommit]).
+func (s *FSStore) UpdateCommit(diffHash, commitHash string) error {
+	return s.Metadata.UpdateCommit(s.Dir, diffHash, commitHash)
+}
+
+// Path returns the file path of the entry with id.
+func (s *FSStore) Path(id string) string {
+	return filepath.Join(s.Dir, id)
+}
+
+// ConsolidatedStore stores every entry in one YAML file such as news.yaml.
+//
+// Entries are identified by position as <base>#<n>, counting from 1, so ids
+// after a deleted entry shift down by one.
+type ConsolidatedStore struct {
+	Path string
+}
+
+// NewConsolidatedStore returns a store for the consolidated file at path.
+func NewConsolidatedStore(path string) *ConsolidatedStore {
+	return &ConsolidatedStore{Path: path}
+}
+
+// Write appends entry to the file and returns its id.
+func (s *ConsolidatedStore) Write(entry Entry) (string, error) {
+	entries, err := ReadConsolidated(s.Path)
+	if err != nil {
+		return "", err
+	}
+	entries = append(entries, entry)
+	if err := WriteConsolidated(s.Path, entries); err != nil {
+		return "", err
+	}
+	return s.id(len(entries) - 1), nil
+}
+
+// List returns the file's entries with positional ids.
+func (s *ConsolidatedStore) List() ([]EntryWithFile, error) {
+	entries, err := ReadConsolidated(s.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]EntryWithFile, 0, len(entries))
+	for i, entry := range entries {
+		results = append(results, EntryWithFile{Entry: entry, Filename: s.id(i)})
+	}
+	return results, nil
+}
+
+// Delete removes the entry with id from the file.
+func (s *ConsolidatedStore) Delete(id string) error {
+	entries, index, err := s.lookup(id)
+	if err != nil {
+		return err
+	}
+	return WriteConsolidated(s.Path, append(entries[:index], entries[index+1:]...))
+}
+
+// Update replaces the entry with id.
+func (s *ConsolidatedStore) Update(id string, entry Entry) error {
+	entries, index, err := s.lookup(id)
+	if err != nil {
+		return err
+	}
+	entries[index] = entry
+	return WriteConsolidated(s.Path, entries)
+}
+
+// LoadMetadata indexes the file's entries by diff hash (see [ConsolidatedMetadata]).
+func (s *ConsolidatedStore) LoadMetadata() (map[string]Metadata, error) {
+	return ConsolidatedMetadata(s.Path)
+}
+
+// WriteGenerated appends the entry for meta and returns its id. An entry
+// already in the file with the same diff hash is kept, and its id returned.
+func (s *ConsolidatedStore) WriteGenerated(meta Metadata) (string, error) {
+	entries, err := ReadConsolidated(s.Path)
+	if err != nil {
+		return "", err
+	}
+	for i, entry := range entries {
+		if entry.DiffHash == meta.DiffHash {
+			return s.id(i), nil
+		}
+	}
+	return s.Write(meta.Entry())
+}
+
+// UpdateCommit rewrites the commit of the entry with diffHash (see
+// [UpdateConsolidatedCommit]).
+func (s *ConsolidatedStore) UpdateCommit(diffHash, commitHash string) error {
+	return UpdateConsolidatedCommit(s.Path, diffHash, commitHash)
+}
+
+// id formats the id of the entry at index.
+func (s *ConsolidatedStore) id(index int) string {
+	return fmt.Sprintf("%s#%d", filepath.Base(s.Path), index+1)
+}
+
+// lookup reads the file and resolves id to an index into its entries.
+func (s *ConsolidatedStore) lookup(id string) ([]Entry, int, error) {
+	entries, err := ReadConsolidated(s.Path)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	base, position, ok := strings.Cut(id, "#")
+	n, convErr := strconv.Atoi(position)
+	if !ok || convErr != nil || base != filepath.Base(s.Path) || n < 1 || n > len(entries) {
+		return nil, 0, fmt.Errorf("entry %s does not exist", id)
+	}
+	return entries, n - 1, nil
+}
+
+// MemoryStore keeps entries in memory. It backs tests and previews that must
+// not touch the filesystem.
+type MemoryStore struct {
+	ids     []string
+	entries map[string]Entry
+	next    int
+}
+
+// NewMemoryStore returns a store holding entries, in order.
+func NewMemoryStore(entries ...Entry) *MemoryStore {
+	s := &MemoryStore{entries: make(map[string]Entry)}
+	for _, entry := range entries {
+		s.Write(entry)
+	}
+	return s
+}
+
+// Write stores entry under a new id of the form entry-<n>.md.
+func (s *MemoryStore) Write(entry Entry) (string, error) {
+	s.next++
+	id := fmt.Sprintf("entry-%d.md", s.next)
+	s.ids = append(s.ids, id)
+	s.entries[id] = entry
+	return id, nil
+}
+
+// List returns the stored entries in insertion order.
+func (s *MemoryStore) List() ([]EntryWithFile, error) {
+	results := make([]EntryWithFile, 0, len(s.ids))
+	for _, id := range s.ids {
+		results = append(results, EntryWithFile{Entry: s.entries[id], Filename: id})
+	}
+	return results, nil
+}
+
+// Delete removes the entry with id.
+func (s *MemoryStore) Delete(id string) error {
+	if _, ok := s.entries[id]; !ok {
+		return fmt.Errorf("entry %s does not exist", id)
+	}
+	delete(s.entries, id)
+	for i, existing := range s.ids {
+		if existing == id {
+			s.ids = append(s.ids[:i], s.ids[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// Update replaces the entry with id.
+func (s *MemoryStore) Update(id string, entry Entry) error {
+	if _, ok := s.entries[id]; !ok {
+		return fmt.Errorf("entry %s does not exist", id)
+	}
+	s.entries[id] = entry
+	return nil
+}
+
+// LoadMetadata derives metadata from the stored entries' frontmatter fields,
+// like [MetadataFromEntries].
+func (s *MemoryStore) LoadMetadata() (map[string]Metadata, error) {
+	entries, _ := s.List()
+	return metadataFromEntries(entries), nil
+}
+
+// WriteGenerated stores the entry for meta under a new id.
+func (s *MemoryStore) WriteGenerated(meta Metadata) (string, error) {
+	return s.Write(meta.Entry())
+}
+
+// UpdateCommit sets the commit of the entry with diffHash.
+func (s *MemoryStore) UpdateCommit(diffHash, commitHash string) error {
+	for _, id := range s.ids {
+		entry := s.entries[id]
+		if entry.DiffHash == diffHash {
+			entry.CommitHash = commitHash
+			s.entries[id] = entry
+			return nil
+		}
+	}
+	return fmt.Errorf("no entry with diff hash %s", diffHash)
+}
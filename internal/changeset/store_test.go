@@ -0,0 +1,181 @@
+package changeset
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stormlightlabs/git-storm/internal/testutils"
+)
+
+// exerciseStore runs the same write/list/update/delete flow against any [Store].
+func exerciseStore(t *testing.T, store Store) {
+	t.Helper()
+
+	first, err := store.Write(Entry{Type: "added", Summary: "First"})
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	second, err := store.Write(Entry{Type: "fixed", Summary: "Second"})
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	entries, err := store.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 2)
+
+	if err := store.Update(first, Entry{Type: "changed", Summary: "First, revised"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if err := store.Delete(second); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	entries, err = store.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 1)
+	testutils.Expect.Equal(t, entries[0].Filename, first)
+	testutils.Expect.Equal(t, entries[0].Entry.Type, "changed")
+	testutils.Expect.Equal(t, entries[0].Entry.Summary, "First, revised")
+
+	if err := store.Delete("missing.md"); err == nil {
+		t.Error("Delete() of an unknown id should fail")
+	}
+	if err := store.Update("missing.md", Entry{Type: "added", Summary: "x"}); err == nil {
+		t.Error("Update() of an unknown id should fail")
+	}
+}
+
+func TestStores(t *testing.T) {
+	t.Run("fs", func(t *testing.T) {
+		exerciseStore(t, NewFSStore(t.TempDir()))
+	})
+	t.Run("consolidated", func(t *testing.T) {
+		exerciseStore(t, NewConsolidatedStore(filepath.Join(t.TempDir(), "news.yaml")))
+	})
+	t.Run("memory", func(t *testing.T) {
+		exerciseStore(t, NewMemoryStore())
+	})
+}
+
+func TestStores_MetadataFromEntries(t *testing.T) {
+	entry := Entry{Type: "fixed", Summary: "Hashed", DiffHash: "abc123", CommitHash: "deadbeef"}
+	for name, store := range map[string]Store{
+		"consolidated": NewConsolidatedStore(filepath.Join(t.TempDir(), "news.yaml")),
+		"memory":       NewMemoryStore(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := store.Write(Entry{Type: "added", Summary: "Unhashed"}); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if _, err := store.Write(entry); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+
+			metadata, err := store.LoadMetadata()
+			if err != nil {
+				t.Fatalf("LoadMetadata() error = %v", err)
+			}
+			testutils.Expect.Equal(t, len(metadata), 1)
+			testutils.Expect.Equal(t, metadata["abc123"].CommitHash, "deadbeef")
+		})
+	}
+}
+
+func TestFSStore_WritesEntryFiles(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFSStore(dir)
+
+	id, err := store.Write(Entry{Type: "added", Summary: "On disk"})
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	testutils.Expect.Equal(t, filepath.Dir(store.Path(id)), dir)
+	if _, err := os.Stat(store.Path(id)); err != nil {
+		t.Errorf("entry file should exist: %v", err)
+	}
+
+	if err := SaveMetadata(dir, Metadata{DiffHash: "d1", CommitHash: "c1", Filename: id}); err != nil {
+		t.Fatalf("SaveMetadata() error = %v", err)
+	}
+	metadata, err := store.LoadMetadata()
+	if err != nil {
+		t.Fatalf("LoadMetadata() error = %v", err)
+	}
+	testutils.Expect.Equal(t, metadata["d1"].Filename, id)
+}
+
+func TestConsolidatedStore_PositionalIDs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "news.yaml")
+	if err := WriteConsolidated(path, []Entry{
+		{Type: "added", Summary: "One"},
+		{Type: "fixed", Summary: "Two"},
+		{Type: "changed", Summary: "Three"},
+	}); err != nil {
+		t.Fatalf("WriteConsolidated() error = %v", err)
+	}
+
+	store := NewConsolidatedStore(path)
+	entries, err := store.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	testutils.Expect.Equal(t, entries[1].Filename, "news.yaml#2")
+
+	if err := store.Delete("news.yaml#1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	remaining, err := ReadConsolidated(path)
+	if err != nil {
+		t.Fatalf("ReadConsolidated() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(remaining), 2)
+	testutils.Expect.Equal(t, remaining[0].Summary, "Two", "later ids should shift down after a delete")
+
+	for _, id := range []string{"news.yaml#0", "news.yaml#3", "other.yaml#1", "news.yaml"} {
+		if err := store.Update(id, Entry{}); err == nil {
+			t.Errorf("Update(%q) should fail", id)
+		}
+	}
+}
+
+func TestStores_GeneratedEntries(t *testing.T) {
+	meta := Metadata{Type: "added", Summary: "Generated", DiffHash: "abc1234def", CommitHash: "c1", Author: "Dev"}
+	for name, store := range map[string]Store{
+		"fs":           NewFSStore(t.TempDir()),
+		"consolidated": NewConsolidatedStore(filepath.Join(t.TempDir(), "news.yaml")),
+		"memory":       NewMemoryStore(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			id, err := store.WriteGenerated(meta)
+			if err != nil {
+				t.Fatalf("WriteGenerated() error = %v", err)
+			}
+			entries, err := store.List()
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			testutils.Expect.Equal(t, len(entries), 1)
+			testutils.Expect.Equal(t, entries[0].Filename, id)
+			testutils.Expect.Equal(t, entries[0].Entry.DiffHash, meta.DiffHash)
+
+			if err := store.UpdateCommit(meta.DiffHash, "c2"); err != nil {
+				t.Fatalf("UpdateCommit() error = %v", err)
+			}
+			metadata, err := store.LoadMetadata()
+			if err != nil {
+				t.Fatalf("LoadMetadata() error = %v", err)
+			}
+			testutils.Expect.Equal(t, metadata[meta.DiffHash].CommitHash, "c2")
+
+			if err := store.UpdateCommit("missing", "c3"); err == nil {
+				t.Error("UpdateCommit() of an unknown diff hash should fail")
+			}
+		})
+	}
+}
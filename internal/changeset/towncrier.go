@@ -0,0 +1,85 @@
+package changeset
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// TowncrierTypes maps Towncrier's default fragment types to the change types
+// they become. Any other fragment type is used as-is, so projects can
+// register custom Towncrier types under the same name.
+var TowncrierTypes = map[string]string{
+	"feature": "added",
+	"bugfix":  "fixed",
+	"doc":     "changed",
+	"removal": "removed",
+	"misc":    "changed",
+}
+
+// towncrierFilenameRegex matches Towncrier fragment names:
+// <issue>.<type>[.<counter>].md, where an issue starting with "+" is an orphan
+// fragment not tied to any issue.
+var towncrierFilenameRegex = regexp.MustCompile(`^(\+?[^.]+)\.([A-Za-z][\w-]*)(?:\.\d+)?\.md$`)
+
+// isTowncrierFragment reports whether a file is a Towncrier fragment: named
+// <issue>.<type>.md and holding plain text rather than YAML frontmatter.
+func isTowncrierFragment(name string, content []byte) bool {
+	return towncrierFilenameRegex.MatchString(name) && !bytes.HasPrefix(bytes.TrimSpace(content), []byte("---"))
+}
+
+// parseTowncrier builds an Entry from a Towncrier fragment. The type comes
+// from the filename through [TowncrierTypes], the summary is the first line of
+// the text, and any remaining text becomes the body. Fragments tied to an
+// issue reference it as "(#<issue>)" after the summary, like Towncrier does.
+func parseTowncrier(name string, content []byte) (Entry, error) {
+	match := towncrierFilenameRegex.FindStringSubmatch(name)
+	if match == nil {
+		return Entry{}, fmt.Errorf("invalid Towncrier fragment name %s: expected <issue>.<type>.md", name)
+	}
+	issue, fragmentType := match[1], strings.ToLower(match[2])
+
+	summary, body, _ := strings.Cut(strings.TrimSpace(string(content)), "\n")
+	summary = strings.TrimSpace(summary)
+	if summary == "" {
+		return Entry{}, fmt.Errorf("summary is required")
+	}
+	if !strings.HasPrefix(issue, "+") {
+		summary = fmt.Sprintf("%s (#%s)", summary, issue)
+	}
+
+	if mapped, ok := TowncrierTypes[fragmentType]; ok {
+		fragmentType = mapped
+	}
+	return Entry{Type: fragmentType, Summary: summary, Body: strings.TrimSpace(body)}, nil
+}
+
+// parseEntryFile parses an entry file in either supported format: YAML
+// frontmatter (see [parseEntry]) or a Towncrier fragment.
+func parseEntryFile(name string, content []byte) (Entry, error) {
+	if isTowncrierFragment(name, content) {
+		return parseTowncrier(name, content)
+	}
+	return parseEntry(content)
+}
+
+// validateTowncrier checks a Towncrier fragment: it must parse, and its
+// mapped type must be one of types' names, or of [DefaultTypes] when types
+// is nil.
+func validateTowncrier(name string, content []byte, types *Registry) []SchemaViolation {
+	entry, err := parseTowncrier(name, content)
+	if err != nil {
+		return []SchemaViolation{{Message: err.Error()}}
+	}
+	if types == nil {
+		types = DefaultRegistry()
+	}
+	if !types.Has(entry.Type) {
+		return []SchemaViolation{{
+			Field:   "type",
+			Message: fmt.Sprintf("type must be one of [%s]", strings.Join(types.Names(), ", ")),
+		}}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package changeset
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stormlightlabs/git-storm/internal/testutils"
+)
+
+func TestParseTowncrier(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    Entry
+		wantErr bool
+	}{
+		{
+			name:    "123.feature.md",
+			content: "Add OAuth login.\n",
+			want:    Entry{Type: "added", Summary: "Add OAuth login. (#123)"},
+		},
+		{
+			name:    "45.bugfix.1.md",
+			content: "Fix crash on empty input.\n\nThe parser now returns an error instead.\n",
+			want:    Entry{Type: "fixed", Summary: "Fix crash on empty input. (#45)", Body: "The parser now returns an error instead."},
+		},
+		{
+			name:    "+cleanup.misc.md",
+			content: "Tidy the build scripts.",
+			want:    Entry{Type: "changed", Summary: "Tidy the build scripts."},
+		},
+		{
+			name:    "7.security.md",
+			content: "Escape user input.",
+			want:    Entry{Type: "security", Summary: "Escape user input. (#7)"},
+		},
+		{name: "8.feature.md", content: "\n\n", wantErr: true},
+		{name: "feature.md", content: "No issue or type.", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTowncrier(tt.name, []byte(tt.content))
+			if tt.wantErr {
+				testutils.Expect.True(t, err != nil, "expected an error")
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseTowncrier() error = %v", err)
+			}
+			testutils.Expect.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestList_Towncrier(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"123.feature.md":             "Add OAuth login.\n",
+		"20240101-120000-fix-bug.md": "---\ntype: fixed\nsummary: Fix bug\n---\n",
+		// Frontmatter wins even when the name looks like a fragment.
+		"9.removal.md": "---\ntype: deprecated\nsummary: Deprecate v1 API\n---\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	entries, err := List(dir)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(entries), 3)
+
+	byFile := make(map[string]Entry)
+	for _, e := range entries {
+		byFile[e.Filename] = e.Entry
+	}
+	testutils.Expect.Equal(t, byFile["123.feature.md"], Entry{Type: "added", Summary: "Add OAuth login. (#123)"})
+	testutils.Expect.Equal(t, byFile["20240101-120000-fix-bug.md"].Type, "fixed")
+	testutils.Expect.Equal(t, byFile["9.removal.md"].Type, "deprecated")
+}
+
+func TestValidateDir_Towncrier(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"1.feature.md": "Good fragment.",
+		"2.unknown.md": "Unregistered type.",
+		"3.bugfix.md":  "   ",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	results, err := ValidateDir(dir, nil)
+	if err != nil {
+		t.Fatalf("ValidateDir() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(results), 2)
+	testutils.Expect.Equal(t, results["2.unknown.md"][0].Field, "type")
+	testutils.Expect.Equal(t, results["3.bugfix.md"][0].Message, "summary is required")
+
+	violations, err := ValidateFile(filepath.Join(dir, "1.feature.md"), nil)
+	if err != nil {
+		t.Fatalf("ValidateFile() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(violations), 0)
+}
@@ -0,0 +1,163 @@
+package changeset
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChangeType is a kind of change an entry can record. Each type renders as its
+// own changelog section.
+type ChangeType struct {
+	// Name is the value of an entry's type field, e.g. "added".
+	Name string `yaml:"name" toml:"name"`
+	// Title is the section heading; the capitalized name is used when empty.
+	Title string `yaml:"title" toml:"title"`
+}
+
+// UnmarshalYAML accepts either a bare type name or a {name, title} mapping.
+func (t *ChangeType) UnmarshalYAML(unmarshal func(any) error) error {
+	var name string
+	if err := unmarshal(&name); err == nil {
+		*t = ChangeType{Name: name}
+		return nil
+	}
+
+	type plain ChangeType
+	var full plain
+	if err := unmarshal(&full); err != nil {
+		return err
+	}
+	*t = ChangeType(full)
+	return nil
+}
+
+// UnmarshalTOML accepts either a bare type name or a {name, title} table.
+func (t *ChangeType) UnmarshalTOML(value any) error {
+	switch v := value.(type) {
+	case string:
+		*t = ChangeType{Name: v}
+		return nil
+	case map[string]any:
+		var full ChangeType
+		for key, field := range v {
+			s, ok := field.(string)
+			if !ok {
+				return fmt.Errorf("change type %s must be a string", key)
+			}
+			switch key {
+			case "name":
+				full.Name = s
+			case "title":
+				full.Title = s
+			default:
+				return fmt.Errorf("unknown change type field %q", key)
+			}
+		}
+		*t = full
+		return nil
+	default:
+		return fmt.Errorf("change type must be a name or a {name, title} table, got %T", value)
+	}
+}
+
+// DefaultTypes are the Keep a Changelog change types, in section order.
+var DefaultTypes = []ChangeType{
+	{Name: "added", Title: "Added"},
+	{Name: "changed", Title: "Changed"},
+	{Name: "deprecated", Title: "Deprecated"},
+	{Name: "removed", Title: "Removed"},
+	{Name: "fixed", Title: "Fixed"},
+	{Name: "security", Title: "Security"},
+}
+
+// Registry is the ordered set of change types a project accepts. Its order is
+// the order sections render in.
+type Registry struct {
+	types []ChangeType
+}
+
+// DefaultRegistry returns a registry of [DefaultTypes].
+func DefaultRegistry() *Registry {
+	r, _ := NewRegistry(nil)
+	return r
+}
+
+// NewRegistry builds a registry from types, or from [DefaultTypes] when types
+// is empty. Names are lowercased; empty and duplicate names are rejected.
+func NewRegistry(types []ChangeType) (*Registry, error) {
+	if len(types) == 0 {
+		types = DefaultTypes
+	}
+
+	r := &Registry{types: make([]ChangeType, 0, len(types))}
+	for _, t := range types {
+		t.Name = strings.ToLower(strings.TrimSpace(t.Name))
+		t.Title = strings.TrimSpace(t.Title)
+		if t.Name == "" {
+			return nil, fmt.Errorf("change type name cannot be empty")
+		}
+		if r.Has(t.Name) {
+			return nil, fmt.Errorf("duplicate change type %q", t.Name)
+		}
+		if t.Title == "" {
+			t.Title = strings.ToUpper(t.Name[:1]) + t.Name[1:]
+		}
+		r.types = append(r.types, t)
+	}
+	return r, nil
+}
+
+// ParseTypes parses --types values of the form name or name=Title.
+func ParseTypes(values []string) ([]ChangeType, error) {
+	var types []ChangeType
+	for _, value := range values {
+		name, title, _ := strings.Cut(value, "=")
+		if strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid change type %q: expected name or name=Title", value)
+		}
+		types = append(types, ChangeType{Name: name, Title: title})
+	}
+	return types, nil
+}
+
+// Types returns the registered types in order.
+func (r *Registry) Types() []ChangeType {
+	return append([]ChangeType(nil), r.types...)
+}
+
+// Names returns the registered type names in order.
+func (r *Registry) Names() []string {
+	names := make([]string, len(r.types))
+	for i, t := range r.types {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// Has reports whether name is a registered type.
+func (r *Registry) Has(name string) bool {
+	for _, t := range r.types {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Title returns the section title of name, or "" when it isn't registered.
+func (r *Registry) Title(name string) string {
+	for _, t := range r.types {
+		if t.Name == name {
+			return t.Title
+		}
+	}
+	return ""
+}
+
+// Validate returns an error naming the accepted types when name isn't registered.
+func (r *Registry) Validate(name string) error {
+	if r.Has(name) {
+		return nil
+	}
+	return fmt.Errorf("invalid type %q: must be one of %s", name, strings.Join(r.Names(), ", "))
+}
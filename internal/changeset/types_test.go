@@ -0,0 +1,61 @@
+package changeset
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stormlightlabs/git-storm/internal/testutils"
+)
+
+func TestDefaultRegistry(t *testing.T) {
+	r := DefaultRegistry()
+	testutils.Expect.Equal(t, strings.Join(r.Names(), ","), "added,changed,deprecated,removed,fixed,security")
+	testutils.Expect.Equal(t, r.Title("security"), "Security")
+	testutils.Expect.True(t, r.Has("deprecated"))
+	testutils.Expect.False(t, r.Has("docs"))
+}
+
+func TestNewRegistry(t *testing.T) {
+	r, err := NewRegistry([]ChangeType{{Name: " Perf ", Title: "Performance"}, {Name: "docs"}})
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+	testutils.Expect.Equal(t, strings.Join(r.Names(), ","), "perf,docs")
+	testutils.Expect.Equal(t, r.Title("perf"), "Performance")
+	testutils.Expect.Equal(t, r.Title("docs"), "Docs", "a missing title should be derived from the name")
+
+	err = r.Validate("added")
+	if err == nil || !strings.Contains(err.Error(), "must be one of perf, docs") {
+		t.Errorf("Validate() should list the registered types, got %v", err)
+	}
+
+	for _, types := range [][]ChangeType{
+		{{Name: "added"}, {Name: "ADDED"}},
+		{{Name: " "}},
+	} {
+		if _, err := NewRegistry(types); err == nil {
+			t.Errorf("NewRegistry(%v) expected error", types)
+		}
+	}
+}
+
+func TestParseTypes(t *testing.T) {
+	types, err := ParseTypes([]string{"added", "perf=Performance Improvements"})
+	if err != nil {
+		t.Fatalf("ParseTypes() error = %v", err)
+	}
+	testutils.Expect.Equal(t, types, []ChangeType{{Name: "added"}, {Name: "perf", Title: "Performance Improvements"}})
+
+	if _, err := ParseTypes([]string{"=Title"}); err == nil {
+		t.Error("ParseTypes() should reject a missing name")
+	}
+}
+
+func TestChangeType_UnmarshalYAML(t *testing.T) {
+	var types []ChangeType
+	if err := yaml.Unmarshal([]byte("- added\n- name: perf\n  title: Performance\n"), &types); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	testutils.Expect.Equal(t, types, []ChangeType{{Name: "added"}, {Name: "perf", Title: "Performance"}})
+}
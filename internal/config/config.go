@@ -0,0 +1,185 @@
+// Package config loads project-level defaults from a .storm.yaml or
+// .storm.toml file in the repository root.
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/goccy/go-yaml"
+	"github.com/stormlightlabs/git-storm/internal/changelog"
+	"github.com/stormlightlabs/git-storm/internal/changeset"
+)
+
+// Entry formats accepted in the format key.
+const (
+	// FormatStorm is storm's own .changes layout.
+	FormatStorm = "storm"
+	// FormatChangesets reads and writes a .changeset directory kept by the JS
+	// changesets tool; see [changeset.ChangesetsStore].
+	FormatChangesets = "changesets"
+)
+
+// Filenames lists the config files [Load] looks for, in order of precedence.
+var Filenames = []string{".storm.yaml", ".storm.yml", ".storm.toml"}
+
+// Config holds project defaults shared by every command. Zero values mean
+// "use the built-in default".
+type Config struct {
+	// Repo is the default for --repo.
+	Repo string `yaml:"repo" toml:"repo"`
+	// Output is the default for --output.
+	Output string `yaml:"output" toml:"output"`
+	// ChangesDir is where unreleased entries live instead of .changes.
+	ChangesDir string `yaml:"changes_dir" toml:"changes_dir"`
+	// Format is the layout of unreleased entries: [FormatStorm] (the default)
+	// or [FormatChangesets].
+	Format string `yaml:"format" toml:"format"`
+	// Types replaces [changeset.DefaultTypes] as the change types entries may
+	// use, in section order. Each is a name or a {name, title} mapping.
+	Types []changeset.ChangeType `yaml:"types" toml:"types"`
+	// Header replaces the preamble written above the first version.
+	Header string `yaml:"header" toml:"header"`
+	// Template is a text/template file laying out CHANGELOG.md, the default
+	// for release --changelog-template. [Load] resolves it against the
+	// config file's directory.
+	Template string `yaml:"template" toml:"template"`
+	// InferScope makes generate infer missing scopes from the paths a commit
+	// changed, like its --infer-scope flag.
+	InferScope bool `yaml:"infer_scope" toml:"infer_scope"`
+	// ScopeMap maps path prefixes to the scopes inferred for them, e.g.
+	// "internal/diff/" to "differ", like generate's --scope-map.
+	ScopeMap map[string]string `yaml:"scope_map" toml:"scope_map"`
+	// Categories maps conventional commit types (feat, perf, ...) to change
+	// types, overriding the built-in mapping. An empty value skips the type.
+	Categories map[string]string `yaml:"categories" toml:"categories"`
+	// Hosts maps self-hosted git hostnames to their forge (github, gitlab,
+	// bitbucket, or gitea) so changelog links can be generated for them.
+	Hosts map[string]string `yaml:"hosts" toml:"hosts"`
+	// Packages splits a monorepo into packages released on their own.
+	Packages []Package `yaml:"packages" toml:"packages"`
+	// Hooks are shell commands run around storm release.
+	Hooks Hooks `yaml:"hooks" toml:"hooks"`
+	// Lint configures storm check --lint and --commit-msg.
+	Lint Lint `yaml:"lint" toml:"lint"`
+
+	// Path is the file the config was loaded from; empty when none was found.
+	Path string `yaml:"-" toml:"-"`
+}
+
+// Hooks holds the shell commands storm release runs from the repository root.
+type Hooks struct {
+	// PreRelease runs before anything is written; a failure aborts the release.
+	PreRelease string `yaml:"pre_release" toml:"pre_release"`
+	// PostRelease runs once the release, tag, and pushes are done.
+	PostRelease string `yaml:"post_release" toml:"post_release"`
+}
+
+// Lint holds the commit message rules storm check enforces. Zero lengths use
+// the defaults of 72 characters per subject and 100 per body line.
+type Lint struct {
+	// Types lists the allowed commit types. It defaults to the conventional
+	// types plus any keys of Categories.
+	Types []string `yaml:"types" toml:"types"`
+	// Scopes lists the allowed scopes; empty allows any scope.
+	Scopes []string `yaml:"scopes" toml:"scopes"`
+	// MaxSubjectLength caps the subject line; -1 disables the check.
+	MaxSubjectLength int `yaml:"max_subject_length" toml:"max_subject_length"`
+	// MaxBodyLineLength caps each body line; -1 disables the check.
+	MaxBodyLineLength int `yaml:"max_body_line_length" toml:"max_body_line_length"`
+	// RequiredFooters lists footers every commit needs, e.g. "Signed-off-by".
+	RequiredFooters []string `yaml:"required_footers" toml:"required_footers"`
+}
+
+// Load reads the first of [Filenames] present in dir. A missing file is not an
+// error and yields the zero Config.
+func Load(dir string) (Config, error) {
+	for _, name := range Filenames {
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return Config{}, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		cfg, err := Parse(data, filepath.Ext(name))
+		if err != nil {
+			return Config{}, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		cfg.Path = path
+		if cfg.Template != "" && !filepath.IsAbs(cfg.Template) {
+			cfg.Template = filepath.Join(dir, cfg.Template)
+		}
+		return cfg, nil
+	}
+	return Config{}, nil
+}
+
+// Parse decodes config data in the format named by ext (".yaml", ".yml", or ".toml").
+// Unknown keys are rejected so typos don't silently fall back to defaults.
+func Parse(data []byte, ext string) (Config, error) {
+	var cfg Config
+	switch strings.ToLower(ext) {
+	case ".yaml", ".yml":
+		if len(bytes.TrimSpace(data)) == 0 {
+			return cfg, nil
+		}
+		if err := yaml.UnmarshalWithOptions(data, &cfg, yaml.DisallowUnknownField()); err != nil {
+			return Config{}, err
+		}
+	case ".toml":
+		meta, err := toml.Decode(string(data), &cfg)
+		if err != nil {
+			return Config{}, err
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return Config{}, fmt.Errorf("unknown field %q", undecoded[0].String())
+		}
+	default:
+		return Config{}, fmt.Errorf("unsupported config format %q", ext)
+	}
+
+	switch cfg.Format {
+	case "", FormatStorm, FormatChangesets:
+	default:
+		return Config{}, fmt.Errorf("unknown format %q: must be %s or %s", cfg.Format, FormatStorm, FormatChangesets)
+	}
+	if _, err := cfg.Registry(); err != nil {
+		return Config{}, err
+	}
+	if _, err := cfg.Forges(); err != nil {
+		return Config{}, err
+	}
+	if err := validatePackages(cfg.Packages); err != nil {
+		return Config{}, err
+	}
+	cfg.Header = strings.TrimSpace(cfg.Header)
+	return cfg, nil
+}
+
+// Registry returns the configured change types, or the defaults when none are set.
+func (c Config) Registry() (*changeset.Registry, error) {
+	return changeset.NewRegistry(c.Types)
+}
+
+// Forges returns the configured hosts keyed by lowercased hostname.
+func (c Config) Forges() (map[string]changelog.Forge, error) {
+	if len(c.Hosts) == 0 {
+		return nil, nil
+	}
+	forges := make(map[string]changelog.Forge, len(c.Hosts))
+	for host, name := range c.Hosts {
+		forge, err := changelog.ParseForge(name)
+		if err != nil {
+			return nil, fmt.Errorf("host %s: %w", host, err)
+		}
+		forges[strings.ToLower(host)] = forge
+	}
+	return forges, nil
+}
@@ -0,0 +1,197 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/git-storm/internal/changelog"
+	"github.com/stormlightlabs/git-storm/internal/changeset"
+	"github.com/stormlightlabs/git-storm/internal/testutils"
+)
+
+func TestLoad_YAML(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, filepath.Join(dir, ".storm.yaml"), `output: docs/CHANGELOG.md
+changes_dir: changes
+types:
+  - added
+  - fixed
+  - name: perf
+    title: Performance
+header: |
+  # Release Notes
+
+  Everything that shipped.
+categories:
+  docs: docs
+  chore: ""
+hosts:
+  Git.Example.com: gitlab
+hooks:
+  pre_release: make test
+template: templates/CHANGELOG.tmpl
+infer_scope: true
+scope_map:
+  internal/diff/: differ
+`)
+
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	testutils.Expect.Equal(t, cfg.Path, filepath.Join(dir, ".storm.yaml"))
+	testutils.Expect.Equal(t, cfg.Output, "docs/CHANGELOG.md")
+	testutils.Expect.Equal(t, cfg.ChangesDir, "changes")
+	registry, err := cfg.Registry()
+	if err != nil {
+		t.Fatalf("Registry() error = %v", err)
+	}
+	testutils.Expect.Equal(t, strings.Join(registry.Names(), ","), "added,fixed,perf")
+	testutils.Expect.Equal(t, registry.Title("perf"), "Performance")
+	testutils.Expect.Equal(t, cfg.Header, "# Release Notes\n\nEverything that shipped.")
+	testutils.Expect.Equal(t, cfg.Categories["docs"], "docs")
+	category, ok := cfg.Categories["chore"]
+	testutils.Expect.True(t, ok && category == "", "an empty category should be kept to skip the type")
+	forges, err := cfg.Forges()
+	if err != nil {
+		t.Fatalf("Forges() error = %v", err)
+	}
+	testutils.Expect.Equal(t, forges, map[string]changelog.Forge{"git.example.com": changelog.ForgeGitLab})
+	testutils.Expect.Equal(t, cfg.Hooks, Hooks{PreRelease: "make test"})
+	testutils.Expect.Equal(t, cfg.Template, filepath.Join(dir, "templates", "CHANGELOG.tmpl"), "the template path should be relative to the config file")
+	testutils.Expect.True(t, cfg.InferScope)
+	testutils.Expect.Equal(t, cfg.ScopeMap, map[string]string{"internal/diff/": "differ"})
+}
+
+func TestLoad_TOML(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, filepath.Join(dir, ".storm.toml"), `# storm settings
+repo = "."
+output = 'CHANGES.md'   # literal string
+types = [
+  "added",
+  "fixed", # trailing comma below
+]
+header = """
+# Release Notes
+Tab:\tdone"""
+
+[categories]
+perf = "fixed"
+"build" = "changed"
+
+[hooks]
+post_release = "./scripts/announce.sh"
+
+[lint]
+scopes = ["cli", "core"]
+max_subject_length = -1
+required_footers = ["Signed-off-by"]
+`)
+
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	testutils.Expect.Equal(t, cfg.Repo, ".")
+	testutils.Expect.Equal(t, cfg.Output, "CHANGES.md")
+	testutils.Expect.Equal(t, len(cfg.Types), 2)
+	testutils.Expect.Equal(t, cfg.Types[1].Name, "fixed")
+	testutils.Expect.Equal(t, cfg.Header, "# Release Notes\nTab:\tdone")
+	testutils.Expect.Equal(t, cfg.Categories["perf"], "fixed")
+	testutils.Expect.Equal(t, cfg.Categories["build"], "changed")
+	testutils.Expect.Equal(t, cfg.Hooks.PostRelease, "./scripts/announce.sh")
+	testutils.Expect.Equal(t, cfg.Lint, Lint{Scopes: []string{"cli", "core"}, MaxSubjectLength: -1, RequiredFooters: []string{"Signed-off-by"}})
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, filepath.Join(dir, ".storm.toml"), `output = "from-toml.md"`)
+	writeConfig(t, filepath.Join(dir, ".storm.yaml"), `output: from-yaml.md`)
+
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	testutils.Expect.Equal(t, cfg.Output, "from-yaml.md", ".storm.yaml should win over .storm.toml")
+}
+
+func TestLoad_Missing(t *testing.T) {
+	cfg, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	testutils.Expect.Equal(t, cfg.Path, "")
+	registry, err := cfg.Registry()
+	if err != nil {
+		t.Fatalf("Registry() error = %v", err)
+	}
+	testutils.Expect.Equal(t, strings.Join(registry.Names(), ","), strings.Join(changeset.DefaultRegistry().Names(), ","))
+}
+
+func TestParse_Errors(t *testing.T) {
+	cases := []struct {
+		name, ext, data string
+	}{
+		{"unknown yaml key", ".yaml", "outptu: CHANGELOG.md\n"},
+		{"unknown toml key", ".toml", "outptu = \"CHANGELOG.md\"\n"},
+		{"wrong type", ".yaml", "types: added\n"},
+		{"duplicate change type", ".yaml", "types: [added, Added]\n"},
+		{"duplicate toml key", ".toml", "output = \"a\"\noutput = \"b\"\n"},
+		{"unterminated string", ".toml", "output = \"CHANGELOG.md\n"},
+		{"missing equals", ".toml", "output \"CHANGELOG.md\"\n"},
+		{"unknown toml table", ".toml", "[[plugins]]\nname = \"x\"\n"},
+		{"package without paths", ".yaml", "packages:\n  - name: api\n"},
+		{"duplicate package", ".toml", "[[packages]]\nname = \"api\"\npaths = [\"api\"]\n[[packages]]\nname = \"api\"\npaths = [\"lib\"]\n"},
+		{"invalid package glob", ".yaml", "packages:\n  - name: api\n    paths: [\"api/[\"]\n"},
+		{"unknown change type field", ".toml", "types = [{ name = \"perf\", tilte = \"Performance\" }]\n"},
+		{"trailing garbage", ".toml", "output = \"a\" \"b\"\n"},
+		{"unknown forge", ".yaml", "hosts:\n  git.example.com: sourcehut\n"},
+		{"unknown entry format", ".yaml", "format: towncrier\n"},
+		{"unsupported format", ".json", "{}"},
+	}
+
+	for _, tc := range cases {
+		if _, err := Parse([]byte(tc.data), tc.ext); err == nil {
+			t.Errorf("%s: Parse() expected error", tc.name)
+		}
+	}
+}
+
+func TestParse_TOMLTypeTables(t *testing.T) {
+	cfg, err := Parse([]byte(`types = ["added"]
+
+[categories]
+perf = "performance"
+`), ".toml")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	testutils.Expect.Equal(t, cfg.Types[0].Name, "added")
+
+	cfg, err = Parse([]byte(`[[types]]
+name = "added"
+
+[[types]]
+name = "perf"
+title = "Performance"
+`), ".toml")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	registry, err := cfg.Registry()
+	if err != nil {
+		t.Fatalf("Registry() error = %v", err)
+	}
+	testutils.Expect.Equal(t, strings.Join(registry.Names(), ","), "added,perf")
+	testutils.Expect.Equal(t, registry.Title("perf"), "Performance")
+}
+
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
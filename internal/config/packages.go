@@ -0,0 +1,136 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Package is one package of a monorepo, with its own changelog and tags.
+type Package struct {
+	// Name identifies the package in entries' package field and prefixes its
+	// tags, e.g. "api" tags "api/v1.2.0".
+	Name string `yaml:"name" toml:"name"`
+	// Paths are globs of the directories and files the package owns, relative
+	// to the repository root, e.g. "services/api" or "libs/*/api/**". A glob
+	// matching a directory owns everything below it.
+	Paths []string `yaml:"paths" toml:"paths"`
+	// Changelog is the package's changelog relative to the repository root.
+	// It defaults to CHANGELOG.md in the directory Paths[0] starts with.
+	Changelog string `yaml:"changelog" toml:"changelog"`
+}
+
+// Owns reports whether file, a path relative to the repository root, belongs
+// to the package.
+func (p Package) Owns(file string) bool {
+	segments := strings.Split(path.Clean(file), "/")
+	for _, pattern := range p.Paths {
+		patternSegments := strings.Split(path.Clean(pattern), "/")
+		for i := len(segments); i > 0; i-- {
+			if matchSegments(patternSegments, segments[:i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ChangelogPath returns the package's changelog relative to the repository root.
+func (p Package) ChangelogPath() string {
+	if p.Changelog != "" {
+		return p.Changelog
+	}
+	var dir []string
+	for _, segment := range strings.Split(path.Clean(p.Paths[0]), "/") {
+		if strings.ContainsAny(segment, "*?[") {
+			break
+		}
+		dir = append(dir, segment)
+	}
+	if len(dir) == 0 {
+		dir = []string{p.Name}
+	}
+	return path.Join(append(dir, "CHANGELOG.md")...)
+}
+
+// TagName returns the package's tag for version, e.g. "api/v1.2.0".
+func (p Package) TagName(version string) string {
+	return fmt.Sprintf("%s/v%s", p.Name, version)
+}
+
+// Package returns the configured package called name.
+func (c Config) Package(name string) (Package, bool) {
+	for _, pkg := range c.Packages {
+		if pkg.Name == name {
+			return pkg, true
+		}
+	}
+	return Package{}, false
+}
+
+// PackageFor returns the name of the package owning most of paths, or ""
+// when none of them belong to a package. Ties go to the package listed first.
+func (c Config) PackageFor(paths []string) string {
+	best, bestCount := "", 0
+	for _, pkg := range c.Packages {
+		count := 0
+		for _, p := range paths {
+			if pkg.Owns(p) {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = pkg.Name, count
+		}
+	}
+	return best
+}
+
+// validatePackages checks that packages have unique names and valid globs.
+func validatePackages(packages []Package) error {
+	seen := make(map[string]bool, len(packages))
+	for _, pkg := range packages {
+		if strings.TrimSpace(pkg.Name) == "" {
+			return fmt.Errorf("package name cannot be empty")
+		}
+		if seen[pkg.Name] {
+			return fmt.Errorf("duplicate package %q", pkg.Name)
+		}
+		seen[pkg.Name] = true
+
+		if len(pkg.Paths) == 0 {
+			return fmt.Errorf("package %s: paths cannot be empty", pkg.Name)
+		}
+		for _, pattern := range pkg.Paths {
+			for _, segment := range strings.Split(pattern, "/") {
+				if _, err := path.Match(segment, ""); err != nil {
+					return fmt.Errorf("package %s: invalid path glob %q", pkg.Name, pattern)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// matchSegments matches path segments against glob segments, where "**"
+// matches any number of segments, including none.
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stormlightlabs/git-storm/internal/testutils"
+)
+
+func TestPackage_Owns(t *testing.T) {
+	pkg := Package{Name: "api", Paths: []string{"services/api", "libs/*/api/**", "proto/api.proto"}}
+
+	cases := map[string]bool{
+		"services/api/main.go":          true,
+		"services/api/handlers/user.go": true,
+		"services/apiv2/main.go":        false,
+		"libs/auth/api/client.go":       true,
+		"libs/auth/api/v1/client.go":    true,
+		"libs/auth/web/client.go":       false,
+		"proto/api.proto":               true,
+		"proto/web.proto":               false,
+		"README.md":                     false,
+	}
+	for file, want := range cases {
+		testutils.Expect.Equal(t, pkg.Owns(file), want, file)
+	}
+}
+
+func TestPackage_ChangelogPath(t *testing.T) {
+	testutils.Expect.Equal(t, Package{Name: "api", Paths: []string{"services/api/**"}}.ChangelogPath(), "services/api/CHANGELOG.md")
+	testutils.Expect.Equal(t, Package{Name: "api", Paths: []string{"**/api"}}.ChangelogPath(), "api/CHANGELOG.md")
+	testutils.Expect.Equal(t, Package{Name: "api", Paths: []string{"api"}, Changelog: "docs/API.md"}.ChangelogPath(), "docs/API.md")
+	testutils.Expect.Equal(t, Package{Name: "api"}.TagName("1.2.0"), "api/v1.2.0")
+}
+
+func TestConfig_PackageFor(t *testing.T) {
+	cfg, err := Parse([]byte(`packages:
+  - name: api
+    paths: [services/api]
+  - name: web
+    paths: [services/web]
+`), ".yaml")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	testutils.Expect.Equal(t, cfg.PackageFor([]string{"services/web/app.ts", "services/web/index.html", "services/api/main.go"}), "web")
+	testutils.Expect.Equal(t, cfg.PackageFor([]string{"services/api/main.go", "services/web/app.ts"}), "api", "ties go to the first package")
+	testutils.Expect.Equal(t, cfg.PackageFor([]string{"README.md"}), "")
+
+	pkg, ok := cfg.Package("web")
+	testutils.Expect.True(t, ok)
+	testutils.Expect.Equal(t, pkg.ChangelogPath(), "services/web/CHANGELOG.md")
+	_, ok = cfg.Package("docs")
+	testutils.Expect.False(t, ok)
+}
@@ -0,0 +1,47 @@
+package diff
+
+// ChangeRuns returns the bounds [start, end) of each maximal run of changed
+// edits: the blocks hunk navigation jumps between.
+func ChangeRuns(edits []Edit) [][2]int {
+	var runs [][2]int
+	for i := 0; i < len(edits); {
+		if edits[i].Kind == Equal {
+			i++
+			continue
+		}
+		start := i
+		for i < len(edits) && edits[i].Kind != Equal {
+			i++
+		}
+		runs = append(runs, [2]int{start, i})
+	}
+	return runs
+}
+
+// ApplySelected rebuilds lines from an edit script, taking the new side of each
+// change for which take reports true and the old side otherwise. Taking
+// every change yields the new sequence and taking none the old one.
+func ApplySelected(edits []Edit, take func(i int) bool) []string {
+	lines := make([]string, 0, len(edits))
+	for i, e := range edits {
+		switch e.Kind {
+		case Equal:
+			lines = append(lines, e.Content)
+		case Insert:
+			if take(i) {
+				lines = append(lines, e.Content)
+			}
+		case Delete:
+			if !take(i) {
+				lines = append(lines, e.Content)
+			}
+		case Replace:
+			if take(i) {
+				lines = append(lines, e.NewContent)
+			} else {
+				lines = append(lines, e.Content)
+			}
+		}
+	}
+	return lines
+}
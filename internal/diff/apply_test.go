@@ -0,0 +1,57 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangeRuns(t *testing.T) {
+	edits := []Edit{
+		{Kind: Delete, AIndex: 0, BIndex: -1, Content: "a"},
+		{Kind: Insert, AIndex: -1, BIndex: 0, Content: "A"},
+		{Kind: Equal, AIndex: 1, BIndex: 1, Content: "b"},
+		{Kind: Equal, AIndex: 2, BIndex: 2, Content: "c"},
+		{Kind: Replace, AIndex: 3, BIndex: 3, Content: "d", NewContent: "D"},
+	}
+	want := [][2]int{{0, 2}, {4, 5}}
+	if got := ChangeRuns(edits); !reflect.DeepEqual(got, want) {
+		t.Errorf("ChangeRuns() = %v, want %v", got, want)
+	}
+	if got := ChangeRuns(edits[2:4]); got != nil {
+		t.Errorf("ChangeRuns() without changes = %v, want nil", got)
+	}
+}
+
+func TestApplySelected(t *testing.T) {
+	a := []string{"one", "two", "three", "four"}
+	b := []string{"one", "2", "three", "four", "five"}
+	edits, err := (&Myers{}).Compute(a, b)
+	if err != nil {
+		t.Fatalf("Compute() error = %v", err)
+	}
+
+	all := func(int) bool { return true }
+	none := func(int) bool { return false }
+	if got := ApplySelected(edits, all); !reflect.DeepEqual(got, b) {
+		t.Errorf("ApplySelected(all) = %q, want %q", got, b)
+	}
+	if got := ApplySelected(edits, none); !reflect.DeepEqual(got, a) {
+		t.Errorf("ApplySelected(none) = %q, want %q", got, a)
+	}
+
+	runs := ChangeRuns(edits)
+	if len(runs) != 2 {
+		t.Fatalf("expected two change runs, got %v", runs)
+	}
+	last := runs[1]
+	got := ApplySelected(edits, func(i int) bool { return i >= last[0] && i < last[1] })
+	want := []string{"one", "two", "three", "four", "five"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ApplySelected(last run) = %q, want %q", got, want)
+	}
+
+	replaced := []Edit{{Kind: Replace, AIndex: 0, BIndex: 0, Content: "old", NewContent: "new"}}
+	if got := ApplySelected(replaced, all); !reflect.DeepEqual(got, []string{"new"}) {
+		t.Errorf("ApplySelected() of a replacement = %q, want [new]", got)
+	}
+}
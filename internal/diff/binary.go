@@ -0,0 +1,76 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// binarySniffLen is how much of a file [IsBinary] inspects for null bytes,
+// matching git's own heuristic.
+const binarySniffLen = 8000
+
+// hexDumpWidth is the number of bytes shown on each [HexDump] line.
+const hexDumpWidth = 16
+
+// IsBinary reports whether content looks like binary data rather than text:
+// it has a null byte near the start, as git checks, or is not valid UTF-8.
+// Splitting such content into lines produces a meaningless diff.
+func IsBinary(content string) bool {
+	if strings.IndexByte(content[:min(len(content), binarySniffLen)], 0) >= 0 {
+		return true
+	}
+	return !utf8.ValidString(content)
+}
+
+// BinarySummary describes a change to a binary file in place of its diff.
+func BinarySummary(oldSize, newSize int) string {
+	return fmt.Sprintf("Binary files differ (size %s → %s)", FormatSize(oldSize), FormatSize(newSize))
+}
+
+// FormatSize renders a byte count with a binary unit, e.g. "512 B" or "1.5 KiB".
+func FormatSize(n int) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value, exp := float64(n)/unit, 0
+	for value >= unit && exp < 3 {
+		value /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", value, "KMGT"[exp])
+}
+
+// HexDump renders content in the style of `hexdump -C`: an offset, sixteen
+// hex bytes, and their printable ASCII characters per line. Diffing two dumps
+// line by line gives a readable fallback for binary files.
+func HexDump(content string) string {
+	var sb strings.Builder
+	for offset := 0; offset < len(content); offset += hexDumpWidth {
+		chunk := content[offset:min(offset+hexDumpWidth, len(content))]
+
+		fmt.Fprintf(&sb, "%08x ", offset)
+		for i := range hexDumpWidth {
+			if i%8 == 0 {
+				sb.WriteByte(' ')
+			}
+			if i < len(chunk) {
+				fmt.Fprintf(&sb, "%02x ", chunk[i])
+			} else {
+				sb.WriteString("   ")
+			}
+		}
+
+		sb.WriteString(" |")
+		for i := range len(chunk) {
+			if c := chunk[i]; c >= 0x20 && c < 0x7f {
+				sb.WriteByte(c)
+			} else {
+				sb.WriteByte('.')
+			}
+		}
+		sb.WriteString("|\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,62 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsBinary(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{name: "empty", content: "", want: false},
+		{name: "text", content: "hello\nworld\n", want: false},
+		{name: "utf-8 text", content: "naïve → café\n", want: false},
+		{name: "null byte", content: "PNG\x00\x01", want: true},
+		{name: "invalid utf-8", content: "caf\xe9\n", want: true},
+		{name: "null byte past sniff window", content: strings.Repeat("a", binarySniffLen) + "\x00", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsBinary(tt.content); got != tt.want {
+				t.Errorf("IsBinary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := map[int]string{
+		0:               "0 B",
+		512:             "512 B",
+		1024:            "1.0 KiB",
+		1536:            "1.5 KiB",
+		5 * 1024 * 1024: "5.0 MiB",
+	}
+	for n, want := range tests {
+		if got := FormatSize(n); got != want {
+			t.Errorf("FormatSize(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestBinarySummary(t *testing.T) {
+	want := "Binary files differ (size 512 B → 1.5 KiB)"
+	if got := BinarySummary(512, 1536); got != want {
+		t.Errorf("BinarySummary() = %q, want %q", got, want)
+	}
+}
+
+func TestHexDump(t *testing.T) {
+	got := HexDump("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDRab")
+	want := "00000000  89 50 4e 47 0d 0a 1a 0a  00 00 00 0d 49 48 44 52  |.PNG........IHDR|\n" +
+		"00000010  61 62                                             |ab|\n"
+	if got != want {
+		t.Errorf("HexDump() =\n%s\nwant\n%s", got, want)
+	}
+	if HexDump("") != "" {
+		t.Error("HexDump() of empty content should be empty")
+	}
+}
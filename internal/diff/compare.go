@@ -0,0 +1,88 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Algorithms returns every diff algorithm available in this package.
+func Algorithms() []Diff {
+	return []Diff{&LCS{}, &Myers{}, &Histogram{}}
+}
+
+// AlgorithmResult holds one algorithm's output when comparing algorithms.
+type AlgorithmResult struct {
+	Name   string
+	Edits  []Edit
+	Counts map[EditKind]int
+	Valid  bool // whether applying Edits reproduces the new content
+	Err    error
+}
+
+// CompareAlgorithms runs each algorithm over a and b and summarizes the results.
+func CompareAlgorithms(a, b []string, algorithms []Diff) []AlgorithmResult {
+	results := make([]AlgorithmResult, 0, len(algorithms))
+	for _, algo := range algorithms {
+		result := AlgorithmResult{Name: algo.Name()}
+		result.Edits, result.Err = algo.Compute(a, b)
+		if result.Err == nil {
+			result.Counts = CountEditKinds(result.Edits)
+			result.Valid = slices.Equal(ApplyEdits(a, result.Edits), b)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// FormatComparison renders a plain-text report of per-algorithm edit counts,
+// noting where each edit script diverges from the first algorithm's.
+func FormatComparison(results []AlgorithmResult) string {
+	var sb strings.Builder
+	kinds := []EditKind{Equal, Insert, Delete, Replace}
+
+	fmt.Fprintf(&sb, "%-10s %7s %7s %7s %7s %7s  %s\n", "Algorithm", "Total", "Equal", "Insert", "Delete", "Replace", "Valid")
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Fprintf(&sb, "%-10s error: %v\n", result.Name, result.Err)
+			continue
+		}
+		fmt.Fprintf(&sb, "%-10s %7d", result.Name, len(result.Edits))
+		for _, kind := range kinds {
+			fmt.Fprintf(&sb, " %7d", result.Counts[kind])
+		}
+		fmt.Fprintf(&sb, "  %t\n", result.Valid)
+	}
+
+	if len(results) < 2 || results[0].Err != nil {
+		return sb.String()
+	}
+
+	sb.WriteString("\n")
+	base := results[0]
+	for _, result := range results[1:] {
+		if result.Err != nil {
+			continue
+		}
+		if idx := firstDifference(base.Edits, result.Edits); idx < 0 {
+			fmt.Fprintf(&sb, "%s produces the same edit script as %s\n", result.Name, base.Name)
+		} else {
+			fmt.Fprintf(&sb, "%s differs from %s starting at edit %d\n", result.Name, base.Name, idx)
+		}
+	}
+	return sb.String()
+}
+
+// firstDifference returns the index of the first differing edit, or -1 if equal.
+func firstDifference(a, b []Edit) int {
+	for i := range min(len(a), len(b)) {
+		if !reflect.DeepEqual(a[i], b[i]) {
+			return i
+		}
+	}
+	if len(a) != len(b) {
+		return min(len(a), len(b))
+	}
+	return -1
+}
@@ -0,0 +1,59 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompareAlgorithms(t *testing.T) {
+	a := strings.Split(fixtureOriginal, "\n")
+	b := strings.Split(fixtureUpdated, "\n")
+
+	results := CompareAlgorithms(a, b, Algorithms())
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+
+	for _, result := range results {
+		if result.Err != nil {
+			t.Fatalf("%s error: %v", result.Name, result.Err)
+		}
+		if !result.Valid {
+			t.Errorf("%s edits should reconstruct the new content", result.Name)
+		}
+
+		want := CountEditKinds(result.Edits)
+		for kind, count := range want {
+			if result.Counts[kind] != count {
+				t.Errorf("%s %v count = %d, want %d", result.Name, kind, result.Counts[kind], count)
+			}
+		}
+		if result.Counts[Equal]+result.Counts[Delete] != len(a) {
+			t.Errorf("%s Equal+Delete = %d, want %d", result.Name, result.Counts[Equal]+result.Counts[Delete], len(a))
+		}
+	}
+
+	report := FormatComparison(results)
+	for _, want := range []string{"LCS", "Myers", "Histogram", "Total", "Insert", "Delete"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+	if !strings.Contains(report, "Myers produces the same edit script as LCS") && !strings.Contains(report, "Myers differs from LCS") {
+		t.Errorf("report should note script differences:\n%s", report)
+	}
+}
+
+func TestFirstDifference(t *testing.T) {
+	a := []Edit{{Kind: Equal, Content: "x"}, {Kind: Insert, Content: "y"}}
+
+	if got := firstDifference(a, a); got != -1 {
+		t.Errorf("identical scripts: got %d, want -1", got)
+	}
+	if got := firstDifference(a, a[:1]); got != 1 {
+		t.Errorf("prefix script: got %d, want 1", got)
+	}
+	if got := firstDifference(a, []Edit{{Kind: Delete, Content: "x"}}); got != 0 {
+		t.Errorf("different first edit: got %d, want 0", got)
+	}
+}
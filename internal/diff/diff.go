@@ -1,5 +1,14 @@
 package diff
 
+import (
+	"fmt"
+	"regexp"
+)
+
+// DefaultLargeDiffThreshold is the edit count above which a diff is considered
+// too large to render in full without being asked to.
+const DefaultLargeDiffThreshold = 5000
+
 // EditKind defines the type of diff operation.
 type EditKind int
 
@@ -32,6 +41,8 @@ type Edit struct {
 	BIndex     int      // index in new sequence (-1 for Delete-only)
 	Content    string   // the line or token (old content for Replace)
 	NewContent string   // new content (only used for Replace operations)
+	OldSpans   []Span   // changed words within Content (Replace only; see [AddWordSpans])
+	NewSpans   []Span   // changed words within NewContent (Replace only)
 }
 
 type outputEdit struct {
@@ -361,6 +372,211 @@ func CountEditKinds(edits []Edit) map[EditKind]int {
 	return counts
 }
 
+// Stat counts the lines an edit script adds and removes.
+type Stat struct {
+	Added   int
+	Removed int
+}
+
+// DiffStat tallies the lines added and removed by edits. A Replace counts as
+// one removed and one added line.
+func DiffStat(edits []Edit) Stat {
+	var stat Stat
+	for _, edit := range edits {
+		switch edit.Kind {
+		case Insert:
+			stat.Added++
+		case Delete:
+			stat.Removed++
+		case Replace:
+			stat.Added++
+			stat.Removed++
+		}
+	}
+	return stat
+}
+
+// IsLargeDiff reports whether the edit script exceeds threshold.
+//
+// A non-positive threshold disables the check.
+func IsLargeDiff(edits []Edit, threshold int) bool {
+	return threshold > 0 && len(edits) > threshold
+}
+
+// HunksOnly reduces edits to the changed lines and up to context unchanged lines
+// on either side of each change.
+//
+// Every omitted run of unchanged lines is replaced by a single compressed marker,
+// matching the marker used by the formatters' compressed view.
+func HunksOnly(edits []Edit, context int) []Edit {
+	if len(edits) == 0 {
+		return edits
+	}
+
+	keep := make([]bool, len(edits))
+	for i, edit := range edits {
+		if edit.Kind == Equal {
+			continue
+		}
+		for j := max(0, i-context); j <= min(len(edits)-1, i+context); j++ {
+			keep[j] = true
+		}
+	}
+
+	result := make([]Edit, 0, len(edits))
+	hidden := 0
+	flush := func() {
+		if hidden > 0 {
+			result = append(result, Edit{
+				Kind:    Equal,
+				AIndex:  -2,
+				BIndex:  -2,
+				Content: fmt.Sprintf("%s %d unchanged lines", compressedIndicator, hidden),
+			})
+			hidden = 0
+		}
+	}
+
+	for i, edit := range edits {
+		if !keep[i] {
+			hidden++
+			continue
+		}
+		flush()
+		result = append(result, edit)
+	}
+	flush()
+
+	return result
+}
+
+// IgnoreMatchingLines turns runs of changes whose lines all match re into context,
+// mirroring git's -I option.
+//
+// Runs containing any non-matching line are left untouched. Deleted and inserted
+// lines within an ignored run are paired in order; unpaired lines keep only their
+// own side's index.
+func IgnoreMatchingLines(edits []Edit, re *regexp.Regexp) []Edit {
+	if re == nil || len(edits) == 0 {
+		return edits
+	}
+
+	result := make([]Edit, 0, len(edits))
+	for i := 0; i < len(edits); {
+		if edits[i].Kind == Equal {
+			result = append(result, edits[i])
+			i++
+			continue
+		}
+
+		end := i
+		for end < len(edits) && edits[end].Kind != Equal {
+			end++
+		}
+		run := edits[i:end]
+		i = end
+
+		if !runMatches(run, re) {
+			result = append(result, run...)
+			continue
+		}
+		result = append(result, collapseRun(run)...)
+	}
+
+	return result
+}
+
+// runMatches reports whether every changed line in run matches re.
+func runMatches(run []Edit, re *regexp.Regexp) bool {
+	for _, edit := range run {
+		if !re.MatchString(edit.Content) {
+			return false
+		}
+		if edit.Kind == Replace && !re.MatchString(edit.NewContent) {
+			return false
+		}
+	}
+	return true
+}
+
+// collapseRun converts a run of changes into Equal edits.
+func collapseRun(run []Edit) []Edit {
+	var deletes, inserts []Edit
+	for _, edit := range run {
+		switch edit.Kind {
+		case Delete:
+			deletes = append(deletes, edit)
+		case Insert:
+			inserts = append(inserts, edit)
+		case Replace:
+			deletes = append(deletes, Edit{Kind: Delete, AIndex: edit.AIndex, BIndex: -1, Content: edit.Content})
+			inserts = append(inserts, Edit{Kind: Insert, AIndex: -1, BIndex: edit.BIndex, Content: edit.NewContent})
+		}
+	}
+
+	collapsed := make([]Edit, 0, max(len(deletes), len(inserts)))
+	for k := range max(len(deletes), len(inserts)) {
+		switch {
+		case k < len(deletes) && k < len(inserts):
+			collapsed = append(collapsed, Edit{Kind: Equal, AIndex: deletes[k].AIndex, BIndex: inserts[k].BIndex, Content: inserts[k].Content})
+		case k < len(inserts):
+			collapsed = append(collapsed, Edit{Kind: Equal, AIndex: -1, BIndex: inserts[k].BIndex, Content: inserts[k].Content})
+		default:
+			collapsed = append(collapsed, Edit{Kind: Equal, AIndex: deletes[k].AIndex, BIndex: -1, Content: deletes[k].Content})
+		}
+	}
+	return collapsed
+}
+
+// PairReplacements pairs deletes and inserts positionally within each contiguous
+// run of changed lines, producing aligned Replace rows.
+//
+// Unlike [MergeReplacements], which searches a window for the most similar
+// partner, the k-th delete of a run is only paired with the k-th insert of the
+// same run, and only when [areSimilarLines] accepts them. This smooths
+// Delete/Insert flip-flops in modified blocks into contiguous Replace blocks.
+// Unpaired lines are kept in place as a Delete followed by an Insert.
+func PairReplacements(edits []Edit) []Edit {
+	result := make([]Edit, 0, len(edits))
+	for i := 0; i < len(edits); {
+		if edits[i].Kind != Delete && edits[i].Kind != Insert {
+			result = append(result, edits[i])
+			i++
+			continue
+		}
+
+		var deletes, inserts []Edit
+		for ; i < len(edits) && (edits[i].Kind == Delete || edits[i].Kind == Insert); i++ {
+			if edits[i].Kind == Delete {
+				deletes = append(deletes, edits[i])
+			} else {
+				inserts = append(inserts, edits[i])
+			}
+		}
+
+		for k := range max(len(deletes), len(inserts)) {
+			switch {
+			case k < len(deletes) && k < len(inserts) && areSimilarLines(deletes[k].Content, inserts[k].Content):
+				result = append(result, Edit{
+					Kind:       Replace,
+					AIndex:     deletes[k].AIndex,
+					BIndex:     inserts[k].BIndex,
+					Content:    deletes[k].Content,
+					NewContent: inserts[k].Content,
+				})
+			default:
+				if k < len(deletes) {
+					result = append(result, deletes[k])
+				}
+				if k < len(inserts) {
+					result = append(result, inserts[k])
+				}
+			}
+		}
+	}
+	return result
+}
+
 // MergeReplacements merges Delete+Insert pairs into Replace operations for better side-by-side rendering.
 //
 // This function identifies blocks of Delete and Insert operations and pairs them up based on similarity.
@@ -2,6 +2,8 @@ package diff
 
 import (
 	_ "embed"
+	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 )
@@ -14,6 +16,7 @@ type algorithmFactory struct {
 var diffAlgorithms = []algorithmFactory{
 	{name: "LCS", new: func() Diff { return &LCS{} }},
 	{name: "Myers", new: func() Diff { return &Myers{} }},
+	{name: "Histogram", new: func() Diff { return &Histogram{} }},
 }
 
 //go:embed fixtures/diffs_original.md
@@ -547,42 +550,87 @@ func TestDiff_CrossValidation(t *testing.T) {
 		{"complex", []string{"1", "2", "3", "4"}, []string{"1", "x", "y", "4"}},
 		{"empty to content", []string{}, []string{"a", "b", "c"}},
 		{"content to empty", []string{"a", "b", "c"}, []string{}},
+		{"repeated lines", []string{"}", "", "}", "x", "}", ""}, []string{"", "}", "y", "}", "", "}"}},
+		{"moved block", []string{"func a() {", "}", "", "func b() {", "}"}, []string{"func b() {", "}", "", "func a() {", "}"}},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			lcs := &LCS{}
-			myers := &Myers{}
+			for _, alg := range diffAlgorithms {
+				edits, err := alg.new().Compute(tc.a, tc.b)
+				if err != nil {
+					t.Fatalf("%s error: %v", alg.name, err)
+				}
 
-			lcsEdits, err := lcs.Compute(tc.a, tc.b)
-			if err != nil {
-				t.Fatalf("LCS error: %v", err)
+				result := ApplyEdits(tc.a, edits)
+				if len(result) != len(tc.b) {
+					t.Errorf("%s reconstruction length mismatch: %d != %d", alg.name, len(result), len(tc.b))
+				}
+				for i := range tc.b {
+					if i < len(result) && result[i] != tc.b[i] {
+						t.Errorf("%s line %d: %q != %q", alg.name, i, result[i], tc.b[i])
+					}
+				}
 			}
+		})
+	}
+}
 
-			myersEdits, err := myers.Compute(tc.a, tc.b)
-			if err != nil {
-				t.Fatalf("Myers error: %v", err)
-			}
+// repetitiveLines builds n lines of Go-like source where braces, blank lines,
+// and returns repeat constantly and only the function names are unique.
+func repetitiveLines(n int, rename func(int) string) []string {
+	lines := make([]string, 0, n)
+	for i := 0; len(lines) < n; i++ {
+		lines = append(lines, "func "+rename(i)+"() error {", "\treturn nil", "}", "")
+	}
+	return lines[:n]
+}
 
-			lcsResult := ApplyEdits(tc.a, lcsEdits)
-			myersResult := ApplyEdits(tc.a, myersEdits)
+func TestHistogram_AnchorsOnRareLines(t *testing.T) {
+	a := []string{"x", "x", "x", "u", "y"}
+	b := []string{"q", "x", "x", "x", "w", "u", "r"}
 
-			if len(lcsResult) != len(tc.b) {
-				t.Errorf("LCS reconstruction length mismatch: %d != %d", len(lcsResult), len(tc.b))
-			}
-			if len(myersResult) != len(tc.b) {
-				t.Errorf("Myers reconstruction length mismatch: %d != %d", len(myersResult), len(tc.b))
-			}
+	region, found := newHistogramState(a, b).findRegion(0, len(a), 0, len(b))
+	if !found {
+		t.Fatal("expected an anchor region")
+	}
+	want := histogramRegion{aStart: 3, bStart: 5, length: 1, lowCount: 1}
+	if region != want {
+		t.Errorf("region = %+v, want %+v (the unique line beats the longer run of repeats)", region, want)
+	}
 
-			for i := range tc.b {
-				if i < len(lcsResult) && lcsResult[i] != tc.b[i] {
-					t.Errorf("LCS line %d: %q != %q", i, lcsResult[i], tc.b[i])
-				}
-				if i < len(myersResult) && myersResult[i] != tc.b[i] {
-					t.Errorf("Myers line %d: %q != %q", i, myersResult[i], tc.b[i])
-				}
-			}
-		})
+	edits, err := (&Histogram{}).Compute(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if counts := CountEditKinds(edits); counts[Equal] != 4 {
+		t.Errorf("Equal count = %d, want 4 (the repeats are matched while recursing)", counts[Equal])
+	}
+}
+
+func TestHistogram_RepetitiveFile(t *testing.T) {
+	a := repetitiveLines(2000, func(i int) string { return fmt.Sprintf("f%d", i) })
+	b := repetitiveLines(2000, func(i int) string {
+		if i%7 == 0 {
+			return fmt.Sprintf("g%d", i)
+		}
+		return fmt.Sprintf("f%d", i)
+	})
+
+	edits, err := (&Histogram{}).Compute(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result := ApplyEdits(a, edits)
+	if strings.Join(result, "\n") != strings.Join(b, "\n") {
+		t.Fatal("edits should reconstruct the new content")
+	}
+
+	stat := DiffStat(edits)
+	renamed := (len(a)/4 + 6) / 7
+	if stat.Added != renamed || stat.Removed != renamed {
+		t.Errorf("DiffStat() = %+v, want %d added and removed (one per renamed function)", stat, renamed)
 	}
 }
 
@@ -678,3 +726,215 @@ func BenchmarkMyers_MediumInput(b *testing.B) {
 		_, _ = myers.Compute(a, c)
 	}
 }
+
+func BenchmarkHistogram_SmallInput(b *testing.B) {
+	a := []string{"line1", "line2", "line3", "line4", "line5"}
+	c := []string{"line1", "modified", "line3", "line4", "added"}
+	histogram := &Histogram{}
+
+	for b.Loop() {
+		_, _ = histogram.Compute(a, c)
+	}
+}
+
+func BenchmarkHistogram_MediumInput(b *testing.B) {
+	a := make([]string, 50)
+	c := make([]string, 50)
+	for i := range 50 {
+		a[i] = "line" + strings.Repeat("x", i)
+		if i%5 == 0 {
+			c[i] = "modified" + strings.Repeat("y", i)
+		} else {
+			c[i] = a[i]
+		}
+	}
+
+	histogram := &Histogram{}
+
+	for b.Loop() {
+		_, _ = histogram.Compute(a, c)
+	}
+}
+
+func BenchmarkDiff_RepetitiveInput(b *testing.B) {
+	a := repetitiveLines(2000, func(i int) string { return fmt.Sprintf("f%d", i) })
+	c := repetitiveLines(2000, func(i int) string {
+		if i%7 == 0 {
+			return fmt.Sprintf("g%d", i)
+		}
+		return fmt.Sprintf("f%d", i)
+	})
+
+	for _, alg := range diffAlgorithms {
+		b.Run(alg.name, func(b *testing.B) {
+			m := alg.new()
+			for b.Loop() {
+				_, _ = m.Compute(a, c)
+			}
+		})
+	}
+}
+
+func TestHunksOnly(t *testing.T) {
+	var edits []Edit
+	for i := range 10 {
+		edits = append(edits, Edit{Kind: Equal, AIndex: i, BIndex: i, Content: "same"})
+	}
+	edits = append(edits, Edit{Kind: Delete, AIndex: 10, BIndex: -1, Content: "gone"})
+	for i := 11; i < 13; i++ {
+		edits = append(edits, Edit{Kind: Equal, AIndex: i, BIndex: i - 1, Content: "same"})
+	}
+
+	result := HunksOnly(edits, 2)
+
+	if len(result) != 6 {
+		t.Fatalf("Expected marker + 2 context + change + 2 context, got %d edits", len(result))
+	}
+	if result[0].AIndex != -2 || !strings.Contains(result[0].Content, "8 unchanged lines") {
+		t.Errorf("Expected leading marker for 8 hidden lines, got %+v", result[0])
+	}
+	if result[3].Kind != Delete {
+		t.Errorf("Expected the change to be kept, got %v", result[3].Kind)
+	}
+	if result[5].AIndex == -2 {
+		t.Error("Trailing run within context should not be replaced by a marker")
+	}
+}
+
+func TestIsLargeDiff(t *testing.T) {
+	edits := make([]Edit, 5)
+	if !IsLargeDiff(edits, 4) {
+		t.Error("Expected 5 edits to exceed a threshold of 4")
+	}
+	if IsLargeDiff(edits, 5) {
+		t.Error("Expected 5 edits not to exceed a threshold of 5")
+	}
+	if IsLargeDiff(edits, 0) {
+		t.Error("Expected a non-positive threshold to disable the check")
+	}
+}
+
+func TestIgnoreMatchingLines(t *testing.T) {
+	comment := regexp.MustCompile(`^\s*//`)
+
+	t.Run("comment-only changes become context", func(t *testing.T) {
+		a := []string{"package main", "// old comment", "func main() {}"}
+		b := []string{"package main", "// new comment", "// extra comment", "func main() {}"}
+
+		edits, err := (&Myers{}).Compute(a, b)
+		if err != nil {
+			t.Fatalf("Compute failed: %v", err)
+		}
+
+		filtered := IgnoreMatchingLines(edits, comment)
+		for _, edit := range filtered {
+			if edit.Kind != Equal {
+				t.Fatalf("expected only Equal edits, got %v for %q", edit.Kind, edit.Content)
+			}
+		}
+		if len(filtered) != 4 {
+			t.Fatalf("expected 4 lines of context, got %d", len(filtered))
+		}
+		if filtered[1].AIndex != 1 || filtered[1].BIndex != 1 || filtered[1].Content != "// new comment" {
+			t.Errorf("unexpected paired edit: %+v", filtered[1])
+		}
+		if filtered[2].AIndex != -1 || filtered[2].BIndex != 2 {
+			t.Errorf("unpaired insert should keep only its new index, got %+v", filtered[2])
+		}
+	})
+
+	t.Run("non-matching line keeps the run", func(t *testing.T) {
+		a := []string{"package main", "// old comment", "func main() {}"}
+		b := []string{"package main", "// new comment", "func main() { run() }"}
+
+		edits, err := (&Myers{}).Compute(a, b)
+		if err != nil {
+			t.Fatalf("Compute failed: %v", err)
+		}
+
+		filtered := IgnoreMatchingLines(edits, comment)
+		counts := CountEditKinds(filtered)
+		if counts[Insert] != 2 || counts[Delete] != 2 {
+			t.Errorf("expected changes to remain, got %v", counts)
+		}
+	})
+
+	t.Run("nil pattern is a no-op", func(t *testing.T) {
+		edits := []Edit{{Kind: Insert, AIndex: -1, BIndex: 0, Content: "// added"}}
+		filtered := IgnoreMatchingLines(edits, nil)
+		if len(filtered) != 1 || filtered[0].Kind != Insert {
+			t.Errorf("expected edits unchanged, got %+v", filtered)
+		}
+	})
+}
+
+func TestPairReplacements(t *testing.T) {
+	t.Run("modified block becomes aligned replace rows", func(t *testing.T) {
+		edits := []Edit{
+			{Kind: Equal, AIndex: 0, BIndex: 0, Content: "func main() {"},
+			{Kind: Delete, AIndex: 1, BIndex: -1, Content: "\tfmt.Println(\"one\")"},
+			{Kind: Insert, AIndex: -1, BIndex: 1, Content: "\tfmt.Println(\"uno\")"},
+			{Kind: Delete, AIndex: 2, BIndex: -1, Content: "\tfmt.Println(\"two\")"},
+			{Kind: Insert, AIndex: -1, BIndex: 2, Content: "\tfmt.Println(\"dos\")"},
+			{Kind: Delete, AIndex: 3, BIndex: -1, Content: "\tfmt.Println(\"six\")"},
+			{Kind: Insert, AIndex: -1, BIndex: 3, Content: "\tfmt.Println(\"tres\")"},
+			{Kind: Equal, AIndex: 4, BIndex: 4, Content: "}"},
+		}
+
+		paired := PairReplacements(edits)
+		if len(paired) != 5 {
+			t.Fatalf("expected 5 edits, got %d: %+v", len(paired), paired)
+		}
+		for k := 1; k <= 3; k++ {
+			edit := paired[k]
+			if edit.Kind != Replace {
+				t.Fatalf("row %d: expected Replace, got %v", k, edit.Kind)
+			}
+			if edit.AIndex != k || edit.BIndex != k {
+				t.Errorf("row %d: expected aligned indices %d/%d, got %d/%d", k, k, k, edit.AIndex, edit.BIndex)
+			}
+			if edit.Content != edits[2*k-1].Content || edit.NewContent != edits[2*k].Content {
+				t.Errorf("row %d: unexpected contents %q -> %q", k, edit.Content, edit.NewContent)
+			}
+		}
+	})
+
+	t.Run("dissimilar lines stay as delete and insert", func(t *testing.T) {
+		edits := []Edit{
+			{Kind: Delete, AIndex: 0, BIndex: -1, Content: "import \"os\""},
+			{Kind: Delete, AIndex: 1, BIndex: -1, Content: "const version = \"1.0.0\""},
+			{Kind: Insert, AIndex: -1, BIndex: 0, Content: "type Config struct{}"},
+			{Kind: Insert, AIndex: -1, BIndex: 1, Content: "const version = \"1.1.0\""},
+		}
+
+		paired := PairReplacements(edits)
+		kinds := make([]EditKind, len(paired))
+		for i, edit := range paired {
+			kinds[i] = edit.Kind
+		}
+		expected := []EditKind{Delete, Insert, Replace}
+		if len(kinds) != len(expected) {
+			t.Fatalf("expected kinds %v, got %v", expected, kinds)
+		}
+		for i := range expected {
+			if kinds[i] != expected[i] {
+				t.Fatalf("expected kinds %v, got %v", expected, kinds)
+			}
+		}
+	})
+}
+
+func TestDiffStat(t *testing.T) {
+	edits := []Edit{
+		{Kind: Equal, Content: "same"},
+		{Kind: Delete, Content: "old"},
+		{Kind: Insert, Content: "new"},
+		{Kind: Insert, Content: "extra"},
+		{Kind: Replace, Content: "before", NewContent: "after"},
+	}
+
+	stat := DiffStat(edits)
+	if stat.Added != 3 || stat.Removed != 2 {
+		t.Errorf("DiffStat() = %+v, want Added 3, Removed 2", stat)
+	}
+}
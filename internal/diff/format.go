@@ -2,6 +2,7 @@ package diff
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -9,6 +10,10 @@ import (
 	"github.com/stormlightlabs/git-storm/internal/style"
 )
 
+// DefaultMinUnchangedToHide is the shortest run of unchanged lines that
+// formatters collapse into a single indicator when not expanded.
+const DefaultMinUnchangedToHide = 10
+
 const (
 	SymbolAdd          = "┃" // addition
 	SymbolChange       = "▎" // modification/change
@@ -27,15 +32,68 @@ const (
 	lineNumWidth        = 4
 	gutterWidth         = 3
 	minPaneWidth        = 40
-	contextLines        = 3  // Lines to show before/after changes
-	minUnchangedToHide  = 10 // Minimum unchanged lines before hiding
+	contextLines        = 3 // Lines to show before/after changes
 	compressedIndicator = "⋮"
+	annotationWidth     = 20 // Width of the blame annotation column, including its trailing space
 )
 
 type Formatter interface {
 	Format(edits []Edit) string
 }
 
+// prepareEdits applies the shared pre-rendering passes: ignored lines are
+// dropped, replacements are merged, and their changed words are found.
+func prepareEdits(edits []Edit, ignore *regexp.Regexp, align bool) []Edit {
+	edits = IgnoreMatchingLines(edits, ignore)
+	if align {
+		edits = PairReplacements(edits)
+	}
+	return AddWordSpans(MergeReplacements(edits))
+}
+
+// renderWords renders prefix and content in base, emphasizing the changed
+// spans of content with base reversed.
+//
+// content is detabbed and passed through fit before styling; spans beyond a
+// truncation point are dropped. Without spans the whole line is styled alike.
+func renderWords(prefix, content string, spans []Span, base lipgloss.Style, fit func(string) string) string {
+	if len(spans) == 0 {
+		return base.Render(prefix + fit(detab(content, 8)))
+	}
+
+	detabbed, spans := detabSpans(content, spans, 8)
+	visible := fit(detabbed)
+	ellipsis := ""
+	if !strings.HasPrefix(detabbed, visible) {
+		trimmed, ok := strings.CutSuffix(visible, "...")
+		if !ok || !strings.HasPrefix(detabbed, trimmed) {
+			return base.Render(prefix + visible)
+		}
+		visible, ellipsis = trimmed, "..."
+	}
+
+	emphasis := base.Reverse(true)
+	var sb strings.Builder
+	plain := prefix
+	pos := 0
+	for _, span := range spans {
+		start, end := min(span.Start, len(visible)), min(span.End, len(visible))
+		if start >= end {
+			continue
+		}
+		plain += visible[pos:start]
+		if plain != "" {
+			sb.WriteString(base.Render(plain))
+		}
+		sb.WriteString(emphasis.Render(visible[start:end]))
+		plain, pos = "", end
+	}
+	if plain += visible[pos:] + ellipsis; plain != "" {
+		sb.WriteString(base.Render(plain))
+	}
+	return sb.String()
+}
+
 // SideBySideFormatter renders diff edits in a split-pane layout with syntax highlighting.
 type SideBySideFormatter struct {
 	// TerminalWidth is the total available width for rendering
@@ -46,6 +104,18 @@ type SideBySideFormatter struct {
 	Expanded bool
 	// EnableWordWrap enables word wrapping for long lines
 	EnableWordWrap bool
+	// IgnoreMatchingLines treats changes whose lines all match as unchanged
+	IgnoreMatchingLines *regexp.Regexp
+	// AlignReplacements pairs changed lines positionally within each block (see [PairReplacements])
+	AlignReplacements bool
+	// MinUnchangedToHide is the shortest unchanged run that is compressed.
+	// Zero uses [DefaultMinUnchangedToHide].
+	MinUnchangedToHide int
+	// Theme overrides the added, removed, and context styles; nil uses [DefaultTheme].
+	Theme *Theme
+	// Annotations, indexed by new-file line, are shown dimmed in a prefix
+	// column beside inserted and replaced lines (e.g. blame "abc1234 author").
+	Annotations []string
 }
 
 // Format renders the edits as a styled side-by-side diff string.
@@ -57,12 +127,7 @@ func (f *SideBySideFormatter) Format(edits []Edit) string {
 		return style.StyleText.Render("No changes")
 	}
 
-	processedEdits := MergeReplacements(edits)
-
-	if !f.Expanded {
-		processedEdits = f.compressUnchangedBlocks(processedEdits)
-	}
-
+	processedEdits := f.processEdits(edits)
 	paneWidth := f.calculatePaneWidth()
 
 	var sb strings.Builder
@@ -71,6 +136,10 @@ func (f *SideBySideFormatter) Format(edits []Edit) string {
 	for _, edit := range processedEdits {
 		left, right := f.renderEdit(edit, paneWidth)
 
+		if len(f.Annotations) > 0 {
+			sb.WriteString(f.renderAnnotation(edit, lineNumStyle))
+		}
+
 		if f.ShowLineNumbers {
 			leftNum := f.formatLineNum(edit.AIndex, lineNumStyle)
 			rightNum := f.formatLineNum(edit.BIndex, lineNumStyle)
@@ -91,12 +160,55 @@ func (f *SideBySideFormatter) Format(edits []Edit) string {
 	return sb.String()
 }
 
+// processEdits applies the passes that decide which rows Format renders.
+func (f *SideBySideFormatter) processEdits(edits []Edit) []Edit {
+	processed := prepareEdits(edits, f.IgnoreMatchingLines, f.AlignReplacements)
+	if !f.Expanded {
+		processed = f.compressUnchangedBlocks(processed)
+	}
+	return processed
+}
+
+// HunkLines returns the line of Format's output on which each block of
+// changed lines starts, in order. Compressed unchanged regions separate
+// blocks but never start one.
+func (f *SideBySideFormatter) HunkLines(edits []Edit) []int {
+	return hunkLines(f.processEdits(edits), func(Edit) int { return 1 })
+}
+
+// hunkLines finds the first output line of each run of changed edits, given
+// how many lines each edit renders as.
+func hunkLines(edits []Edit, height func(Edit) int) []int {
+	var lines []int
+	line := 0
+	for i, edit := range edits {
+		if edit.Kind != Equal && (i == 0 || edits[i-1].Kind == Equal) {
+			lines = append(lines, line)
+		}
+		line += height(edit)
+	}
+	return lines
+}
+
+// renderAnnotation renders the annotation column for an edit, blank for
+// lines that weren't added or changed.
+func (f *SideBySideFormatter) renderAnnotation(edit Edit, st lipgloss.Style) string {
+	text := ""
+	if (edit.Kind == Insert || edit.Kind == Replace) && edit.BIndex >= 0 && edit.BIndex < len(f.Annotations) {
+		text = truncateToWidth(f.Annotations[edit.BIndex], annotationWidth-1)
+	}
+	return f.padToWidth(st.Render(text), annotationWidth)
+}
+
 // calculatePaneWidth determines the width available for each content pane.
 func (f *SideBySideFormatter) calculatePaneWidth() int {
 	usedWidth := gutterWidth
 	if f.ShowLineNumbers {
 		usedWidth += 2 * lineNumWidth
 	}
+	if len(f.Annotations) > 0 {
+		usedWidth += annotationWidth
+	}
 
 	availableWidth := f.TerminalWidth - usedWidth
 	if availableWidth < 0 {
@@ -130,27 +242,27 @@ func (f *SideBySideFormatter) renderEdit(edit Edit, paneWidth int) (left, right
 		return styled, styled
 	}
 
+	theme := resolveTheme(f.Theme)
 	switch edit.Kind {
 	case Equal:
-		leftStyled := f.padToWidth(style.StyleText.Render(content), paneWidth)
-		rightStyled := f.padToWidth(style.StyleText.Render(content), paneWidth)
+		leftStyled := f.padToWidth(theme.Context.Render(content), paneWidth)
+		rightStyled := f.padToWidth(theme.Context.Render(content), paneWidth)
 		return leftStyled, rightStyled
 
 	case Delete:
-		leftStyled := f.padToWidth(style.StyleRemoved.Render(content), paneWidth)
+		leftStyled := f.padToWidth(theme.Removed.Render(content), paneWidth)
 		rightStyled := f.padToWidth("", paneWidth)
 		return leftStyled, rightStyled
 
 	case Insert:
 		leftStyled := f.padToWidth("", paneWidth)
-		rightStyled := f.padToWidth(style.StyleAdded.Render(content), paneWidth)
+		rightStyled := f.padToWidth(theme.Added.Render(content), paneWidth)
 		return leftStyled, rightStyled
 
 	case Replace:
-		newContent := detab(edit.NewContent, 8)
-		newContent = f.truncateContent(newContent, paneWidth)
-		leftStyled := f.padToWidth(style.StyleRemoved.Render(content), paneWidth)
-		rightStyled := f.padToWidth(style.StyleAdded.Render(newContent), paneWidth)
+		fit := func(s string) string { return f.truncateContent(s, paneWidth) }
+		leftStyled := f.padToWidth(renderWords("", edit.Content, edit.OldSpans, theme.Removed, fit), paneWidth)
+		rightStyled := f.padToWidth(renderWords("", edit.NewContent, edit.NewSpans, theme.Added, fit), paneWidth)
 		return leftStyled, rightStyled
 
 	default:
@@ -187,10 +299,10 @@ func (f *SideBySideFormatter) renderGutter(kind EditKind) string {
 		st = lipgloss.NewStyle().Foreground(lipgloss.Color("#6C7A89"))
 	case Delete:
 		symbol = " " + SymbolDeleteLine + " "
-		st = style.StyleRemoved
+		st = resolveTheme(f.Theme).Removed
 	case Insert:
 		symbol = " " + SymbolAdd + " "
-		st = style.StyleAdded
+		st = resolveTheme(f.Theme).Added
 	case Replace:
 		symbol = " " + SymbolChange + " "
 		st = style.StyleChanged
@@ -261,11 +373,24 @@ func truncateToWidth(s string, width int) string {
 	return result.String()
 }
 
-// compressUnchangedBlocks compresses large blocks of unchanged lines.
-//
-// It keeps contextLines before and after changes, and replaces large
-// blocks of unchanged lines with a single compressed indicator.
+// compressUnchangedBlocks compresses large blocks of unchanged lines using
+// the formatter's MinUnchangedToHide threshold.
 func (f *SideBySideFormatter) compressUnchangedBlocks(edits []Edit) []Edit {
+	return compressUnchangedRuns(edits, f.MinUnchangedToHide)
+}
+
+// compressUnchangedRuns compresses runs of at least minRun unchanged lines.
+//
+// It keeps contextLines before and after changes, and replaces the rest of
+// each run with a single compressed indicator. A non-positive minRun uses
+// [DefaultMinUnchangedToHide]; values too small to hide anything are raised
+// to 2*contextLines+1.
+func compressUnchangedRuns(edits []Edit, minRun int) []Edit {
+	if minRun <= 0 {
+		minRun = DefaultMinUnchangedToHide
+	}
+	minRun = max(minRun, 2*contextLines+1)
+
 	if len(edits) == 0 {
 		return edits
 	}
@@ -281,7 +406,7 @@ func (f *SideBySideFormatter) compressUnchangedBlocks(edits []Edit) []Edit {
 			nextIsChanged := !isLast && edits[i+1].Kind != Equal
 
 			if isLast || nextIsChanged {
-				if len(unchangedRun) >= minUnchangedToHide {
+				if len(unchangedRun) >= minRun {
 					for j := 0; j < contextLines && j < len(unchangedRun); j++ {
 						result = append(result, unchangedRun[j])
 					}
@@ -307,7 +432,7 @@ func (f *SideBySideFormatter) compressUnchangedBlocks(edits []Edit) []Edit {
 			}
 		} else {
 			if len(unchangedRun) > 0 {
-				if len(unchangedRun) >= minUnchangedToHide {
+				if len(unchangedRun) >= minRun {
 					for j := 0; j < contextLines && j < len(unchangedRun); j++ {
 						result = append(result, unchangedRun[j])
 					}
@@ -357,6 +482,15 @@ type UnifiedFormatter struct {
 	Expanded bool
 	// EnableWordWrap enables word wrapping for long lines
 	EnableWordWrap bool
+	// IgnoreMatchingLines treats changes whose lines all match as unchanged
+	IgnoreMatchingLines *regexp.Regexp
+	// AlignReplacements pairs changed lines positionally within each block (see [PairReplacements])
+	AlignReplacements bool
+	// MinUnchangedToHide is the shortest unchanged run that is compressed.
+	// Zero uses [DefaultMinUnchangedToHide].
+	MinUnchangedToHide int
+	// Theme overrides the added, removed, and context styles; nil uses [DefaultTheme].
+	Theme *Theme
 }
 
 // Format renders the edits as a styled unified diff string.
@@ -367,12 +501,7 @@ func (f *UnifiedFormatter) Format(edits []Edit) string {
 		return style.StyleText.Render("No changes")
 	}
 
-	processedEdits := MergeReplacements(edits)
-
-	if !f.Expanded {
-		processedEdits = f.compressUnchangedBlocks(processedEdits)
-	}
-
+	processedEdits := f.processEdits(edits)
 	contentWidth := f.calculateContentWidth()
 
 	var sb strings.Builder
@@ -393,6 +522,26 @@ func (f *UnifiedFormatter) Format(edits []Edit) string {
 	return sb.String()
 }
 
+// processEdits applies the passes that decide which lines Format renders.
+func (f *UnifiedFormatter) processEdits(edits []Edit) []Edit {
+	processed := prepareEdits(edits, f.IgnoreMatchingLines, f.AlignReplacements)
+	if !f.Expanded {
+		processed = f.compressUnchangedBlocks(processed)
+	}
+	return processed
+}
+
+// HunkLines returns the line of Format's output on which each block of
+// changed lines starts, in order. A Replace renders as two lines.
+func (f *UnifiedFormatter) HunkLines(edits []Edit) []int {
+	return hunkLines(f.processEdits(edits), func(edit Edit) int {
+		if edit.Kind == Replace {
+			return 2
+		}
+		return 1
+	})
+}
+
 // calculateContentWidth determines the width available for content.
 func (f *UnifiedFormatter) calculateContentWidth() int {
 	usedWidth := 2
@@ -433,15 +582,17 @@ func (f *UnifiedFormatter) renderEdit(edit Edit, contentWidth int, lineNumStyle
 	content := detab(edit.Content, 8)
 	content = f.truncateContent(content, contentWidth)
 
+	theme := resolveTheme(f.Theme)
 	switch edit.Kind {
 	case Equal:
-		sb.WriteString(style.StyleText.Render(" " + content))
+		sb.WriteString(theme.Context.Render(" " + content))
 	case Delete:
-		sb.WriteString(style.StyleRemoved.Render("-" + content))
+		sb.WriteString(theme.Removed.Render("-" + content))
 	case Insert:
-		sb.WriteString(style.StyleAdded.Render("+" + content))
+		sb.WriteString(theme.Added.Render("+" + content))
 	case Replace:
-		sb.WriteString(style.StyleRemoved.Render("-" + content))
+		fit := func(s string) string { return f.truncateContent(s, contentWidth) }
+		sb.WriteString(renderWords("-", edit.Content, edit.OldSpans, theme.Removed, fit))
 	default:
 		sb.WriteString(" " + content)
 	}
@@ -460,9 +611,8 @@ func (f *UnifiedFormatter) renderReplaceNew(edit Edit, contentWidth int, lineNum
 		sb.WriteString(" ")
 	}
 
-	content := detab(edit.NewContent, 8)
-	content = f.truncateContent(content, contentWidth)
-	sb.WriteString(style.StyleAdded.Render("+" + content))
+	fit := func(s string) string { return f.truncateContent(s, contentWidth) }
+	sb.WriteString(renderWords("+", edit.NewContent, edit.NewSpans, resolveTheme(f.Theme).Added, fit))
 
 	return sb.String()
 }
@@ -501,77 +651,8 @@ func (f *UnifiedFormatter) truncateContent(content string, maxWidth int) string
 	return truncateToWidth(content, maxWidth-3) + "..."
 }
 
-// compressUnchangedBlocks compresses large blocks of unchanged lines.
+// compressUnchangedBlocks compresses large blocks of unchanged lines using
+// the formatter's MinUnchangedToHide threshold.
 func (f *UnifiedFormatter) compressUnchangedBlocks(edits []Edit) []Edit {
-	if len(edits) == 0 {
-		return edits
-	}
-
-	var result []Edit
-	var unchangedRun []Edit
-
-	for i, edit := range edits {
-		if edit.Kind == Equal {
-			unchangedRun = append(unchangedRun, edit)
-
-			isLast := i == len(edits)-1
-			nextIsChanged := !isLast && edits[i+1].Kind != Equal
-
-			if isLast || nextIsChanged {
-				if len(unchangedRun) >= minUnchangedToHide {
-					for j := 0; j < contextLines && j < len(unchangedRun); j++ {
-						result = append(result, unchangedRun[j])
-					}
-
-					hiddenCount := len(unchangedRun) - (2 * contextLines)
-					if hiddenCount > 0 {
-						result = append(result, Edit{
-							Kind:    Equal,
-							AIndex:  -2,
-							BIndex:  -2,
-							Content: fmt.Sprintf("%s %d unchanged lines", compressedIndicator, hiddenCount),
-						})
-					}
-
-					start := max(len(unchangedRun)-contextLines, contextLines)
-					for j := start; j < len(unchangedRun); j++ {
-						result = append(result, unchangedRun[j])
-					}
-				} else {
-					result = append(result, unchangedRun...)
-				}
-				unchangedRun = nil
-			}
-		} else {
-			if len(unchangedRun) > 0 {
-				if len(unchangedRun) >= minUnchangedToHide {
-					for j := 0; j < contextLines && j < len(unchangedRun); j++ {
-						result = append(result, unchangedRun[j])
-					}
-
-					hiddenCount := len(unchangedRun) - (2 * contextLines)
-					if hiddenCount > 0 {
-						result = append(result, Edit{
-							Kind:    Equal,
-							AIndex:  -2,
-							BIndex:  -2,
-							Content: fmt.Sprintf("%s %d unchanged lines", compressedIndicator, hiddenCount),
-						})
-					}
-
-					start := max(len(unchangedRun)-contextLines, contextLines)
-					for j := start; j < len(unchangedRun); j++ {
-						result = append(result, unchangedRun[j])
-					}
-				} else {
-					result = append(result, unchangedRun...)
-				}
-				unchangedRun = nil
-			}
-
-			result = append(result, edit)
-		}
-	}
-
-	return result
+	return compressUnchangedRuns(edits, f.MinUnchangedToHide)
 }
@@ -155,3 +155,50 @@ func countCompressedBlocks(edits []Edit) int {
 	}
 	return count
 }
+
+func TestFormatter_MinUnchangedToHidePerFormatter(t *testing.T) {
+	edits := []Edit{{Kind: Insert, AIndex: -1, BIndex: 0, Content: "new line"}}
+	edits = append(edits, makeEqualEdits(9)...)
+	edits = append(edits, Edit{Kind: Delete, AIndex: 9, BIndex: -1, Content: "removed line"})
+
+	unified := &UnifiedFormatter{MinUnchangedToHide: 9}
+	if got := countCompressedBlocks(unified.compressUnchangedBlocks(edits)); got != 1 {
+		t.Errorf("Unified view with threshold 9 should compress the 9-line run, got %d blocks", got)
+	}
+
+	split := &SideBySideFormatter{MinUnchangedToHide: 12}
+	if got := countCompressedBlocks(split.compressUnchangedBlocks(edits)); got != 0 {
+		t.Errorf("Split view with threshold 12 should not compress the 9-line run, got %d blocks", got)
+	}
+
+	if !strings.Contains(unified.Format(edits), "3 unchanged lines") {
+		t.Error("Unified output should report the 3 hidden lines")
+	}
+	if strings.Contains(split.Format(edits), "unchanged lines") {
+		t.Error("Split output should render the run in full")
+	}
+}
+
+func TestCompressUnchangedRuns_Threshold(t *testing.T) {
+	tests := []struct {
+		name   string
+		minRun int
+		run    int
+		want   int
+	}{
+		{name: "zero uses default below", minRun: 0, run: DefaultMinUnchangedToHide - 1, want: 0},
+		{name: "zero uses default at", minRun: 0, run: DefaultMinUnchangedToHide, want: 1},
+		{name: "custom threshold", minRun: 15, run: 14, want: 0},
+		{name: "too small is raised", minRun: 2, run: 2*contextLines + 1, want: 1},
+		{name: "too small never hides context", minRun: 2, run: 2 * contextLines, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edits := append([]Edit{{Kind: Insert, AIndex: -1, BIndex: 0, Content: "x"}}, makeEqualEdits(tt.run)...)
+			if got := countCompressedBlocks(compressUnchangedRuns(edits, tt.minRun)); got != tt.want {
+				t.Errorf("compressUnchangedRuns(minRun=%d, run=%d) = %d blocks, want %d", tt.minRun, tt.run, got, tt.want)
+			}
+		})
+	}
+}
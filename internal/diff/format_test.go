@@ -1,6 +1,7 @@
 package diff
 
 import (
+	"regexp"
 	"strings"
 	"testing"
 
@@ -421,3 +422,98 @@ func TestUnifiedFormatter_CalculateContentWidth(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatter_IgnoreMatchingLines(t *testing.T) {
+	edits := []Edit{
+		{Kind: Equal, AIndex: 0, BIndex: 0, Content: "package main"},
+		{Kind: Delete, AIndex: 1, BIndex: -1, Content: "// Generated at 10:00"},
+		{Kind: Insert, AIndex: -1, BIndex: 1, Content: "// Generated at 11:00"},
+	}
+	re := regexp.MustCompile(`^// Generated at`)
+
+	formatter := &UnifiedFormatter{TerminalWidth: 80, IgnoreMatchingLines: re}
+	output := formatter.Format(edits)
+
+	if strings.Contains(output, "10:00") {
+		t.Errorf("ignored deletion should not be rendered, got:\n%s", output)
+	}
+	if !strings.Contains(output, "11:00") {
+		t.Errorf("ignored change should render as context, got:\n%s", output)
+	}
+}
+
+func TestSideBySideFormatter_Annotations(t *testing.T) {
+	formatter := &SideBySideFormatter{
+		TerminalWidth:   140,
+		ShowLineNumbers: true,
+		Expanded:        true,
+		Annotations:     []string{"aaaaaaa Early Author", "bbbbbbb A Very Long Author Name That Overflows"},
+	}
+
+	edits := []Edit{
+		{Kind: Equal, AIndex: 0, BIndex: 0, Content: "kept"},
+		{Kind: Insert, AIndex: -1, BIndex: 1, Content: "added"},
+	}
+	lines := strings.Split(strings.TrimRight(formatter.Format(edits), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+
+	if strings.Contains(lines[0], "aaaaaaa") {
+		t.Error("unchanged lines should not be annotated")
+	}
+	if !strings.Contains(lines[1], "bbbbbbb A Very") {
+		t.Errorf("inserted line should carry its annotation, got %q", lines[1])
+	}
+	if strings.Contains(lines[1], "Overflows") {
+		t.Error("long annotations should be truncated to the column width")
+	}
+	if lipgloss.Width(lines[0]) != lipgloss.Width(lines[1]) {
+		t.Error("annotated and blank rows should have the same width")
+	}
+}
+
+func TestFormatters_HunkLines(t *testing.T) {
+	var edits []Edit
+	a, b := 0, 0
+	equal := func(n int) {
+		for range n {
+			edits = append(edits, Edit{Kind: Equal, AIndex: a, BIndex: b, Content: "same"})
+			a++
+			b++
+		}
+	}
+	equal(20)
+	edits = append(edits,
+		Edit{Kind: Delete, AIndex: a, BIndex: -1, Content: `version = "1.0.0"`},
+		Edit{Kind: Insert, AIndex: -1, BIndex: b, Content: `version = "1.0.1"`},
+		Edit{Kind: Insert, AIndex: -1, BIndex: b + 1, Content: "first addition"},
+	)
+	a, b = a+1, b+2
+	equal(20)
+	edits = append(edits, Edit{Kind: Insert, AIndex: -1, BIndex: b, Content: "second addition"})
+
+	formatters := map[string]interface {
+		Formatter
+		HunkLines([]Edit) []int
+	}{
+		"split":            &SideBySideFormatter{TerminalWidth: 120},
+		"unified":          &UnifiedFormatter{TerminalWidth: 120},
+		"unified expanded": &UnifiedFormatter{TerminalWidth: 120, Expanded: true},
+	}
+	for name, f := range formatters {
+		t.Run(name, func(t *testing.T) {
+			lines := strings.Split(f.Format(edits), "\n")
+			hunks := f.HunkLines(edits)
+			if len(hunks) != 2 {
+				t.Fatalf("HunkLines() = %v, want 2 hunks", hunks)
+			}
+			if !strings.Contains(lines[hunks[0]], "1.0.0") {
+				t.Errorf("first hunk should start at the replaced line, got %q", lines[hunks[0]])
+			}
+			if !strings.Contains(lines[hunks[1]], "second addition") {
+				t.Errorf("second hunk should start at the last insertion, got %q", lines[hunks[1]])
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package diff
+
+// maxChainLength is the most occurrences a line may have in the old sequence
+// and still anchor a [Histogram] split. Regions made only of more common
+// lines are handed to [Myers].
+const maxChainLength = 64
+
+// Histogram implements git's histogram diff.
+//
+// It anchors on the longest common run of lines that occur least often in the
+// old sequence, then recurses on the lines before and after that run. Rare
+// lines such as function signatures make better anchors than the blank lines
+// and closing braces that dominate most files, so repetitive files diff into
+// more readable hunks than with [Myers], and far faster than [LCS]. Ranges
+// without a usable anchor fall back to [Myers].
+type Histogram struct{}
+
+// Name returns algorithm name.
+func (h *Histogram) Name() string {
+	return "Histogram"
+}
+
+// Compute computes the diff edits needed to transform a into b.
+func (h *Histogram) Compute(a, b []string) ([]Edit, error) {
+	s := newHistogramState(a, b)
+	return s.diff(make([]Edit, 0, max(len(a), len(b))), 0, len(a), 0, len(b))
+}
+
+// histogramState holds the sequences interned to line IDs, so lines compare
+// as integers, and the occurrence chains reused by every recursion step.
+type histogramState struct {
+	a, b     []string
+	aID, bID []int
+	// head[id] is the first index of id in the range being scanned, and
+	// next[i] the following index of a[i]'s line; -1 ends a chain.
+	head, next []int
+	count      []int
+}
+
+func newHistogramState(a, b []string) *histogramState {
+	ids := make(map[string]int)
+	intern := func(lines []string) []int {
+		out := make([]int, len(lines))
+		for i, line := range lines {
+			id, ok := ids[line]
+			if !ok {
+				id = len(ids)
+				ids[line] = id
+			}
+			out[i] = id
+		}
+		return out
+	}
+
+	s := &histogramState{a: a, b: b, aID: intern(a), bID: intern(b)}
+	s.head = make([]int, len(ids))
+	for i := range s.head {
+		s.head[i] = -1
+	}
+	s.next = make([]int, len(a))
+	s.count = make([]int, len(ids))
+	return s
+}
+
+// histogramRegion is a run of lines common to both sequences.
+type histogramRegion struct {
+	aStart, bStart, length int
+	// lowCount is the fewest occurrences in the old range of any line in the run.
+	lowCount int
+}
+
+// diff appends the edits transforming a[aLo:aHi] into b[bLo:bHi].
+func (s *histogramState) diff(edits []Edit, aLo, aHi, bLo, bHi int) ([]Edit, error) {
+	for aLo < aHi && bLo < bHi && s.aID[aLo] == s.bID[bLo] {
+		edits = append(edits, Edit{Kind: Equal, AIndex: aLo, BIndex: bLo, Content: s.a[aLo]})
+		aLo++
+		bLo++
+	}
+
+	var suffix []Edit
+	for aLo < aHi && bLo < bHi && s.aID[aHi-1] == s.bID[bHi-1] {
+		aHi--
+		bHi--
+		suffix = append(suffix, Edit{Kind: Equal, AIndex: aHi, BIndex: bHi, Content: s.a[aHi]})
+	}
+
+	var err error
+	switch {
+	case aLo == aHi || bLo == bHi:
+		for i := aLo; i < aHi; i++ {
+			edits = append(edits, Edit{Kind: Delete, AIndex: i, BIndex: -1, Content: s.a[i]})
+		}
+		for j := bLo; j < bHi; j++ {
+			edits = append(edits, Edit{Kind: Insert, AIndex: -1, BIndex: j, Content: s.b[j]})
+		}
+	default:
+		region, found := s.findRegion(aLo, aHi, bLo, bHi)
+		if !found {
+			edits, err = appendMyers(edits, s.a, s.b, aLo, aHi, bLo, bHi)
+			break
+		}
+
+		if edits, err = s.diff(edits, aLo, region.aStart, bLo, region.bStart); err != nil {
+			return nil, err
+		}
+		for k := range region.length {
+			i, j := region.aStart+k, region.bStart+k
+			edits = append(edits, Edit{Kind: Equal, AIndex: i, BIndex: j, Content: s.a[i]})
+		}
+		edits, err = s.diff(edits, region.aStart+region.length, aHi, region.bStart+region.length, bHi)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(suffix) - 1; i >= 0; i-- {
+		edits = append(edits, suffix[i])
+	}
+	return edits, nil
+}
+
+// findRegion finds the common run anchored on the rarest lines of a[aLo:aHi],
+// preferring longer runs among equally rare ones. found is false when every
+// shared line occurs more than [maxChainLength] times, or none do.
+func (s *histogramState) findRegion(aLo, aHi, bLo, bHi int) (best histogramRegion, found bool) {
+	for i := aHi - 1; i >= aLo; i-- {
+		id := s.aID[i]
+		s.next[i] = s.head[id]
+		s.head[id] = i
+		s.count[id]++
+	}
+	defer func() {
+		for i := aLo; i < aHi; i++ {
+			s.head[s.aID[i]] = -1
+			s.count[s.aID[i]] = 0
+		}
+	}()
+
+	best.lowCount = maxChainLength + 1
+	// Lines inside a matched run are not rescanned: any run through them is
+	// the one already measured.
+	for j := bLo; j < bHi; {
+		next := j + 1
+		id := s.bID[j]
+		if s.count[id] == 0 || s.count[id] > best.lowCount {
+			j = next
+			continue
+		}
+
+		for i := s.head[id]; i >= 0; i = s.next[i] {
+			start, bStart := i, j
+			for start > aLo && bStart > bLo && s.aID[start-1] == s.bID[bStart-1] {
+				start--
+				bStart--
+			}
+			end, bEnd := i+1, j+1
+			for end < aHi && bEnd < bHi && s.aID[end] == s.bID[bEnd] {
+				end++
+				bEnd++
+			}
+
+			lowCount := s.count[id]
+			for k := start; k < end; k++ {
+				lowCount = min(lowCount, s.count[s.aID[k]])
+			}
+
+			length := end - start
+			if lowCount < best.lowCount || (lowCount == best.lowCount && length > best.length) {
+				best = histogramRegion{aStart: start, bStart: bStart, length: length, lowCount: lowCount}
+				found = true
+			}
+			next = max(next, bEnd)
+		}
+		j = next
+	}
+	return best, found
+}
+
+// appendMyers appends the [Myers] edits for a[aLo:aHi] and b[bLo:bHi],
+// shifted to indexes in the full sequences.
+func appendMyers(edits []Edit, a, b []string, aLo, aHi, bLo, bHi int) ([]Edit, error) {
+	sub, err := (&Myers{}).Compute(a[aLo:aHi], b[bLo:bHi])
+	if err != nil {
+		return nil, err
+	}
+	for _, edit := range sub {
+		if edit.AIndex >= 0 {
+			edit.AIndex += aLo
+		}
+		if edit.BIndex >= 0 {
+			edit.BIndex += bLo
+		}
+		edits = append(edits, edit)
+	}
+	return edits, nil
+}
@@ -0,0 +1,291 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PatchContext is the number of unchanged lines surrounding each patch hunk.
+const PatchContext = 3
+
+// noNewlineMarker tags a final line that lacks a trailing newline so it never
+// compares equal to the same text followed by a newline.
+const noNewlineMarker = "\x00"
+
+// FilePatch holds both sides of a single file for patch generation.
+type FilePatch struct {
+	Path       string
+	OldContent string
+	NewContent string
+	// Created and Deleted mark a file missing on the old or new side. Empty
+	// content on its own is an empty file that exists.
+	Created bool
+	Deleted bool
+	// OldPath, Copied, and Similarity describe a rename or copy; see [PatchFormatter].
+	OldPath    string
+	Copied     bool
+	Similarity int
+}
+
+// FormatGitPatch renders a single file's changes as a git-style unified patch
+// that `git apply` accepts. Returns an empty string when the sides are identical.
+// Binary files get headers and a "Binary files ... differ" line instead of hunks.
+func FormatGitPatch(file FilePatch) (string, error) {
+	formatter := &PatchFormatter{
+		Path:       file.Path,
+		OldPath:    file.OldPath,
+		Copied:     file.Copied,
+		Similarity: file.Similarity,
+		Created:    file.Created,
+		Deleted:    file.Deleted,
+	}
+	if file.OldContent == file.NewContent {
+		return formatter.Format(nil), nil
+	}
+	if IsBinary(file.OldContent) || IsBinary(file.NewContent) {
+		return formatBinaryPatch(file), nil
+	}
+
+	oldLines := splitPatchLines(file.OldContent)
+	newLines := splitPatchLines(file.NewContent)
+
+	myers := &Myers{}
+	edits, err := myers.Compute(oldLines, newLines)
+	if err != nil {
+		return "", fmt.Errorf("failed to diff %s: %w", file.Path, err)
+	}
+	return formatter.Format(edits), nil
+}
+
+// PatchFormatter renders a single file's edits as a git-style unified patch,
+// with diff --git and ---/+++ file headers and @@ hunks, that `git apply`
+// accepts.
+//
+// Replace edits are written as a deletion followed by an insertion. Created
+// and Deleted write the missing side as /dev/null; a side with no lines is
+// otherwise an empty file. When OldPath is set, the patch renames (or copies)
+// OldPath to Path.
+type PatchFormatter struct {
+	// Path is the file's repository-relative path, used for both sides
+	// unless OldPath is set.
+	Path string
+	// OldPath is the path the file was renamed or copied from, or empty.
+	OldPath string
+	// Copied writes copy headers instead of rename headers.
+	Copied bool
+	// Similarity is the percentage written as the rename's similarity index.
+	Similarity int
+	// Created marks the file as new and Deleted as removed.
+	Created bool
+	Deleted bool
+	// Context is the number of unchanged lines around each hunk.
+	// Zero uses [PatchContext].
+	Context int
+}
+
+// renamed reports whether the patch moves the file to a new path.
+func (f *PatchFormatter) renamed() bool {
+	return f.OldPath != "" && f.OldPath != f.Path
+}
+
+// Format renders the edits as a patch, or an empty string when none of them
+// change anything and the file keeps its path. Creating or deleting an empty
+// file writes headers only.
+func (f *PatchFormatter) Format(edits []Edit) string {
+	edits = expandReplacements(edits)
+
+	changed := false
+	for _, e := range edits {
+		changed = changed || e.Kind != Equal
+	}
+	if !changed && !f.renamed() && !f.Created && !f.Deleted {
+		return ""
+	}
+
+	context := f.Context
+	if context <= 0 {
+		context = PatchContext
+	}
+
+	oldPath := f.Path
+	var sb strings.Builder
+	if f.renamed() {
+		oldPath = f.OldPath
+		verb := "rename"
+		if f.Copied {
+			verb = "copy"
+		}
+		fmt.Fprintf(&sb, "diff --git a/%s b/%s\n", oldPath, f.Path)
+		fmt.Fprintf(&sb, "similarity index %d%%\n", f.Similarity)
+		fmt.Fprintf(&sb, "%s from %s\n%s to %s\n", verb, oldPath, verb, f.Path)
+		if !changed {
+			return sb.String()
+		}
+	} else {
+		fmt.Fprintf(&sb, "diff --git a/%s b/%s\n", f.Path, f.Path)
+	}
+	oldName, newName := "a/"+oldPath, "b/"+f.Path
+	switch {
+	case f.Created:
+		sb.WriteString("new file mode 100644\n")
+		oldName = "/dev/null"
+	case f.Deleted:
+		sb.WriteString("deleted file mode 100644\n")
+		newName = "/dev/null"
+	}
+	if !changed {
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", oldName, newName)
+
+	writeHunks(&sb, edits, context)
+	return sb.String()
+}
+
+// formatBinaryPatch writes the "Binary files ... differ" stub git emits for a
+// binary change without --binary.
+func formatBinaryPatch(file FilePatch) string {
+	oldPath := file.Path
+	if file.OldPath != "" {
+		oldPath = file.OldPath
+	}
+	oldName, newName := "a/"+oldPath, "b/"+file.Path
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "diff --git a/%s b/%s\n", oldPath, file.Path)
+	switch {
+	case file.Created:
+		sb.WriteString("new file mode 100644\n")
+		oldName = "/dev/null"
+	case file.Deleted:
+		sb.WriteString("deleted file mode 100644\n")
+		newName = "/dev/null"
+	}
+	fmt.Fprintf(&sb, "Binary files %s and %s differ\n", oldName, newName)
+	return sb.String()
+}
+
+// FormatMultiPatch concatenates the patches of every changed file into one
+// patch that can be applied with a single `git apply`. Unchanged files are skipped.
+func FormatMultiPatch(files []FilePatch) (string, error) {
+	var sb strings.Builder
+	for _, file := range files {
+		patch, err := FormatGitPatch(file)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(patch)
+	}
+	return sb.String(), nil
+}
+
+// splitPatchLines splits content into lines, tagging a final line without a
+// trailing newline with [noNewlineMarker].
+func splitPatchLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+
+	lines := strings.Split(content, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += noNewlineMarker
+	return lines
+}
+
+// expandReplacements rewrites Replace edits as a Delete followed by an Insert.
+func expandReplacements(edits []Edit) []Edit {
+	expanded := make([]Edit, 0, len(edits))
+	for _, e := range edits {
+		if e.Kind == Replace {
+			expanded = append(expanded,
+				Edit{Kind: Delete, AIndex: e.AIndex, BIndex: -1, Content: e.Content},
+				Edit{Kind: Insert, AIndex: -1, BIndex: e.BIndex, Content: e.NewContent},
+			)
+			continue
+		}
+		expanded = append(expanded, e)
+	}
+	return expanded
+}
+
+// writeHunks groups edits into hunks with context lines of surrounding
+// unchanged text and writes them with @@ headers.
+func writeHunks(sb *strings.Builder, edits []Edit, context int) {
+	// aPos[i] and bPos[i] count old and new lines preceding edits[i].
+	aPos := make([]int, len(edits)+1)
+	bPos := make([]int, len(edits)+1)
+	for i, e := range edits {
+		aPos[i+1], bPos[i+1] = aPos[i], bPos[i]
+		if e.Kind != Insert {
+			aPos[i+1]++
+		}
+		if e.Kind != Delete {
+			bPos[i+1]++
+		}
+	}
+
+	i := 0
+	for i < len(edits) {
+		for i < len(edits) && edits[i].Kind == Equal {
+			i++
+		}
+		if i == len(edits) {
+			return
+		}
+
+		start := max(i-context, 0)
+		end := i
+		for end < len(edits) {
+			if edits[end].Kind != Equal {
+				end++
+				continue
+			}
+			run := end
+			for run < len(edits) && edits[run].Kind == Equal {
+				run++
+			}
+			if run == len(edits) || run-end > 2*context {
+				end = min(end+context, len(edits))
+				break
+			}
+			end = run
+		}
+
+		oldCount := aPos[end] - aPos[start]
+		newCount := bPos[end] - bPos[start]
+		oldStart, newStart := aPos[start], bPos[start]
+		if oldCount > 0 {
+			oldStart++
+		}
+		if newCount > 0 {
+			newStart++
+		}
+		fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount))
+
+		for _, e := range edits[start:end] {
+			prefix := " "
+			switch e.Kind {
+			case Insert:
+				prefix = "+"
+			case Delete:
+				prefix = "-"
+			}
+			text, missingNewline := strings.CutSuffix(e.Content, noNewlineMarker)
+			sb.WriteString(prefix + text + "\n")
+			if missingNewline {
+				sb.WriteString("\\ No newline at end of file\n")
+			}
+		}
+
+		i = end
+	}
+}
+
+// hunkRange formats a hunk's start and count, omitting a count of one.
+func hunkRange(start, count int) string {
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
@@ -0,0 +1,264 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatGitPatch(t *testing.T) {
+	tests := []struct {
+		name string
+		file FilePatch
+		want string
+	}{
+		{
+			name: "identical",
+			file: FilePatch{Path: "a.txt", OldContent: "same\n", NewContent: "same\n"},
+			want: "",
+		},
+		{
+			name: "modified line",
+			file: FilePatch{Path: "a.txt", OldContent: "one\ntwo\nthree\n", NewContent: "one\nTWO\nthree\n"},
+			want: "diff --git a/a.txt b/a.txt\n" +
+				"--- a/a.txt\n" +
+				"+++ b/a.txt\n" +
+				"@@ -1,3 +1,3 @@\n" +
+				" one\n" +
+				"-two\n" +
+				"+TWO\n" +
+				" three\n",
+		},
+		{
+			name: "new file",
+			file: FilePatch{Path: "new.txt", NewContent: "hello\n", Created: true},
+			want: "diff --git a/new.txt b/new.txt\n" +
+				"new file mode 100644\n" +
+				"--- /dev/null\n" +
+				"+++ b/new.txt\n" +
+				"@@ -0,0 +1 @@\n" +
+				"+hello\n",
+		},
+		{
+			name: "deleted file",
+			file: FilePatch{Path: "old.txt", OldContent: "bye\nnow\n", Deleted: true},
+			want: "diff --git a/old.txt b/old.txt\n" +
+				"deleted file mode 100644\n" +
+				"--- a/old.txt\n" +
+				"+++ /dev/null\n" +
+				"@@ -1,2 +0,0 @@\n" +
+				"-bye\n" +
+				"-now\n",
+		},
+		{
+			name: "new empty file",
+			file: FilePatch{Path: "empty.txt", Created: true},
+			want: "diff --git a/empty.txt b/empty.txt\n" +
+				"new file mode 100644\n",
+		},
+		{
+			name: "deleted empty file",
+			file: FilePatch{Path: "empty.txt", Deleted: true},
+			want: "diff --git a/empty.txt b/empty.txt\n" +
+				"deleted file mode 100644\n",
+		},
+		{
+			name: "fill empty file",
+			file: FilePatch{Path: "a.txt", NewContent: "hello\n"},
+			want: "diff --git a/a.txt b/a.txt\n" +
+				"--- a/a.txt\n" +
+				"+++ b/a.txt\n" +
+				"@@ -0,0 +1 @@\n" +
+				"+hello\n",
+		},
+		{
+			name: "truncate to empty",
+			file: FilePatch{Path: "a.txt", OldContent: "bye\n"},
+			want: "diff --git a/a.txt b/a.txt\n" +
+				"--- a/a.txt\n" +
+				"+++ b/a.txt\n" +
+				"@@ -1 +0,0 @@\n" +
+				"-bye\n",
+		},
+		{
+			name: "missing trailing newline",
+			file: FilePatch{Path: "a.txt", OldContent: "one\ntwo", NewContent: "one\ntwo\n"},
+			want: "diff --git a/a.txt b/a.txt\n" +
+				"--- a/a.txt\n" +
+				"+++ b/a.txt\n" +
+				"@@ -1,2 +1,2 @@\n" +
+				" one\n" +
+				"-two\n" +
+				"\\ No newline at end of file\n" +
+				"+two\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatGitPatch(tt.file)
+			if err != nil {
+				t.Fatalf("FormatGitPatch() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FormatGitPatch() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatGitPatch_SplitsDistantHunks(t *testing.T) {
+	var oldLines []string
+	for i := range 20 {
+		oldLines = append(oldLines, strings.Repeat("x", i+1))
+	}
+	newLines := append([]string(nil), oldLines...)
+	newLines[1] = "changed early"
+	newLines[18] = "changed late"
+
+	got, err := FormatGitPatch(FilePatch{
+		Path:       "a.txt",
+		OldContent: strings.Join(oldLines, "\n") + "\n",
+		NewContent: strings.Join(newLines, "\n") + "\n",
+	})
+	if err != nil {
+		t.Fatalf("FormatGitPatch() error = %v", err)
+	}
+
+	if n := strings.Count(got, "@@ -"); n != 2 {
+		t.Fatalf("expected 2 hunks, got %d:\n%s", n, got)
+	}
+	if !strings.Contains(got, "@@ -1,5 +1,5 @@") {
+		t.Errorf("expected leading hunk header, got:\n%s", got)
+	}
+	if !strings.Contains(got, "@@ -16,5 +16,5 @@") {
+		t.Errorf("expected trailing hunk header, got:\n%s", got)
+	}
+}
+
+func TestFormatMultiPatch(t *testing.T) {
+	got, err := FormatMultiPatch([]FilePatch{
+		{Path: "a.txt", OldContent: "a\n", NewContent: "b\n"},
+		{Path: "same.txt", OldContent: "s\n", NewContent: "s\n"},
+		{Path: "dir/c.txt", NewContent: "c\n", Created: true},
+	})
+	if err != nil {
+		t.Fatalf("FormatMultiPatch() error = %v", err)
+	}
+
+	for _, header := range []string{"diff --git a/a.txt b/a.txt\n", "diff --git a/dir/c.txt b/dir/c.txt\n"} {
+		if !strings.Contains(got, header) {
+			t.Errorf("expected %q in patch:\n%s", header, got)
+		}
+	}
+	if strings.Contains(got, "same.txt") {
+		t.Errorf("unchanged files should be skipped:\n%s", got)
+	}
+}
+
+func TestPatchFormatter(t *testing.T) {
+	var _ Formatter = &PatchFormatter{}
+
+	var edits []Edit
+	for i := range 6 {
+		edits = append(edits, Edit{Kind: Equal, AIndex: i, BIndex: i, Content: "same"})
+	}
+	edits = append(edits, Edit{Kind: Replace, AIndex: 6, BIndex: 6, Content: "old", NewContent: "new"})
+
+	got := (&PatchFormatter{Path: "a.txt", Context: 1}).Format(edits)
+	want := "diff --git a/a.txt b/a.txt\n" +
+		"--- a/a.txt\n" +
+		"+++ b/a.txt\n" +
+		"@@ -6,2 +6,2 @@\n" +
+		" same\n" +
+		"-old\n" +
+		"+new\n"
+	if got != want {
+		t.Errorf("Format() =\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := (&PatchFormatter{Path: "a.txt"}).Format(edits[:6]); got != "" {
+		t.Errorf("Format() of unchanged edits = %q, want empty", got)
+	}
+
+	inserted := []Edit{{Kind: Insert, AIndex: -1, BIndex: 0, Content: "hello"}}
+	if got := (&PatchFormatter{Path: "new.txt", Created: true}).Format(inserted); !strings.Contains(got, "--- /dev/null\n+++ b/new.txt\n") {
+		t.Errorf("Format() of only insertions should create the file, got:\n%s", got)
+	}
+}
+
+func TestFormatGitPatch_Binary(t *testing.T) {
+	tests := []struct {
+		name string
+		file FilePatch
+		want string
+	}{
+		{
+			name: "modified",
+			file: FilePatch{Path: "logo.png", OldContent: "\x89PNG\x00a", NewContent: "\x89PNG\x00b"},
+			want: "diff --git a/logo.png b/logo.png\nBinary files a/logo.png and b/logo.png differ\n",
+		},
+		{
+			name: "created",
+			file: FilePatch{Path: "logo.png", NewContent: "\x89PNG\x00", Created: true},
+			want: "diff --git a/logo.png b/logo.png\nnew file mode 100644\nBinary files /dev/null and b/logo.png differ\n",
+		},
+		{
+			name: "deleted",
+			file: FilePatch{Path: "logo.png", OldContent: "\x89PNG\x00", Deleted: true},
+			want: "diff --git a/logo.png b/logo.png\ndeleted file mode 100644\nBinary files a/logo.png and /dev/null differ\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatGitPatch(tt.file)
+			if err != nil {
+				t.Fatalf("FormatGitPatch() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FormatGitPatch() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatGitPatch_Rename(t *testing.T) {
+	tests := []struct {
+		name string
+		file FilePatch
+		want string
+	}{
+		{
+			name: "pure rename",
+			file: FilePatch{Path: "docs/a.txt", OldPath: "a.txt", OldContent: "same\n", NewContent: "same\n", Similarity: 100},
+			want: "diff --git a/a.txt b/docs/a.txt\n" +
+				"similarity index 100%\n" +
+				"rename from a.txt\n" +
+				"rename to docs/a.txt\n",
+		},
+		{
+			name: "copy with edits",
+			file: FilePatch{Path: "b.txt", OldPath: "a.txt", OldContent: "one\ntwo\n", NewContent: "one\nthree\n", Copied: true, Similarity: 50},
+			want: "diff --git a/a.txt b/b.txt\n" +
+				"similarity index 50%\n" +
+				"copy from a.txt\n" +
+				"copy to b.txt\n" +
+				"--- a/a.txt\n" +
+				"+++ b/b.txt\n" +
+				"@@ -1,2 +1,2 @@\n" +
+				" one\n" +
+				"-two\n" +
+				"+three\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatGitPatch(tt.file)
+			if err != nil {
+				t.Fatalf("FormatGitPatch() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FormatGitPatch() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package diff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stormlightlabs/git-storm/internal/style"
+)
+
+// Theme holds the styles formatters apply to diff content.
+type Theme struct {
+	// Added styles inserted lines and their gutter markers
+	Added lipgloss.Style
+	// Removed styles deleted lines and their gutter markers
+	Removed lipgloss.Style
+	// Context styles unchanged lines
+	Context lipgloss.Style
+}
+
+// DefaultTheme returns the built-in storm palette.
+func DefaultTheme() Theme {
+	return Theme{
+		Added:   style.StyleAdded,
+		Removed: style.StyleRemoved,
+		Context: style.StyleText,
+	}
+}
+
+// resolveTheme returns t, or [DefaultTheme] when t is nil.
+func resolveTheme(t *Theme) Theme {
+	if t == nil {
+		return DefaultTheme()
+	}
+	return *t
+}
+
+// gitColorNames maps git's named colors to ANSI color indexes.
+var gitColorNames = map[string]int{
+	"black":   0,
+	"red":     1,
+	"green":   2,
+	"yellow":  3,
+	"blue":    4,
+	"magenta": 5,
+	"cyan":    6,
+	"white":   7,
+}
+
+// ThemeFromGitColors maps git's color.diff.* slots onto a [Theme].
+//
+// colors is keyed by slot name as in `git config color.diff.<slot>`: "new"
+// styles additions, "old" deletions, and "context" (or its older alias
+// "plain") unchanged lines. Missing or unparseable slots keep the
+// [DefaultTheme] style.
+func ThemeFromGitColors(colors map[string]string) Theme {
+	theme := DefaultTheme()
+	if st, ok := gitSlotStyle(colors["new"]); ok {
+		theme.Added = st
+	}
+	if st, ok := gitSlotStyle(colors["old"]); ok {
+		theme.Removed = st
+	}
+	context := colors["context"]
+	if context == "" {
+		context = colors["plain"]
+	}
+	if st, ok := gitSlotStyle(context); ok {
+		theme.Context = st
+	}
+	return theme
+}
+
+// gitSlotStyle parses a configured slot value, reporting false when it is unset or invalid.
+func gitSlotStyle(value string) (lipgloss.Style, bool) {
+	if strings.TrimSpace(value) == "" {
+		return lipgloss.Style{}, false
+	}
+	st, err := ParseGitColor(value)
+	return st, err == nil
+}
+
+// ParseGitColor converts a git color value such as "green bold" or
+// "#ff0000 black ul" into a lipgloss style.
+//
+// The first color is the foreground and the second the background. Colors
+// may be names (optionally "bright"-prefixed), 0-255 indexes, or #RRGGBB;
+// "normal" and "default" leave a slot unset. Attributes bold, dim, italic,
+// ul, reverse, blink, and strike are supported, and "no"/"no-" prefixes are
+// accepted and ignored.
+func ParseGitColor(value string) (lipgloss.Style, error) {
+	st := lipgloss.NewStyle()
+	colorSlot := 0
+
+	for word := range strings.FieldsSeq(strings.ToLower(value)) {
+		if strings.HasPrefix(word, "no") && isGitColorAttribute(strings.TrimPrefix(strings.TrimPrefix(word, "no"), "-")) {
+			continue
+		}
+		if isGitColorAttribute(word) {
+			st = applyGitColorAttribute(st, word)
+			continue
+		}
+
+		color, err := parseGitColorWord(word)
+		if err != nil {
+			return lipgloss.NewStyle(), err
+		}
+		switch colorSlot {
+		case 0:
+			if color != "" {
+				st = st.Foreground(color)
+			}
+		case 1:
+			if color != "" {
+				st = st.Background(color)
+			}
+		default:
+			return lipgloss.NewStyle(), fmt.Errorf("too many colors in %q", value)
+		}
+		colorSlot++
+	}
+	return st, nil
+}
+
+// parseGitColorWord converts one git color word to a lipgloss color; "" means unset.
+func parseGitColorWord(word string) (lipgloss.Color, error) {
+	switch {
+	case word == "normal" || word == "default":
+		return "", nil
+	case strings.HasPrefix(word, "#") && len(word) == 7:
+		if _, err := strconv.ParseUint(word[1:], 16, 32); err != nil {
+			return "", fmt.Errorf("invalid hex color %q", word)
+		}
+		return lipgloss.Color(word), nil
+	case strings.HasPrefix(word, "bright"):
+		if index, ok := gitColorNames[strings.TrimPrefix(word, "bright")]; ok {
+			return lipgloss.Color(strconv.Itoa(index + 8)), nil
+		}
+	default:
+		if index, ok := gitColorNames[word]; ok {
+			return lipgloss.Color(strconv.Itoa(index)), nil
+		}
+		if index, err := strconv.Atoi(word); err == nil && index >= 0 && index <= 255 {
+			return lipgloss.Color(word), nil
+		}
+	}
+	return "", fmt.Errorf("unknown color %q", word)
+}
+
+// isGitColorAttribute reports whether word is a git color attribute.
+func isGitColorAttribute(word string) bool {
+	switch word {
+	case "bold", "dim", "italic", "ul", "reverse", "blink", "strike":
+		return true
+	}
+	return false
+}
+
+// applyGitColorAttribute sets the lipgloss equivalent of a git color attribute.
+func applyGitColorAttribute(st lipgloss.Style, attr string) lipgloss.Style {
+	switch attr {
+	case "bold":
+		return st.Bold(true)
+	case "dim":
+		return st.Faint(true)
+	case "italic":
+		return st.Italic(true)
+	case "ul":
+		return st.Underline(true)
+	case "reverse":
+		return st.Reverse(true)
+	case "blink":
+		return st.Blink(true)
+	case "strike":
+		return st.Strikethrough(true)
+	}
+	return st
+}
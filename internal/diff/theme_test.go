@@ -0,0 +1,103 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestParseGitColor(t *testing.T) {
+	tests := []struct {
+		value     string
+		fg, bg    lipgloss.TerminalColor
+		bold, ul  bool
+		wantError bool
+	}{
+		{value: "green", fg: lipgloss.Color("2"), bg: lipgloss.NoColor{}},
+		{value: "red bold", fg: lipgloss.Color("1"), bg: lipgloss.NoColor{}, bold: true},
+		{value: "brightblue black ul", fg: lipgloss.Color("12"), bg: lipgloss.Color("0"), ul: true},
+		{value: "#FF8800", fg: lipgloss.Color("#ff8800"), bg: lipgloss.NoColor{}},
+		{value: "normal 22", fg: lipgloss.NoColor{}, bg: lipgloss.Color("22")},
+		{value: "208 nobold", fg: lipgloss.Color("208"), bg: lipgloss.NoColor{}},
+		{value: "chartreuse", wantError: true},
+		{value: "red green blue", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			st, err := ParseGitColor(tt.value)
+			if tt.wantError {
+				if err == nil {
+					t.Fatalf("ParseGitColor(%q) expected error", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseGitColor(%q) error = %v", tt.value, err)
+			}
+			if got := st.GetForeground(); got != tt.fg {
+				t.Errorf("foreground = %v, want %v", got, tt.fg)
+			}
+			if got := st.GetBackground(); got != tt.bg {
+				t.Errorf("background = %v, want %v", got, tt.bg)
+			}
+			if st.GetBold() != tt.bold {
+				t.Errorf("bold = %v, want %v", st.GetBold(), tt.bold)
+			}
+			if st.GetUnderline() != tt.ul {
+				t.Errorf("underline = %v, want %v", st.GetUnderline(), tt.ul)
+			}
+		})
+	}
+}
+
+func TestThemeFromGitColors(t *testing.T) {
+	theme := ThemeFromGitColors(map[string]string{
+		"new":   "cyan",
+		"old":   "not-a-color",
+		"plain": "white dim",
+	})
+
+	if got := theme.Added.GetForeground(); got != lipgloss.Color("6") {
+		t.Errorf("Added foreground = %v, want cyan (6)", got)
+	}
+	if got, want := theme.Removed.GetForeground(), DefaultTheme().Removed.GetForeground(); got != want {
+		t.Errorf("invalid old color should fall back to the default, got %v want %v", got, want)
+	}
+	if got := theme.Context.GetForeground(); got != lipgloss.Color("7") || !theme.Context.GetFaint() {
+		t.Errorf("plain should style context lines, got fg %v faint %v", got, theme.Context.GetFaint())
+	}
+
+	if got, want := ThemeFromGitColors(nil).Added.GetForeground(), DefaultTheme().Added.GetForeground(); got != want {
+		t.Errorf("empty config should keep the default theme, got %v want %v", got, want)
+	}
+}
+
+func TestFormatters_ApplyTheme(t *testing.T) {
+	theme := &Theme{
+		Added:   lipgloss.NewStyle().Transform(strings.ToUpper),
+		Removed: lipgloss.NewStyle().Transform(func(s string) string { return strings.ReplaceAll(s, "old", "OLD") }),
+		Context: lipgloss.NewStyle(),
+	}
+	edits := []Edit{
+		{Kind: Equal, AIndex: 0, BIndex: 0, Content: "same"},
+		{Kind: Delete, AIndex: 1, BIndex: -1, Content: "old line"},
+		{Kind: Insert, AIndex: -1, BIndex: 1, Content: "new line"},
+	}
+
+	for name, formatter := range map[string]Formatter{
+		"split":   &SideBySideFormatter{TerminalWidth: 120, Theme: theme},
+		"unified": &UnifiedFormatter{TerminalWidth: 120, Theme: theme},
+	} {
+		t.Run(name, func(t *testing.T) {
+			output := formatter.Format(edits)
+			if !strings.Contains(output, "NEW LINE") {
+				t.Errorf("added style should be applied:\n%s", output)
+			}
+			if !strings.Contains(output, "OLD line") {
+				t.Errorf("removed style should be applied:\n%s", output)
+			}
+		})
+	}
+}
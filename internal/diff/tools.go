@@ -59,8 +59,6 @@ type DiffTool interface {
 }
 
 // UnifiedDiff implements unified view (single linear view with additions & deletions).
-//
-// TODO: Support pluggable diff algorithms beyond Myers.
 type UnifiedDiff struct {
 	// TerminalWidth is the total available width for rendering
 	TerminalWidth int
@@ -108,8 +106,6 @@ func (u *UnifiedDiff) Diff(oldContent io.Reader, newContent io.Reader, viewKind
 }
 
 // SplitDiff implements side-by-side view (old on left, new on right).
-//
-// TODO: Support pluggable diff algorithms beyond Myers.
 type SplitDiff struct {
 	// TerminalWidth is the total available width for rendering
 	TerminalWidth int
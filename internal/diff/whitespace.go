@@ -0,0 +1,113 @@
+package diff
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// blankLine matches lines holding nothing but whitespace.
+var blankLine = regexp.MustCompile(`^\s*$`)
+
+// Whitespace selects the whitespace differences a diff ignores, like git's
+// -w, -b, and --ignore-blank-lines. The zero value ignores nothing.
+type Whitespace struct {
+	// IgnoreAll compares lines with all whitespace removed.
+	IgnoreAll bool
+	// IgnoreChange ignores whitespace at line end and treats every other run
+	// of whitespace as a single space.
+	IgnoreChange bool
+	// IgnoreBlankLines treats changes whose lines are all blank as unchanged.
+	IgnoreBlankLines bool
+}
+
+// normalize returns the form of line that is compared.
+func (w Whitespace) normalize(line string) string {
+	switch {
+	case w.IgnoreAll:
+		return strings.Map(func(r rune) rune {
+			if unicode.IsSpace(r) {
+				return -1
+			}
+			return r
+		}, line)
+	case w.IgnoreChange:
+		return collapseSpace(line)
+	default:
+		return line
+	}
+}
+
+// collapseSpace drops trailing whitespace and replaces each remaining run of
+// whitespace with one space.
+func collapseSpace(line string) string {
+	var sb strings.Builder
+	space := false
+	for _, r := range strings.TrimRightFunc(line, unicode.IsSpace) {
+		if unicode.IsSpace(r) {
+			space = true
+			continue
+		}
+		if space {
+			sb.WriteByte(' ')
+			space = false
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// IgnoreWhitespace wraps d so that lines differing only in whitespace w
+// ignores compare equal. Edits keep the original lines, with unchanged lines
+// taken from the new side. d is returned as is when w is the zero value.
+func IgnoreWhitespace(d Diff, w Whitespace) Diff {
+	if w == (Whitespace{}) {
+		return d
+	}
+	return &whitespaceDiff{diff: d, whitespace: w}
+}
+
+// whitespaceDiff compares normalized lines with an underlying algorithm.
+type whitespaceDiff struct {
+	diff       Diff
+	whitespace Whitespace
+}
+
+func (d *whitespaceDiff) Name() string { return d.diff.Name() }
+
+// Compute diffs the normalized lines of a and b, then restores the original
+// lines in the resulting edits.
+func (d *whitespaceDiff) Compute(a, b []string) ([]Edit, error) {
+	edits, err := d.diff.Compute(d.normalizeAll(a), d.normalizeAll(b))
+	if err != nil {
+		return nil, err
+	}
+
+	for i, e := range edits {
+		switch e.Kind {
+		case Equal, Insert:
+			edits[i].Content = b[e.BIndex]
+		case Delete:
+			edits[i].Content = a[e.AIndex]
+		case Replace:
+			edits[i].Content, edits[i].NewContent = a[e.AIndex], b[e.BIndex]
+		}
+	}
+
+	if d.whitespace.IgnoreBlankLines {
+		edits = IgnoreMatchingLines(edits, blankLine)
+	}
+	return edits, nil
+}
+
+// normalizeAll normalizes every line, sharing lines when nothing changes.
+func (d *whitespaceDiff) normalizeAll(lines []string) []string {
+	if !d.whitespace.IgnoreAll && !d.whitespace.IgnoreChange {
+		return lines
+	}
+	normalized := make([]string, len(lines))
+	for i, line := range lines {
+		normalized[i] = d.whitespace.normalize(line)
+	}
+	return normalized
+}
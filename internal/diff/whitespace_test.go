@@ -0,0 +1,99 @@
+package diff
+
+import (
+	"testing"
+)
+
+func TestWhitespace_Normalize(t *testing.T) {
+	tests := []struct {
+		name string
+		ws   Whitespace
+		line string
+		want string
+	}{
+		{name: "zero value", ws: Whitespace{}, line: "  a  b \t", want: "  a  b \t"},
+		{name: "ignore all", ws: Whitespace{IgnoreAll: true}, line: " a \tb ", want: "ab"},
+		{name: "ignore change", ws: Whitespace{IgnoreChange: true}, line: "\t a \t b  ", want: " a b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ws.normalize(tt.line); got != tt.want {
+				t.Errorf("normalize(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIgnoreWhitespace(t *testing.T) {
+	myers := &Myers{}
+	if IgnoreWhitespace(myers, Whitespace{}) != Diff(myers) {
+		t.Error("IgnoreWhitespace() with the zero value should return the algorithm unchanged")
+	}
+
+	a := []string{"func f() {", "\treturn 1", "}"}
+	b := []string{"func f()  {", "    return 1", "}"}
+
+	tests := []struct {
+		name    string
+		ws      Whitespace
+		changed int
+	}{
+		{name: "nothing ignored", ws: Whitespace{IgnoreBlankLines: true}, changed: 4},
+		{name: "space change", ws: Whitespace{IgnoreChange: true}, changed: 0},
+		{name: "all whitespace", ws: Whitespace{IgnoreAll: true}, changed: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edits, err := IgnoreWhitespace(myers, tt.ws).Compute(a, b)
+			if err != nil {
+				t.Fatalf("Compute() error = %v", err)
+			}
+			changed := 0
+			for _, e := range edits {
+				if e.Kind != Equal {
+					changed++
+				}
+			}
+			if changed != tt.changed {
+				t.Errorf("changed edits = %d, want %d: %+v", changed, tt.changed, edits)
+			}
+		})
+	}
+
+	edits, err := IgnoreWhitespace(myers, Whitespace{IgnoreChange: true}).Compute(a, b)
+	if err != nil {
+		t.Fatalf("Compute() error = %v", err)
+	}
+	if edits[1].Content != "    return 1" {
+		t.Errorf("unchanged lines should keep the new side's text, got %q", edits[1].Content)
+	}
+
+	t.Run("leading whitespace still counts for space change", func(t *testing.T) {
+		edits, err := IgnoreWhitespace(myers, Whitespace{IgnoreChange: true}).Compute([]string{"x"}, []string{" x"})
+		if err != nil {
+			t.Fatalf("Compute() error = %v", err)
+		}
+		if len(edits) == 1 && edits[0].Kind == Equal {
+			t.Error("adding indentation should remain a change with IgnoreChange")
+		}
+	})
+}
+
+func TestIgnoreWhitespace_BlankLines(t *testing.T) {
+	a := []string{"one", "two"}
+	b := []string{"one", "", "  ", "two", "three"}
+
+	edits, err := IgnoreWhitespace(&Myers{}, Whitespace{IgnoreBlankLines: true}).Compute(a, b)
+	if err != nil {
+		t.Fatalf("Compute() error = %v", err)
+	}
+	var changed []string
+	for _, e := range edits {
+		if e.Kind != Equal {
+			changed = append(changed, e.Content)
+		}
+	}
+	if len(changed) != 1 || changed[0] != "three" {
+		t.Errorf("only the non-blank insertion should remain, got %q", changed)
+	}
+}
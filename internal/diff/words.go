@@ -0,0 +1,139 @@
+package diff
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxWordTokens bounds the tokens per line diffed by [WordSpans]; longer lines
+// are highlighted as a whole.
+const maxWordTokens = 500
+
+// Span is a byte range [Start, End) within a line.
+type Span struct {
+	Start int
+	End   int
+}
+
+// AddWordSpans fills OldSpans and NewSpans of every Replace edit with the
+// changed words between its old and new content (see [WordSpans]).
+//
+// The input slice is not modified.
+func AddWordSpans(edits []Edit) []Edit {
+	result := make([]Edit, len(edits))
+	copy(result, edits)
+	for i, edit := range result {
+		if edit.Kind == Replace {
+			result[i].OldSpans, result[i].NewSpans = WordSpans(edit.Content, edit.NewContent)
+		}
+	}
+	return result
+}
+
+// WordSpans diffs two versions of a line word by word and returns the byte
+// ranges that changed in each.
+//
+// Lines are split into words (letters, digits, and underscores), whitespace
+// runs, and single punctuation characters, so a changed argument in a function
+// signature yields just that argument. Both results are nil when the lines
+// share less than half their text, since highlighting nearly every word is
+// noisier than coloring the whole line.
+func WordSpans(a, b string) (oldSpans, newSpans []Span) {
+	tokensA, tokensB := wordTokens(a), wordTokens(b)
+	if len(tokensA)+len(tokensB) > maxWordTokens {
+		return nil, nil
+	}
+
+	edits, err := (&Myers{}).Compute(tokensA, tokensB)
+	if err != nil {
+		return nil, nil
+	}
+
+	offsetsA, offsetsB := tokenOffsets(tokensA), tokenOffsets(tokensB)
+	common := 0
+	for _, edit := range edits {
+		switch edit.Kind {
+		case Equal:
+			common += len(edit.Content)
+		case Delete:
+			oldSpans = appendSpan(oldSpans, Span{offsetsA[edit.AIndex], offsetsA[edit.AIndex+1]})
+		case Insert:
+			newSpans = appendSpan(newSpans, Span{offsetsB[edit.BIndex], offsetsB[edit.BIndex+1]})
+		}
+	}
+
+	if common*2 < max(len(a), len(b)) {
+		return nil, nil
+	}
+	return oldSpans, newSpans
+}
+
+// appendSpan appends s, extending the last span instead when they touch.
+func appendSpan(spans []Span, s Span) []Span {
+	if n := len(spans); n > 0 && spans[n-1].End == s.Start {
+		spans[n-1].End = s.End
+		return spans
+	}
+	return append(spans, s)
+}
+
+// tokenOffsets returns the byte offset of each token followed by the total length.
+func tokenOffsets(tokens []string) []int {
+	offsets := make([]int, len(tokens)+1)
+	for i, token := range tokens {
+		offsets[i+1] = offsets[i] + len(token)
+	}
+	return offsets
+}
+
+// wordTokens splits s into words, whitespace runs, and single other characters.
+func wordTokens(s string) []string {
+	var tokens []string
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		class := runeClass(r)
+		j := i + size
+		if class != classOther {
+			for j < len(s) {
+				next, nextSize := utf8.DecodeRuneInString(s[j:])
+				if runeClass(next) != class {
+					break
+				}
+				j += nextSize
+			}
+		}
+		tokens = append(tokens, s[i:j])
+		i = j
+	}
+	return tokens
+}
+
+const (
+	classOther = iota
+	classWord
+	classSpace
+)
+
+func runeClass(r rune) int {
+	switch {
+	case r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+		return classWord
+	case unicode.IsSpace(r):
+		return classSpace
+	default:
+		return classOther
+	}
+}
+
+// detabSpans detabs s like [detab] and shifts spans to match.
+func detabSpans(s string, spans []Span, tabWidth int) (string, []Span) {
+	shift := func(i int) int {
+		return i + strings.Count(s[:i], "\t")*(tabWidth-1)
+	}
+	shifted := make([]Span, len(spans))
+	for i, span := range spans {
+		shifted[i] = Span{shift(span.Start), shift(span.End)}
+	}
+	return detab(s, tabWidth), shifted
+}
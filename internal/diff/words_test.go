@@ -0,0 +1,151 @@
+package diff
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestWordSpans(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		old, new []Span
+	}{
+		{
+			name: "changed argument",
+			a:    "func foo(a int, b string) error {",
+			b:    "func foo(a int, c string) error {",
+			old:  []Span{{16, 17}},
+			new:  []Span{{16, 17}},
+		},
+		{
+			name: "adjacent insertions merge",
+			a:    "return foo(bar)",
+			b:    "return foo(ctx, bar)",
+			old:  nil,
+			new:  []Span{{11, 16}},
+		},
+		{
+			name: "identical lines",
+			a:    "x := 1",
+			b:    "x := 1",
+		},
+		{
+			name: "mostly rewritten line",
+			a:    "alpha beta gamma",
+			b:    "one two three",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old, new := WordSpans(tt.a, tt.b)
+			if !slices.Equal(old, tt.old) {
+				t.Errorf("old spans = %v, want %v", old, tt.old)
+			}
+			if !slices.Equal(new, tt.new) {
+				t.Errorf("new spans = %v, want %v", new, tt.new)
+			}
+		})
+	}
+}
+
+func TestWordSpans_MultiByte(t *testing.T) {
+	a, b := "naïve café", "naïve thé"
+	old, new := WordSpans(a, b)
+	if len(old) != 1 || a[old[0].Start:old[0].End] != "café" {
+		t.Errorf("old spans = %v, want the range of %q", old, "café")
+	}
+	if len(new) != 1 || b[new[0].Start:new[0].End] != "thé" {
+		t.Errorf("new spans = %v, want the range of %q", new, "thé")
+	}
+}
+
+func TestAddWordSpans(t *testing.T) {
+	edits := []Edit{
+		{Kind: Equal, AIndex: 0, BIndex: 0, Content: "package main"},
+		{Kind: Replace, AIndex: 1, BIndex: 1, Content: "var x = 1", NewContent: "var x = 2"},
+	}
+
+	result := AddWordSpans(edits)
+
+	if edits[1].OldSpans != nil || edits[1].NewSpans != nil {
+		t.Error("AddWordSpans should not modify its input")
+	}
+	if result[0].OldSpans != nil {
+		t.Errorf("Equal edits should carry no spans, got %v", result[0].OldSpans)
+	}
+	if !slices.Equal(result[1].OldSpans, []Span{{8, 9}}) || !slices.Equal(result[1].NewSpans, []Span{{8, 9}}) {
+		t.Errorf("Replace spans = %v/%v, want [{8 9}]", result[1].OldSpans, result[1].NewSpans)
+	}
+}
+
+func TestRenderWords(t *testing.T) {
+	renderer := lipgloss.NewRenderer(io.Discard)
+	renderer.SetColorProfile(termenv.TrueColor)
+	base := renderer.NewStyle().Foreground(lipgloss.Color("#FF0000"))
+	emphasis := base.Reverse(true)
+	identity := func(s string) string { return s }
+
+	t.Run("emphasizes spans", func(t *testing.T) {
+		got := renderWords("-", "foo(a, b)", []Span{{7, 8}}, base, identity)
+		want := base.Render("-foo(a, ") + emphasis.Render("b") + base.Render(")")
+		if got != want {
+			t.Errorf("renderWords() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("shifts spans past tabs", func(t *testing.T) {
+		got := renderWords("", "\tx = 1", []Span{{5, 6}}, base, identity)
+		want := base.Render(strings.Repeat(" ", 8)+"x = ") + emphasis.Render("1")
+		if got != want {
+			t.Errorf("renderWords() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("drops spans past truncation", func(t *testing.T) {
+		truncate := func(s string) string { return s[:4] + "..." }
+		got := renderWords("", "abc def ghi", []Span{{2, 5}, {8, 11}}, base, truncate)
+		want := base.Render("ab") + emphasis.Render("c ") + base.Render("...")
+		if got != want {
+			t.Errorf("renderWords() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("no spans styles the whole line", func(t *testing.T) {
+		got := renderWords("+", "foo", nil, base, identity)
+		if want := base.Render("+foo"); got != want {
+			t.Errorf("renderWords() = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestFormatters_WordHighlight(t *testing.T) {
+	edits := []Edit{
+		{Kind: Delete, AIndex: 0, BIndex: -1, Content: `const Version = "1.2.3"`},
+		{Kind: Insert, AIndex: -1, BIndex: 0, Content: `const Version = "1.2.4"`},
+	}
+
+	formatters := map[string]Formatter{
+		"split":   &SideBySideFormatter{TerminalWidth: 120, Expanded: true},
+		"unified": &UnifiedFormatter{TerminalWidth: 120, Expanded: true},
+	}
+	for name, f := range formatters {
+		t.Run(name, func(t *testing.T) {
+			out := f.Format(edits)
+			if !strings.Contains(out, `const Version = "1.2.3"`) || !strings.Contains(out, `const Version = "1.2.4"`) {
+				t.Errorf("word highlighting should keep both lines intact, got:\n%s", out)
+			}
+		})
+	}
+
+	merged := AddWordSpans(MergeReplacements(edits))
+	if len(merged) != 1 || !slices.Equal(merged[0].NewSpans, []Span{{21, 22}}) {
+		t.Errorf("expected one Replace edit highlighting the patch digit, got %+v", merged)
+	}
+}
@@ -0,0 +1,154 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the GitHub REST API root.
+const DefaultBaseURL = "https://api.github.com"
+
+// Release is the payload for creating a GitHub release.
+type Release struct {
+	TagName    string `json:"tag_name"`
+	Name       string `json:"name"`
+	Body       string `json:"body"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+// Client calls the GitHub REST API with a token.
+type Client struct {
+	// BaseURL is the API root; empty uses [DefaultBaseURL].
+	BaseURL    string
+	Token      string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a client for the API at baseURL, or [DefaultBaseURL]
+// when baseURL is empty.
+func NewClient(baseURL, token string) *Client {
+	return &Client{BaseURL: baseURL, Token: token, HTTPClient: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// CreateRelease creates release in owner/repo and returns its web URL.
+func (c *Client) CreateRelease(ctx context.Context, owner, repo string, release Release) (string, error) {
+	payload, err := json.Marshal(release)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode release: %w", err)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/%s/releases", owner, repo), bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, body, err := c.do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to create release: %w", err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to create release: %s", apiError(resp.Status, body))
+	}
+
+	var created struct {
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.Unmarshal(body, &created); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	return created.HTMLURL, nil
+}
+
+// newRequest builds a request for the API path, e.g. "/repos/owner/repo/releases",
+// authenticated with the client's token when it has one.
+func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(baseURL, "/")+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	return req, nil
+}
+
+// do sends req and reads the whole response body.
+func (c *Client) do(req *http.Request) (*http.Response, []byte, error) {
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return resp, body, nil
+}
+
+// apiError describes a failed response, preferring GitHub's error message.
+func apiError(status string, body []byte) string {
+	var msg struct {
+		Message string `json:"message"`
+		Errors  []struct {
+			Code string `json:"code"`
+		} `json:"errors"`
+	}
+	if json.Unmarshal(body, &msg) != nil || msg.Message == "" {
+		return status
+	}
+	var codes []string
+	for _, e := range msg.Errors {
+		if e.Code != "" {
+			codes = append(codes, e.Code)
+		}
+	}
+	if len(codes) > 0 {
+		return fmt.Sprintf("%s: %s (%s)", status, msg.Message, strings.Join(codes, ", "))
+	}
+	return fmt.Sprintf("%s: %s", status, msg.Message)
+}
+
+// APIURL returns the REST API root for a GitHub host: [DefaultBaseURL] for
+// github.com, and https://<host>/api/v3 for GitHub Enterprise Server.
+func APIURL(host string) string {
+	if host == "github.com" {
+		return DefaultBaseURL
+	}
+	return "https://" + host + "/api/v3"
+}
+
+// ParseRepoURL splits a GitHub repository URL such as
+// https://github.com/owner/repo into its host, owner, and repo. Any host is
+// accepted so GitHub Enterprise repositories parse too.
+func ParseRepoURL(repoURL string) (host, owner, repo string, err error) {
+	rest, ok := strings.CutPrefix(strings.TrimSuffix(repoURL, ".git"), "https://")
+	if !ok {
+		return "", "", "", fmt.Errorf("not a GitHub repository URL: %s", repoURL)
+	}
+	host, path, _ := strings.Cut(rest, "/")
+	owner, repo, ok = strings.Cut(strings.Trim(path, "/"), "/")
+	if !ok || host == "" || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", "", "", fmt.Errorf("not a GitHub repository URL: %s", repoURL)
+	}
+	return host, owner, repo, nil
+}
@@ -0,0 +1,73 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/git-storm/internal/testutils"
+)
+
+func TestCreateRelease(t *testing.T) {
+	var got Release
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testutils.Expect.Equal(t, r.Method, http.MethodPost)
+		testutils.Expect.Equal(t, r.URL.Path, "/repos/owner/repo/releases")
+		testutils.Expect.Equal(t, r.Header.Get("Authorization"), "Bearer tok")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"html_url": "https://github.com/owner/repo/releases/tag/v1.0.0"}`))
+	}))
+	defer server.Close()
+
+	release := Release{TagName: "v1.0.0", Name: "v1.0.0", Body: "### Added\n\n- Feature\n", Draft: true}
+	url, err := NewClient(server.URL+"/", "tok").CreateRelease(context.Background(), "owner", "repo", release)
+	if err != nil {
+		t.Fatalf("CreateRelease() error = %v", err)
+	}
+	testutils.Expect.Equal(t, url, "https://github.com/owner/repo/releases/tag/v1.0.0")
+	testutils.Expect.Equal(t, got, release)
+}
+
+func TestCreateRelease_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"message": "Validation Failed", "errors": [{"code": "already_exists"}]}`))
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL, "tok").CreateRelease(context.Background(), "owner", "repo", Release{TagName: "v1.0.0"})
+	if err == nil || !strings.Contains(err.Error(), "422 Unprocessable Entity: Validation Failed (already_exists)") {
+		t.Errorf("CreateRelease() error = %v, want GitHub's validation message", err)
+	}
+}
+
+func TestParseRepoURL(t *testing.T) {
+	host, owner, repo, err := ParseRepoURL("https://github.com/stormlightlabs/git-storm.git")
+	if err != nil {
+		t.Fatalf("ParseRepoURL() error = %v", err)
+	}
+	testutils.Expect.Equal(t, host+"/"+owner+"/"+repo, "github.com/stormlightlabs/git-storm")
+
+	host, owner, repo, err = ParseRepoURL("https://github.example.com/team/app")
+	if err != nil {
+		t.Fatalf("ParseRepoURL() error = %v", err)
+	}
+	testutils.Expect.Equal(t, host+"/"+owner+"/"+repo, "github.example.com/team/app")
+
+	for _, bad := range []string{"git@github.com:owner/repo", "https://github.com/owner", "https://github.com/a/b/c"} {
+		if _, _, _, err := ParseRepoURL(bad); err == nil {
+			t.Errorf("ParseRepoURL(%q) should fail", bad)
+		}
+	}
+}
+
+func TestAPIURL(t *testing.T) {
+	testutils.Expect.Equal(t, APIURL("github.com"), DefaultBaseURL)
+	testutils.Expect.Equal(t, APIURL("github.example.com"), "https://github.example.com/api/v3")
+}
@@ -0,0 +1,134 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// noreplyRegex matches GitHub's private commit emails, such as
+// 12345+octocat@users.noreply.github.com or octocat@users.noreply.github.com.
+var noreplyRegex = regexp.MustCompile(`(?i)^(?:\d+\+)?([a-z0-9-]+)@users\.noreply\.github\.com$`)
+
+// NoreplyLogin returns the login embedded in a GitHub noreply email.
+func NoreplyLogin(email string) (string, bool) {
+	m := noreplyRegex.FindStringSubmatch(email)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// UserLogin returns the login of the GitHub user whose public email is
+// email, or "" when no user lists it.
+func (c *Client) UserLogin(ctx context.Context, email string) (string, error) {
+	query := url.Values{"q": {email + " in:email"}}
+	req, err := c.newRequest(ctx, http.MethodGet, "/search/users?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, body, err := c.do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up %s: %w", email, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to look up %s: %s", email, apiError(resp.Status, body))
+	}
+
+	var result struct {
+		Items []struct {
+			Login string `json:"login"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(result.Items) != 1 {
+		return "", nil
+	}
+	return result.Items[0].Login, nil
+}
+
+// LoginCache maps commit emails to GitHub logins, stored as a JSON object at
+// Path so each email is looked up once. An empty login records an email no
+// user lists.
+type LoginCache struct {
+	Path   string
+	logins map[string]string
+}
+
+// LoadLoginCache reads the cache at path; a missing file yields an empty cache.
+func LoadLoginCache(path string) (*LoginCache, error) {
+	cache := &LoginCache{Path: path, logins: make(map[string]string)}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cache, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read login cache: %w", err)
+	}
+	if err := json.Unmarshal(data, &cache.logins); err != nil {
+		return nil, fmt.Errorf("failed to parse login cache %s: %w", path, err)
+	}
+	return cache, nil
+}
+
+// Lookup returns the cached login for email and whether email is cached.
+func (c *LoginCache) Lookup(email string) (string, bool) {
+	login, ok := c.logins[strings.ToLower(email)]
+	return login, ok
+}
+
+// Store records login for email.
+func (c *LoginCache) Store(email, login string) {
+	c.logins[strings.ToLower(email)] = login
+}
+
+// Save writes the cache to its path.
+func (c *LoginCache) Save() error {
+	data, err := json.MarshalIndent(c.logins, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode login cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(c.Path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write login cache: %w", err)
+	}
+	return nil
+}
+
+// LoginResolver finds the GitHub logins of commit authors, reading noreply
+// emails directly and asking the API only for emails not in its cache. With
+// a nil Client, only noreply emails and the cache are used.
+type LoginResolver struct {
+	Client *Client
+	Cache  *LoginCache
+}
+
+// Login returns the login for email, or "" when it can't be found.
+func (r *LoginResolver) Login(ctx context.Context, email string) (string, error) {
+	if login, ok := NoreplyLogin(email); ok {
+		return login, nil
+	}
+	if login, ok := r.Cache.Lookup(email); ok {
+		return login, nil
+	}
+	if r.Client == nil {
+		return "", nil
+	}
+	login, err := r.Client.UserLogin(ctx, email)
+	if err != nil {
+		return "", err
+	}
+	r.Cache.Store(email, login)
+	return login, nil
+}
@@ -0,0 +1,75 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stormlightlabs/git-storm/internal/testutils"
+)
+
+func TestNoreplyLogin(t *testing.T) {
+	login, ok := NoreplyLogin("12345+octocat@users.noreply.github.com")
+	testutils.Expect.True(t, ok)
+	testutils.Expect.Equal(t, login, "octocat")
+
+	login, ok = NoreplyLogin("octocat@users.noreply.github.com")
+	testutils.Expect.True(t, ok)
+	testutils.Expect.Equal(t, login, "octocat")
+
+	_, ok = NoreplyLogin("octocat@example.com")
+	testutils.Expect.False(t, ok)
+}
+
+func TestLoginResolver(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		testutils.Expect.Equal(t, r.URL.Path, "/search/users")
+		testutils.Expect.Equal(t, r.Header.Get("Authorization"), "")
+		switch r.URL.Query().Get("q") {
+		case "jane@example.com in:email":
+			w.Write([]byte(`{"items": [{"login": "jane"}]}`))
+		default:
+			w.Write([]byte(`{"items": []}`))
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "logins.json")
+	cache, err := LoadLoginCache(path)
+	if err != nil {
+		t.Fatalf("LoadLoginCache() error = %v", err)
+	}
+	resolver := &LoginResolver{Client: NewClient(server.URL, ""), Cache: cache}
+
+	for _, tt := range []struct{ email, want string }{
+		{"jane@example.com", "jane"},
+		{"Jane@Example.com", "jane"},
+		{"nobody@example.com", ""},
+		{"nobody@example.com", ""},
+		{"1+octocat@users.noreply.github.com", "octocat"},
+	} {
+		login, err := resolver.Login(context.Background(), tt.email)
+		if err != nil {
+			t.Fatalf("Login(%s) error = %v", tt.email, err)
+		}
+		testutils.Expect.Equal(t, login, tt.want, tt.email)
+	}
+	testutils.Expect.Equal(t, requests, 2, "cached and noreply emails skip the API")
+
+	if err := cache.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	reloaded, err := LoadLoginCache(path)
+	if err != nil {
+		t.Fatalf("LoadLoginCache() error = %v", err)
+	}
+	login, ok := reloaded.Lookup("jane@example.com")
+	testutils.Expect.True(t, ok)
+	testutils.Expect.Equal(t, login, "jane")
+	_, ok = reloaded.Lookup("nobody@example.com")
+	testutils.Expect.True(t, ok, "misses are cached too")
+}
@@ -1,13 +1,25 @@
 package gitlog
 
 import (
+	"container/heap"
+	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/config"
 	"github.com/go-git/go-git/v6/plumbing"
 	"github.com/go-git/go-git/v6/plumbing/object"
+	"github.com/go-git/go-git/v6/utils/merkletrie"
 )
 
 const ShaLen = 7
@@ -69,6 +81,9 @@ type CommitMeta struct {
 	Breaking    bool
 	Body        string
 	Footers     map[string]string
+	Ticket      string // ticket ID stripped from the subject, if any
+	Fixup       string // target subject of a fixup!/squash!/amend! commit, if any
+	PullRequest int    // number of the pull request a merge or squash-merge commit landed, if any
 }
 
 // CommitParser defines parsing of raw commit message strings into structured metadata.
@@ -90,7 +105,154 @@ type DefaultParser struct{}
 
 // ConventionalParser implements [CommitParser] and parses
 // conventional commits into one or more [CommitMeta]
-type ConventionalParser struct{}
+type ConventionalParser struct {
+	// TicketPattern, when set, is matched against the start of each subject
+	// and the match removed before parsing (e.g. "[ABC-1] feat: x"). The first
+	// capture group, or the whole match without brackets and colons, is
+	// recorded as [CommitMeta.Ticket].
+	TicketPattern *regexp.Regexp
+	// KeepFixups categorizes fixup!/squash!/amend! commits like their target
+	// instead of skipping them.
+	KeepFixups bool
+	// Categories overrides the built-in commit type to changelog category
+	// mapping used by [ConventionalParser.Categorize]. An empty category
+	// skips commits of that type.
+	Categories map[string]string
+	// MergeTitles parses GitHub merge commits by the title of the pull
+	// request they merged, as if it were squashed, instead of leaving them
+	// uncategorized. Use it with [RangeOptions.FirstParent], where the merge
+	// commit stands for the whole branch.
+	MergeTitles bool
+}
+
+// fixupPrefixes are the subject prefixes git uses for autosquash commits.
+var fixupPrefixes = []string{"fixup! ", "squash! ", "amend! "}
+
+// stripFixup removes leading autosquash prefixes from subject, returning the
+// target subject and whether any prefix was present.
+func stripFixup(subject string) (string, bool) {
+	found := false
+	for {
+		trimmed := subject
+		for _, prefix := range fixupPrefixes {
+			trimmed = strings.TrimPrefix(trimmed, prefix)
+		}
+		if trimmed == subject {
+			return subject, found
+		}
+		subject, found = trimmed, true
+	}
+}
+
+// stripTicket removes a leading ticket prefix matched by pattern from subject,
+// returning the remaining subject and the captured ticket ID.
+func stripTicket(subject string, pattern *regexp.Regexp) (string, string) {
+	loc := pattern.FindStringSubmatchIndex(subject)
+	if loc == nil || loc[0] != 0 {
+		return subject, ""
+	}
+
+	ticket := subject[loc[0]:loc[1]]
+	if len(loc) >= 4 && loc[2] >= 0 {
+		ticket = subject[loc[2]:loc[3]]
+	}
+	ticket = strings.Trim(ticket, "[]: \t")
+
+	return strings.TrimSpace(subject[loc[1]:]), ticket
+}
+
+// footerRegex matches the token that starts a conventional commit footer,
+// e.g. "BREAKING CHANGE: x", "Signed-off-by: A <a@b>", or "Refs #12".
+var footerRegex = regexp.MustCompile(`^(BREAKING CHANGE|[A-Za-z][A-Za-z0-9-]*)(?:: ?| #)`)
+
+// footerKey splits a footer line into its token and value.
+func footerKey(line string) (key, value string, ok bool) {
+	m := footerRegex.FindStringSubmatch(line)
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], line[len(m[0]):], true
+}
+
+// StripFooters returns a commit body without its trailing footer paragraphs
+// (trailers such as Signed-off-by, Refs, or BREAKING CHANGE), trimmed of
+// surrounding whitespace.
+func StripFooters(body string) string {
+	paragraphs := strings.Split(strings.TrimSpace(strings.ReplaceAll(body, "\r\n", "\n")), "\n\n")
+	for len(paragraphs) > 0 {
+		if _, _, ok := footerKey(paragraphs[len(paragraphs)-1]); !ok {
+			break
+		}
+		paragraphs = paragraphs[:len(paragraphs)-1]
+	}
+	return strings.TrimSpace(strings.Join(paragraphs, "\n\n"))
+}
+
+// ReferenceRegex matches issue and pull request references such as "#123"
+// or "GH-123", including those after closing keywords like "Closes #123".
+// It skips URL fragments, HTML entities, and references already inside a
+// Markdown link. The groups capture the preceding character, the prefix, and
+// the number.
+var ReferenceRegex = regexp.MustCompile(`(^|[^\w/&#\[-])(#|GH-)(\d+)\b`)
+
+// ParseReferences returns the issue and pull request numbers referenced in
+// text, in order of first appearance.
+func ParseReferences(text string) []int {
+	var refs []int
+	for _, m := range ReferenceRegex.FindAllStringSubmatch(text, -1) {
+		n, err := strconv.Atoi(m[3])
+		if err != nil || n == 0 || slices.Contains(refs, n) {
+			continue
+		}
+		refs = append(refs, n)
+	}
+	return refs
+}
+
+// mergePullRequestRegex matches the subject of a GitHub merge commit and
+// captures the pull request number.
+var mergePullRequestRegex = regexp.MustCompile(`^Merge pull request #(\d+) from \S+`)
+
+// squashMergeRegex matches the pull request number GitHub appends to the
+// subject of a squash merge, e.g. "feat: add login (#12)".
+var squashMergeRegex = regexp.MustCompile(`\s+\(#(\d+)\)$`)
+
+// PullRequestTitle returns the title and number of the pull request a commit
+// landed. A GitHub merge commit names the number in its subject and holds
+// the title on the first body line; a squash merge's subject is the title
+// followed by the number. ok is false for other commits.
+func PullRequestTitle(subject, body string) (title string, number int, ok bool) {
+	if m := mergePullRequestRegex.FindStringSubmatch(subject); m != nil {
+		number, _ = strconv.Atoi(m[1])
+		for _, line := range splitLines(body) {
+			if line = strings.TrimSpace(line); line != "" {
+				return line, number, true
+			}
+		}
+		return "", number, true
+	}
+	if loc := squashMergeRegex.FindStringSubmatchIndex(subject); loc != nil {
+		number, _ = strconv.Atoi(subject[loc[2]:loc[3]])
+		return subject[:loc[0]], number, true
+	}
+	return "", 0, false
+}
+
+// coAuthorRegex matches a "Co-authored-by: Name <email>" trailer line.
+var coAuthorRegex = regexp.MustCompile(`(?im)^co-authored-by:\s*(.*?)\s*<([^>]+)>\s*$`)
+
+// CoAuthors returns the people credited in a commit body's Co-authored-by
+// trailers, in order and without duplicate emails. Their When is zero.
+func CoAuthors(body string) []object.Signature {
+	var authors []object.Signature
+	for _, m := range coAuthorRegex.FindAllStringSubmatch(body, -1) {
+		if slices.ContainsFunc(authors, func(a object.Signature) bool { return strings.EqualFold(a.Email, m[2]) }) {
+			continue
+		}
+		authors = append(authors, object.Signature{Name: m[1], Email: m[2]})
+	}
+	return authors
+}
 
 // Parse parses a conventional commit message into structured metadata.
 //
@@ -99,11 +261,38 @@ type ConventionalParser struct{}
 //	type(scope): description or type(scope)!: description
 //
 // Breaking changes can also be indicated by BREAKING CHANGE: in footer.
+//
+// Autosquash prefixes (fixup!, squash!, amend!) are stripped and the target
+// subject recorded in [CommitMeta.Fixup]; the rest is parsed as the target.
+//
+// The pull request a merge or squash merge landed is recorded in
+// [CommitMeta.PullRequest]. With [ConventionalParser.MergeTitles], a merge
+// commit is parsed as "<pull request title> (#N)", with the rest of its body.
 func (p *ConventionalParser) Parse(hash, subject, body string, date time.Time) (CommitMeta, error) {
 	meta := CommitMeta{
 		Footers: make(map[string]string),
 	}
 
+	if title, number, ok := PullRequestTitle(subject, body); ok {
+		meta.PullRequest = number
+		if p.MergeTitles && mergePullRequestRegex.MatchString(subject) && title != "" {
+			subject = fmt.Sprintf("%s (#%d)", title, number)
+			_, body, _ = strings.Cut(strings.TrimLeft(body, "\r\n"), "\n")
+			body = strings.TrimLeft(body, "\r\n")
+		}
+	}
+
+	if target, ok := stripFixup(subject); ok {
+		subject = target
+		meta.Fixup = target
+	}
+
+	ticket := ""
+	if p.TicketPattern != nil {
+		subject, ticket = stripTicket(subject, p.TicketPattern)
+	}
+	meta.Ticket = ticket
+
 	rest := subject
 
 	colonIdx := -1
@@ -119,6 +308,9 @@ func (p *ConventionalParser) Parse(hash, subject, body string, date time.Time) (
 			Type:        "unknown",
 			Description: subject,
 			Body:        body,
+			Ticket:      ticket,
+			Fixup:       meta.Fixup,
+			PullRequest: meta.PullRequest,
 		}, nil
 	}
 
@@ -176,30 +368,12 @@ func (p *ConventionalParser) Parse(hash, subject, body string, date time.Time) (
 
 		for _, line := range lines {
 			if len(line) > 0 && !inFooter {
-				colonIdx := -1
-				for i := 0; i < len(line); i++ {
-					if line[i] == ':' {
-						colonIdx = i
-						break
-					}
-				}
-				if colonIdx != -1 {
-					key := line[:colonIdx]
-					value := ""
-					if colonIdx+1 < len(line) {
-						value = line[colonIdx+1:]
-						if len(value) > 0 && value[0] == ' ' {
-							value = value[1:]
-						}
-					}
-
-					if key == "BREAKING CHANGE" || key == "BREAKING-CHANGE" {
-						meta.Breaking = true
-						inFooter = true
-						currentFooter = key
-						currentValue = value
-						continue
-					}
+				if key, value, ok := footerKey(line); ok && (key == "BREAKING CHANGE" || key == "BREAKING-CHANGE") {
+					meta.Breaking = true
+					inFooter = true
+					currentFooter = key
+					currentValue = value
+					continue
 				}
 			}
 
@@ -233,8 +407,17 @@ func (p *ConventionalParser) IsValidType(kind CommitKind) bool {
 	return kind != CommitTypeUnknown
 }
 
-// Categorize maps a CommitMeta to a changelog category.
+// Categorize maps a CommitMeta to a changelog category. Fixup commits map to
+// no category unless [ConventionalParser.KeepFixups] is set.
 func (p *ConventionalParser) Categorize(meta CommitMeta) string {
+	if meta.Fixup != "" && !p.KeepFixups {
+		return ""
+	}
+
+	if category, ok := p.Categories[meta.Type]; ok {
+		return category
+	}
+
 	switch meta.Type {
 	case "feat":
 		return "added"
@@ -294,74 +477,380 @@ func ParseRefArgs(args []string) (from, to string) {
 	return args[0], args[1]
 }
 
-// GetCommitRange returns commits reachable from toRef but not from fromRef.
-// This implements git log from..to range semantics.
-func GetCommitRange(repo *git.Repository, fromRef, toRef string) ([]*object.Commit, error) {
-	fromHash, err := repo.ResolveRevision(plumbing.Revision(fromRef))
+// OpenRepo opens the repository at path, which may be a worktree, a bare
+// repository, or any directory below a worktree's root (discovered by walking
+// up like git does). Linked worktrees share their main repository's objects.
+//
+// When no repository is found the error wraps [git.ErrRepositoryNotExists].
+func OpenRepo(path string) (*git.Repository, error) {
+	repo, err := git.PlainOpenWithOptions(path, &git.PlainOpenOptions{EnableDotGitCommonDir: true})
+	if err == nil {
+		return repo, nil
+	}
+	if !errors.Is(err, git.ErrRepositoryNotExists) {
+		return nil, err
+	}
+
+	repo, err = git.PlainOpenWithOptions(path, &git.PlainOpenOptions{DetectDotGit: true, EnableDotGitCommonDir: true})
+	if err == nil {
+		return repo, nil
+	}
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		abs, absErr := filepath.Abs(path)
+		if absErr != nil {
+			abs = path
+		}
+		return nil, fmt.Errorf("no git repository found at or above %s: %w", abs, err)
+	}
+	return nil, err
+}
+
+// DiffColors returns git's color.diff.<slot> settings keyed by lowercased slot
+// name (e.g. "new", "old", "context").
+//
+// System, global, and repository config are layered in that order, so the
+// repository's settings win. An empty map means no diff colors are configured.
+func DiffColors(repo *git.Repository) (map[string]string, error) {
+	colors := make(map[string]string)
+	for _, scope := range []config.Scope{config.SystemScope, config.GlobalScope} {
+		cfg, err := config.LoadConfig(scope)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load git config: %w", err)
+		}
+		addDiffColors(colors, cfg)
+	}
+
+	local, err := repo.Config()
 	if err != nil {
-		return nil, fmt.Errorf("failed to resolve %s: %w", fromRef, err)
+		return nil, fmt.Errorf("failed to load repository config: %w", err)
 	}
+	addDiffColors(colors, local)
+	return colors, nil
+}
 
-	toHash, err := repo.ResolveRevision(plumbing.Revision(toRef))
+// addDiffColors copies the [color "diff"] options of cfg into colors.
+func addDiffColors(colors map[string]string, cfg *config.Config) {
+	if cfg == nil || cfg.Raw == nil || !cfg.Raw.HasSection("color") {
+		return
+	}
+	section := cfg.Raw.Section("color")
+	if !section.HasSubsection("diff") {
+		return
+	}
+	for _, opt := range section.Subsection("diff").Options {
+		colors[strings.ToLower(opt.Key)] = opt.Value
+	}
+}
+
+// TagInfo describes a tag resolved to the commit it points at.
+type TagInfo struct {
+	Name      string
+	Commit    *object.Commit
+	Annotated bool
+	// Date is the tagger date for annotated tags and the committer date for lightweight ones.
+	Date time.Time
+}
+
+// ResolveTag resolves a tag name to its underlying commit, peeling annotated
+// tags (including tags of tags) so both tag types yield the same commit.
+func ResolveTag(repo *git.Repository, name string) (TagInfo, error) {
+	ref, err := repo.Tag(name)
 	if err != nil {
-		return nil, fmt.Errorf("failed to resolve %s: %w", toRef, err)
+		return TagInfo{}, fmt.Errorf("failed to resolve tag %s: %w", name, err)
+	}
+
+	info := TagInfo{Name: name}
+	hash := ref.Hash()
+	for {
+		tag, err := repo.TagObject(hash)
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			break
+		}
+		if err != nil {
+			return TagInfo{}, fmt.Errorf("failed to read tag %s: %w", name, err)
+		}
+		if !info.Annotated {
+			info.Annotated = true
+			info.Date = tag.Tagger.When
+		}
+		hash = tag.Target
 	}
 
-	toCommits := make(map[plumbing.Hash]bool)
-	toIter, err := repo.Log(&git.LogOptions{From: *toHash})
+	commit, err := repo.CommitObject(hash)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get commits from %s: %w", toRef, err)
+		return TagInfo{}, fmt.Errorf("tag %s does not point at a commit: %w", name, err)
+	}
+	info.Commit = commit
+	if !info.Annotated {
+		info.Date = commit.Committer.When
 	}
+	return info, nil
+}
 
-	err = toIter.ForEach(func(c *object.Commit) error {
-		toCommits[c.Hash] = true
+// ListTags returns the names of every tag in the repository, sorted.
+func ListTags(repo *git.Repository) ([]string, error) {
+	iter, err := repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	defer iter.Close()
+
+	var names []string
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		names = append(names, ref.Name().Short())
 		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to iterate commits from %s: %w", toRef, err)
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// resolveCommitHash resolves ref to a commit hash, peeling tags via [ResolveTag].
+func resolveCommitHash(repo *git.Repository, ref string) (plumbing.Hash, error) {
+	if info, err := ResolveTag(repo, ref); err == nil {
+		return info.Commit.Hash, nil
 	}
 
-	fromCommits := make(map[plumbing.Hash]bool)
-	fromIter, err := repo.Log(&git.LogOptions{From: *fromHash})
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get commits from %s: %w", fromRef, err)
+		return plumbing.ZeroHash, fmt.Errorf("failed to resolve %s: %w", ref, err)
 	}
+	return *hash, nil
+}
 
-	err = fromIter.ForEach(func(c *object.Commit) error {
-		fromCommits[c.Hash] = true
-		return nil
-	})
+// RangeOptions configures [GetCommitRange].
+type RangeOptions struct {
+	// NoMerges skips commits with more than one parent, like git log
+	// --no-merges.
+	NoMerges bool
+	// FirstParent follows only the first parent of merge commits, like git
+	// log --first-parent, so a merged branch contributes its merge commit
+	// alone.
+	FirstParent bool
+}
+
+// Sides of a range that reach a commit, as painted by [GetCommitRange].
+const (
+	reachTo uint8 = 1 << iota
+	reachFrom
+)
+
+// rangeWalkSlop is how many more commits [GetCommitRange] reads once every
+// pending commit is reachable from the start of the range, so a commit dated
+// before its parent doesn't end the walk early.
+const rangeWalkSlop = 5
+
+// commitQueue is a heap of commits that pops the newest committer date first.
+type commitQueue []*object.Commit
+
+func (q commitQueue) Len() int           { return len(q) }
+func (q commitQueue) Less(i, j int) bool { return q[i].Committer.When.After(q[j].Committer.When) }
+func (q commitQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *commitQueue) Push(x any)        { *q = append(*q, x.(*object.Commit)) }
+
+func (q *commitQueue) Pop() any {
+	old := *q
+	c := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return c
+}
+
+// GetCommitRange returns commits reachable from toRef but not from fromRef.
+// This implements git log from..to range semantics.
+//
+// Tags are resolved to their underlying commit, so annotated and lightweight
+// tags on the same commit produce the same range.
+//
+// Both refs are walked back together, newest commit first, painting each
+// commit with the sides that reach it. Once every queued commit is reachable
+// from fromRef and older than the commits in the range, the walk has passed
+// the merge bases and stops, so only the commits between them and the refs
+// are read, not the whole history.
+func GetCommitRange(repo *git.Repository, fromRef, toRef string, opts RangeOptions) ([]*object.Commit, error) {
+	fromHash, err := resolveCommitHash(repo, fromRef)
 	if err != nil {
-		return nil, fmt.Errorf("failed to iterate commits from %s: %w", fromRef, err)
+		return nil, err
 	}
 
-	// Collect commits that are in toCommits but not in fromCommits
-	result := []*object.Commit{}
-	toIter, err = repo.Log(&git.LogOptions{From: *toHash})
+	toHash, err := resolveCommitHash(repo, toRef)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get commits from %s: %w", toRef, err)
+		return nil, err
+	}
+
+	flags := make(map[plumbing.Hash]uint8)
+	queue := &commitQueue{}
+	paint := func(hash plumbing.Hash, side uint8) error {
+		if flags[hash]&side != 0 {
+			return nil
+		}
+		c, err := repo.CommitObject(hash)
+		if err != nil {
+			return fmt.Errorf("failed to collect commit range: %w", err)
+		}
+		flags[hash] |= side
+		heap.Push(queue, c)
+		return nil
 	}
+	if err := paint(toHash, reachTo); err != nil {
+		return nil, err
+	}
+	if err := paint(fromHash, reachFrom); err != nil {
+		return nil, err
+	}
+
+	var reached []*object.Commit
+	var oldest time.Time
+	for slop := rangeWalkSlop; queue.Len() > 0 && slop > 0; {
+		c := heap.Pop(queue).(*object.Commit)
+		side := flags[c.Hash]
+		parents := c.ParentHashes
+		if side&reachFrom != 0 {
+			side = reachFrom
+		} else {
+			reached = append(reached, c)
+			if len(reached) == 1 || c.Committer.When.Before(oldest) {
+				oldest = c.Committer.When
+			}
+			if opts.FirstParent && len(parents) > 1 {
+				parents = parents[:1]
+			}
+		}
+		for _, parent := range parents {
+			if err := paint(parent, side); err != nil {
+				return nil, err
+			}
+		}
 
-	err = toIter.ForEach(func(c *object.Commit) error {
-		if !fromCommits[c.Hash] {
+		// Keep walking while a queued commit may still reach a reached one.
+		if queue.pending(flags) || (len(reached) > 0 && queue.Len() > 0 && !(*queue)[0].Committer.When.Before(oldest)) {
+			slop = rangeWalkSlop
+		} else {
+			slop--
+		}
+	}
+
+	// A commit may be found reachable from fromRef after it was reached, so
+	// filter once the walk is done. Reverse to get chronological order
+	// (oldest first).
+	result := []*object.Commit{}
+	for _, c := range slices.Backward(reached) {
+		if flags[c.Hash]&reachFrom == 0 && !(opts.NoMerges && c.NumParents() > 1) {
 			result = append(result, c)
 		}
+	}
+
+	return result, nil
+}
+
+// pending reports whether any queued commit is not yet known to be reachable
+// from the start of the range.
+func (q commitQueue) pending(flags map[plumbing.Hash]uint8) bool {
+	for _, c := range q {
+		if flags[c.Hash]&reachFrom == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// NewAuthors returns the lowercased emails of the authors of commits who
+// authored none of the last limit commits reachable from fromRef (all of them
+// when limit is zero), i.e. first-time contributors. The walk stops as soon as
+// every author has been found.
+func NewAuthors(repo *git.Repository, fromRef string, commits []*object.Commit, limit int) (map[string]bool, error) {
+	authors := make(map[string]bool)
+	for _, c := range commits {
+		authors[strings.ToLower(c.Author.Email)] = true
+	}
+	if len(authors) == 0 {
+		return authors, nil
+	}
+
+	fromHash, err := resolveCommitHash(repo, fromRef)
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := repo.Log(&git.LogOptions{From: fromHash})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits from %s: %w", fromRef, err)
+	}
+	seen := 0
+	err = iter.ForEach(func(c *object.Commit) error {
+		if limit > 0 && seen == limit {
+			return errStopIter
+		}
+		seen++
+		delete(authors, strings.ToLower(c.Author.Email))
+		if len(authors) == 0 {
+			return errStopIter
+		}
 		return nil
 	})
+	if err != nil && !errors.Is(err, errStopIter) {
+		return nil, fmt.Errorf("failed to iterate commits from %s: %w", fromRef, err)
+	}
+	return authors, nil
+}
+
+// HistoryScopes returns the conventional commit scopes used in the last limit
+// commits reachable from HEAD (all of them when limit is zero), most used first.
+func HistoryScopes(repo *git.Repository, limit int) ([]string, error) {
+	head, err := repo.Head()
 	if err != nil {
-		return nil, fmt.Errorf("failed to collect commit range: %w", err)
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	iter, err := repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
 	}
 
-	// Reverse to get chronological order (oldest first)
-	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
-		result[i], result[j] = result[j], result[i]
+	counts := make(map[string]int)
+	seen := 0
+	err = iter.ForEach(func(c *object.Commit) error {
+		if limit > 0 && seen == limit {
+			return errStopIter
+		}
+		seen++
+		subject, _, _ := strings.Cut(c.Message, "\n")
+		if target, ok := stripFixup(subject); ok {
+			subject = target
+		}
+		if m := lintSubjectRegex.FindStringSubmatch(subject); m != nil {
+			for scope := range strings.SplitSeq(m[2], ",") {
+				if scope = strings.TrimSpace(scope); scope != "" {
+					counts[scope]++
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopIter) {
+		return nil, fmt.Errorf("failed to iterate history: %w", err)
 	}
 
-	return result, nil
+	scopes := slices.Collect(maps.Keys(counts))
+	sort.Slice(scopes, func(i, j int) bool {
+		if counts[scopes[i]] != counts[scopes[j]] {
+			return counts[scopes[i]] > counts[scopes[j]]
+		}
+		return scopes[i] < scopes[j]
+	})
+	return scopes, nil
 }
 
-// GetFileContent reads the content of a file at a specific ref (commit, tag, or branch).
+// errStopIter ends a commit iteration early.
+var errStopIter = errors.New("stop iteration")
+
+// GetFileContent reads the content of a file at a specific ref (commit, tag,
+// branch, or one of the pseudo-refs [RefWorktree] and [RefIndex]).
 func GetFileContent(repo *git.Repository, ref, filePath string) (string, error) {
+	if IsPseudoRef(ref) {
+		return pseudoRefContent(repo, ref, filePath)
+	}
+
 	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
 	if err != nil {
 		return "", fmt.Errorf("failed to resolve %s: %w", ref, err)
@@ -390,8 +879,24 @@ func GetFileContent(repo *git.Repository, ref, filePath string) (string, error)
 	return content, nil
 }
 
+// GetFileContentOrEmpty reads a file like [GetFileContent] but returns empty
+// content when the file doesn't exist at ref, e.g. when it was added or deleted.
+func GetFileContentOrEmpty(repo *git.Repository, ref, filePath string) (string, error) {
+	content, err := GetFileContent(repo, ref, filePath)
+	if errors.Is(err, object.ErrFileNotFound) {
+		return "", nil
+	}
+	return content, err
+}
+
 // GetChangedFiles returns the list of files that changed between two commits.
+// Either side may be [RefWorktree] or [RefIndex] to include uncommitted changes.
+// A renamed file is listed under both its old and new paths; see [DetectRenames].
 func GetChangedFiles(repo *git.Repository, fromRef, toRef string) ([]string, error) {
+	if IsPseudoRef(fromRef) || IsPseudoRef(toRef) {
+		return pseudoRefChangedFiles(repo, fromRef, toRef)
+	}
+
 	fromHash, err := repo.ResolveRevision(plumbing.Revision(fromRef))
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve %s: %w", fromRef, err)
@@ -422,7 +927,7 @@ func GetChangedFiles(repo *git.Repository, fromRef, toRef string) ([]string, err
 		return nil, fmt.Errorf("failed to get tree for %s: %w", toRef, err)
 	}
 
-	changes, err := fromTree.Diff(toTree)
+	changes, err := object.DiffTreeWithOptions(context.TODO(), fromTree, toTree, &object.DiffTreeOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to compute diff: %w", err)
 	}
@@ -438,3 +943,257 @@ func GetChangedFiles(repo *git.Repository, fromRef, toRef string) ([]string, err
 
 	return files, nil
 }
+
+// FileChange holds both sides of a file touched by a single commit.
+//
+// OldContent is empty for added files and NewContent is empty for deleted ones.
+// Action tells those apart from empty files that exist on both sides.
+// After [DetectRenames], a renamed or copied file carries the path it came from
+// in OldPath and that file's content in OldContent.
+type FileChange struct {
+	Path       string
+	OldContent string
+	NewContent string
+	// Action is whether the file was inserted, deleted, or modified.
+	Action merkletrie.Action
+	// OldPath is the path the file was renamed or copied from, or empty.
+	OldPath string
+	// Copied marks OldPath as a copy source that still exists.
+	Copied bool
+	// Similarity is the percentage of content shared with OldPath.
+	Similarity int
+}
+
+// GetFileChange reads both sides of path between fromRef and toRef, either of
+// which may be a pseudo-ref. A side where the file doesn't exist is empty, and
+// Action records whether the file was inserted, deleted, or modified.
+func GetFileChange(repo *git.Repository, fromRef, toRef, path string) (FileChange, error) {
+	change := FileChange{Path: path, Action: merkletrie.Modify}
+
+	var oldErr, newErr error
+	change.OldContent, oldErr = GetFileContent(repo, fromRef, path)
+	change.NewContent, newErr = GetFileContent(repo, toRef, path)
+	for _, err := range []error{oldErr, newErr} {
+		if err != nil && !errors.Is(err, object.ErrFileNotFound) {
+			return FileChange{}, err
+		}
+	}
+
+	switch {
+	case oldErr != nil && newErr != nil:
+		return FileChange{}, fmt.Errorf("%s not found in %s or %s: %w", path, fromRef, toRef, oldErr)
+	case oldErr != nil:
+		change.Action = merkletrie.Insert
+	case newErr != nil:
+		change.Action = merkletrie.Delete
+	}
+	return change, nil
+}
+
+// CommitChanges enumerates the changes a commit introduces relative to its
+// first parent, or to an empty tree for the root commit.
+//
+// Renames are not detected: a moved file is a deletion and an insertion.
+// Viewers pair them up with [DetectRenames].
+func CommitChanges(commit *object.Commit) (object.Changes, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit tree: %w", err)
+	}
+
+	if commit.NumParents() == 0 {
+		changes, err := object.DiffTreeWithOptions(context.TODO(), &object.Tree{}, tree, &object.DiffTreeOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute diff for initial commit: %w", err)
+		}
+		return changes, nil
+	}
+
+	parent, err := commit.Parent(0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parent commit: %w", err)
+	}
+	parentTree, err := parent.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parent tree: %w", err)
+	}
+
+	changes, err := object.DiffTreeWithOptions(context.TODO(), parentTree, tree, &object.DiffTreeOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute diff: %w", err)
+	}
+	return changes, nil
+}
+
+// CommitPaths returns the sorted paths a commit changed. Renames contribute
+// both their old and new paths.
+func CommitPaths(commit *object.Commit) ([]string, error) {
+	changes, err := CommitChanges(commit)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var paths []string
+	for _, change := range changes {
+		for _, name := range []string{change.From.Name, change.To.Name} {
+			if name != "" && !seen[name] {
+				seen[name] = true
+				paths = append(paths, name)
+			}
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// InferScope returns the scope shared by a strict majority of paths, or ""
+// when changes are spread across directories.
+//
+// mapping assigns scopes to path prefixes (e.g. "internal/ui/" -> "ui"); the
+// longest matching prefix wins. Paths without a mapping take the name of
+// their parent directory, and files at the repository root have no scope.
+func InferScope(paths []string, mapping map[string]string) string {
+	if len(paths) == 0 {
+		return ""
+	}
+
+	counts := make(map[string]int)
+	for _, p := range paths {
+		counts[pathScope(p, mapping)]++
+	}
+
+	for scope, count := range counts {
+		if scope != "" && count*2 > len(paths) {
+			return scope
+		}
+	}
+	return ""
+}
+
+// pathScope maps a single path to a scope.
+func pathScope(p string, mapping map[string]string) string {
+	best := -1
+	scope := ""
+	for prefix, s := range mapping {
+		if strings.HasPrefix(p, prefix) && len(prefix) > best {
+			best = len(prefix)
+			scope = s
+		}
+	}
+	if best >= 0 {
+		return scope
+	}
+
+	dir := path.Dir(p)
+	if dir == "." {
+		return ""
+	}
+	return path.Base(dir)
+}
+
+// BlameLine records the commit and author that last changed a line.
+type BlameLine struct {
+	Hash   string
+	Author string
+}
+
+// Annotation renders the line's short hash and author, e.g. "abc1234 Jane Doe".
+func (b BlameLine) Annotation() string {
+	hash := b.Hash
+	if len(hash) > ShaLen {
+		hash = hash[:ShaLen]
+	}
+	return strings.TrimSpace(hash + " " + b.Author)
+}
+
+// BlameFile resolves ref and returns the blame for each line of path at that
+// commit, indexed by zero-based line number.
+func BlameFile(repo *git.Repository, ref, path string) ([]BlameLine, error) {
+	hash, err := resolveCommitHash(repo, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", ref, err)
+	}
+
+	result, err := git.Blame(commit, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to blame %s at %s: %w", path, ref, err)
+	}
+
+	lines := make([]BlameLine, len(result.Lines))
+	for i, line := range result.Lines {
+		lines[i] = BlameLine{Hash: line.Hash.String(), Author: line.AuthorName}
+	}
+	return lines, nil
+}
+
+// BlameAnnotations renders each blame line with [BlameLine.Annotation].
+func BlameAnnotations(lines []BlameLine) []string {
+	annotations := make([]string, len(lines))
+	for i, line := range lines {
+		annotations[i] = line.Annotation()
+	}
+	return annotations
+}
+
+// GetCommitFileChanges resolves ref and returns every file its commit changed,
+// with the content on both sides, sorted by path.
+func GetCommitFileChanges(repo *git.Repository, ref string) ([]FileChange, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", ref, err)
+	}
+	return CommitFileChanges(commit)
+}
+
+// CommitFileChanges returns every file commit changed relative to its first
+// parent, with the content on both sides, sorted by path. A renamed file is
+// listed as a deletion and an addition; see [DetectRenames].
+func CommitFileChanges(commit *object.Commit) ([]FileChange, error) {
+	changes, err := CommitChanges(commit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]FileChange, 0, len(changes))
+	for _, change := range changes {
+		from, to, err := change.Files()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read files for %s: %w", change.String(), err)
+		}
+
+		action, err := change.Action()
+		if err != nil {
+			return nil, fmt.Errorf("failed to classify %s: %w", change.String(), err)
+		}
+
+		fc := FileChange{Path: change.To.Name, Action: action}
+		if fc.Path == "" {
+			fc.Path = change.From.Name
+		}
+		if from != nil {
+			if fc.OldContent, err = from.Contents(); err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", from.Name, err)
+			}
+		}
+		if to != nil {
+			if fc.NewContent, err = to.Contents(); err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", to.Name, err)
+			}
+		}
+		result = append(result, fc)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Path < result[j].Path })
+	return result, nil
+}
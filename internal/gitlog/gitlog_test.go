@@ -1,9 +1,19 @@
 package gitlog
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/plumbing"
+	"github.com/go-git/go-git/v6/plumbing/object"
+	"github.com/go-git/go-git/v6/utils/merkletrie"
 	"github.com/stormlightlabs/git-storm/internal/testutils"
 )
 
@@ -126,6 +136,133 @@ func TestConventionalParser_Parse(t *testing.T) {
 	}
 }
 
+func TestConventionalParser_TicketPattern(t *testing.T) {
+	parser := &ConventionalParser{TicketPattern: regexp.MustCompile(`^\[?([A-Z][A-Z0-9]+-\d+)\]?:?\s*`)}
+
+	tests := []struct {
+		name       string
+		subject    string
+		wantType   string
+		wantScope  string
+		wantDesc   string
+		wantTicket string
+	}{
+		{"bracketed prefix", "[ABC-1] feat: x", "feat", "", "x", "ABC-1"},
+		{"colon prefix", "JIRA-123: fix(api): handle nil", "fix", "api", "handle nil", "JIRA-123"},
+		{"no prefix", "feat: plain", "feat", "", "plain", ""},
+		{"prefix mid-subject is kept", "feat: refs ABC-1", "feat", "", "refs ABC-1", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta, err := parser.Parse("abc", tt.subject, "", time.Now())
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			testutils.Expect.Equal(t, meta.Type, tt.wantType)
+			testutils.Expect.Equal(t, meta.Scope, tt.wantScope)
+			testutils.Expect.Equal(t, meta.Description, tt.wantDesc)
+			testutils.Expect.Equal(t, meta.Ticket, tt.wantTicket)
+		})
+	}
+
+	t.Run("without pattern subject is unchanged", func(t *testing.T) {
+		meta, err := (&ConventionalParser{}).Parse("abc", "[ABC-1] feat: x", "", time.Now())
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+		testutils.Expect.Equal(t, meta.Type, "[ABC-1] feat")
+		testutils.Expect.Equal(t, meta.Ticket, "")
+	})
+}
+
+func TestStripFooters(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"empty", "", ""},
+		{"no footers", "\nFirst paragraph.\n\nSecond paragraph.\n", "First paragraph.\n\nSecond paragraph."},
+		{"trailers", "Explain the change.\n\nRefs: #12\nSigned-off-by: A <a@example.com>", "Explain the change."},
+		{"hash footer", "Explain.\n\nCloses #7", "Explain."},
+		{"breaking change", "Explain.\n\nBREAKING CHANGE: config moved\n\nReviewed-by: B", "Explain."},
+		{"footers only", "Signed-off-by: A <a@example.com>", ""},
+		{"colon inside prose is kept", "Note the ratio 3:2 here.", "Note the ratio 3:2 here."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.Expect.Equal(t, StripFooters(tt.body), tt.want)
+		})
+	}
+}
+
+func TestParseReferences(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []int
+	}{
+		{"none", "fix crash on empty input", nil},
+		{"hash", "fix crash (#123)", []int{123}},
+		{"gh prefix", "GH-45: handle empty hunks", []int{45}},
+		{"closing footer", "Explain.\n\nCloses #7\nFixes #8, #7", []int{7, 8}},
+		{"urls and entities are ignored", "see https://example.com/a#12 and &#34;", nil},
+		{"zero is ignored", "step #0", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.Expect.Equal(t, ParseReferences(tt.text), tt.want)
+		})
+	}
+}
+
+func TestCoAuthors(t *testing.T) {
+	body := "Explain.\n\nCo-authored-by: Jane Doe <jane@example.com>\nco-authored-by: Bob <bob@example.com>\nCo-Authored-By: Jane D <JANE@example.com>"
+	authors := CoAuthors(body)
+	testutils.Expect.Equal(t, len(authors), 2)
+	testutils.Expect.Equal(t, authors[0].Name, "Jane Doe")
+	testutils.Expect.Equal(t, authors[0].Email, "jane@example.com")
+	testutils.Expect.Equal(t, authors[1].Email, "bob@example.com")
+	testutils.Expect.Equal(t, len(CoAuthors("No trailers here.")), 0)
+}
+
+func TestConventionalParser_Fixups(t *testing.T) {
+	tests := []struct {
+		subject   string
+		wantType  string
+		wantDesc  string
+		wantFixup string
+	}{
+		{"fixup! feat(ui): add search", "feat", "add search", "feat(ui): add search"},
+		{"squash! fix: handle nil", "fix", "handle nil", "fix: handle nil"},
+		{"amend! fixup! docs: tweak", "docs", "tweak", "docs: tweak"},
+		{"fixup! Update readme", "unknown", "Update readme", "Update readme"},
+		{"feat: mention fixup! later", "feat", "mention fixup! later", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subject, func(t *testing.T) {
+			meta, err := (&ConventionalParser{}).Parse("abc", tt.subject, "", time.Now())
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			testutils.Expect.Equal(t, meta.Type, tt.wantType)
+			testutils.Expect.Equal(t, meta.Description, tt.wantDesc)
+			testutils.Expect.Equal(t, meta.Fixup, tt.wantFixup)
+		})
+	}
+
+	meta, err := (&ConventionalParser{}).Parse("abc", "fixup! feat: add search", "", time.Now())
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	testutils.Expect.Equal(t, (&ConventionalParser{}).Categorize(meta), "", "fixups are skipped by default")
+	testutils.Expect.Equal(t, (&ConventionalParser{KeepFixups: true}).Categorize(meta), "added")
+}
+
 func TestConventionalParser_Categorize(t *testing.T) {
 	parser := &ConventionalParser{}
 
@@ -186,6 +323,26 @@ func TestConventionalParser_Categorize(t *testing.T) {
 	}
 }
 
+func TestConventionalParser_CategorizeOverrides(t *testing.T) {
+	parser := &ConventionalParser{Categories: map[string]string{
+		"perf":  "fixed",
+		"docs":  "documentation",
+		"chore": "",
+	}}
+
+	tests := map[string]string{
+		"perf":  "fixed",
+		"docs":  "documentation",
+		"chore": "",
+		"feat":  "added",
+	}
+	for commitType, want := range tests {
+		if got := parser.Categorize(CommitMeta{Type: commitType}); got != want {
+			t.Errorf("Categorize(%s) = %q, want %q", commitType, got, want)
+		}
+	}
+}
+
 func TestConventionalParser_IsValidType(t *testing.T) {
 	parser := &ConventionalParser{}
 
@@ -283,7 +440,7 @@ func TestGetCommitRange(t *testing.T) {
 	testutils.AddCommit(t, repo, "d.txt", "content d", "feat: add d feature")
 	testutils.AddCommit(t, repo, "e.txt", "content e", "fix: fix e bug")
 
-	rangeCommits, err := GetCommitRange(repo, "v1.0.0", "HEAD")
+	rangeCommits, err := GetCommitRange(repo, "v1.0.0", "HEAD", RangeOptions{})
 	if err != nil {
 		t.Fatalf("GetCommitRange() error = %v", err)
 	}
@@ -302,7 +459,7 @@ func TestGetCommitRange(t *testing.T) {
 func TestGetCommitRange_SameRef(t *testing.T) {
 	repo := testutils.SetupTestRepo(t)
 
-	rangeCommits, err := GetCommitRange(repo, "HEAD", "HEAD")
+	rangeCommits, err := GetCommitRange(repo, "HEAD", "HEAD", RangeOptions{})
 	if err != nil {
 		t.Fatalf("GetCommitRange() error = %v", err)
 	}
@@ -312,6 +469,144 @@ func TestGetCommitRange_SameRef(t *testing.T) {
 	}
 }
 
+func TestGetCommitRange_Merges(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.CreateTag(t, repo, "v1.0.0")
+	testutils.AddMergeCommit(t, repo, "side.txt", "side", "feat: add side work", "Merge pull request #7 from me/side\n\nfeat: add side work")
+	testutils.AddCommit(t, repo, "after.txt", "after", "fix: after the merge")
+
+	subjects := func(opts RangeOptions) []string {
+		t.Helper()
+		commits, err := GetCommitRange(repo, "v1.0.0", "HEAD", opts)
+		if err != nil {
+			t.Fatalf("GetCommitRange(%+v) error = %v", opts, err)
+		}
+		var subjects []string
+		for _, c := range commits {
+			subjects = append(subjects, strings.Split(c.Message, "\n")[0])
+		}
+		return subjects
+	}
+
+	testutils.Expect.Equal(t, len(subjects(RangeOptions{})), 3)
+	testutils.Expect.Equal(t, subjects(RangeOptions{NoMerges: true}), []string{"feat: add side work", "fix: after the merge"})
+	testutils.Expect.Equal(t, subjects(RangeOptions{FirstParent: true}), []string{"Merge pull request #7 from me/side", "fix: after the merge"})
+	testutils.Expect.Equal(t, subjects(RangeOptions{FirstParent: true, NoMerges: true}), []string{"fix: after the merge"})
+}
+
+func TestPullRequestTitle(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject string
+		body    string
+		title   string
+		number  int
+		ok      bool
+	}{
+		{"merge commit", "Merge pull request #12 from octo/login", "\nfeat: add login\n\nDetails.", "feat: add login", 12, true},
+		{"merge without title", "Merge pull request #12 from octo/login", "", "", 12, true},
+		{"squash merge", "fix(api): handle timeouts (#34)", "* wip\n* more", "fix(api): handle timeouts", 34, true},
+		{"reference mid-subject", "fix: crash in #34 handler", "", "", 0, false},
+		{"branch merge", "Merge branch 'main' into feature", "", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, number, ok := PullRequestTitle(tt.subject, tt.body)
+			testutils.Expect.Equal(t, title, tt.title)
+			testutils.Expect.Equal(t, number, tt.number)
+			testutils.Expect.Equal(t, ok, tt.ok)
+		})
+	}
+}
+
+func TestConventionalParser_MergeTitles(t *testing.T) {
+	subject := "Merge pull request #12 from octo/login"
+	body := "\nfeat(auth): add login\n\nUses OAuth."
+
+	meta, err := (&ConventionalParser{}).Parse("hash", subject, body, time.Now())
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	testutils.Expect.Equal(t, meta.Type, "unknown")
+	testutils.Expect.Equal(t, meta.PullRequest, 12)
+
+	meta, err = (&ConventionalParser{MergeTitles: true}).Parse("hash", subject, body, time.Now())
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	testutils.Expect.Equal(t, meta.Type, "feat")
+	testutils.Expect.Equal(t, meta.Scope, "auth")
+	testutils.Expect.Equal(t, meta.Description, "add login (#12)")
+	testutils.Expect.Equal(t, meta.Body, "Uses OAuth.")
+	testutils.Expect.Equal(t, meta.PullRequest, 12)
+
+	meta, err = (&ConventionalParser{}).Parse("hash", "fix: handle timeouts (#34)", "", time.Now())
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	testutils.Expect.Equal(t, meta.Description, "handle timeouts (#34)")
+	testutils.Expect.Equal(t, meta.PullRequest, 34)
+}
+
+func TestNewAuthors(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.CreateTag(t, repo, "v1.0.0")
+
+	testutils.AddCommit(t, repo, "a.txt", "a", "feat: regular")
+	testutils.AddAuthoredCommit(t, repo, "Jane Doe", "Jane@Example.com", "b.txt", "b", "fix: first patch")
+	testutils.AddAuthoredCommit(t, repo, "Jane Doe", "jane@example.com", "c.txt", "c", "fix: second patch")
+
+	commits, err := GetCommitRange(repo, "v1.0.0", "HEAD", RangeOptions{})
+	if err != nil {
+		t.Fatalf("GetCommitRange() error = %v", err)
+	}
+	authors, err := NewAuthors(repo, "v1.0.0", commits, 0)
+	if err != nil {
+		t.Fatalf("NewAuthors() error = %v", err)
+	}
+	testutils.Expect.Equal(t, authors, map[string]bool{"jane@example.com": true})
+
+	testutils.AddAuthoredCommit(t, repo, "Old Hand", "old@example.com", "d.txt", "d", "chore: early work")
+	testutils.CreateTag(t, repo, "v1.1.0")
+	testutils.AddAuthoredCommit(t, repo, "Old Hand", "old@example.com", "f.txt", "f", "fix: return")
+	commits, err = GetCommitRange(repo, "v1.1.0", "HEAD", RangeOptions{})
+	if err != nil {
+		t.Fatalf("GetCommitRange() error = %v", err)
+	}
+	authors, err = NewAuthors(repo, "v1.1.0", commits, 1)
+	if err != nil {
+		t.Fatalf("NewAuthors() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(authors), 0, "authors found within the limit are not new")
+
+	testutils.AddAuthoredCommit(t, repo, "Jane Doe", "jane@example.com", "g.txt", "g", "fix: later patch")
+	authors, err = NewAuthors(repo, "HEAD~1", []*object.Commit{testutils.GetCommitHistory(t, repo)[0]}, 2)
+	if err != nil {
+		t.Fatalf("NewAuthors() error = %v", err)
+	}
+	testutils.Expect.Equal(t, authors, map[string]bool{"jane@example.com": true}, "authors older than the limit are reported as new")
+}
+
+func TestHistoryScopes(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.AddCommit(t, repo, "a.txt", "1", "feat(cli): add flag")
+	testutils.AddCommit(t, repo, "b.txt", "2", "fix(api,cli): handle nil")
+	testutils.AddCommit(t, repo, "c.txt", "3", "fixup! docs(readme): typo")
+
+	scopes, err := HistoryScopes(repo, 0)
+	if err != nil {
+		t.Fatalf("HistoryScopes() error = %v", err)
+	}
+	testutils.Expect.Equal(t, scopes, []string{"cli", "api", "readme"})
+
+	scopes, err = HistoryScopes(repo, 1)
+	if err != nil {
+		t.Fatalf("HistoryScopes() error = %v", err)
+	}
+	testutils.Expect.Equal(t, scopes, []string{"readme"})
+}
+
 func TestGetFileContent(t *testing.T) {
 	repo := testutils.SetupTestRepo(t)
 
@@ -338,6 +633,48 @@ func TestGetFileContent_InvalidFile(t *testing.T) {
 	}
 }
 
+func TestGetFileContentOrEmpty(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.CreateTag(t, repo, "before")
+	testutils.AddCommit(t, repo, "added.txt", "new file", "feat: add file")
+	testutils.CreateTag(t, repo, "after-add")
+	testutils.RemoveCommit(t, repo, "README.md", "chore: remove readme")
+
+	t.Run("file only in to", func(t *testing.T) {
+		oldContent, err := GetFileContentOrEmpty(repo, "before", "added.txt")
+		if err != nil {
+			t.Fatalf("GetFileContentOrEmpty() error = %v", err)
+		}
+		testutils.Expect.Equal(t, oldContent, "")
+
+		newContent, err := GetFileContentOrEmpty(repo, "after-add", "added.txt")
+		if err != nil {
+			t.Fatalf("GetFileContentOrEmpty() error = %v", err)
+		}
+		testutils.Expect.Equal(t, newContent, "new file")
+	})
+
+	t.Run("file only in from", func(t *testing.T) {
+		oldContent, err := GetFileContentOrEmpty(repo, "after-add", "README.md")
+		if err != nil {
+			t.Fatalf("GetFileContentOrEmpty() error = %v", err)
+		}
+		testutils.Expect.True(t, oldContent != "", "README.md should exist before removal")
+
+		newContent, err := GetFileContentOrEmpty(repo, "HEAD", "README.md")
+		if err != nil {
+			t.Fatalf("GetFileContentOrEmpty() error = %v", err)
+		}
+		testutils.Expect.Equal(t, newContent, "")
+	})
+
+	t.Run("invalid ref still fails", func(t *testing.T) {
+		if _, err := GetFileContentOrEmpty(repo, "no-such-ref", "README.md"); err == nil {
+			t.Error("Expected error for unresolvable ref")
+		}
+	})
+}
+
 func TestGetChangedFiles(t *testing.T) {
 	repo := testutils.SetupTestRepo(t)
 
@@ -368,3 +705,312 @@ func TestGetChangedFiles_NoChanges(t *testing.T) {
 		t.Errorf("Expected 0 changed files when refs are the same, got %d", len(files))
 	}
 }
+
+func TestGetCommitFileChanges(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.AddCommit(t, repo, "a.txt", "hello world\nsee you later", "feat: reword goodbye")
+	testutils.RemoveCommit(t, repo, "c.txt", "chore: drop c")
+
+	commits := testutils.GetCommitHistory(t, repo)
+
+	t.Run("modified file", func(t *testing.T) {
+		changes, err := GetCommitFileChanges(repo, commits[1].Hash.String())
+		if err != nil {
+			t.Fatalf("GetCommitFileChanges() error = %v", err)
+		}
+		testutils.Expect.Equal(t, changes, []FileChange{
+			{Path: "a.txt", OldContent: "hello world\ngoodbye world", NewContent: "hello world\nsee you later", Action: merkletrie.Modify},
+		})
+	})
+
+	t.Run("deleted file", func(t *testing.T) {
+		changes, err := GetCommitFileChanges(repo, "HEAD")
+		if err != nil {
+			t.Fatalf("GetCommitFileChanges() error = %v", err)
+		}
+		testutils.Expect.Equal(t, len(changes), 1)
+		testutils.Expect.Equal(t, changes[0].Path, "c.txt")
+		testutils.Expect.Equal(t, changes[0].NewContent, "")
+		testutils.Expect.Equal(t, changes[0].Action, merkletrie.Delete)
+	})
+
+	t.Run("root commit", func(t *testing.T) {
+		root := commits[len(commits)-1]
+		changes, err := GetCommitFileChanges(repo, root.Hash.String())
+		if err != nil {
+			t.Fatalf("GetCommitFileChanges() error = %v", err)
+		}
+		testutils.Expect.Equal(t, changes, []FileChange{
+			{Path: "README.md", NewContent: "# Project\n\nInitial version", Action: merkletrie.Insert},
+		})
+	})
+}
+
+func TestResolveTag_AnnotatedAndLightweight(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	head := testutils.GetCommitHistory(t, repo)[0]
+
+	taggedAt := head.Committer.When.Add(48 * time.Hour)
+	testutils.CreateTag(t, repo, "v1.0.0-light")
+	testutils.CreateAnnotatedTag(t, repo, "v1.0.0", taggedAt)
+
+	testutils.AddCommit(t, repo, "d.txt", "after tag", "feat: after tag")
+	testutils.AddCommit(t, repo, "e.txt", "after tag again", "fix: after tag again")
+
+	light, err := ResolveTag(repo, "v1.0.0-light")
+	if err != nil {
+		t.Fatalf("ResolveTag(lightweight) error = %v", err)
+	}
+	annotated, err := ResolveTag(repo, "v1.0.0")
+	if err != nil {
+		t.Fatalf("ResolveTag(annotated) error = %v", err)
+	}
+
+	testutils.Expect.Equal(t, light.Commit.Hash, head.Hash)
+	testutils.Expect.Equal(t, annotated.Commit.Hash, head.Hash, "Annotated tag should peel to the tagged commit")
+	testutils.Expect.False(t, light.Annotated)
+	testutils.Expect.True(t, annotated.Annotated)
+	testutils.Expect.True(t, light.Date.Equal(head.Committer.When), "Lightweight tag date should be the commit date")
+	testutils.Expect.True(t, annotated.Date.Equal(taggedAt), "Annotated tag date should be the tagger date")
+
+	lightRange, err := GetCommitRange(repo, "v1.0.0-light", "HEAD", RangeOptions{})
+	if err != nil {
+		t.Fatalf("GetCommitRange(lightweight) error = %v", err)
+	}
+	annotatedRange, err := GetCommitRange(repo, "v1.0.0", "HEAD", RangeOptions{})
+	if err != nil {
+		t.Fatalf("GetCommitRange(annotated) error = %v", err)
+	}
+
+	testutils.Expect.Equal(t, len(lightRange), 2)
+	testutils.Expect.Equal(t, len(annotatedRange), len(lightRange))
+	for i := range lightRange {
+		testutils.Expect.Equal(t, annotatedRange[i].Hash, lightRange[i].Hash)
+	}
+
+	if _, err := ResolveTag(repo, "missing"); err == nil {
+		t.Error("Expected error for missing tag")
+	}
+}
+
+func TestInferScope(t *testing.T) {
+	tests := []struct {
+		name    string
+		paths   []string
+		mapping map[string]string
+		want    string
+	}{
+		{"single directory", []string{"internal/ui/a.go", "internal/ui/b.go"}, nil, "ui"},
+		{"dominant directory", []string{"internal/ui/a.go", "internal/ui/b.go", "cmd/main.go"}, nil, "ui"},
+		{"mixed directories", []string{"internal/ui/a.go", "cmd/main.go"}, nil, ""},
+		{"root files", []string{"README.md", "go.mod"}, nil, ""},
+		{"mapped prefix", []string{"internal/diff/format.go", "internal/diff/myers.go"}, map[string]string{"internal/": "core", "internal/diff/": "differ"}, "differ"},
+		{"no paths", nil, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.Expect.Equal(t, InferScope(tt.paths, tt.mapping), tt.want)
+		})
+	}
+}
+
+func TestCommitPaths(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.AddFilesCommit(t, repo, map[string]string{
+		"internal/ui/view.go":  "package ui",
+		"internal/ui/model.go": "package ui",
+	}, "feat: add view")
+
+	head := testutils.GetCommitHistory(t, repo)[0]
+	paths, err := CommitPaths(head)
+	if err != nil {
+		t.Fatalf("CommitPaths() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(paths), 2)
+	testutils.Expect.Equal(t, paths[0], "internal/ui/model.go")
+	testutils.Expect.Equal(t, InferScope(paths, nil), "ui")
+}
+
+func TestBlameFile(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.AddCommit(t, repo, "blame.txt", "one\ntwo\n", "feat: add blame file")
+	first := testutils.GetCommitHistory(t, repo)[0].Hash.String()
+	testutils.AddCommit(t, repo, "blame.txt", "one\nTWO\nthree\n", "fix: update blame file")
+	second := testutils.GetCommitHistory(t, repo)[0].Hash.String()
+
+	lines, err := BlameFile(repo, "HEAD", "blame.txt")
+	if err != nil {
+		t.Fatalf("BlameFile() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(lines), 3)
+	testutils.Expect.Equal(t, lines[0].Hash, first)
+	testutils.Expect.Equal(t, lines[1].Hash, second)
+	testutils.Expect.Equal(t, lines[2].Hash, second)
+	testutils.Expect.Equal(t, lines[1].Annotation(), second[:ShaLen]+" Test Author")
+
+	if _, err := BlameFile(repo, "HEAD", "missing.txt"); err == nil {
+		t.Error("BlameFile() expected error for a missing file")
+	}
+}
+
+func TestListTags(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+
+	tags, err := ListTags(repo)
+	if err != nil {
+		t.Fatalf("ListTags() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(tags), 0)
+
+	testutils.CreateTag(t, repo, "v1.1.0")
+	testutils.CreateAnnotatedTag(t, repo, "v1.0.0", time.Now())
+
+	tags, err = ListTags(repo)
+	if err != nil {
+		t.Fatalf("ListTags() error = %v", err)
+	}
+	testutils.Expect.Equal(t, tags, []string{"v1.0.0", "v1.1.0"})
+}
+
+func TestOpenRepo(t *testing.T) {
+	t.Run("discovers root from a subdirectory", func(t *testing.T) {
+		repo := testutils.SetupTestRepo(t)
+		testutils.AddCommit(t, repo, "pkg/sub/file.txt", "x", "feat: nested file")
+		wt, err := repo.Worktree()
+		if err != nil {
+			t.Fatalf("failed to get worktree: %v", err)
+		}
+
+		opened, err := OpenRepo(filepath.Join(wt.Filesystem.Root(), "pkg", "sub"))
+		if err != nil {
+			t.Fatalf("OpenRepo() error = %v", err)
+		}
+		head, err := opened.Head()
+		if err != nil {
+			t.Fatalf("Head() error = %v", err)
+		}
+		testutils.Expect.Equal(t, head.Hash(), testutils.GetCommitHistory(t, repo)[0].Hash)
+	})
+
+	t.Run("opens a bare repository", func(t *testing.T) {
+		dir := t.TempDir()
+		if _, err := git.PlainInit(dir, true); err != nil {
+			t.Fatalf("failed to init bare repo: %v", err)
+		}
+
+		opened, err := OpenRepo(dir)
+		if err != nil {
+			t.Fatalf("OpenRepo() error = %v", err)
+		}
+		_, err = opened.Worktree()
+		testutils.Expect.True(t, errors.Is(err, git.ErrIsBareRepository), "bare repository should have no worktree")
+	})
+
+	t.Run("reports a non-repository path", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := OpenRepo(dir)
+		if err == nil {
+			t.Fatal("OpenRepo() expected error outside a repository")
+		}
+		testutils.Expect.True(t, errors.Is(err, git.ErrRepositoryNotExists))
+		testutils.Expect.True(t, strings.Contains(err.Error(), "no git repository found"))
+	})
+}
+
+func TestDiffColors(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+
+	repo := testutils.SetupTestRepo(t)
+
+	colors, err := DiffColors(repo)
+	if err != nil {
+		t.Fatalf("DiffColors() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(colors), 0, "no diff colors should be found without config")
+
+	global := "[color \"diff\"]\n\tnew = blue\n\told = red bold\n"
+	if err := os.WriteFile(filepath.Join(home, ".gitconfig"), []byte(global), 0644); err != nil {
+		t.Fatalf("failed to write global config: %v", err)
+	}
+
+	cfg, err := repo.Config()
+	if err != nil {
+		t.Fatalf("failed to read repo config: %v", err)
+	}
+	cfg.Raw.Section("color").Subsection("diff").SetOption("Old", "magenta")
+	cfg.Raw.Section("color").Subsection("diff").SetOption("context", "white dim")
+	if err := repo.SetConfig(cfg); err != nil {
+		t.Fatalf("failed to write repo config: %v", err)
+	}
+
+	colors, err = DiffColors(repo)
+	if err != nil {
+		t.Fatalf("DiffColors() error = %v", err)
+	}
+	testutils.Expect.Equal(t, colors["new"], "blue", "global setting should apply")
+	testutils.Expect.Equal(t, colors["old"], "magenta", "repository setting should override global")
+	testutils.Expect.Equal(t, colors["context"], "white dim")
+}
+
+// syntheticHistory creates a bare repository whose main branch is a linear
+// history of n commits sharing an empty tree, with the tag "base" recent
+// commits before its tip.
+func syntheticHistory(b *testing.B, n, recent int) *git.Repository {
+	b.Helper()
+	repo, err := git.PlainInit(b.TempDir(), true)
+	if err != nil {
+		b.Fatalf("PlainInit() error = %v", err)
+	}
+
+	store := func(o interface {
+		Encode(plumbing.EncodedObject) error
+	}) plumbing.Hash {
+		obj := repo.Storer.NewEncodedObject()
+		if err := o.Encode(obj); err != nil {
+			b.Fatalf("failed to encode object: %v", err)
+		}
+		hash, err := repo.Storer.SetEncodedObject(obj)
+		if err != nil {
+			b.Fatalf("failed to store object: %v", err)
+		}
+		return hash
+	}
+
+	tree := store(&object.Tree{})
+	var head plumbing.Hash
+	for i := range n {
+		sig := object.Signature{Name: "Test Author", Email: "test@example.com", When: time.Unix(1_700_000_000+int64(i), 0)}
+		commit := &object.Commit{Author: sig, Committer: sig, Message: fmt.Sprintf("feat: change %d\n", i), TreeHash: tree}
+		if i > 0 {
+			commit.ParentHashes = []plumbing.Hash{head}
+		}
+		head = store(commit)
+		if i == n-recent-1 {
+			if _, err := repo.CreateTag("base", head, nil); err != nil {
+				b.Fatalf("failed to create tag: %v", err)
+			}
+		}
+	}
+
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("main"), head)); err != nil {
+		b.Fatalf("failed to create branch: %v", err)
+	}
+	return repo
+}
+
+func BenchmarkGetCommitRange(b *testing.B) {
+	repo := syntheticHistory(b, 50_000, 100)
+
+	for b.Loop() {
+		commits, err := GetCommitRange(repo, "base", "main", RangeOptions{})
+		if err != nil {
+			b.Fatalf("GetCommitRange() error = %v", err)
+		}
+		if len(commits) != 100 {
+			b.Fatalf("GetCommitRange() returned %d commits, want 100", len(commits))
+		}
+	}
+}
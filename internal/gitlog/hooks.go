@@ -0,0 +1,57 @@
+package gitlog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/config"
+	"github.com/go-git/go-git/v6/storage/filesystem"
+)
+
+// HooksDir returns the directory git runs repo's hooks from: core.hooksPath
+// from the repository or global config, resolved against the worktree root,
+// or else the hooks directory inside the git directory.
+func HooksDir(repo *git.Repository) (string, error) {
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get worktree: %w", err)
+	}
+	root := worktree.Filesystem.Root()
+
+	var hooksPath string
+	global, err := config.LoadConfig(config.GlobalScope)
+	if err != nil {
+		return "", fmt.Errorf("failed to load git config: %w", err)
+	}
+	local, err := repo.Config()
+	if err != nil {
+		return "", fmt.Errorf("failed to load repository config: %w", err)
+	}
+	for _, cfg := range []*config.Config{global, local} {
+		if cfg != nil && cfg.Raw != nil {
+			setOption(&hooksPath, cfg.Raw.Section("core").Option("hooksPath"))
+		}
+	}
+
+	if hooksPath != "" {
+		if rest, ok := strings.CutPrefix(hooksPath, "~/"); ok {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", fmt.Errorf("failed to resolve %s: %w", hooksPath, err)
+			}
+			hooksPath = filepath.Join(home, rest)
+		}
+		if !filepath.IsAbs(hooksPath) {
+			hooksPath = filepath.Join(root, hooksPath)
+		}
+		return filepath.Clean(hooksPath), nil
+	}
+
+	if storage, ok := repo.Storer.(*filesystem.Storage); ok {
+		return filepath.Join(storage.Filesystem().Root(), "hooks"), nil
+	}
+	return filepath.Join(root, ".git", "hooks"), nil
+}
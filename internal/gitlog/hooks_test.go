@@ -0,0 +1,41 @@
+package gitlog
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/git-storm/internal/testutils"
+)
+
+func TestHooksDir(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	repo := testutils.SetupTestRepo(t)
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+	root := wt.Filesystem.Root()
+
+	dir, err := HooksDir(repo)
+	if err != nil {
+		t.Fatalf("HooksDir() error = %v", err)
+	}
+	testutils.Expect.True(t, strings.HasSuffix(dir, filepath.Join(".git", "hooks")), dir)
+
+	cfg, err := repo.Config()
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	cfg.Raw.Section("core").SetOption("hooksPath", ".githooks")
+	if err := repo.SetConfig(cfg); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	dir, err = HooksDir(repo)
+	if err != nil {
+		t.Fatalf("HooksDir() error = %v", err)
+	}
+	testutils.Expect.Equal(t, dir, filepath.Join(root, ".githooks"))
+}
@@ -0,0 +1,158 @@
+package gitlog
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Defaults for the length limits in [LintRules].
+const (
+	DefaultMaxSubjectLength  = 72
+	DefaultMaxBodyLineLength = 100
+)
+
+// ConventionalTypes are the commit types allowed by [LintMessage] when
+// [LintRules.Types] is empty.
+var ConventionalTypes = []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert"}
+
+// Rules reported in [LintIssue.Rule].
+const (
+	LintSubjectFormat  = "subject-format"
+	LintType           = "type"
+	LintScope          = "scope"
+	LintSubjectLength  = "subject-length"
+	LintBodySeparator  = "body-separator"
+	LintBodyLineLength = "body-line-length"
+	LintFooter         = "footer"
+)
+
+// LintRules configures [LintMessage].
+type LintRules struct {
+	// Types lists the allowed commit types; empty allows [ConventionalTypes].
+	Types []string
+	// Scopes lists the allowed scopes; empty allows any scope.
+	Scopes []string
+	// MaxSubjectLength caps the subject line in characters. Zero uses
+	// [DefaultMaxSubjectLength]; a negative value disables the check.
+	MaxSubjectLength int
+	// MaxBodyLineLength caps each body line in characters. Zero uses
+	// [DefaultMaxBodyLineLength]; a negative value disables the check. Lines
+	// holding a URL are exempt, as they can't be wrapped.
+	MaxBodyLineLength int
+	// RequiredFooters lists footer tokens every message needs, e.g.
+	// "Signed-off-by". Tokens are matched case-insensitively.
+	RequiredFooters []string
+}
+
+// LintIssue is one way a commit message breaks [LintRules].
+type LintIssue struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// lintSubjectRegex matches a conventional subject and captures its type,
+// scope, and description.
+var lintSubjectRegex = regexp.MustCompile(`^([^\s():!]+)(?:\(([^()]*)\))?!?: (\S.*)$`)
+
+// LintMessage checks a commit message against rules and returns every issue
+// found. Merge commits and git's "Revert" subjects are not checked, and
+// autosquash prefixes (fixup!, squash!, amend!) are ignored.
+func LintMessage(message string, rules LintRules) []LintIssue {
+	lines := strings.Split(strings.TrimRight(strings.ReplaceAll(message, "\r\n", "\n"), "\n"), "\n")
+	subject := strings.TrimSpace(lines[0])
+	if strings.HasPrefix(subject, "Merge ") || strings.HasPrefix(subject, `Revert "`) {
+		return nil
+	}
+
+	var issues []LintIssue
+	add := func(rule, format string, args ...any) {
+		issues = append(issues, LintIssue{Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	target, _ := stripFixup(subject)
+	if m := lintSubjectRegex.FindStringSubmatch(target); m == nil {
+		add(LintSubjectFormat, `subject must look like "type(scope): description"`)
+	} else {
+		types := rules.Types
+		if len(types) == 0 {
+			types = ConventionalTypes
+		}
+		if !slices.Contains(types, m[1]) {
+			add(LintType, "type %q must be one of [%s]", m[1], strings.Join(types, ", "))
+		}
+		if len(rules.Scopes) > 0 && m[2] != "" {
+			for scope := range strings.SplitSeq(m[2], ",") {
+				if scope = strings.TrimSpace(scope); !slices.Contains(rules.Scopes, scope) {
+					add(LintScope, "scope %q must be one of [%s]", scope, strings.Join(rules.Scopes, ", "))
+				}
+			}
+		}
+	}
+
+	if limit := lintLimit(rules.MaxSubjectLength, DefaultMaxSubjectLength); limit > 0 {
+		if n := utf8.RuneCountInString(subject); n > limit {
+			add(LintSubjectLength, "subject is %d characters, more than %d", n, limit)
+		}
+	}
+
+	if len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		add(LintBodySeparator, "a blank line must separate the subject from the body")
+	}
+
+	if limit := lintLimit(rules.MaxBodyLineLength, DefaultMaxBodyLineLength); limit > 0 {
+		for i, line := range lines[1:] {
+			if n := utf8.RuneCountInString(line); n > limit && !strings.Contains(line, "://") {
+				add(LintBodyLineLength, "line %d is %d characters, more than %d", i+2, n, limit)
+			}
+		}
+	}
+
+	if len(rules.RequiredFooters) > 0 {
+		// Footers live in the message's last paragraph.
+		body := strings.Join(lines[1:], "\n")
+		if i := strings.LastIndex(body, "\n\n"); i >= 0 {
+			body = body[i+2:]
+		}
+		present := make(map[string]bool)
+		for line := range strings.SplitSeq(body, "\n") {
+			if key, _, ok := footerKey(line); ok {
+				present[strings.ToLower(key)] = true
+			}
+		}
+		for _, footer := range rules.RequiredFooters {
+			if !present[strings.ToLower(footer)] {
+				add(LintFooter, "missing required %s footer", footer)
+			}
+		}
+	}
+
+	return issues
+}
+
+// lintLimit resolves a configured limit: zero uses fallback, negative disables.
+func lintLimit(limit, fallback int) int {
+	if limit == 0 {
+		return fallback
+	}
+	return limit
+}
+
+// scissorsLine marks where git cuts a verbose commit message template.
+const scissorsLine = "# ------------------------ >8 ------------------------"
+
+// CleanMessage strips what git removes from a message being committed: the
+// text below the scissors line and "#" comment lines. It lets a commit-msg
+// hook lint the message as it will be recorded.
+func CleanMessage(message string) string {
+	message, _, _ = strings.Cut(strings.ReplaceAll(message, "\r\n", "\n"), scissorsLine)
+	var kept []string
+	for line := range strings.SplitSeq(message, "\n") {
+		if !strings.HasPrefix(line, "#") {
+			kept = append(kept, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n")) + "\n"
+}
@@ -0,0 +1,71 @@
+package gitlog
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stormlightlabs/git-storm/internal/testutils"
+)
+
+func TestLintMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		rules   LintRules
+		want    []string
+	}{
+		{name: "valid", message: "feat(cli): add lint mode\n\nExplain why.\n"},
+		{name: "breaking", message: "refactor!: drop v1 API"},
+		{name: "merge commits are skipped", message: "Merge branch 'main' into topic"},
+		{name: "fixup of a valid subject", message: "fixup! fix: handle empty input"},
+		{name: "not conventional", message: "Add lint mode", want: []string{LintSubjectFormat}},
+		{name: "missing space", message: "feat:add lint mode", want: []string{LintSubjectFormat}},
+		{name: "unknown type", message: "feature: add lint mode", want: []string{LintType}},
+		{
+			name:    "configured types",
+			message: "feat: add lint mode",
+			rules:   LintRules{Types: []string{"add", "fix"}},
+			want:    []string{LintType},
+		},
+		{
+			name:    "scopes",
+			message: "fix(cli,api): handle empty input",
+			rules:   LintRules{Scopes: []string{"cli", "core"}},
+			want:    []string{LintScope},
+		},
+		{name: "long subject", message: "fix: " + strings.Repeat("a", 70), want: []string{LintSubjectLength}},
+		{name: "subject limit disabled", message: "fix: " + strings.Repeat("a", 70), rules: LintRules{MaxSubjectLength: -1}},
+		{name: "no blank line", message: "fix: handle empty input\nMore detail.", want: []string{LintBodySeparator}},
+		{
+			name:    "long body line",
+			message: "fix: handle empty input\n\n" + strings.Repeat("word ", 25) + "\n\nSee https://example.com/" + strings.Repeat("x", 100),
+			want:    []string{LintBodyLineLength},
+		},
+		{
+			name:    "required footer present",
+			message: "fix: handle empty input\n\nBody.\n\nSigned-off-by: A <a@example.com>",
+			rules:   LintRules{RequiredFooters: []string{"signed-off-by"}},
+		},
+		{
+			name:    "required footer only in prose",
+			message: "fix: handle empty input\n\nSigned-off-by: nobody, see below.\n\nThanks.",
+			rules:   LintRules{RequiredFooters: []string{"Signed-off-by"}},
+			want:    []string{LintFooter},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, issue := range LintMessage(tt.message, tt.rules) {
+				got = append(got, issue.Rule)
+			}
+			testutils.Expect.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestCleanMessage(t *testing.T) {
+	message := "fix: handle empty input\n\nBody.\n# Please enter the commit message\n# ------------------------ >8 ------------------------\ndiff --git a/x b/x\n"
+	testutils.Expect.Equal(t, CleanMessage(message), "fix: handle empty input\n\nBody.\n")
+}
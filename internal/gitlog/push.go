@@ -0,0 +1,130 @@
+package gitlog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/config"
+	"github.com/go-git/go-git/v6/plumbing/transport"
+	"github.com/go-git/go-git/v6/plumbing/transport/http"
+	"github.com/go-git/go-git/v6/plumbing/transport/ssh"
+)
+
+// scpURL matches scp-like SSH remotes such as git@github.com:owner/repo.git.
+var scpURL = regexp.MustCompile(`^(?:([^@/]+)@)?([^:/]+):(.*)$`)
+
+// PushRefs pushes refspecs (e.g. "refs/tags/v1.2.0:refs/tags/v1.2.0") to
+// remoteName. Pushing refs the remote already has is not an error.
+//
+// SSH remotes authenticate through the SSH agent; HTTP(S) remotes ask
+// git credential fill, so the user's configured credential helpers apply.
+// Other remotes, like local paths, push without auth.
+func PushRefs(repo *git.Repository, remoteName string, refspecs []string) error {
+	remote, err := repo.Remote(remoteName)
+	if err != nil {
+		return fmt.Errorf("failed to get remote %s: %w", remoteName, err)
+	}
+	urls := remote.Config().URLs
+	if len(urls) == 0 {
+		return fmt.Errorf("remote %s has no URL", remoteName)
+	}
+	auth, err := remoteAuth(urls[0])
+	if err != nil {
+		return err
+	}
+
+	specs := make([]config.RefSpec, 0, len(refspecs))
+	for _, spec := range refspecs {
+		rs := config.RefSpec(spec)
+		if err := rs.Validate(); err != nil {
+			return fmt.Errorf("invalid refspec %s: %w", spec, err)
+		}
+		specs = append(specs, rs)
+	}
+
+	err = repo.Push(&git.PushOptions{RemoteName: remoteName, RefSpecs: specs, Auth: auth})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("failed to push to %s: %w", remoteName, err)
+	}
+	return nil
+}
+
+// remoteAuth picks the auth method for a remote URL, or nil when none is needed.
+func remoteAuth(rawURL string) (transport.AuthMethod, error) {
+	if u, err := url.Parse(rawURL); err == nil {
+		switch u.Scheme {
+		case "ssh", "git+ssh", "ssh+git":
+			return sshAgentAuth(u.User.Username())
+		case "http", "https":
+			return credentialAuth(u)
+		}
+	}
+	// A single-letter host is a Windows drive, not an scp-like remote.
+	if m := scpURL.FindStringSubmatch(rawURL); m != nil && !strings.Contains(rawURL, "://") && len(m[2]) > 1 {
+		return sshAgentAuth(m[1])
+	}
+	return nil, nil
+}
+
+// sshAgentAuth authenticates as user (default "git") with the SSH agent's keys.
+func sshAgentAuth(user string) (transport.AuthMethod, error) {
+	if user == "" {
+		user = "git"
+	}
+	auth, err := ssh.NewSSHAgentAuth(user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to use SSH agent: %w", err)
+	}
+	return auth, nil
+}
+
+// credentialAuth asks git credential fill for the URL's username and
+// password. Credentials in the URL itself take precedence; when git isn't
+// available or has no credentials, the push is attempted without auth.
+func credentialAuth(u *url.URL) (transport.AuthMethod, error) {
+	if password, ok := u.User.Password(); ok {
+		return &http.BasicAuth{Username: u.User.Username(), Password: password}, nil
+	}
+
+	var input strings.Builder
+	fmt.Fprintf(&input, "protocol=%s\nhost=%s\npath=%s\n", u.Scheme, u.Host, strings.TrimPrefix(u.Path, "/"))
+	if name := u.User.Username(); name != "" {
+		fmt.Fprintf(&input, "username=%s\n", name)
+	}
+	input.WriteString("\n")
+
+	cmd := exec.Command("git", "credential", "fill")
+	cmd.Stdin = strings.NewReader(input.String())
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, nil
+	}
+	username, password := parseCredential(out)
+	if username == "" && password == "" {
+		return nil, nil
+	}
+	return &http.BasicAuth{Username: username, Password: password}, nil
+}
+
+// parseCredential reads the username and password from git credential output.
+func parseCredential(out []byte) (username, password string) {
+	for line := range bytes.Lines(out) {
+		key, value, ok := strings.Cut(strings.TrimRight(string(line), "\r\n"), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "username":
+			username = value
+		case "password":
+			password = value
+		}
+	}
+	return username, password
+}
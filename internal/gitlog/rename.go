@@ -0,0 +1,158 @@
+package gitlog
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v6/utils/merkletrie"
+)
+
+// DefaultRenameThreshold is the similarity percentage [DetectRenames] requires
+// by default, matching git's -M default.
+const DefaultRenameThreshold = 50
+
+// renameLimit caps the added and source files [DetectRenames] compares, like
+// git's diff.renameLimit, since every pair is scored.
+const renameLimit = 1000
+
+// RenameOptions configures [DetectRenames].
+type RenameOptions struct {
+	// Threshold is the minimum similarity percentage for a match. Zero uses
+	// [DefaultRenameThreshold] and a negative value disables detection.
+	Threshold int
+	// Copies also matches added files against modified files they were
+	// copied from, like git's -C.
+	Copies bool
+}
+
+// DisplayPath returns the file's path, prefixed with "old → " when it was
+// renamed or copied.
+func (c FileChange) DisplayPath() string {
+	if c.OldPath == "" {
+		return c.Path
+	}
+	return c.OldPath + " → " + c.Path
+}
+
+// Similarity returns the percentage of content a and b share: the bytes of
+// lines common to both, counting repeated lines once per occurrence, over the
+// size of the larger side.
+func Similarity(a, b string) int {
+	if a == b {
+		return 100
+	}
+	if a == "" || b == "" {
+		return 0
+	}
+
+	counts := make(map[string]int)
+	for _, line := range strings.SplitAfter(a, "\n") {
+		counts[line]++
+	}
+	common := 0
+	for _, line := range strings.SplitAfter(b, "\n") {
+		if counts[line] > 0 {
+			counts[line]--
+			common += len(line)
+		}
+	}
+	return common * 100 / max(len(a), len(b))
+}
+
+// DetectRenames pairs added files with deleted files whose content is at least
+// opts.Threshold percent similar, replacing each pair with one change that
+// diffs the new path against the old. The most similar pairs are matched
+// first, preferring files with the same base name on ties. Files are classed
+// by their Action, so an empty file gaining content is not an addition, and
+// the paired change becomes a [merkletrie.Modify].
+//
+// With opts.Copies, added files left unmatched are paired with the most
+// similar modified or deleted file as copies. Changes are otherwise returned
+// in their original order. Detection is skipped when more than 1000 files
+// would be compared on either side.
+func DetectRenames(changes []FileChange, opts RenameOptions) []FileChange {
+	threshold := opts.Threshold
+	if threshold == 0 {
+		threshold = DefaultRenameThreshold
+	}
+	if threshold < 0 {
+		return changes
+	}
+
+	var added, deleted, sources []int
+	for i, c := range changes {
+		switch {
+		case c.OldPath != "":
+		case c.Action == merkletrie.Insert:
+			added = append(added, i)
+		case c.Action == merkletrie.Delete:
+			deleted = append(deleted, i)
+			sources = append(sources, i)
+		case c.Action == merkletrie.Modify:
+			sources = append(sources, i)
+		}
+	}
+	if len(added) == 0 || len(deleted) == 0 && !opts.Copies || len(added) > renameLimit || len(sources) > renameLimit {
+		return changes
+	}
+
+	type pair struct{ add, src, score int }
+	score := func(candidates []int) []pair {
+		var pairs []pair
+		for _, a := range added {
+			for _, d := range candidates {
+				if s := Similarity(changes[d].OldContent, changes[a].NewContent); s >= threshold {
+					pairs = append(pairs, pair{a, d, s})
+				}
+			}
+		}
+		sort.SliceStable(pairs, func(i, j int) bool {
+			if pairs[i].score != pairs[j].score {
+				return pairs[i].score > pairs[j].score
+			}
+			return sameBase(changes, pairs[i].add, pairs[i].src) && !sameBase(changes, pairs[j].add, pairs[j].src)
+		})
+		return pairs
+	}
+
+	result := append([]FileChange(nil), changes...)
+	matched, renamed := make(map[int]bool), make(map[int]bool)
+	for _, p := range score(deleted) {
+		if matched[p.add] || renamed[p.src] {
+			continue
+		}
+		matched[p.add], renamed[p.src] = true, true
+		result[p.add].OldPath = changes[p.src].Path
+		result[p.add].OldContent = changes[p.src].OldContent
+		result[p.add].Action = merkletrie.Modify
+		result[p.add].Similarity = p.score
+	}
+
+	if opts.Copies {
+		for _, p := range score(sources) {
+			if matched[p.add] {
+				continue
+			}
+			matched[p.add] = true
+			result[p.add].OldPath = changes[p.src].Path
+			result[p.add].OldContent = changes[p.src].OldContent
+			result[p.add].Action = merkletrie.Modify
+			result[p.add].Copied = true
+			result[p.add].Similarity = p.score
+		}
+	}
+
+	kept := result[:0]
+	for i, c := range result {
+		if !renamed[i] {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+// sameBase reports whether changes a and b have the same file name.
+func sameBase(changes []FileChange, a, b int) bool {
+	return path.Base(changes[a].Path) == path.Base(changes[b].Path)
+}
@@ -0,0 +1,154 @@
+package gitlog
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v6/utils/merkletrie"
+	"github.com/stormlightlabs/git-storm/internal/testutils"
+)
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want int
+	}{
+		{name: "identical", a: "one\ntwo\n", b: "one\ntwo\n", want: 100},
+		{name: "one side empty", a: "one\n", b: "", want: 0},
+		{name: "disjoint", a: "one\n", b: "two\n", want: 0},
+		{name: "half shared", a: "aaaa\nbbbb\n", b: "aaaa\ncccc\n", want: 50},
+		{name: "repeated lines count once each", a: "x\nx\n", b: "x\ny\n", want: 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.Expect.Equal(t, Similarity(tt.a, tt.b), tt.want)
+		})
+	}
+}
+
+func TestDetectRenames(t *testing.T) {
+	body := "package a\n\nfunc A() {}\n\nfunc B() {}\n"
+
+	t.Run("pairs a delete and an add", func(t *testing.T) {
+		changes := []FileChange{
+			{Path: "new/a.go", NewContent: body + "// moved\n", Action: merkletrie.Insert},
+			{Path: "old/a.go", OldContent: body, Action: merkletrie.Delete},
+			{Path: "other.txt", OldContent: "x\n", NewContent: "y\n", Action: merkletrie.Modify},
+		}
+		got := DetectRenames(changes, RenameOptions{})
+		testutils.Expect.Equal(t, len(got), 2)
+		testutils.Expect.Equal(t, got[0].OldPath, "old/a.go")
+		testutils.Expect.Equal(t, got[0].OldContent, body)
+		testutils.Expect.Equal(t, got[0].DisplayPath(), "old/a.go → new/a.go")
+		testutils.Expect.True(t, got[0].Similarity >= DefaultRenameThreshold && got[0].Similarity < 100)
+		testutils.Expect.False(t, got[0].Copied)
+		testutils.Expect.Equal(t, got[0].Action, merkletrie.Modify)
+		testutils.Expect.Equal(t, got[1].Path, "other.txt")
+	})
+
+	t.Run("threshold", func(t *testing.T) {
+		changes := []FileChange{
+			{Path: "b.go", NewContent: "package a\nfunc B() {}\n", Action: merkletrie.Insert},
+			{Path: "a.go", OldContent: "package a\nfunc A() {}\n", Action: merkletrie.Delete},
+		}
+		testutils.Expect.Equal(t, DetectRenames(changes, RenameOptions{Threshold: 90}), changes)
+		testutils.Expect.Equal(t, DetectRenames(changes, RenameOptions{Threshold: -1}), changes)
+		testutils.Expect.Equal(t, len(DetectRenames(changes, RenameOptions{Threshold: 40})), 1)
+	})
+
+	t.Run("prefers the same base name on ties", func(t *testing.T) {
+		changes := []FileChange{
+			{Path: "x/util.go", OldContent: body, Action: merkletrie.Delete},
+			{Path: "y/main.go", OldContent: body, Action: merkletrie.Delete},
+			{Path: "z/main.go", NewContent: body, Action: merkletrie.Insert},
+		}
+		got := DetectRenames(changes, RenameOptions{})
+		testutils.Expect.Equal(t, len(got), 2)
+		testutils.Expect.Equal(t, got[0].Path, "x/util.go")
+		testutils.Expect.Equal(t, got[1].OldPath, "y/main.go")
+		testutils.Expect.Equal(t, got[1].Similarity, 100)
+	})
+
+	t.Run("copies", func(t *testing.T) {
+		changes := []FileChange{
+			{Path: "a.go", OldContent: body, NewContent: body + "// edited\n", Action: merkletrie.Modify},
+			{Path: "b.go", NewContent: body, Action: merkletrie.Insert},
+		}
+		testutils.Expect.Equal(t, DetectRenames(changes, RenameOptions{}), changes)
+
+		got := DetectRenames(changes, RenameOptions{Copies: true})
+		testutils.Expect.Equal(t, len(got), 2)
+		testutils.Expect.Equal(t, got[0].OldPath, "")
+		testutils.Expect.Equal(t, got[1].OldPath, "a.go")
+		testutils.Expect.True(t, got[1].Copied)
+		testutils.Expect.Equal(t, got[1].Similarity, 100)
+	})
+}
+
+func TestDetectRenames_EmptyFiles(t *testing.T) {
+	body := "package a\n\nfunc A() {}\n"
+	changes := []FileChange{
+		{Path: "empty.go", NewContent: body, Action: merkletrie.Modify},
+		{Path: "gone.go", OldContent: body, Action: merkletrie.Delete},
+	}
+	testutils.Expect.Equal(t, DetectRenames(changes, RenameOptions{}), changes, "an empty file gaining content is a modification, not an addition")
+
+	changes = []FileChange{
+		{Path: "added.go", NewContent: body, Action: merkletrie.Insert},
+		{Path: "truncated.go", OldContent: body, Action: merkletrie.Modify},
+	}
+	testutils.Expect.Equal(t, DetectRenames(changes, RenameOptions{}), changes, "a file truncated to empty is not a deletion")
+}
+
+func TestCommitFileChanges_DetectRenames(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.RenameCommit(t, repo, "a.txt", "docs/a.txt", "docs: move a")
+
+	changes, err := GetCommitFileChanges(repo, "HEAD")
+	if err != nil {
+		t.Fatalf("GetCommitFileChanges() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(changes), 2)
+
+	renames := DetectRenames(changes, RenameOptions{})
+	testutils.Expect.Equal(t, len(renames), 1)
+	testutils.Expect.Equal(t, renames[0].DisplayPath(), "a.txt → docs/a.txt")
+	testutils.Expect.Equal(t, renames[0].Similarity, 100)
+	testutils.Expect.Equal(t, renames[0].OldContent, renames[0].NewContent)
+}
+
+func TestCommitChanges_NoRenameDetection(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.RenameCommit(t, repo, "a.txt", "docs/a.txt", "docs: move a")
+
+	changes, err := CommitChanges(testutils.GetCommitHistory(t, repo)[0])
+	if err != nil {
+		t.Fatalf("CommitChanges() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(changes), 2, "a rename should stay a deletion and an insertion")
+	for _, change := range changes {
+		testutils.Expect.True(t, change.From.Name == "" || change.To.Name == "", "unexpected rename "+change.String())
+	}
+}
+
+func TestGetFileChange_EmptyFile(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	testutils.AddCommit(t, repo, "empty.txt", "", "chore: add empty file")
+	testutils.AddCommit(t, repo, "empty.txt", "content\n", "feat: fill empty file")
+
+	change, err := GetFileChange(repo, "HEAD~1", "HEAD", "empty.txt")
+	if err != nil {
+		t.Fatalf("GetFileChange() error = %v", err)
+	}
+	testutils.Expect.Equal(t, change.Action, merkletrie.Modify)
+
+	change, err = GetFileChange(repo, "HEAD~2", "HEAD", "empty.txt")
+	if err != nil {
+		t.Fatalf("GetFileChange() error = %v", err)
+	}
+	testutils.Expect.Equal(t, change.Action, merkletrie.Insert)
+
+	if _, err := GetFileChange(repo, "HEAD~1", "HEAD", "missing.txt"); err == nil {
+		t.Error("GetFileChange() expected an error for a path missing on both sides")
+	}
+}
@@ -0,0 +1,229 @@
+package gitlog
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/config"
+	"github.com/go-git/go-git/v6/plumbing"
+	"github.com/go-git/go-git/v6/plumbing/object"
+)
+
+// Signature formats accepted in gpg.format.
+const (
+	SignOpenPGP = "openpgp"
+	SignSSH     = "ssh"
+	SignX509    = "x509"
+)
+
+// SigningConfig is git's signing setup, read by [LoadSigningConfig].
+type SigningConfig struct {
+	// Format is gpg.format: openpgp (the default), ssh, or x509.
+	Format string
+	// Key is user.signingKey. For openpgp it's a key ID, and when empty gpg
+	// picks its default key. For ssh it's a key file or a literal public key
+	// whose private half is in the SSH agent.
+	Key string
+	// Program is gpg.<format>.program (or gpg.program for openpgp).
+	Program string
+	// Name and Email are user.name and user.email, the identity git tags with.
+	Name  string
+	Email string
+}
+
+// LoadSigningConfig reads the signing settings from system, global, and
+// repository config, with the repository's settings winning.
+func LoadSigningConfig(repo *git.Repository) (SigningConfig, error) {
+	var layers []*config.Config
+	for _, scope := range []config.Scope{config.SystemScope, config.GlobalScope} {
+		cfg, err := config.LoadConfig(scope)
+		if err != nil {
+			return SigningConfig{}, fmt.Errorf("failed to load git config: %w", err)
+		}
+		layers = append(layers, cfg)
+	}
+	local, err := repo.Config()
+	if err != nil {
+		return SigningConfig{}, fmt.Errorf("failed to load repository config: %w", err)
+	}
+	layers = append(layers, local)
+
+	signing := SigningConfig{Format: SignOpenPGP}
+	programs := map[string]string{}
+	for _, cfg := range layers {
+		if cfg == nil || cfg.Raw == nil {
+			continue
+		}
+		user := cfg.Raw.Section("user")
+		setOption(&signing.Key, user.Option("signingkey"))
+		setOption(&signing.Name, user.Option("name"))
+		setOption(&signing.Email, user.Option("email"))
+
+		gpg := cfg.Raw.Section("gpg")
+		if format := gpg.Option("format"); format != "" {
+			signing.Format = strings.ToLower(format)
+		}
+		if program := gpg.Option("program"); program != "" {
+			programs[SignOpenPGP] = program
+		}
+		for _, format := range []string{SignOpenPGP, SignSSH, SignX509} {
+			if program := gpg.Subsection(format).Option("program"); program != "" {
+				programs[format] = program
+			}
+		}
+	}
+
+	switch signing.Format {
+	case SignOpenPGP, SignSSH, SignX509:
+	default:
+		return SigningConfig{}, fmt.Errorf("unsupported gpg.format %q", signing.Format)
+	}
+	signing.Program = programs[signing.Format]
+	return signing, nil
+}
+
+// setOption overwrites dst with value when value is set.
+func setOption(dst *string, value string) {
+	if value != "" {
+		*dst = value
+	}
+}
+
+// Sign returns an armored detached signature of payload, made the way git
+// signs tags: gpg -bsau <key> for openpgp and x509, or ssh-keygen -Y sign -n
+// git for ssh.
+func (c SigningConfig) Sign(payload []byte) (string, error) {
+	if c.Format == SignSSH {
+		return c.signSSH(payload)
+	}
+
+	program := c.Program
+	if program == "" {
+		program = "gpg"
+		if c.Format == SignX509 {
+			program = "gpgsm"
+		}
+	}
+	args := []string{"--status-fd=2", "-bsa"}
+	if c.Key != "" {
+		args = []string{"--status-fd=2", "-bsau", c.Key}
+	}
+	cmd := exec.Command(program, args...)
+	cmd.Stdin = bytes.NewReader(payload)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%s failed to sign: %w: %s", program, err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
+
+// signSSH signs payload with ssh-keygen, which needs both in files.
+func (c SigningConfig) signSSH(payload []byte) (string, error) {
+	if c.Key == "" {
+		return "", fmt.Errorf("ssh signing requires user.signingKey")
+	}
+	program := c.Program
+	if program == "" {
+		program = "ssh-keygen"
+	}
+
+	dir, err := os.MkdirTemp("", "storm-sign-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	args := []string{"-Y", "sign", "-n", "git", "-f"}
+	key := strings.TrimPrefix(c.Key, "key::")
+	if strings.HasPrefix(key, "ssh-") || strings.HasPrefix(key, "ecdsa-") || strings.HasPrefix(key, "sk-") {
+		// A literal public key signs with the matching key in the agent.
+		keyFile := filepath.Join(dir, "key.pub")
+		if err := os.WriteFile(keyFile, []byte(key+"\n"), 0600); err != nil {
+			return "", fmt.Errorf("failed to write signing key: %w", err)
+		}
+		args = append(args, keyFile, "-U")
+	} else {
+		if rest, ok := strings.CutPrefix(key, "~/"); ok {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", fmt.Errorf("failed to expand %s: %w", key, err)
+			}
+			key = filepath.Join(home, rest)
+		}
+		args = append(args, key)
+	}
+
+	buffer := filepath.Join(dir, "payload")
+	if err := os.WriteFile(buffer, payload, 0600); err != nil {
+		return "", fmt.Errorf("failed to write signing payload: %w", err)
+	}
+	cmd := exec.Command(program, append(args, buffer)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s failed to sign: %w: %s", program, err, strings.TrimSpace(stderr.String()))
+	}
+	sig, err := os.ReadFile(buffer + ".sig")
+	if err != nil {
+		return "", fmt.Errorf("failed to read ssh signature: %w", err)
+	}
+	return string(sig), nil
+}
+
+// CreateSignedTag creates the annotated tag name for the commit target, like
+// git tag -s: the tag object is signed with signing and the signature is
+// appended to it. message gets a trailing newline if it lacks one.
+func CreateSignedTag(repo *git.Repository, name string, target plumbing.Hash, message string, tagger object.Signature, signing SigningConfig) (plumbing.Hash, error) {
+	if !strings.HasSuffix(message, "\n") {
+		message += "\n"
+	}
+	tag := &object.Tag{
+		Name:       name,
+		Tagger:     tagger,
+		Message:    message,
+		TargetType: plumbing.CommitObject,
+		Target:     target,
+	}
+
+	unsigned := repo.Storer.NewEncodedObject()
+	if err := tag.EncodeWithoutSignature(unsigned); err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to encode tag: %w", err)
+	}
+	r, err := unsigned.Reader()
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to encode tag: %w", err)
+	}
+	payload, err := io.ReadAll(r)
+	r.Close()
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to encode tag: %w", err)
+	}
+
+	sig, err := signing.Sign(payload)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	tag.PGPSignature = sig
+
+	obj := repo.Storer.NewEncodedObject()
+	if err := tag.Encode(obj); err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to encode tag: %w", err)
+	}
+	hash, err := repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to store tag: %w", err)
+	}
+	ref := plumbing.NewHashReference(plumbing.NewTagReferenceName(name), hash)
+	if err := repo.Storer.SetReference(ref); err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to create tag ref: %w", err)
+	}
+	return hash, nil
+}
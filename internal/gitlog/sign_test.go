@@ -0,0 +1,126 @@
+package gitlog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/plumbing/object"
+	"github.com/stormlightlabs/git-storm/internal/testutils"
+)
+
+// configureSigning points the repository's gpg.program (or gpg.ssh.program)
+// at a fake signer script and isolates the test from the user's git config.
+func configureSigning(t *testing.T, repo *git.Repository, options map[string]string, script string) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	program := filepath.Join(t.TempDir(), "signer")
+	if err := os.WriteFile(program, []byte("#!/bin/sh\n"+script), 0755); err != nil {
+		t.Fatalf("Failed to write signer: %v", err)
+	}
+
+	cfg, err := repo.Config()
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	for key, value := range options {
+		section, option, _ := strings.Cut(key, ".")
+		if sub, opt, ok := strings.Cut(option, "."); ok {
+			cfg.Raw.Section(section).Subsection(sub).SetOption(opt, value)
+		} else {
+			cfg.Raw.Section(section).SetOption(option, value)
+		}
+	}
+	if options["gpg.format"] == SignSSH {
+		cfg.Raw.Section("gpg").Subsection("ssh").SetOption("program", program)
+	} else {
+		cfg.Raw.Section("gpg").SetOption("program", program)
+	}
+	if err := repo.SetConfig(cfg); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+}
+
+func TestCreateSignedTag_OpenPGP(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	payloadFile := filepath.Join(t.TempDir(), "payload")
+	configureSigning(t, repo, map[string]string{"user.signingkey": "ABCD1234", "user.name": "Jane", "user.email": "jane@example.com"},
+		`test "$2" = -bsau && test "$3" = ABCD1234 || exit 1
+cat > `+payloadFile+`
+printf -- '-----BEGIN PGP SIGNATURE-----\nfake\n-----END PGP SIGNATURE-----\n'
+`)
+
+	signing, err := LoadSigningConfig(repo)
+	if err != nil {
+		t.Fatalf("LoadSigningConfig() error = %v", err)
+	}
+	testutils.Expect.Equal(t, signing.Format, SignOpenPGP)
+	testutils.Expect.Equal(t, signing.Key, "ABCD1234")
+	testutils.Expect.Equal(t, signing.Name+" <"+signing.Email+">", "Jane <jane@example.com>")
+
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("Failed to get HEAD: %v", err)
+	}
+	tagger := object.Signature{Name: "Jane", Email: "jane@example.com", When: time.Unix(1700000000, 0).UTC()}
+	hash, err := CreateSignedTag(repo, "v1.0.0", head.Hash(), "Release 1.0.0", tagger, signing)
+	if err != nil {
+		t.Fatalf("CreateSignedTag() error = %v", err)
+	}
+
+	ref, err := repo.Tag("v1.0.0")
+	if err != nil {
+		t.Fatalf("tag v1.0.0 should exist: %v", err)
+	}
+	testutils.Expect.Equal(t, ref.Hash(), hash)
+	tag, err := repo.TagObject(hash)
+	if err != nil {
+		t.Fatalf("TagObject() error = %v", err)
+	}
+	testutils.Expect.Equal(t, tag.Message, "Release 1.0.0\n")
+	testutils.Expect.Equal(t, tag.PGPSignature, "-----BEGIN PGP SIGNATURE-----\nfake\n-----END PGP SIGNATURE-----\n")
+	testutils.Expect.Equal(t, tag.Target, head.Hash())
+
+	payload, err := os.ReadFile(payloadFile)
+	if err != nil {
+		t.Fatalf("signer should have received the payload: %v", err)
+	}
+	testutils.Expect.True(t, strings.HasPrefix(string(payload), "object "+head.Hash().String()+"\ntype commit\ntag v1.0.0\n"), "the signed payload is the unsigned tag object")
+	testutils.Expect.True(t, strings.HasSuffix(string(payload), "\n\nRelease 1.0.0\n"), "the signed payload ends with the message")
+}
+
+func TestSigningConfig_SSH(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	configureSigning(t, repo, map[string]string{"gpg.format": "ssh", "user.signingkey": "ssh-ed25519 AAAAC3Nza test"},
+		`test "$1 $2 $3 $4" = "-Y sign -n git" && test "$7" = -U || exit 1
+printf -- '-----BEGIN SSH SIGNATURE-----\nfake\n-----END SSH SIGNATURE-----\n' > "$8.sig"
+`)
+
+	signing, err := LoadSigningConfig(repo)
+	if err != nil {
+		t.Fatalf("LoadSigningConfig() error = %v", err)
+	}
+	testutils.Expect.Equal(t, signing.Format, SignSSH)
+	sig, err := signing.Sign([]byte("payload"))
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	testutils.Expect.Equal(t, sig, "-----BEGIN SSH SIGNATURE-----\nfake\n-----END SSH SIGNATURE-----\n")
+
+	if _, err := (SigningConfig{Format: SignSSH}).Sign([]byte("payload")); err == nil {
+		t.Error("ssh signing without a key should fail")
+	}
+}
+
+func TestLoadSigningConfig_UnknownFormat(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	configureSigning(t, repo, map[string]string{"gpg.format": "pgp2"}, "exit 1\n")
+	if _, err := LoadSigningConfig(repo); err == nil || !strings.Contains(err.Error(), `unsupported gpg.format "pgp2"`) {
+		t.Errorf("LoadSigningConfig() error = %v", err)
+	}
+}
@@ -0,0 +1,277 @@
+package gitlog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/plumbing"
+	"github.com/go-git/go-git/v6/plumbing/filemode"
+	"github.com/go-git/go-git/v6/plumbing/format/index"
+	"github.com/go-git/go-git/v6/plumbing/object"
+)
+
+// Pseudo-refs naming uncommitted content. [GetFileContent] and
+// [GetChangedFiles] accept them anywhere a commit ref is accepted.
+const (
+	// RefWorktree is the files on disk. Like git diff, untracked files are
+	// left out of changed-file listings.
+	RefWorktree = "WORKTREE"
+	// RefIndex is the staging area.
+	RefIndex = "INDEX"
+)
+
+// IsPseudoRef reports whether ref is [RefWorktree] or [RefIndex].
+func IsPseudoRef(ref string) bool {
+	return ref == RefWorktree || ref == RefIndex
+}
+
+// pseudoRefContent reads filePath from the worktree or the index. A missing
+// file wraps [object.ErrFileNotFound], as it does for commits.
+func pseudoRefContent(repo *git.Repository, ref, filePath string) (string, error) {
+	if ref == RefIndex {
+		idx, err := repo.Storer.Index()
+		if err != nil {
+			return "", fmt.Errorf("failed to read index: %w", err)
+		}
+		entry, err := idx.Entry(filePath)
+		if errors.Is(err, index.ErrEntryNotFound) {
+			return "", fmt.Errorf("file not found: %w", object.ErrFileNotFound)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read index entry %s: %w", filePath, err)
+		}
+		return blobContent(repo, entry.Hash)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get worktree: %w", err)
+	}
+	f, err := wt.Filesystem.Open(filePath)
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("file not found: %w", object.ErrFileNotFound)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", filePath, err)
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+	return string(content), nil
+}
+
+// blobContent reads the blob with the given hash.
+func blobContent(repo *git.Repository, hash plumbing.Hash) (string, error) {
+	blob, err := repo.BlobObject(hash)
+	if err != nil {
+		return "", fmt.Errorf("failed to get blob %s: %w", hash, err)
+	}
+	r, err := blob.Reader()
+	if err != nil {
+		return "", fmt.Errorf("failed to read blob %s: %w", hash, err)
+	}
+	defer r.Close()
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to read blob %s: %w", hash, err)
+	}
+	return string(content), nil
+}
+
+// StageContent stores content in the index as the staged version of
+// filePath, leaving the worktree alone. A path missing from the index is
+// added as a regular file.
+func StageContent(repo *git.Repository, filePath, content string) error {
+	obj := repo.Storer.NewEncodedObject()
+	obj.SetType(plumbing.BlobObject)
+	obj.SetSize(int64(len(content)))
+	w, err := obj.Writer()
+	if err != nil {
+		return fmt.Errorf("failed to write blob for %s: %w", filePath, err)
+	}
+	if _, err := io.WriteString(w, content); err != nil {
+		w.Close()
+		return fmt.Errorf("failed to write blob for %s: %w", filePath, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to write blob for %s: %w", filePath, err)
+	}
+	hash, err := repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return fmt.Errorf("failed to store blob for %s: %w", filePath, err)
+	}
+
+	idx, err := repo.Storer.Index()
+	if err != nil {
+		return fmt.Errorf("failed to read index: %w", err)
+	}
+	entry, err := idx.Entry(filePath)
+	if errors.Is(err, index.ErrEntryNotFound) {
+		entry = idx.Add(filePath)
+		entry.Mode = filemode.Regular
+	} else if err != nil {
+		return fmt.Errorf("failed to read index entry %s: %w", filePath, err)
+	}
+	entry.Hash = hash
+	entry.Size = uint32(len(content))
+	// The staged content no longer matches the file on disk, so clear the
+	// cached stat data to make git compare them again.
+	entry.CreatedAt, entry.ModifiedAt = time.Time{}, time.Time{}
+
+	if err := repo.Storer.SetIndex(idx); err != nil {
+		return fmt.Errorf("failed to write index: %w", err)
+	}
+	return nil
+}
+
+// pseudoRefChangedFiles lists the paths that differ between fromRef and toRef
+// when either is a pseudo-ref, ordered by path.
+//
+// Commits and the index are compared by blob hash. Worktree files aren't
+// hashed: candidates are the paths where the other side differs from the
+// index plus those git status reports as modified on disk, and each is
+// confirmed by comparing content.
+func pseudoRefChangedFiles(repo *git.Repository, fromRef, toRef string) ([]string, error) {
+	if fromRef == toRef {
+		return []string{}, nil
+	}
+
+	other := fromRef
+	if fromRef == RefWorktree {
+		other = toRef
+	} else if toRef != RefWorktree {
+		return changedBetween(repo, fromRef, toRef)
+	}
+
+	candidates, err := changedBetween(repo, other, RefIndex)
+	if err != nil {
+		return nil, err
+	}
+	modified, err := worktreeModified(repo)
+	if err != nil {
+		return nil, err
+	}
+	candidates = append(candidates, modified...)
+	sort.Strings(candidates)
+
+	files := []string{}
+	for i, path := range candidates {
+		if i > 0 && candidates[i-1] == path {
+			continue
+		}
+		differs, err := contentDiffers(repo, other, RefWorktree, path)
+		if err != nil {
+			return nil, err
+		}
+		if differs {
+			files = append(files, path)
+		}
+	}
+	return files, nil
+}
+
+// changedBetween compares the blob hashes of two commits or the index.
+func changedBetween(repo *git.Repository, fromRef, toRef string) ([]string, error) {
+	from, err := refBlobs(repo, fromRef)
+	if err != nil {
+		return nil, err
+	}
+	to, err := refBlobs(repo, toRef)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	for path, hash := range from {
+		if toHash, ok := to[path]; !ok || toHash != hash {
+			files = append(files, path)
+		}
+	}
+	for path := range to {
+		if _, ok := from[path]; !ok {
+			files = append(files, path)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// refBlobs maps every file in a commit's tree, or in the index, to its blob hash.
+func refBlobs(repo *git.Repository, ref string) (map[string]plumbing.Hash, error) {
+	blobs := make(map[string]plumbing.Hash)
+	if ref == RefIndex {
+		idx, err := repo.Storer.Index()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read index: %w", err)
+		}
+		for _, entry := range idx.Entries {
+			if _, seen := blobs[entry.Name]; !seen {
+				blobs[entry.Name] = entry.Hash
+			}
+		}
+		return blobs, nil
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", ref, err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree for %s: %w", ref, err)
+	}
+	err = tree.Files().ForEach(func(f *object.File) error {
+		blobs[f.Name] = f.Hash
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files for %s: %w", ref, err)
+	}
+	return blobs, nil
+}
+
+// worktreeModified returns tracked paths whose worktree content differs from the index.
+func worktreeModified(repo *git.Repository) ([]string, error) {
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree: %w", err)
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree status: %w", err)
+	}
+
+	var paths []string
+	for path, s := range status {
+		if s.Worktree != git.Unmodified && s.Worktree != git.Untracked {
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+// contentDiffers reports whether path differs between two refs, counting a
+// file present on only one side as a difference.
+func contentDiffers(repo *git.Repository, fromRef, toRef, path string) (bool, error) {
+	from, fromErr := GetFileContent(repo, fromRef, path)
+	to, toErr := GetFileContent(repo, toRef, path)
+	for _, err := range []error{fromErr, toErr} {
+		if err != nil && !errors.Is(err, object.ErrFileNotFound) {
+			return false, err
+		}
+	}
+	return from != to || (fromErr == nil) != (toErr == nil), nil
+}
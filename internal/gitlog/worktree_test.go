@@ -0,0 +1,159 @@
+package gitlog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v6"
+	"github.com/stormlightlabs/git-storm/internal/testutils"
+)
+
+// dirtyTestRepo returns a test repository with a staged change to a.txt, an
+// unstaged change to b.txt, c.txt deleted from disk, and an untracked file.
+func dirtyTestRepo(t *testing.T) *git.Repository {
+	t.Helper()
+	repo := testutils.SetupTestRepo(t)
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+	root := wt.Filesystem.Root()
+
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	write("a.txt", "hello world\nstaged line")
+	if _, err := wt.Add("a.txt"); err != nil {
+		t.Fatalf("Failed to stage a.txt: %v", err)
+	}
+	write("b.txt", "fixed bug\nunstaged line")
+	if err := os.Remove(filepath.Join(root, "c.txt")); err != nil {
+		t.Fatalf("Failed to remove c.txt: %v", err)
+	}
+	write("untracked.txt", "scratch")
+	return repo
+}
+
+func TestGetChangedFiles_PseudoRefs(t *testing.T) {
+	repo := dirtyTestRepo(t)
+
+	tests := []struct {
+		from, to string
+		want     []string
+	}{
+		{"HEAD", RefWorktree, []string{"a.txt", "b.txt", "c.txt"}},
+		{"HEAD", RefIndex, []string{"a.txt"}},
+		{RefIndex, RefWorktree, []string{"b.txt", "c.txt"}},
+		{RefWorktree, "HEAD", []string{"a.txt", "b.txt", "c.txt"}},
+		{"HEAD~1", RefIndex, []string{"a.txt", "b.txt"}},
+		{RefWorktree, RefWorktree, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+".."+tt.to, func(t *testing.T) {
+			files, err := GetChangedFiles(repo, tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("GetChangedFiles() error = %v", err)
+			}
+			testutils.Expect.Equal(t, strings.Join(files, ","), strings.Join(tt.want, ","))
+		})
+	}
+}
+
+func TestGetChangedFiles_RevertedWorktreeChange(t *testing.T) {
+	repo := testutils.SetupTestRepo(t)
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+	path := filepath.Join(wt.Filesystem.Root(), "a.txt")
+	if err := os.WriteFile(path, []byte("staged"), 0644); err != nil {
+		t.Fatalf("Failed to write a.txt: %v", err)
+	}
+	if _, err := wt.Add("a.txt"); err != nil {
+		t.Fatalf("Failed to stage a.txt: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("hello world\ngoodbye world"), 0644); err != nil {
+		t.Fatalf("Failed to restore a.txt: %v", err)
+	}
+
+	files, err := GetChangedFiles(repo, "HEAD", RefWorktree)
+	if err != nil {
+		t.Fatalf("GetChangedFiles() error = %v", err)
+	}
+	testutils.Expect.Equal(t, len(files), 0, "a staged change reverted on disk leaves the worktree equal to HEAD")
+}
+
+func TestGetFileContent_PseudoRefs(t *testing.T) {
+	repo := dirtyTestRepo(t)
+
+	tests := []struct {
+		ref, path, want string
+	}{
+		{RefIndex, "a.txt", "hello world\nstaged line"},
+		{RefWorktree, "a.txt", "hello world\nstaged line"},
+		{RefIndex, "b.txt", "fixed bug\nwith proper handling"},
+		{RefWorktree, "b.txt", "fixed bug\nunstaged line"},
+		{RefWorktree, "untracked.txt", "scratch"},
+	}
+	for _, tt := range tests {
+		got, err := GetFileContent(repo, tt.ref, tt.path)
+		if err != nil {
+			t.Fatalf("GetFileContent(%s, %s) error = %v", tt.ref, tt.path, err)
+		}
+		testutils.Expect.Equal(t, got, tt.want, tt.ref+":"+tt.path)
+	}
+
+	for _, ref := range []string{RefIndex, RefWorktree} {
+		content, err := GetFileContentOrEmpty(repo, ref, "missing.txt")
+		if err != nil {
+			t.Errorf("GetFileContentOrEmpty(%s) error = %v", ref, err)
+		}
+		testutils.Expect.Equal(t, content, "")
+	}
+
+	content, err := GetFileContentOrEmpty(repo, RefWorktree, "c.txt")
+	if err != nil {
+		t.Fatalf("GetFileContentOrEmpty() error = %v", err)
+	}
+	testutils.Expect.Equal(t, content, "", "a file deleted from disk reads as empty")
+}
+
+func TestStageContent(t *testing.T) {
+	repo := dirtyTestRepo(t)
+
+	if err := StageContent(repo, "b.txt", "fixed bug\npartly staged"); err != nil {
+		t.Fatalf("StageContent() error = %v", err)
+	}
+	staged, err := GetFileContent(repo, RefIndex, "b.txt")
+	if err != nil {
+		t.Fatalf("GetFileContent() error = %v", err)
+	}
+	testutils.Expect.Equal(t, staged, "fixed bug\npartly staged")
+	onDisk, err := GetFileContent(repo, RefWorktree, "b.txt")
+	if err != nil {
+		t.Fatalf("GetFileContent() error = %v", err)
+	}
+	testutils.Expect.Equal(t, onDisk, "fixed bug\nunstaged line", "staging should leave the worktree alone")
+
+	files, err := GetChangedFiles(repo, RefIndex, RefWorktree)
+	if err != nil {
+		t.Fatalf("GetChangedFiles() error = %v", err)
+	}
+	testutils.Expect.Equal(t, strings.Join(files, ","), "b.txt,c.txt")
+
+	if err := StageContent(repo, "new.txt", "added\n"); err != nil {
+		t.Fatalf("StageContent() of a new path error = %v", err)
+	}
+	added, err := GetFileContent(repo, RefIndex, "new.txt")
+	if err != nil {
+		t.Fatalf("GetFileContent() error = %v", err)
+	}
+	testutils.Expect.Equal(t, added, "added\n")
+}
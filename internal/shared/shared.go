@@ -1,6 +1,9 @@
 package shared
 
 import (
+	"fmt"
+	"time"
+
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
@@ -10,3 +13,27 @@ var caser = cases.Title(language.English)
 func TitleCase(s string) string {
 	return caser.String(s)
 }
+
+// TimeAgo formats t as a compact relative time such as "5m ago" or "3d ago".
+func TimeAgo(t time.Time) string {
+	duration := time.Since(t)
+
+	if duration < time.Minute {
+		return "just now"
+	} else if duration < time.Hour {
+		minutes := int(duration.Minutes())
+		return fmt.Sprintf("%dm ago", minutes)
+	} else if duration < 24*time.Hour {
+		hours := int(duration.Hours())
+		return fmt.Sprintf("%dh ago", hours)
+	} else if duration < 30*24*time.Hour {
+		days := int(duration.Hours() / 24)
+		return fmt.Sprintf("%dd ago", days)
+	} else if duration < 365*24*time.Hour {
+		months := int(duration.Hours() / 24 / 30)
+		return fmt.Sprintf("%dmo ago", months)
+	} else {
+		years := int(duration.Hours() / 24 / 365)
+		return fmt.Sprintf("%dy ago", years)
+	}
+}
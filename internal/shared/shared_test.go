@@ -1,6 +1,9 @@
 package shared
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestTitleCase(t *testing.T) {
 	t.Run("Basic", func(t *testing.T) {
@@ -34,3 +37,29 @@ func TestTitleCase(t *testing.T) {
 		}
 	})
 }
+
+func TestTimeAgo(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		time     time.Time
+		expected string
+	}{
+		{"just now", now, "just now"},
+		{"minutes ago", now.Add(-5 * time.Minute), "5m ago"},
+		{"hours ago", now.Add(-2 * time.Hour), "2h ago"},
+		{"days ago", now.Add(-3 * 24 * time.Hour), "3d ago"},
+		{"months ago", now.Add(-45 * 24 * time.Hour), "1mo ago"},
+		{"years ago", now.Add(-400 * 24 * time.Hour), "1y ago"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := TimeAgo(tc.time)
+			if result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
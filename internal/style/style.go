@@ -4,10 +4,14 @@ package style
 import (
 	"fmt"
 	"image/color"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/charmbracelet/fang"
 	"github.com/charmbracelet/lipgloss"
 	lg "github.com/charmbracelet/lipgloss/v2"
+	"github.com/muesli/termenv"
 )
 
 var (
@@ -36,59 +40,122 @@ var (
 
 func fgColor(c lipgloss.Color) lipgloss.Style { return lipgloss.NewStyle().Foreground(c) }
 
+// ColorMode controls whether helpers emit ANSI styling.
+type ColorMode int
+
+const (
+	ColorAuto   ColorMode = iota // style unless NO_COLOR is set or the writer isn't a terminal
+	ColorAlways                  // always style, even when output is redirected
+	ColorNever                   // never style
+)
+
+var (
+	colorMode ColorMode
+	out       io.Writer = os.Stdout
+	renderer            = lipgloss.NewRenderer(os.Stdout)
+)
+
+// ParseColorMode converts "auto", "always", or "never" into a [ColorMode].
+func ParseColorMode(s string) (ColorMode, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "auto":
+		return ColorAuto, nil
+	case "always":
+		return ColorAlways, nil
+	case "never":
+		return ColorNever, nil
+	default:
+		return ColorAuto, fmt.Errorf("invalid color mode %q: expected auto, always, or never", s)
+	}
+}
+
+// SetColorMode sets how every helper in this package renders styled text.
+func SetColorMode(mode ColorMode) {
+	colorMode = mode
+	resetRenderer()
+}
+
+// SetWriter redirects helper output, e.g. to capture it in tests. A nil writer restores stdout.
+func SetWriter(w io.Writer) {
+	if w == nil {
+		w = os.Stdout
+	}
+	out = w
+	resetRenderer()
+}
+
+// Writer returns the writer helpers print to.
+func Writer() io.Writer { return out }
+
+func resetRenderer() {
+	renderer = lipgloss.NewRenderer(out)
+	if colorMode == ColorAlways {
+		renderer.SetColorProfile(termenv.TrueColor)
+	}
+}
+
+// Plain reports whether styling is disabled by the color mode or NO_COLOR.
+func Plain() bool {
+	switch colorMode {
+	case ColorNever:
+		return true
+	case ColorAlways:
+		return false
+	default:
+		return os.Getenv("NO_COLOR") != ""
+	}
+}
+
+// Render applies st to s, or returns s unchanged when output is plain.
+func Render(st lipgloss.Style, s string) string {
+	if Plain() {
+		return s
+	}
+	return st.Renderer(renderer).Render(s)
+}
+
 func Headline(s string) {
-	v := StyleHeadline.Render(s)
-	fmt.Println(v)
+	fmt.Fprintln(out, Render(StyleHeadline, s))
 }
 
 func Headlinef(format string, args ...any) {
-	s := fmt.Sprintf(format, args...)
-	v := StyleHeadline.Render(s)
-	fmt.Println(v)
+	Headline(fmt.Sprintf(format, args...))
 }
 
 func Added(s string) {
-	v := StyleAdded.Render(s)
-	fmt.Println(v)
+	fmt.Fprintln(out, Render(StyleAdded, s))
 }
 
 func Addedf(format string, args ...any) {
-	s := fmt.Sprintf(format, args...)
-	v := StyleAdded.Render(s)
-	fmt.Println(v)
+	Added(fmt.Sprintf(format, args...))
 }
 
 func Successf(format string, args ...any) {
-	s := fmt.Sprintf(format, args...)
-	v := StyleAdded.Render(s)
-	fmt.Println(v)
+	fmt.Fprintln(out, Render(StyleAdded, fmt.Sprintf(format, args...)))
 }
 
 func Warningf(format string, args ...any) {
-	s := fmt.Sprintf(format, args...)
-	v := StyleSecurity.Render(s)
-	fmt.Println(v)
+	fmt.Fprintln(out, Render(StyleSecurity, fmt.Sprintf(format, args...)))
 }
 
-func Newline() { fmt.Println() }
+func Newline() { fmt.Fprintln(out) }
 
 func Fixed(s string) {
-	v := StyleFixed.Render(s)
-	fmt.Println(v)
+	fmt.Fprintln(out, Render(StyleFixed, s))
 }
 
 func Styled(st lipgloss.Style) func(s string, a ...any) {
-	return func(s string, a ...any) { fmt.Printf(s, a...) }
+	return func(s string, a ...any) { fmt.Fprint(out, Render(st, fmt.Sprintf(s, a...))) }
 }
 
 func Styledln(st lipgloss.Style) func(s string, a ...any) {
-	return func(s string, a ...any) { fmt.Println(fmt.Sprintf(s, a...)) }
+	return func(s string, a ...any) { fmt.Fprintln(out, Render(st, fmt.Sprintf(s, a...))) }
 }
 
-// Println wraps [fmt.Println] & [fmt.Sprintf]
+// Println wraps [fmt.Fprintln] & [fmt.Sprintf], writing to the configured writer.
 func Println(format string, args ...any) {
 	msg := fmt.Sprintf(format, args...)
-	fmt.Println(msg)
+	fmt.Fprintln(out, msg)
 }
 
 var darkTheme = fang.ColorScheme{
@@ -0,0 +1,80 @@
+package style
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func captureOutput(t *testing.T, mode ColorMode) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	SetWriter(&buf)
+	SetColorMode(mode)
+	t.Cleanup(func() {
+		SetColorMode(ColorAuto)
+		SetWriter(nil)
+	})
+	return &buf
+}
+
+func TestPlainModeEmitsNoANSI(t *testing.T) {
+	buf := captureOutput(t, ColorNever)
+
+	Headlinef("Release %s", "1.0.0")
+	Addedf("✓ Created %s", "entry.md")
+	Successf("done")
+	Warningf("careful")
+	Fixed("fixed")
+	Styledln(StyleRemoved)("removed %d", 2)
+	Println("plain %s", "text")
+
+	got := buf.String()
+	if strings.Contains(got, "\x1b[") {
+		t.Fatalf("expected no ANSI escape codes, got %q", got)
+	}
+	for _, want := range []string{"Release 1.0.0", "✓ Created entry.md", "done", "careful", "fixed", "removed 2", "plain text"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected output to contain %q, got %q", want, got)
+		}
+	}
+}
+
+func TestAlwaysModeEmitsANSI(t *testing.T) {
+	buf := captureOutput(t, ColorAlways)
+
+	Headline("styled")
+
+	if !strings.Contains(buf.String(), "\x1b[") {
+		t.Fatalf("expected ANSI escape codes, got %q", buf.String())
+	}
+}
+
+func TestNoColorEnv(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	captureOutput(t, ColorAuto)
+
+	if !Plain() {
+		t.Error("NO_COLOR should force plain output in auto mode")
+	}
+	if got := Render(StyleAdded, "text"); got != "text" {
+		t.Errorf("Render() = %q, want %q", got, "text")
+	}
+}
+
+func TestParseColorMode(t *testing.T) {
+	tests := map[string]ColorMode{"": ColorAuto, "auto": ColorAuto, "ALWAYS": ColorAlways, "never": ColorNever}
+	for input, want := range tests {
+		got, err := ParseColorMode(input)
+		if err != nil {
+			t.Fatalf("ParseColorMode(%q) error = %v", input, err)
+		}
+		if got != want {
+			t.Errorf("ParseColorMode(%q) = %v, want %v", input, got, want)
+		}
+	}
+
+	if _, err := ParseColorMode("sometimes"); err == nil {
+		t.Error("expected error for invalid mode")
+	}
+}
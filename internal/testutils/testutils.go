@@ -75,6 +75,27 @@ func CreateTag(t *testing.T, repo *git.Repository, tagName string) {
 	}
 }
 
+// CreateAnnotatedTag creates an annotated tag at HEAD with the given tagger date.
+func CreateAnnotatedTag(t *testing.T, repo *git.Repository, tagName string, when time.Time) {
+	t.Helper()
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("failed to get HEAD: %v", err)
+	}
+
+	_, err = repo.CreateTag(tagName, head.Hash(), &git.CreateTagOptions{
+		Message: "Release " + tagName,
+		Tagger: &object.Signature{
+			Name:  "Test Author",
+			Email: "test@example.com",
+			When:  when,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create tag %s: %v", tagName, err)
+	}
+}
+
 // CreateTagAtCommit creates a lightweight tag at a specific commit hash.
 func CreateTagAtCommit(t *testing.T, repo *git.Repository, tagName, commitHash string) error {
 	t.Helper()
@@ -114,13 +135,98 @@ func GetCommitHistory(t *testing.T, repo *git.Repository) []*object.Commit {
 
 // AddCommit adds a new commit to the repository with the given file changes.
 func AddCommit(t *testing.T, repo *git.Repository, filename, content, message string) {
+	t.Helper()
+	AddAuthoredCommit(t, repo, "Test Author", "test@example.com", filename, content, message)
+}
+
+// AddAuthoredCommit is like [AddCommit] with the commit authored by name and email.
+func AddAuthoredCommit(t *testing.T, repo *git.Repository, name, email, filename, content, message string) {
+	t.Helper()
+	w, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+
+	writeAndAdd(t, w, filename, content)
+
+	if _, err := w.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  name,
+			Email: email,
+			When:  time.Now(),
+		},
+	}); err != nil {
+		t.Fatalf("commit failed: %v", err)
+	}
+}
+
+// AddFilesCommit writes every file in files (path -> content) and records them in a single commit.
+func AddFilesCommit(t *testing.T, repo *git.Repository, files map[string]string, message string) {
 	t.Helper()
 	w, err := repo.Worktree()
 	if err != nil {
 		t.Fatalf("failed to get worktree: %v", err)
 	}
 
+	for filename, content := range files {
+		writeAndAdd(t, w, filename, content)
+	}
+
+	if _, err := w.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  "Test Author",
+			Email: "test@example.com",
+			When:  time.Now(),
+		},
+	}); err != nil {
+		t.Fatalf("commit failed: %v", err)
+	}
+}
+
+// AddMergeCommit commits filename on a branch forked from HEAD with
+// sideMessage, then merges that commit back into HEAD with message. The merge's
+// first parent is the old HEAD and its second the side commit.
+func AddMergeCommit(t *testing.T, repo *git.Repository, filename, content, sideMessage, message string) {
+	t.Helper()
+	base, err := repo.Head()
+	if err != nil {
+		t.Fatalf("failed to get HEAD: %v", err)
+	}
+
+	AddCommit(t, repo, filename, content, sideMessage)
+	side, err := repo.Head()
+	if err != nil {
+		t.Fatalf("failed to get HEAD: %v", err)
+	}
+
+	w, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	if err := w.Reset(&git.ResetOptions{Commit: base.Hash(), Mode: git.HardReset}); err != nil {
+		t.Fatalf("failed to reset to %s: %v", base.Hash(), err)
+	}
+
+	writeAndAdd(t, w, filename, content)
+	if _, err := w.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  "Test Author",
+			Email: "test@example.com",
+			When:  time.Now(),
+		},
+		Parents: []plumbing.Hash{base.Hash(), side.Hash()},
+	}); err != nil {
+		t.Fatalf("merge commit failed: %v", err)
+	}
+}
+
+// writeAndAdd writes filename (creating parent directories) and stages it.
+func writeAndAdd(t *testing.T, w *git.Worktree, filename, content string) {
+	t.Helper()
 	path := filepath.Join(w.Filesystem.Root(), filename)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create directory for %s: %v", filename, err)
+	}
 	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
 		t.Fatalf("failed to write file %s: %v", filename, err)
 	}
@@ -128,6 +234,45 @@ func AddCommit(t *testing.T, repo *git.Repository, filename, content, message st
 	if _, err := w.Add(filename); err != nil {
 		t.Fatalf("failed to add file %s: %v", filename, err)
 	}
+}
+
+// RenameCommit moves from to to, creating parent directories, and commits the rename.
+func RenameCommit(t *testing.T, repo *git.Repository, from, to, message string) {
+	t.Helper()
+	w, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filepath.Join(w.Filesystem.Root(), to)), 0755); err != nil {
+		t.Fatalf("failed to create directory for %s: %v", to, err)
+	}
+	if _, err := w.Move(from, to); err != nil {
+		t.Fatalf("failed to move %s to %s: %v", from, to, err)
+	}
+
+	if _, err := w.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  "Test Author",
+			Email: "test@example.com",
+			When:  time.Now(),
+		},
+	}); err != nil {
+		t.Fatalf("commit failed: %v", err)
+	}
+}
+
+// RemoveCommit deletes filename from the worktree and commits the removal.
+func RemoveCommit(t *testing.T, repo *git.Repository, filename, message string) {
+	t.Helper()
+	w, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+
+	if _, err := w.Remove(filename); err != nil {
+		t.Fatalf("failed to remove file %s: %v", filename, err)
+	}
 
 	if _, err := w.Commit(message, &git.CommitOptions{
 		Author: &object.Signature{
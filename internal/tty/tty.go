@@ -6,6 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
 
 	"golang.org/x/term"
 )
@@ -15,6 +19,26 @@ func IsTTY(fd uintptr) bool {
 	return term.IsTerminal(int(fd))
 }
 
+// DefaultWidth is the rendering width used when no terminal size is available.
+const DefaultWidth = 80
+
+// Width returns the column count for output written to fd. A positive COLUMNS
+// environment variable takes precedence, then the terminal size of fd; when
+// neither is available (e.g. output is piped) [DefaultWidth] is returned.
+func Width(fd uintptr) int {
+	if cols, err := strconv.Atoi(strings.TrimSpace(os.Getenv("COLUMNS"))); err == nil && cols > 0 {
+		return cols
+	}
+
+	if IsTTY(fd) {
+		if width, _, err := term.GetSize(int(fd)); err == nil && width > 0 {
+			return width
+		}
+	}
+
+	return DefaultWidth
+}
+
 // IsInteractive checks if both stdin and stdout are connected to a terminal.
 // This is the primary check for determining if TUI applications can run.
 func IsInteractive() bool {
@@ -108,3 +132,33 @@ func ErrorInteractiveFlag(flagName string) error {
 
 	return errors.New(msg)
 }
+
+// Editor returns the user's preferred text editor: $VISUAL, then $EDITOR,
+// falling back to vi (notepad on Windows).
+func Editor() string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(name)); editor != "" {
+			return editor
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+// EditorCommand returns a command opening path in [Editor], attached to the
+// terminal. The editor runs through the shell so values with arguments, such
+// as "code --wait", work.
+func EditorCommand(path string) *exec.Cmd {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", Editor()+` "`+path+`"`)
+	} else {
+		cmd = exec.Command("sh", "-c", Editor()+` "$1"`, "sh", path)
+	}
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd
+}
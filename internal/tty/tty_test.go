@@ -2,6 +2,8 @@ package tty
 
 import (
 	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 )
@@ -286,3 +288,78 @@ func TestErrorInteractiveFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestWidth(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+	defer f.Close()
+
+	tests := []struct {
+		name    string
+		columns string
+		want    int
+	}{
+		{name: "COLUMNS set", columns: "132", want: 132},
+		{name: "non-TTY default", columns: "", want: DefaultWidth},
+		{name: "invalid COLUMNS", columns: "wide", want: DefaultWidth},
+		{name: "non-positive COLUMNS", columns: "0", want: DefaultWidth},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("COLUMNS", tt.columns)
+			if got := Width(f.Fd()); got != tt.want {
+				t.Errorf("Width() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditor(t *testing.T) {
+	tests := []struct {
+		name   string
+		visual string
+		editor string
+		want   string
+	}{
+		{name: "VISUAL wins", visual: "code --wait", editor: "nano", want: "code --wait"},
+		{name: "EDITOR", visual: "", editor: "nano", want: "nano"},
+		{name: "blank falls through", visual: "  ", editor: "nano", want: "nano"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("VISUAL", tt.visual)
+			t.Setenv("EDITOR", tt.editor)
+			if got := Editor(); got != tt.want {
+				t.Errorf("Editor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	path := filepath.Join(t.TempDir(), "entry file.md")
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "echo edited >")
+
+	cmd := EditorCommand(path)
+	cmd.Stdin, cmd.Stdout = nil, nil
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("EditorCommand().Run() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("editor did not write the file: %v", err)
+	}
+	if string(data) != "edited\n" {
+		t.Errorf("file = %q, want %q", data, "edited\n")
+	}
+}
@@ -2,12 +2,14 @@ package ui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/stormlightlabs/git-storm/internal/changeset"
 	"github.com/stormlightlabs/git-storm/internal/style"
 )
@@ -25,13 +27,36 @@ const (
 type ReviewItem struct {
 	Entry  changeset.EntryWithFile
 	Action ReviewAction
+	// NewType is the type picked with t, or "" when the entry keeps its own.
+	NewType string
+}
+
+// Retyped reports whether the item was given a new type during review.
+func (i ReviewItem) Retyped() bool {
+	return i.NewType != "" && i.NewType != i.Entry.Entry.Type
+}
+
+// Type returns the item's type as reviewed: NewType if set, else the entry's.
+func (i ReviewItem) Type() string {
+	if i.NewType != "" {
+		return i.NewType
+	}
+	return i.Entry.Entry.Type
 }
 
 // ChangesetReviewModel holds the state for the interactive changeset review TUI.
+//
+// The list can be narrowed with a / filter (see [listFilter]) and sorted with
+// s; the cursor moves over the entries shown.
 type ChangesetReviewModel struct {
 	viewport  viewport.Model
 	items     []ReviewItem
-	cursor    int
+	types     []string // the types t cycles through
+	metadata  map[string]changeset.Metadata
+	filter    listFilter
+	sort      listSort
+	visible   []int // indices into items of the entries shown, in order
+	cursor    int   // index into visible
 	ready     bool
 	width     int
 	height    int
@@ -50,6 +75,9 @@ type changesetReviewKeyMap struct {
 	Delete   key.Binding
 	Edit     key.Binding
 	Keep     key.Binding
+	Type     key.Binding
+	TypeBack key.Binding
+	Breaking key.Binding
 	Confirm  key.Binding
 	Quit     key.Binding
 }
@@ -91,6 +119,18 @@ var reviewKeys = changesetReviewKeyMap{
 		key.WithKeys(" "),
 		key.WithHelp("space", "keep"),
 	),
+	Type: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "next type"),
+	),
+	TypeBack: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "previous type"),
+	),
+	Breaking: key.NewBinding(
+		key.WithKeys("b"),
+		key.WithHelp("b", "next breaking"),
+	),
 	Confirm: key.NewBinding(
 		key.WithKeys("enter", "c"),
 		key.WithHelp("enter/c", "confirm"),
@@ -103,6 +143,12 @@ var reviewKeys = changesetReviewKeyMap{
 
 // NewChangesetReviewModel creates a new changeset review model.
 func NewChangesetReviewModel(entries []changeset.EntryWithFile) ChangesetReviewModel {
+	return NewChangesetReviewModelWithTypes(entries, validTypes)
+}
+
+// NewChangesetReviewModelWithTypes creates a review model whose t key cycles
+// entries through types, e.g. a project's change type registry.
+func NewChangesetReviewModelWithTypes(entries []changeset.EntryWithFile, types []string) ChangesetReviewModel {
 	items := make([]ReviewItem, 0, len(entries))
 
 	for _, entry := range entries {
@@ -112,11 +158,22 @@ func NewChangesetReviewModel(entries []changeset.EntryWithFile) ChangesetReviewM
 		})
 	}
 
-	return ChangesetReviewModel{
+	m := ChangesetReviewModel{
 		items:  items,
+		types:  types,
+		filter: newListFilter(),
 		cursor: 0,
 		ready:  false,
 	}
+	m.refilter()
+	return m
+}
+
+// SetMetadata provides the generate metadata of entries, keyed by diff hash,
+// whose commit author and date are used to filter and sort the entries.
+func (m *ChangesetReviewModel) SetMetadata(metadata map[string]changeset.Metadata) {
+	m.metadata = metadata
+	m.refilter()
 }
 
 // Init initializes the model (required by Bubble Tea).
@@ -130,11 +187,28 @@ func (m ChangesetReviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.filter.typing {
+			cmd = m.filter.update(msg)
+			m.refilter()
+			return m, cmd
+		}
+
 		switch {
+		case msg.Type == tea.KeyEsc && m.filter.active():
+			m.filter.query = ""
+			m.refilter()
+
 		case key.Matches(msg, reviewKeys.Quit):
 			m.cancelled = true
 			return m, tea.Quit
 
+		case key.Matches(msg, listKeys.Filter):
+			return m, m.filter.start()
+
+		case key.Matches(msg, listKeys.Sort):
+			m.sort = (m.sort + 1) % sortCount
+			m.refilter()
+
 		case key.Matches(msg, reviewKeys.Confirm):
 			m.confirmed = true
 			return m, tea.Quit
@@ -146,7 +220,7 @@ func (m ChangesetReviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case key.Matches(msg, reviewKeys.Down):
-			if m.cursor < len(m.items)-1 {
+			if m.cursor < len(m.visible)-1 {
 				m.cursor++
 				m.ensureVisible()
 			}
@@ -160,8 +234,8 @@ func (m ChangesetReviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case key.Matches(msg, reviewKeys.PageDown):
 			m.cursor += m.viewport.Height
-			if m.cursor >= len(m.items) {
-				m.cursor = len(m.items) - 1
+			if m.cursor >= len(m.visible) {
+				m.cursor = max(len(m.visible)-1, 0)
 			}
 			m.ensureVisible()
 
@@ -170,26 +244,29 @@ func (m ChangesetReviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.ensureVisible()
 
 		case key.Matches(msg, reviewKeys.Bottom):
-			m.cursor = len(m.items) - 1
+			m.cursor = max(len(m.visible)-1, 0)
 			m.ensureVisible()
 
-		case key.Matches(msg, reviewKeys.Delete):
-			if m.cursor >= 0 && m.cursor < len(m.items) {
-				m.items[m.cursor].Action = ActionDelete
-				m.updateContent()
+		case key.Matches(msg, reviewKeys.Breaking):
+			if next := m.nextBreaking(); next >= 0 {
+				m.cursor = next
+				m.ensureVisible()
 			}
 
+		case key.Matches(msg, reviewKeys.Delete):
+			m.setAction(ActionDelete)
+
 		case key.Matches(msg, reviewKeys.Edit):
-			if m.cursor >= 0 && m.cursor < len(m.items) {
-				m.items[m.cursor].Action = ActionEdit
-				m.updateContent()
-			}
+			m.setAction(ActionEdit)
 
 		case key.Matches(msg, reviewKeys.Keep):
-			if m.cursor >= 0 && m.cursor < len(m.items) {
-				m.items[m.cursor].Action = ActionKeep
-				m.updateContent()
-			}
+			m.setAction(ActionKeep)
+
+		case key.Matches(msg, reviewKeys.Type):
+			m.cycleType(1)
+
+		case key.Matches(msg, reviewKeys.TypeBack):
+			m.cycleType(-1)
 		}
 
 	case tea.WindowSizeMsg:
@@ -238,6 +315,101 @@ func (m ChangesetReviewModel) IsConfirmed() bool {
 	return m.confirmed
 }
 
+// current returns the item under the cursor, or nil when no entry is shown.
+func (m *ChangesetReviewModel) current() *ReviewItem {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return nil
+	}
+	return &m.items[m.visible[m.cursor]]
+}
+
+// setAction marks the entry under the cursor with action.
+func (m *ChangesetReviewModel) setAction(action ReviewAction) {
+	if item := m.current(); item != nil {
+		item.Action = action
+		m.updateContent()
+	}
+}
+
+// nextBreaking returns the position of the next breaking entry shown after
+// the cursor, wrapping around to the top, or -1 when there are none.
+func (m ChangesetReviewModel) nextBreaking() int {
+	for offset := 1; offset <= len(m.visible); offset++ {
+		i := (m.cursor + offset) % len(m.visible)
+		if m.items[m.visible[i]].Entry.Entry.Breaking {
+			return i
+		}
+	}
+	return -1
+}
+
+// refilter recomputes the entries shown after the filter or sort changed,
+// moving the cursor back to the top.
+func (m *ChangesetReviewModel) refilter() {
+	rows := make([]listRow, len(m.items))
+	for i, item := range m.items {
+		entry := item.Entry.Entry
+		row := listRow{
+			types: []string{item.Type()},
+			scope: entry.Scope,
+			text:  entry.Summary,
+		}
+		if len(entry.Authors) > 0 {
+			row.author = entry.Authors[0]
+		}
+		if meta, ok := m.metadata[entry.DiffHash]; ok && entry.DiffHash != "" {
+			row.author, row.date = meta.Author, meta.Date
+		}
+		rows[i] = row
+	}
+	m.visible = m.filter.apply(rows, m.sort)
+	m.cursor = 0
+	m.viewport.YOffset = 0
+	m.updateContent()
+}
+
+// cycleType moves the type of the entry under the cursor by step through the
+// model's types, wrapping around. Returning to the entry's own type clears
+// the change.
+func (m *ChangesetReviewModel) cycleType(step int) {
+	item := m.current()
+	if item == nil || len(m.types) == 0 {
+		return
+	}
+
+	idx := slices.Index(m.types, item.Type())
+	if idx < 0 && step < 0 {
+		idx = 0
+	}
+	item.NewType = m.types[(idx+step+len(m.types))%len(m.types)]
+	if item.NewType == item.Entry.Entry.Type {
+		item.NewType = ""
+	}
+	m.updateContent()
+}
+
+// retypedCount returns how many entries not marked for deletion have a new type.
+func (m ChangesetReviewModel) retypedCount() int {
+	count := 0
+	for _, item := range m.items {
+		if item.Retyped() && item.Action != ActionDelete {
+			count++
+		}
+	}
+	return count
+}
+
+// breakingCount returns how many entries not marked for deletion are breaking.
+func (m ChangesetReviewModel) breakingCount() int {
+	count := 0
+	for _, item := range m.items {
+		if item.Entry.Entry.Breaking && item.Action != ActionDelete {
+			count++
+		}
+	}
+	return count
+}
+
 // ensureVisible scrolls the viewport to keep the cursor visible.
 func (m *ChangesetReviewModel) ensureVisible() {
 	lineHeight := 1
@@ -260,8 +432,8 @@ func (m *ChangesetReviewModel) updateContent() {
 
 	var content strings.Builder
 
-	for i, item := range m.items {
-		content.WriteString(m.renderReviewLine(i, item))
+	for i, index := range m.visible {
+		content.WriteString(m.renderReviewLine(i, m.items[index]))
 		content.WriteString("\n")
 	}
 
@@ -285,7 +457,7 @@ func (m ChangesetReviewModel) renderReviewLine(index int, item ReviewItem) strin
 		actionStyle = lipgloss.NewStyle().Foreground(style.SecurityColor)
 	}
 
-	categoryStyle := getCategoryStyle(item.Entry.Entry.Type)
+	categoryStyle := getCategoryStyle(item.Type())
 	lineStyle := lipgloss.NewStyle()
 
 	if index == m.cursor {
@@ -293,7 +465,11 @@ func (m ChangesetReviewModel) renderReviewLine(index int, item ReviewItem) strin
 		actionStyle = actionStyle.Bold(true)
 	}
 
-	typeLabel := fmt.Sprintf("%-8s", item.Entry.Entry.Type)
+	typeLabel := fmt.Sprintf("%-8s", item.Type())
+	if item.Retyped() {
+		// Mark types changed with t; the entry's file is updated on confirm.
+		typeLabel = fmt.Sprintf("%-8s", item.Type()+"*")
+	}
 	scopePart := ""
 	if item.Entry.Entry.Scope != "" {
 		scopePart = fmt.Sprintf("(%s) ", item.Entry.Entry.Scope)
@@ -322,9 +498,13 @@ func (m ChangesetReviewModel) renderReviewHeader() string {
 		Bold(true).
 		Padding(0, 1)
 
-	return headerStyle.Render(
+	header := headerStyle.Render(
 		fmt.Sprintf("Review unreleased changes (%d entries)", len(m.items)),
 	)
+	if status := m.filter.status(len(m.visible), len(m.items), m.sort); status != "" {
+		header += " " + status
+	}
+	return header
 }
 
 // renderReviewFooter creates the footer with help text and action summary.
@@ -349,13 +529,16 @@ func (m ChangesetReviewModel) renderReviewFooter() string {
 		}
 	}
 
-	helpText := "↑/↓: navigate • space: keep • x: delete • e: edit • enter: confirm • q: quit"
-	actionInfo := fmt.Sprintf("keep: %d | delete: %d | edit: %d", keepCount, deleteCount, editCount)
+	helpText := "↑/↓: navigate • space: keep • x: delete • e: edit • t/T: type • b: next breaking • /: filter • s: sort • enter: confirm • q: quit"
+	actionInfo := fmt.Sprintf("keep: %d | delete: %d | edit: %d | retyped: %d | breaking: %d", keepCount, deleteCount, editCount, m.retypedCount(), m.breakingCount())
 
 	totalWidth := m.width
-	helpWidth := lipgloss.Width(helpText)
 	actionWidth := lipgloss.Width(actionInfo)
-	padding := max(totalWidth-helpWidth-actionWidth-2, 0)
+	// The counts matter more than the key hints, so the hints give way when
+	// both don't fit.
+	helpText = ansi.Truncate(helpText, max(totalWidth-actionWidth-3, 0), "…")
+	helpWidth := lipgloss.Width(helpText)
+	padding := max(totalWidth-helpWidth-actionWidth-2, 1)
 
 	return footerStyle.Render(
 		helpText + strings.Repeat(" ", padding) + actionInfo,
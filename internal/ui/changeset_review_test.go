@@ -475,3 +475,177 @@ func TestChangesetReviewModel_ActionCounts(t *testing.T) {
 		t.Errorf("Expected 1 keep action, got %d", keepCount)
 	}
 }
+
+func TestChangesetReviewModel_BreakingEntries(t *testing.T) {
+	breaking := func(filename, summary string) changeset.EntryWithFile {
+		entry := createMockEntry(filename, "changed", "api", summary)
+		entry.Entry.Breaking = true
+		return entry
+	}
+
+	entries := []changeset.EntryWithFile{
+		createMockEntry("test1.md", "added", "cli", "Safe addition"),
+		breaking("test2.md", "Drop legacy endpoint"),
+		createMockEntry("test3.md", "fixed", "", "Safe fix"),
+		breaking("test4.md", "Rename config keys"),
+	}
+
+	t.Run("footer counts pending breaking entries", func(t *testing.T) {
+		model := NewChangesetReviewModel(entries)
+		updated, _ := model.Update(tea.WindowSizeMsg{Width: 140, Height: 30})
+		model = updated.(ChangesetReviewModel)
+
+		if !strings.Contains(model.renderReviewFooter(), "breaking: 2") {
+			t.Errorf("Footer should show 2 breaking entries, got %q", model.renderReviewFooter())
+		}
+
+		updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+		model = updated.(ChangesetReviewModel)
+		updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+		model = updated.(ChangesetReviewModel)
+
+		if !strings.Contains(model.renderReviewFooter(), "breaking: 1") {
+			t.Errorf("Deleted breaking entries should not be counted, got %q", model.renderReviewFooter())
+		}
+	})
+
+	t.Run("jump key lands on breaking entries", func(t *testing.T) {
+		model := NewChangesetReviewModel(entries)
+		tm := teatest.NewTestModel(t, model, teatest.WithInitialTermSize(140, 30))
+
+		teatest.WaitFor(t, tm.Output(), func(out []byte) bool {
+			return strings.Contains(string(out), "breaking: 2")
+		}, teatest.WithDuration(time.Second))
+
+		tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+		tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+		tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+		tm.WaitFinished(t, teatest.WithFinalTimeout(time.Second))
+
+		final := tm.FinalModel(t).(ChangesetReviewModel)
+		if final.cursor != 3 {
+			t.Errorf("Expected cursor on second breaking entry (3), got %d", final.cursor)
+		}
+		if !final.items[final.cursor].Entry.Entry.Breaking {
+			t.Error("Cursor should land on a breaking entry")
+		}
+	})
+
+	t.Run("jump wraps and ignores missing breaking entries", func(t *testing.T) {
+		model := NewChangesetReviewModel(entries)
+		model.cursor = 3
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+		cursor := updated.(ChangesetReviewModel).cursor
+		if cursor != 1 {
+			t.Errorf("Expected jump to wrap to 1, got %d", cursor)
+		}
+
+		plain := NewChangesetReviewModel(entries[:1])
+		updated, _ = plain.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+		if updated.(ChangesetReviewModel).cursor != 0 {
+			t.Error("Cursor should not move when there are no breaking entries")
+		}
+	})
+}
+
+func TestChangesetReviewModel_Retype(t *testing.T) {
+	entries := []changeset.EntryWithFile{
+		createMockEntry("test1.md", "added", "cli", "Actually a fix"),
+		createMockEntry("test2.md", "fixed", "", "Fine as is"),
+	}
+
+	model := NewChangesetReviewModelWithTypes(entries, []string{"added", "changed", "fixed"})
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 140, Height: 30})
+	model = updated.(ChangesetReviewModel)
+
+	press := func(r rune) {
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		model = updated.(ChangesetReviewModel)
+	}
+
+	press('t')
+	press('t')
+	if got := model.items[0].Type(); got != "fixed" {
+		t.Errorf("t twice should move added to fixed, got %q", got)
+	}
+	if !model.items[0].Retyped() {
+		t.Error("Item should be retyped")
+	}
+	if model.items[0].Entry.Entry.Type != "added" {
+		t.Error("The entry itself should keep its type until the review is applied")
+	}
+	if !strings.Contains(model.renderReviewLine(0, model.items[0]), "fixed*") {
+		t.Error("Retyped entries should be marked in their line")
+	}
+	if !strings.Contains(model.renderReviewFooter(), "retyped: 1") {
+		t.Errorf("Footer should count retyped entries, got %q", model.renderReviewFooter())
+	}
+
+	press('T')
+	press('T')
+	if model.items[0].Retyped() || model.items[0].NewType != "" {
+		t.Errorf("Cycling back to the original type should clear the change, got %q", model.items[0].NewType)
+	}
+
+	press('j')
+	press('T')
+	if got := model.items[1].Type(); got != "changed" {
+		t.Errorf("T should move fixed back to changed, got %q", got)
+	}
+	press('x')
+	if !strings.Contains(model.renderReviewFooter(), "retyped: 0") {
+		t.Errorf("Deleted entries should not be counted as retyped, got %q", model.renderReviewFooter())
+	}
+}
+
+func TestChangesetReviewModel_FilterAndSort(t *testing.T) {
+	entries := []changeset.EntryWithFile{
+		createMockEntry("test1.md", "added", "cli", "Add export"),
+		createMockEntry("test2.md", "fixed", "ui", "Fix crash in review"),
+		createMockEntry("test3.md", "fixed", "cli", "Fix flag parsing"),
+	}
+	entries[0].Entry.DiffHash = "aaa"
+	entries[2].Entry.DiffHash = "ccc"
+
+	model := NewChangesetReviewModel(entries)
+	model.SetMetadata(map[string]changeset.Metadata{
+		"aaa": {Author: "Zoe"},
+		"ccc": {Author: "Ann"},
+	})
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 160, Height: 30})
+	model = updated.(ChangesetReviewModel)
+
+	press := func(msgs ...tea.KeyMsg) {
+		for _, msg := range msgs {
+			updated, _ := model.Update(msg)
+			model = updated.(ChangesetReviewModel)
+		}
+	}
+
+	press(
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}},
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("type:fix scope:cli")},
+	)
+	if len(model.visible) != 1 || model.visible[0] != 2 {
+		t.Fatalf("Filter should narrow to the cli fix while typing, got %v", model.visible)
+	}
+	press(tea.KeyMsg{Type: tea.KeyEnter}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if model.items[2].Action != ActionDelete || model.items[0].Action != ActionKeep {
+		t.Error("Actions should apply to the filtered entry under the cursor")
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.IsCancelled() || len(model.visible) != 3 {
+		t.Fatalf("esc should clear the filter, got %v", model.visible)
+	}
+
+	for range sortAuthor {
+		press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	}
+	if len(model.visible) != 3 || model.visible[0] != 2 || model.visible[1] != 0 || model.visible[2] != 1 {
+		t.Errorf("Sorting by author should order Ann, Zoe, then entries without one, got %v", model.visible)
+	}
+	if !strings.Contains(model.renderReviewHeader(), "sort: author") {
+		t.Errorf("Header should show the sort, got %q", model.renderReviewHeader())
+	}
+}
@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/go-git/go-git/v6/plumbing/object"
+	"github.com/stormlightlabs/git-storm/internal/diff"
+	"github.com/stormlightlabs/git-storm/internal/gitlog"
+	"github.com/stormlightlabs/git-storm/internal/style"
+)
+
+// previewContext is the unchanged lines kept around each change in the
+// preview's mini-diff.
+const previewContext = 2
+
+// previewMaxDiffLines caps the mini-diff of one commit so huge commits stay
+// quick to render; the stats still list every file.
+const previewMaxDiffLines = 400
+
+// commitPreview is what the selector's preview pane shows of a commit beyond
+// its message: the files it changed with their edits.
+type commitPreview struct {
+	files []previewFile
+	err   error // Why the changes couldn't be read, shown in place of them
+}
+
+// previewFile is one file changed by a previewed commit.
+type previewFile struct {
+	path   string // including the old path of a renamed file
+	binary bool
+	edits  []diff.Edit
+	stat   diff.Stat
+}
+
+// commitPreviewMsg delivers a preview loaded in the background for items[index].
+type commitPreviewMsg struct {
+	index   int
+	preview commitPreview
+}
+
+// loadCommitPreviewCmd reads the changes of the commit at items[index]
+// without blocking the selector.
+func loadCommitPreviewCmd(index int, commit *object.Commit) tea.Cmd {
+	return func() tea.Msg {
+		return commitPreviewMsg{index: index, preview: loadCommitPreview(commit)}
+	}
+}
+
+// loadCommitPreview reads the files commit changed, pairing renames, and
+// diffs their text contents.
+func loadCommitPreview(commit *object.Commit) commitPreview {
+	changes, err := gitlog.CommitFileChanges(commit)
+	if err != nil {
+		return commitPreview{err: err}
+	}
+
+	var preview commitPreview
+	for _, change := range gitlog.DetectRenames(changes, gitlog.RenameOptions{}) {
+		file := previewFile{path: change.DisplayPath()}
+		if diff.IsBinary(change.OldContent) || diff.IsBinary(change.NewContent) {
+			file.binary = true
+		} else {
+			edits, err := computeFileEdits(change.OldContent, change.NewContent, diff.Whitespace{})
+			if err != nil {
+				return commitPreview{err: fmt.Errorf("diff computation failed for %s: %w", change.Path, err)}
+			}
+			file.edits = edits
+			file.stat = diff.DiffStat(edits)
+		}
+		preview.files = append(preview.files, file)
+	}
+	return preview
+}
+
+// render lays out item's full message, a diffstat, and a mini-diff of the
+// changed hunks, fitted to width.
+func (p commitPreview) render(item CommitItem, width int) string {
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("#6C7A89"))
+	commit := item.Commit
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Foreground(style.AccentBlue).Bold(true).Render("commit " + commit.Hash.String()))
+	b.WriteString("\n")
+	b.WriteString(dim.Render(fmt.Sprintf("%s <%s> • %s", commit.Author.Name, commit.Author.Email, commit.Author.When.Format("2006-01-02 15:04"))))
+	b.WriteString("\n\n")
+	for line := range strings.SplitSeq(strings.TrimRight(commit.Message, "\n"), "\n") {
+		b.WriteString("    " + line + "\n")
+	}
+	b.WriteString("\n")
+
+	if p.err != nil {
+		b.WriteString(style.StyleRemoved.Render(fmt.Sprintf("Can't show changes: %v", p.err)))
+		return b.String()
+	}
+	if len(p.files) == 0 {
+		b.WriteString(dim.Render("No files changed"))
+		return b.String()
+	}
+
+	b.WriteString(p.stat())
+	b.WriteString("\n")
+
+	formatter := &diff.UnifiedFormatter{TerminalWidth: width, ShowLineNumbers: true, Expanded: true}
+	lines := 0
+	for _, file := range p.files {
+		if lines >= previewMaxDiffLines {
+			b.WriteString(dim.Render("… more changes not shown"))
+			b.WriteString("\n")
+			break
+		}
+
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render(file.path))
+		b.WriteString("\n")
+		if file.binary {
+			b.WriteString(dim.Render("binary file"))
+			b.WriteString("\n\n")
+			continue
+		}
+
+		hunks := formatter.Format(diff.HunksOnly(file.edits, previewContext))
+		b.WriteString(hunks)
+		b.WriteString("\n\n")
+		lines += strings.Count(hunks, "\n") + 1
+	}
+	return b.String()
+}
+
+// stat renders a diffstat of the files: a row per file and a summary line.
+func (p commitPreview) stat() string {
+	pathWidth := 0
+	for _, file := range p.files {
+		pathWidth = max(pathWidth, lipgloss.Width(file.path))
+	}
+
+	var b strings.Builder
+	var added, removed int
+	for _, file := range p.files {
+		added += file.stat.Added
+		removed += file.stat.Removed
+		if file.binary {
+			fmt.Fprintf(&b, " %-*s | Bin\n", pathWidth, file.path)
+			continue
+		}
+		fmt.Fprintf(&b, " %-*s | %4d %s%s\n", pathWidth, file.path, file.stat.Added+file.stat.Removed,
+			style.StyleAdded.Render(strings.Repeat("+", min(file.stat.Added, 30))),
+			style.StyleRemoved.Render(strings.Repeat("-", min(file.stat.Removed, 30))))
+	}
+	noun := "files"
+	if len(p.files) == 1 {
+		noun = "file"
+	}
+	fmt.Fprintf(&b, " %d %s changed, %d insertions(+), %d deletions(-)\n", len(p.files), noun, added, removed)
+	return b.String()
+}
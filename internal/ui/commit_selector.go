@@ -3,7 +3,6 @@ package ui
 import (
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
@@ -11,6 +10,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/go-git/go-git/v6/plumbing/object"
 	"github.com/stormlightlabs/git-storm/internal/gitlog"
+	"github.com/stormlightlabs/git-storm/internal/shared"
 	"github.com/stormlightlabs/git-storm/internal/style"
 )
 
@@ -23,10 +23,24 @@ type CommitItem struct {
 }
 
 // CommitSelectorModel holds the state for the interactive commit selector TUI.
+//
+// The list can be narrowed with a / filter (see [listFilter]) and sorted with
+// s; the cursor moves over the commits shown, and selecting or deselecting
+// all applies to them only. Tab splits the screen to preview the highlighted
+// commit's message, diffstat, and changed hunks below the list.
 type CommitSelectorModel struct {
 	viewport  viewport.Model
 	items     []CommitItem
-	cursor    int
+	filter    listFilter
+	sort      listSort
+	visible   []int                  // indices into items of the commits shown, in order
+	paths     map[int][]string       // changed paths of items, loaded by path: filters
+	cursor    int                    // index into visible
+	preview   bool                   // whether the preview pane is shown
+	pane      viewport.Model         // the preview pane
+	paneIndex int                    // item the pane shows, or -1
+	paneWidth int                    // width the pane was rendered at
+	previews  map[int]*commitPreview // previews of items, nil while loading
 	ready     bool
 	fromRef   string
 	toRef     string
@@ -49,6 +63,9 @@ type commitSelectorKeyMap struct {
 	DeselectAll key.Binding
 	Confirm     key.Binding
 	Quit        key.Binding
+	Preview     key.Binding
+	PreviewDown key.Binding
+	PreviewUp   key.Binding
 }
 
 var commitKeys = commitSelectorKeyMap{
@@ -96,6 +113,18 @@ var commitKeys = commitSelectorKeyMap{
 		key.WithKeys("q", "esc", "ctrl+c"),
 		key.WithHelp("q", "quit"),
 	),
+	Preview: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "preview"),
+	),
+	PreviewDown: key.NewBinding(
+		key.WithKeys("ctrl+d"),
+		key.WithHelp("ctrl+d", "scroll preview down"),
+	),
+	PreviewUp: key.NewBinding(
+		key.WithKeys("ctrl+u"),
+		key.WithHelp("ctrl+u", "scroll preview up"),
+	),
 }
 
 // NewCommitSelectorModel creates a new commit selector model.
@@ -132,13 +161,19 @@ func NewCommitSelectorModel(commits []*object.Commit, fromRef, toRef string, par
 		})
 	}
 
-	return CommitSelectorModel{
-		items:   items,
-		cursor:  0,
-		fromRef: fromRef,
-		toRef:   toRef,
-		ready:   false,
+	m := CommitSelectorModel{
+		items:     items,
+		filter:    newListFilter(),
+		paths:     make(map[int][]string),
+		previews:  make(map[int]*commitPreview),
+		paneIndex: -1,
+		cursor:    0,
+		fromRef:   fromRef,
+		toRef:     toRef,
+		ready:     false,
 	}
+	m.refilter()
+	return m
 }
 
 // Init initializes the model (required by Bubble Tea).
@@ -152,11 +187,40 @@ func (m CommitSelectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.filter.typing {
+			cmd = m.filter.update(msg)
+			m.refilter()
+			return m, tea.Batch(cmd, m.showPreview())
+		}
+
 		switch {
+		case msg.Type == tea.KeyEsc && m.filter.active():
+			m.filter.query = ""
+			m.refilter()
+
 		case key.Matches(msg, commitKeys.Quit):
 			m.cancelled = true
 			return m, tea.Quit
 
+		case key.Matches(msg, commitKeys.Preview):
+			m.preview = !m.preview
+			m.resize()
+
+		case m.preview && key.Matches(msg, commitKeys.PreviewDown):
+			m.pane.HalfPageDown()
+			return m, nil
+
+		case m.preview && key.Matches(msg, commitKeys.PreviewUp):
+			m.pane.HalfPageUp()
+			return m, nil
+
+		case key.Matches(msg, listKeys.Filter):
+			return m, m.filter.start()
+
+		case key.Matches(msg, listKeys.Sort):
+			m.sort = (m.sort + 1) % sortCount
+			m.refilter()
+
 		case key.Matches(msg, commitKeys.Confirm):
 			m.confirmed = true
 			return m, tea.Quit
@@ -168,7 +232,7 @@ func (m CommitSelectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case key.Matches(msg, commitKeys.Down):
-			if m.cursor < len(m.items)-1 {
+			if m.cursor < len(m.visible)-1 {
 				m.cursor++
 				m.ensureVisible()
 			}
@@ -182,8 +246,8 @@ func (m CommitSelectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case key.Matches(msg, commitKeys.PageDown):
 			m.cursor += m.viewport.Height
-			if m.cursor >= len(m.items) {
-				m.cursor = len(m.items) - 1
+			if m.cursor >= len(m.visible) {
+				m.cursor = max(len(m.visible)-1, 0)
 			}
 			m.ensureVisible()
 
@@ -192,45 +256,92 @@ func (m CommitSelectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.ensureVisible()
 
 		case key.Matches(msg, commitKeys.Bottom):
-			m.cursor = len(m.items) - 1
+			m.cursor = max(len(m.visible)-1, 0)
 			m.ensureVisible()
 
 		case key.Matches(msg, commitKeys.Toggle):
-			if m.cursor >= 0 && m.cursor < len(m.items) {
-				m.items[m.cursor].Selected = !m.items[m.cursor].Selected
+			if m.cursor >= 0 && m.cursor < len(m.visible) {
+				item := &m.items[m.visible[m.cursor]]
+				item.Selected = !item.Selected
 				m.updateContent()
 			}
 
 		case key.Matches(msg, commitKeys.SelectAll):
-			for i := range m.items {
+			for _, i := range m.visible {
 				m.items[i].Selected = true
 			}
 			m.updateContent()
 
 		case key.Matches(msg, commitKeys.DeselectAll):
-			for i := range m.items {
+			for _, i := range m.visible {
 				m.items[i].Selected = false
 			}
 			m.updateContent()
 		}
 
+	case commitPreviewMsg:
+		m.previews[msg.index] = &msg.preview
+		return m, m.showPreview()
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 
 		if !m.ready {
 			m.viewport = viewport.New(msg.Width, msg.Height-4)
+			m.pane = viewport.New(msg.Width, 0)
 			m.ready = true
-			m.updateContent()
-		} else {
-			m.viewport.Width = msg.Width
-			m.viewport.Height = msg.Height - 4
-			m.updateContent()
 		}
+		m.resize()
 	}
 
 	m.viewport, cmd = m.viewport.Update(msg)
-	return m, cmd
+	return m, tea.Batch(cmd, m.showPreview())
+}
+
+// resize splits the height between the list and, when shown, the preview
+// pane below it.
+func (m *CommitSelectorModel) resize() {
+	if !m.ready {
+		return
+	}
+
+	height := max(m.height-4, 1)
+	m.viewport.Width, m.viewport.Height = m.width, height
+	if m.preview {
+		m.viewport.Height = max(height/2, 1)
+		m.pane.Width, m.pane.Height = m.width, max(height-m.viewport.Height-1, 1)
+	}
+	m.ensureVisible()
+}
+
+// showPreview fills the preview pane with the highlighted commit, loading its
+// changes in the background the first time it's shown.
+func (m *CommitSelectorModel) showPreview() tea.Cmd {
+	if !m.preview || !m.ready || m.cursor >= len(m.visible) {
+		m.pane.SetContent("")
+		m.paneIndex = -1
+		return nil
+	}
+
+	index := m.visible[m.cursor]
+	preview, loaded := m.previews[index]
+	if !loaded {
+		m.previews[index] = nil
+		m.pane.SetContent("Loading...")
+		m.paneIndex = -1
+		return loadCommitPreviewCmd(index, m.items[index].Commit)
+	}
+	if preview == nil || (m.paneIndex == index && m.paneWidth == m.width) {
+		return nil
+	}
+
+	if m.paneIndex != index {
+		m.pane.GotoTop()
+	}
+	m.pane.SetContent(preview.render(m.items[index], m.width))
+	m.paneIndex, m.paneWidth = index, m.width
+	return nil
 }
 
 // View renders the current view of the commit selector.
@@ -242,6 +353,10 @@ func (m CommitSelectorModel) View() string {
 	header := m.renderCommitHeader()
 	footer := m.renderCommitFooter()
 
+	if m.preview {
+		divider := lipgloss.NewStyle().Foreground(lipgloss.Color("#6C7A89")).Render(strings.Repeat("─", max(m.width, 1)))
+		return fmt.Sprintf("%s\n%s\n%s\n%s\n%s", header, m.viewport.View(), divider, m.pane.View(), footer)
+	}
 	return fmt.Sprintf("%s\n%s\n%s", header, m.viewport.View(), footer)
 }
 
@@ -277,6 +392,41 @@ func (m CommitSelectorModel) IsConfirmed() bool {
 	return m.confirmed
 }
 
+// refilter recomputes the commits shown after the filter or sort changed,
+// moving the cursor back to the top.
+func (m *CommitSelectorModel) refilter() {
+	rows := make([]listRow, len(m.items))
+	for i, item := range m.items {
+		category := item.Category
+		if category == "" {
+			category = "skip"
+		}
+		rows[i] = listRow{
+			types:  []string{category, item.Meta.Type},
+			scope:  item.Meta.Scope,
+			author: item.Commit.Author.Name,
+			text:   item.Meta.Description,
+			date:   item.Commit.Author.When,
+			paths:  func() []string { return m.commitPaths(i) },
+		}
+	}
+	m.visible = m.filter.apply(rows, m.sort)
+	m.cursor = 0
+	m.viewport.YOffset = 0
+	m.updateContent()
+}
+
+// commitPaths returns the paths changed by items[i], caching them. Commits
+// whose changes can't be read have none.
+func (m *CommitSelectorModel) commitPaths(i int) []string {
+	if paths, ok := m.paths[i]; ok {
+		return paths
+	}
+	paths, _ := gitlog.CommitPaths(m.items[i].Commit)
+	m.paths[i] = paths
+	return paths
+}
+
 // ensureVisible scrolls the viewport to keep the cursor visible.
 func (m *CommitSelectorModel) ensureVisible() {
 	lineHeight := 1
@@ -299,8 +449,8 @@ func (m *CommitSelectorModel) updateContent() {
 
 	var content strings.Builder
 
-	for i, item := range m.items {
-		content.WriteString(m.renderCommitLine(i, item))
+	for i, index := range m.visible {
+		content.WriteString(m.renderCommitLine(i, m.items[index]))
 		content.WriteString("\n")
 	}
 
@@ -330,7 +480,7 @@ func (m CommitSelectorModel) renderCommitLine(index int, item CommitItem) string
 		author = author[:12] + "..."
 	}
 
-	timeAgo := fmtTimeAgo(item.Commit.Author.When)
+	timeAgo := shared.TimeAgo(item.Commit.Author.When)
 
 	category := item.Category
 	if category == "" {
@@ -366,9 +516,13 @@ func (m CommitSelectorModel) renderCommitHeader() string {
 		Bold(true).
 		Padding(0, 1)
 
-	return headerStyle.Render(
+	header := headerStyle.Render(
 		fmt.Sprintf("Select commits to include (%s..%s)", m.fromRef, m.toRef),
 	)
+	if status := m.filter.status(len(m.visible), len(m.items), m.sort); status != "" {
+		header += " " + status
+	}
+	return header
 }
 
 // renderCommitFooter creates the footer with help text and selection count.
@@ -385,7 +539,10 @@ func (m CommitSelectorModel) renderCommitFooter() string {
 		}
 	}
 
-	helpText := "↑/↓: navigate • space: toggle • a/A: select/deselect all • enter: confirm • q: quit"
+	helpText := "↑/↓: navigate • space: toggle • a/A: select/deselect all • /: filter • s: sort • tab: preview • enter: confirm • q: quit"
+	if m.preview {
+		helpText = "↑/↓: navigate • space: toggle • ctrl+d/ctrl+u: scroll preview • tab: hide preview • enter: confirm • q: quit"
+	}
 	selectionInfo := fmt.Sprintf("%d/%d selected", selectedCount, len(m.items))
 
 	totalWidth := m.width
@@ -398,30 +555,6 @@ func (m CommitSelectorModel) renderCommitFooter() string {
 	)
 }
 
-// fmtTimeAgo returns a human-readable relative time string.
-func fmtTimeAgo(t time.Time) string {
-	duration := time.Since(t)
-
-	if duration < time.Minute {
-		return "just now"
-	} else if duration < time.Hour {
-		minutes := int(duration.Minutes())
-		return fmt.Sprintf("%dm ago", minutes)
-	} else if duration < 24*time.Hour {
-		hours := int(duration.Hours())
-		return fmt.Sprintf("%dh ago", hours)
-	} else if duration < 30*24*time.Hour {
-		days := int(duration.Hours() / 24)
-		return fmt.Sprintf("%dd ago", days)
-	} else if duration < 365*24*time.Hour {
-		months := int(duration.Hours() / 24 / 30)
-		return fmt.Sprintf("%dmo ago", months)
-	} else {
-		years := int(duration.Hours() / 24 / 365)
-		return fmt.Sprintf("%dy ago", years)
-	}
-}
-
 func getCategoryStyle(c string) lipgloss.Style {
 	s := lipgloss.NewStyle().Foreground(lipgloss.Color("#6C7A89"))
 	switch c {
@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/x/exp/teatest"
 	"github.com/go-git/go-git/v6/plumbing"
 	"github.com/go-git/go-git/v6/plumbing/object"
+	"github.com/stormlightlabs/git-storm/internal/diff"
 	"github.com/stormlightlabs/git-storm/internal/gitlog"
 )
 
@@ -407,32 +408,6 @@ func TestCommitSelectorModel_RenderCommitLine(t *testing.T) {
 	}
 }
 
-func TestFormatTimeAgo(t *testing.T) {
-	now := time.Now()
-
-	tests := []struct {
-		name     string
-		time     time.Time
-		expected string
-	}{
-		{"just now", now, "just now"},
-		{"minutes ago", now.Add(-5 * time.Minute), "5m ago"},
-		{"hours ago", now.Add(-2 * time.Hour), "2h ago"},
-		{"days ago", now.Add(-3 * 24 * time.Hour), "3d ago"},
-		{"months ago", now.Add(-45 * 24 * time.Hour), "1mo ago"},
-		{"years ago", now.Add(-400 * 24 * time.Hour), "1y ago"},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			result := fmtTimeAgo(tc.time)
-			if result != tc.expected {
-				t.Errorf("Expected %q, got %q", tc.expected, result)
-			}
-		})
-	}
-}
-
 func TestCommitSelectorModel_EmptyCommits(t *testing.T) {
 	commits := []*object.Commit{}
 
@@ -475,3 +450,93 @@ func TestCommitSelectorModel_WindowResize(t *testing.T) {
 		t.Errorf("Expected dimensions 120x40, got %dx%d", model.width, model.height)
 	}
 }
+
+func TestCommitSelectorModel_FilterAndSort(t *testing.T) {
+	now := time.Now()
+	commits := []*object.Commit{
+		createMockCommit("a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2", "feat: alpha 1", now.Add(-2*time.Hour)),
+		createMockCommit("b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3", "feat: other 2", now),
+		createMockCommit("c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4", "feat: alpha 3", now.Add(-time.Hour)),
+	}
+
+	model := NewCommitSelectorModel(commits, "v1.0.0", "HEAD", &mockParser{})
+	updatedModel, _ := model.Update(tea.WindowSizeMsg{Width: 140, Height: 30})
+	model = updatedModel.(CommitSelectorModel)
+
+	for _, msg := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune{'/'}},
+		{Type: tea.KeyRunes, Runes: []rune("alpha")},
+		{Type: tea.KeyEnter},
+		{Type: tea.KeyRunes, Runes: []rune{'A'}},
+		{Type: tea.KeyRunes, Runes: []rune{'s'}},
+	} {
+		updatedModel, _ = model.Update(msg)
+		model = updatedModel.(CommitSelectorModel)
+	}
+
+	if model.IsConfirmed() {
+		t.Fatal("enter should close the filter prompt, not confirm")
+	}
+	if !strings.Contains(model.renderCommitHeader(), `filter "alpha" (2/3)`) {
+		t.Errorf("Header should show the filter, got %q", model.renderCommitHeader())
+	}
+	if model.items[0].Selected || model.items[2].Selected || !model.items[1].Selected {
+		t.Error("Deselect all should only apply to the filtered commits")
+	}
+	if len(model.visible) != 2 || model.visible[0] != 2 || model.visible[1] != 0 {
+		t.Errorf("Sorting by date should show the newest match first, got %v", model.visible)
+	}
+
+	updatedModel, _ = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	model = updatedModel.(CommitSelectorModel)
+	if model.IsCancelled() {
+		t.Fatal("esc should clear the filter before quitting")
+	}
+	if len(model.visible) != 3 || model.visible[0] != 1 {
+		t.Errorf("Clearing the filter should show every commit, newest first, got %v", model.visible)
+	}
+}
+
+func TestCommitSelectorModel_Preview(t *testing.T) {
+	now := time.Now()
+	commits := []*object.Commit{
+		createMockCommit("a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2", "feat: add feature\n\nExplains the feature.", now),
+	}
+
+	model := NewCommitSelectorModel(commits, "v1.0.0", "HEAD", &mockParser{})
+	updatedModel, _ := model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	model = updatedModel.(CommitSelectorModel)
+
+	updatedModel, cmd := model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	model = updatedModel.(CommitSelectorModel)
+	if !model.preview {
+		t.Fatal("tab should open the preview pane")
+	}
+	if cmd == nil {
+		t.Error("Opening the preview should load the highlighted commit")
+	}
+	if !strings.Contains(model.View(), "Loading") {
+		t.Error("Preview should show it's loading until the changes arrive")
+	}
+
+	edits := []diff.Edit{
+		{Kind: diff.Equal, AIndex: 0, BIndex: 0, Content: "package main"},
+		{Kind: diff.Insert, AIndex: -1, BIndex: 1, Content: "// feature"},
+	}
+	preview := commitPreview{files: []previewFile{{path: "main.go", edits: edits, stat: diff.DiffStat(edits)}}}
+	updatedModel, _ = model.Update(commitPreviewMsg{index: 0, preview: preview})
+	model = updatedModel.(CommitSelectorModel)
+
+	view := model.View()
+	for _, want := range []string{"commit a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2", "Explains the feature.", "1 file changed, 1 insertions(+), 0 deletions(-)", "// feature"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Preview should contain %q", want)
+		}
+	}
+
+	updatedModel, _ = model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	model = updatedModel.(CommitSelectorModel)
+	if model.preview || strings.Contains(model.View(), "Explains the feature.") {
+		t.Error("tab should close the preview pane")
+	}
+}
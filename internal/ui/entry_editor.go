@@ -2,31 +2,64 @@ package ui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"unicode"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/stormlightlabs/git-storm/internal/changeset"
 	"github.com/stormlightlabs/git-storm/internal/style"
+	"github.com/stormlightlabs/git-storm/internal/tty"
 )
 
-// EntryEditorModel holds the state for the inline entry editor TUI.
+// Fields of the entry editor form, in focus order.
+const (
+	fieldType = iota
+	fieldScope
+	fieldSummary
+	fieldBreaking
+	fieldBody
+	fieldCount
+)
+
+// summaryCharLimit caps the summary field.
+const summaryCharLimit = 200
+
+// EntryEditorModel holds the state for the inline entry editor TUI: a form
+// with a type selector, a scope input suggesting known scopes, summary and
+// body text areas, and a breaking toggle. Saving is refused while any field
+// is invalid, with the errors shown under their fields.
+//
+// When the entry's file is known (see [EntryEditorModel.SetFile]), ctrl+o
+// suspends the form to edit the file in $EDITOR instead.
 type EntryEditorModel struct {
 	entry     changeset.Entry
 	filename  string
-	inputs    []textinput.Model
-	focusIdx  int
-	typeIdx   int // index in validTypes array
+	path      string              // the entry's file, if it can be edited in $EDITOR
+	registry  *changeset.Registry // types the file is validated against
+	external  bool                // the file was opened in $EDITOR
+	problems  []string            // problems found in the file after $EDITOR exited
+	scope     textinput.Model
+	summary   textarea.Model
+	body      textarea.Model
+	breaking  bool
+	focusIdx  int // one of the field* constants
+	types     []string
+	typeIdx   int // index in types
+	known     int // types[:known] are the configured types
+	errors    map[int]string
 	confirmed bool
 	cancelled bool
 	width     int
 	height    int
 }
 
-// validTypes defines the allowed changelog entry types.
-var validTypes = []string{"added", "changed", "fixed", "removed", "security"}
+// validTypes are the entry types the editor cycles through by default.
+var validTypes = changeset.DefaultRegistry().Names()
 
 // editorKeyMap defines keyboard shortcuts for the entry editor.
 type editorKeyMap struct {
@@ -35,6 +68,10 @@ type editorKeyMap struct {
 	Confirm   key.Binding
 	Quit      key.Binding
 	CycleType key.Binding
+	TypeLeft  key.Binding
+	TypeRight key.Binding
+	Toggle    key.Binding
+	External  key.Binding
 }
 
 var editorKeys = editorKeyMap{
@@ -58,39 +95,104 @@ var editorKeys = editorKeyMap{
 		key.WithKeys("ctrl+t"),
 		key.WithHelp("ctrl+t", "cycle type"),
 	),
+	TypeLeft: key.NewBinding(
+		key.WithKeys("left", "h"),
+		key.WithHelp("←", "previous type"),
+	),
+	TypeRight: key.NewBinding(
+		key.WithKeys("right", "l"),
+		key.WithHelp("→", "next type"),
+	),
+	Toggle: key.NewBinding(
+		key.WithKeys(" ", "x"),
+		key.WithHelp("space", "toggle"),
+	),
+	External: key.NewBinding(
+		key.WithKeys("ctrl+o"),
+		key.WithHelp("ctrl+o", "open in $EDITOR"),
+	),
+}
+
+// externalEditMsg reports that the $EDITOR opened with ctrl+o has exited.
+type externalEditMsg struct {
+	err error
 }
 
 // NewEntryEditorModel creates a new editor initialized with the given entry.
 func NewEntryEditorModel(entry changeset.EntryWithFile) EntryEditorModel {
+	return NewEntryEditorModelWithTypes(entry, validTypes)
+}
+
+// NewEntryEditorModelWithTypes creates an editor whose type field cycles
+// through types, e.g. a project's change type registry. An entry type missing
+// from types is kept as an extra choice so saving doesn't silently change it,
+// but it must be replaced before the entry can be saved.
+func NewEntryEditorModelWithTypes(entry changeset.EntryWithFile, types []string) EntryEditorModel {
 	m := EntryEditorModel{
-		entry:    entry.Entry,
 		filename: entry.Filename,
-		inputs:   make([]textinput.Model, 2),
+		types:    types,
+		known:    len(types),
 	}
 
-	for i, t := range validTypes {
-		if t == entry.Entry.Type {
-			m.typeIdx = i
-			break
-		}
-	}
+	m.scope = textinput.New()
+	m.scope.Placeholder = "optional scope (e.g., cli, api)"
+	m.scope.CharLimit = 50
+	m.scope.Width = 50
+	m.scope.ShowSuggestions = true
+	// Tab moves between fields, so suggestions are accepted with → instead.
+	m.scope.KeyMap.AcceptSuggestion = key.NewBinding(key.WithKeys("right"))
 
-	m.inputs[0] = textinput.New()
-	m.inputs[0].Placeholder = "optional scope (e.g., cli, api)"
-	m.inputs[0].SetValue(entry.Entry.Scope)
-	m.inputs[0].CharLimit = 50
-	m.inputs[0].Width = 50
+	m.summary = textarea.New()
+	m.summary.Placeholder = "brief description of the change"
+	m.summary.ShowLineNumbers = false
+	m.summary.CharLimit = summaryCharLimit
+	m.summary.KeyMap.InsertNewline.SetEnabled(false)
+	m.summary.SetWidth(80)
+	m.summary.SetHeight(2)
 
-	m.inputs[1] = textinput.New()
-	m.inputs[1].Placeholder = "brief description of the change"
-	m.inputs[1].SetValue(entry.Entry.Summary)
-	m.inputs[1].CharLimit = 200
-	m.inputs[1].Width = 80
+	m.body = textarea.New()
+	m.body.Placeholder = "optional details, rendered below the entry"
+	m.body.ShowLineNumbers = false
+	m.body.SetWidth(80)
+	m.body.SetHeight(6)
 
-	m.inputs[0].Focus()
+	m.setEntry(entry.Entry)
 	return m
 }
 
+// setEntry fills the form with entry's values.
+func (m *EntryEditorModel) setEntry(entry changeset.Entry) {
+	m.entry = entry
+	m.breaking = entry.Breaking
+	m.types = m.types[:m.known]
+	m.typeIdx = slices.Index(m.types, entry.Type)
+	if m.typeIdx < 0 {
+		m.typeIdx = 0
+		if entry.Type != "" {
+			m.types = append(slices.Clone(m.types), entry.Type)
+			m.typeIdx = len(m.types) - 1
+		}
+	}
+	m.scope.SetValue(entry.Scope)
+	m.summary.SetValue(entry.Summary)
+	m.body.SetValue(entry.Body)
+}
+
+// SetFile enables ctrl+o, which suspends the form and opens the entry's file
+// at path in $EDITOR. When the editor exits the file is validated against
+// types and the form refilled from it; if the file has problems they are
+// listed instead and the form is left as it was, so saving rewrites the file.
+func (m *EntryEditorModel) SetFile(path string, types *changeset.Registry) {
+	m.path = path
+	m.registry = types
+}
+
+// SetScopeSuggestions sets the scopes offered while typing in the scope
+// field, e.g. those used in the git history, most common first.
+func (m *EntryEditorModel) SetScopeSuggestions(scopes []string) {
+	m.scope.SetSuggestions(scopes)
+}
+
 // Init implements tea.Model.
 func (m EntryEditorModel) Init() tea.Cmd {
 	return textinput.Blink
@@ -105,26 +207,44 @@ func (m EntryEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.cancelled = true
 			return m, tea.Quit
 		case key.Matches(msg, editorKeys.Confirm):
-			m.confirmed = true
-			return m, tea.Quit
+			return m.confirm()
+		case m.path != "" && key.Matches(msg, editorKeys.External):
+			return m, tea.ExecProcess(tty.EditorCommand(m.path), func(err error) tea.Msg {
+				return externalEditMsg{err: err}
+			})
 		case key.Matches(msg, editorKeys.CycleType):
-			m.typeIdx = (m.typeIdx + 1) % len(validTypes)
+			m.cycleType(1)
 			return m, nil
 		case key.Matches(msg, editorKeys.Next):
-			m.nextField()
-			return m, nil
+			return m, m.focusField((m.focusIdx + 1) % fieldCount)
 		case key.Matches(msg, editorKeys.Prev):
-			m.prevField()
+			return m, m.focusField((m.focusIdx + fieldCount - 1) % fieldCount)
+		case msg.String() == "enter" && m.focusIdx != fieldBody:
+			return m.confirm()
+		case m.focusIdx == fieldType && key.Matches(msg, editorKeys.TypeLeft):
+			m.cycleType(-1)
+			return m, nil
+		case m.focusIdx == fieldType && key.Matches(msg, editorKeys.TypeRight):
+			m.cycleType(1)
+			return m, nil
+		case m.focusIdx == fieldBreaking && key.Matches(msg, editorKeys.Toggle):
+			m.breaking = !m.breaking
 			return m, nil
-		case msg.String() == "enter":
-			m.confirmed = true
-			return m, tea.Quit
 		}
+	case externalEditMsg:
+		m.external = true
+		m.reloadFile(msg.err)
+		return m, nil
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		width := min(80, max(msg.Width-4, 20))
+		m.scope.Width = min(50, width)
+		m.summary.SetWidth(width)
+		m.body.SetWidth(width)
 	}
 	cmd := m.updateInputs(msg)
+	m.refreshErrors()
 	return m, cmd
 }
 
@@ -143,39 +263,70 @@ func (m EntryEditorModel) View() string {
 	b.WriteString(title)
 	b.WriteString("\n\n")
 
-	typeLabel := lipgloss.NewStyle().Foreground(lipgloss.Color("#6C7A89")).Render("Type:")
-	typeValue := getCategoryStyle(validTypes[m.typeIdx]).Render(validTypes[m.typeIdx])
-	b.WriteString(fmt.Sprintf("%s %s (ctrl+t to cycle)\n", typeLabel, typeValue))
+	if len(m.problems) > 0 {
+		b.WriteString(style.StyleRemoved.Render("✗ The file has problems; fix them with ctrl+o, or save to rewrite it from the form:"))
+		b.WriteString("\n")
+		for _, p := range m.problems {
+			b.WriteString(style.StyleRemoved.Render("  - "+p) + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	typeValue := getCategoryStyle(m.types[m.typeIdx]).Render(m.types[m.typeIdx])
+	b.WriteString(fmt.Sprintf("%s ‹ %s › (←/→ or ctrl+t to change)\n", m.label(fieldType, "Type:"), typeValue))
+	b.WriteString(m.fieldError(fieldType))
 
-	scopeLabel := lipgloss.NewStyle().Foreground(lipgloss.Color("#6C7A89")).Render("Scope:")
-	b.WriteString(fmt.Sprintf("\n%s\n%s\n", scopeLabel, m.inputs[0].View()))
+	b.WriteString(fmt.Sprintf("\n%s\n%s\n", m.label(fieldScope, "Scope:"), m.scope.View()))
+	b.WriteString(m.fieldError(fieldScope))
 
-	summaryLabel := lipgloss.NewStyle().Foreground(lipgloss.Color("#6C7A89")).Render("Summary:")
-	b.WriteString(fmt.Sprintf("\n%s\n%s\n", summaryLabel, m.inputs[1].View()))
+	b.WriteString(fmt.Sprintf("\n%s\n%s\n", m.label(fieldSummary, "Summary:"), m.summary.View()))
+	b.WriteString(m.fieldError(fieldSummary))
 
-	breakingLabel := lipgloss.NewStyle().Foreground(lipgloss.Color("#6C7A89")).Render("Breaking:")
-	breakingValue := "no"
-	if m.entry.Breaking {
-		breakingValue = style.StyleRemoved.Render("yes")
+	breakingValue := "[ ] no"
+	if m.breaking {
+		breakingValue = style.StyleRemoved.Render("[x] yes")
 	}
-	b.WriteString(fmt.Sprintf("\n%s %s\n", breakingLabel, breakingValue))
+	b.WriteString(fmt.Sprintf("\n%s %s (space to toggle)\n", m.label(fieldBreaking, "Breaking:"), breakingValue))
+
+	b.WriteString(fmt.Sprintf("\n%s\n%s\n", m.label(fieldBody, "Body:"), m.body.View()))
 
 	b.WriteString("\n")
 	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6C7A89"))
-	b.WriteString(helpStyle.Render("tab: next • shift+tab: prev • ctrl+t: cycle type • enter/ctrl+s: save • esc: cancel"))
+	help := "tab: next • shift+tab: prev • →: accept scope • enter/ctrl+s: save (enter adds a line in body) • esc: cancel"
+	if m.path != "" {
+		help += " • ctrl+o: open in $EDITOR"
+	}
+	b.WriteString(helpStyle.Render(help))
 	return b.String()
 }
 
+// label renders a field label, highlighted while the field has focus.
+func (m EntryEditorModel) label(field int, text string) string {
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6C7A89"))
+	if m.focusIdx == field {
+		labelStyle = lipgloss.NewStyle().Bold(true).Foreground(style.AccentBlue)
+	}
+	return labelStyle.Render(text)
+}
+
+// fieldError renders the validation error of field, if any, as its own line.
+func (m EntryEditorModel) fieldError(field int) string {
+	msg, ok := m.errors[field]
+	if !ok {
+		return ""
+	}
+	return style.StyleRemoved.Render("✗ "+msg) + "\n"
+}
+
 // GetEditedEntry returns the entry with updated values.
 func (m EntryEditorModel) GetEditedEntry() changeset.Entry {
-	return changeset.Entry{
-		Type:       validTypes[m.typeIdx],
-		Scope:      strings.TrimSpace(m.inputs[0].Value()),
-		Summary:    strings.TrimSpace(m.inputs[1].Value()),
-		Breaking:   m.entry.Breaking,
-		CommitHash: m.entry.CommitHash,
-		DiffHash:   m.entry.DiffHash,
-	}
+	entry := m.entry
+	entry.Type = m.types[m.typeIdx]
+	entry.Scope = strings.TrimSpace(m.scope.Value())
+	entry.Summary = strings.TrimSpace(m.summary.Value())
+	entry.Breaking = m.breaking
+	entry.Body = strings.TrimSpace(m.body.Value())
+	return entry
 }
 
 // IsConfirmed returns true if the user confirmed the edit.
@@ -188,26 +339,124 @@ func (m EntryEditorModel) IsCancelled() bool {
 	return m.cancelled
 }
 
-// nextField moves focus to the next input field.
-func (m *EntryEditorModel) nextField() {
-	m.inputs[m.focusIdx].Blur()
-	m.focusIdx = (m.focusIdx + 1) % len(m.inputs)
-	m.inputs[m.focusIdx].Focus()
+// EditedExternally returns true if the entry's file was opened in $EDITOR,
+// whose changes are kept on disk even when the form is cancelled.
+func (m EntryEditorModel) EditedExternally() bool {
+	return m.external
+}
+
+// FileProblems returns the problems found in the entry's file the last time
+// $EDITOR exited, if any.
+func (m EntryEditorModel) FileProblems() []string {
+	return m.problems
+}
+
+// reloadFile refills the form from the entry's file once $EDITOR exits with
+// editorErr, or records why it can't.
+func (m *EntryEditorModel) reloadFile(editorErr error) {
+	m.problems = nil
+	if editorErr != nil {
+		m.problems = []string{fmt.Sprintf("editor %q failed: %v", tty.Editor(), editorErr)}
+		return
+	}
+
+	violations, err := changeset.ValidateFile(m.path, m.registry)
+	if err != nil {
+		m.problems = []string{err.Error()}
+		return
+	}
+	for _, v := range violations {
+		m.problems = append(m.problems, v.Message)
+	}
+	if len(m.problems) > 0 {
+		return
+	}
+
+	entry, err := changeset.Read(m.path)
+	if err != nil {
+		m.problems = []string{err.Error()}
+		return
+	}
+	m.setEntry(entry)
+	m.refreshErrors()
+}
+
+// confirm saves the entry when every field is valid; otherwise it shows the
+// errors and focuses the first invalid field.
+func (m EntryEditorModel) confirm() (tea.Model, tea.Cmd) {
+	m.errors = m.validate()
+	if len(m.errors) > 0 {
+		for field := range fieldCount {
+			if _, ok := m.errors[field]; ok {
+				return m, m.focusField(field)
+			}
+		}
+	}
+	m.confirmed = true
+	return m, tea.Quit
+}
+
+// validate checks the form, returning an error message per invalid field.
+func (m EntryEditorModel) validate() map[int]string {
+	errs := make(map[int]string)
+	if m.typeIdx >= m.known {
+		errs[fieldType] = fmt.Sprintf("type %q is not a configured change type", m.types[m.typeIdx])
+	}
+	if strings.ContainsFunc(strings.TrimSpace(m.scope.Value()), unicode.IsSpace) {
+		errs[fieldScope] = "scope cannot contain spaces"
+	}
+	summary := strings.TrimSpace(m.summary.Value())
+	switch {
+	case summary == "":
+		errs[fieldSummary] = "summary is required"
+	case strings.Contains(summary, "\n"):
+		errs[fieldSummary] = "summary must be a single line; put details in the body"
+	}
+	return errs
+}
+
+// refreshErrors revalidates the form once a save attempt has shown errors,
+// so they clear as the fields are fixed.
+func (m *EntryEditorModel) refreshErrors() {
+	if m.errors != nil {
+		m.errors = m.validate()
+	}
+}
+
+// cycleType moves the type selector by step, wrapping around.
+func (m *EntryEditorModel) cycleType(step int) {
+	m.typeIdx = (m.typeIdx + step + len(m.types)) % len(m.types)
+	m.refreshErrors()
 }
 
-// prevField moves focus to the previous input field.
-func (m *EntryEditorModel) prevField() {
-	m.inputs[m.focusIdx].Blur()
-	m.focusIdx--
-	if m.focusIdx < 0 {
-		m.focusIdx = len(m.inputs) - 1
+// focusField moves focus to field, blurring the previous one.
+func (m *EntryEditorModel) focusField(field int) tea.Cmd {
+	m.scope.Blur()
+	m.summary.Blur()
+	m.body.Blur()
+	m.focusIdx = field
+
+	switch field {
+	case fieldScope:
+		return m.scope.Focus()
+	case fieldSummary:
+		return m.summary.Focus()
+	case fieldBody:
+		return m.body.Focus()
 	}
-	m.inputs[m.focusIdx].Focus()
+	return nil
 }
 
-// updateInputs handles updates for text input fields.
+// updateInputs passes msg to the focused text field.
 func (m *EntryEditorModel) updateInputs(msg tea.Msg) tea.Cmd {
 	var cmd tea.Cmd
-	m.inputs[m.focusIdx], cmd = m.inputs[m.focusIdx].Update(msg)
+	switch m.focusIdx {
+	case fieldScope:
+		m.scope, cmd = m.scope.Update(msg)
+	case fieldSummary:
+		m.summary, cmd = m.summary.Update(msg)
+	case fieldBody:
+		m.body, cmd = m.body.Update(msg)
+	}
 	return cmd
 }
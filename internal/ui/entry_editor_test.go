@@ -1,6 +1,10 @@
 package ui
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -287,9 +291,10 @@ func TestEntryEditorModel_TypeIndexForDifferentTypes(t *testing.T) {
 	}{
 		{"added", 0},
 		{"changed", 1},
-		{"fixed", 2},
+		{"deprecated", 2},
 		{"removed", 3},
-		{"security", 4},
+		{"fixed", 4},
+		{"security", 5},
 	}
 
 	for _, tt := range tests {
@@ -310,3 +315,187 @@ func TestEntryEditorModel_TypeIndexForDifferentTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestEntryEditorModel_CustomTypes(t *testing.T) {
+	types := []string{"added", "perf", "docs"}
+	entry := changeset.EntryWithFile{
+		Entry:    changeset.Entry{Type: "docs", Summary: "Document registry"},
+		Filename: "test.md",
+	}
+
+	model := NewEntryEditorModelWithTypes(entry, types)
+	if model.typeIdx != 2 {
+		t.Fatalf("Type index for docs should be 2, got %d", model.typeIdx)
+	}
+
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	model = updated.(EntryEditorModel)
+	if got := model.GetEditedEntry().Type; got != "added" {
+		t.Errorf("cycling past the last type should wrap to added, got %s", got)
+	}
+
+	legacy := NewEntryEditorModelWithTypes(changeset.EntryWithFile{
+		Entry: changeset.Entry{Type: "chore", Summary: "Unregistered type"},
+	}, types)
+	if got := legacy.GetEditedEntry().Type; got != "chore" {
+		t.Errorf("an unregistered type should be kept, got %s", got)
+	}
+	if len(types) != 3 {
+		t.Errorf("the caller's types should not be modified, got %v", types)
+	}
+}
+
+func TestEntryEditorModel_Validation(t *testing.T) {
+	model := NewEntryEditorModelWithTypes(changeset.EntryWithFile{
+		Entry:    changeset.Entry{Type: "chore", Scope: "cli", Summary: ""},
+		Filename: "test.md",
+	}, []string{"added", "fixed"})
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	model = updated.(EntryEditorModel)
+	if model.IsConfirmed() {
+		t.Fatal("an invalid entry should not be saved")
+	}
+	if cmd != nil {
+		if _, ok := cmd().(tea.QuitMsg); ok {
+			t.Error("an invalid entry should not quit the editor")
+		}
+	}
+	if model.errors[fieldType] == "" || model.errors[fieldSummary] == "" {
+		t.Errorf("expected type and summary errors, got %v", model.errors)
+	}
+	if model.focusIdx != fieldType {
+		t.Errorf("focus should move to the first invalid field, got %d", model.focusIdx)
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRight})
+	model = updated.(EntryEditorModel)
+	if _, ok := model.errors[fieldType]; ok {
+		t.Error("choosing a configured type should clear the type error")
+	}
+
+	model.focusField(fieldSummary)
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Fix crash")})
+	model = updated.(EntryEditorModel)
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(EntryEditorModel)
+	if !model.IsConfirmed() {
+		t.Fatalf("a valid entry should be saved, errors: %v", model.errors)
+	}
+	if got := model.GetEditedEntry(); got.Type != "added" || got.Summary != "Fix crash" {
+		t.Errorf("GetEditedEntry() = %+v", got)
+	}
+}
+
+func TestEntryEditorModel_BreakingAndBody(t *testing.T) {
+	entry := changeset.EntryWithFile{
+		Entry: changeset.Entry{
+			Type:       "added",
+			Summary:    "Test entry",
+			Body:       "First line",
+			Links:      changeset.Links{{Name: "PR", URL: "https://example.com/pull/1"}},
+			References: []int{12},
+		},
+		Filename: "test.md",
+	}
+	model := NewEntryEditorModel(entry)
+
+	model.focusField(fieldBreaking)
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	model = updated.(EntryEditorModel)
+
+	model.focusField(fieldBody)
+	model.body.CursorEnd()
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(EntryEditorModel)
+	if model.IsConfirmed() {
+		t.Fatal("enter in the body should add a line, not save")
+	}
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Second line")})
+	model = updated.(EntryEditorModel)
+
+	edited := model.GetEditedEntry()
+	if !edited.Breaking {
+		t.Error("space on the breaking field should toggle it")
+	}
+	if edited.Body != "First line\nSecond line" {
+		t.Errorf("Body = %q", edited.Body)
+	}
+	if len(edited.Links) != 1 || len(edited.References) != 1 {
+		t.Error("fields the form doesn't show should be preserved")
+	}
+}
+
+func TestEntryEditorModel_ScopeSuggestions(t *testing.T) {
+	model := NewEntryEditorModel(changeset.EntryWithFile{
+		Entry:    changeset.Entry{Type: "added", Summary: "Test entry"},
+		Filename: "test.md",
+	})
+	model.SetScopeSuggestions([]string{"changelog", "cli"})
+	model.focusField(fieldScope)
+
+	for _, msg := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("ch")},
+		{Type: tea.KeyRight},
+	} {
+		updated, _ := model.Update(msg)
+		model = updated.(EntryEditorModel)
+	}
+	if got := model.GetEditedEntry().Scope; got != "changelog" {
+		t.Errorf("→ should accept the suggested scope, got %q", got)
+	}
+}
+
+func TestEntryEditorModel_ExternalEdit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "entry.md")
+	writeEntry := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write entry: %v", err)
+		}
+	}
+	writeEntry("---\ntype: added\nsummary: Test entry\n---\n")
+
+	model := NewEntryEditorModel(changeset.EntryWithFile{
+		Entry:    changeset.Entry{Type: "added", Summary: "Test entry"},
+		Filename: "entry.md",
+	})
+	if _, cmd := model.Update(tea.KeyMsg{Type: tea.KeyCtrlO}); cmd != nil {
+		t.Error("ctrl+o should do nothing without a file")
+	}
+
+	model.SetFile(path, nil)
+	if _, cmd := model.Update(tea.KeyMsg{Type: tea.KeyCtrlO}); cmd == nil {
+		t.Fatal("ctrl+o should open the file in $EDITOR")
+	}
+
+	writeEntry("---\ntype: fixed\nscope: cli\nsummary: Edited by hand\n---\n\nMore details.\n")
+	updated, _ := model.Update(externalEditMsg{})
+	model = updated.(EntryEditorModel)
+	if len(model.FileProblems()) != 0 {
+		t.Fatalf("FileProblems() = %v, want none", model.FileProblems())
+	}
+	edited := model.GetEditedEntry()
+	if edited.Type != "fixed" || edited.Scope != "cli" || edited.Summary != "Edited by hand" || edited.Body != "More details." {
+		t.Errorf("form should be refilled from the file, got %+v", edited)
+	}
+	if !model.EditedExternally() {
+		t.Error("EditedExternally() should be true")
+	}
+
+	writeEntry("---\ntype: fixed\n---\n")
+	updated, _ = model.Update(externalEditMsg{})
+	model = updated.(EntryEditorModel)
+	if len(model.FileProblems()) == 0 {
+		t.Fatal("a file without a summary should have problems")
+	}
+	if got := model.GetEditedEntry().Summary; got != "Edited by hand" {
+		t.Errorf("form should keep its values when the file is invalid, got summary %q", got)
+	}
+
+	updated, _ = model.Update(externalEditMsg{err: errors.New("exit status 1")})
+	model = updated.(EntryEditorModel)
+	if problems := model.FileProblems(); len(problems) != 1 || !strings.Contains(problems[0], "exit status 1") {
+		t.Errorf("FileProblems() = %v, want the editor's error", problems)
+	}
+}
@@ -0,0 +1,277 @@
+package ui
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stormlightlabs/git-storm/internal/diff"
+	"github.com/stormlightlabs/git-storm/internal/style"
+)
+
+// fileTreeThreshold is the file count above which the multi-file viewer opens
+// with the file tree shown in place of the paginator dots.
+const fileTreeThreshold = 10
+
+// fileTree lists a changeset's files grouped by directory for the sidebar of
+// [MultiFileDiffModel]. Directories are sorted by path, with files at the
+// repository root first, and files are sorted by name within each directory.
+type fileTree struct {
+	rows []treeRow
+	// order holds file indexes in the order they appear in rows.
+	order []int
+}
+
+// treeRow is a directory heading or a file entry.
+type treeRow struct {
+	dir  string // Directory label; empty for file rows
+	file int    // Index into the model's files, or -1 for directory rows
+}
+
+// newFileTree builds the tree for files.
+func newFileTree(files []FileDiff) fileTree {
+	order := make([]int, len(files))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		di, dj := treeDir(files[order[i]]), treeDir(files[order[j]])
+		if di != dj {
+			return di == "." || (dj != "." && di < dj)
+		}
+		return path.Base(files[order[i]].TreePath()) < path.Base(files[order[j]].TreePath())
+	})
+
+	var tree fileTree
+	tree.order = order
+	dir := "."
+	for _, idx := range order {
+		if d := treeDir(files[idx]); d != dir {
+			dir = d
+			tree.rows = append(tree.rows, treeRow{dir: d + "/", file: -1})
+		}
+		tree.rows = append(tree.rows, treeRow{file: idx})
+	}
+	return tree
+}
+
+// treeDir returns the directory a file is grouped under, "." for the root.
+func treeDir(f FileDiff) string {
+	return path.Dir(f.TreePath())
+}
+
+// step returns the file delta positions away from file in tree order,
+// clamped to the first and last files.
+func (t fileTree) step(file, delta int) int {
+	if len(t.order) == 0 {
+		return file
+	}
+	pos := 0
+	for i, idx := range t.order {
+		if idx == file {
+			pos = i
+			break
+		}
+	}
+	pos = min(max(pos+delta, 0), len(t.order)-1)
+	return t.order[pos]
+}
+
+// first returns the first file in tree order.
+func (t fileTree) first() int {
+	if len(t.order) == 0 {
+		return 0
+	}
+	return t.order[0]
+}
+
+// last returns the last file in tree order.
+func (t fileTree) last() int {
+	if len(t.order) == 0 {
+		return 0
+	}
+	return t.order[len(t.order)-1]
+}
+
+// render draws the rows in a width × height pane, scrolled to keep the
+// current file visible. The current file is highlighted more strongly when
+// the tree has focus.
+func (t fileTree) render(files []FileDiff, current, width, height int, focused bool) string {
+	if height <= 0 || width <= 0 {
+		return ""
+	}
+
+	currentRow := 0
+	for i, row := range t.rows {
+		if row.file == current {
+			currentRow = i
+			break
+		}
+	}
+	offset := min(max(currentRow-height/2, 0), max(len(t.rows)-height, 0))
+	end := min(offset+height, len(t.rows))
+
+	dirStyle := lipgloss.NewStyle().Foreground(style.AccentBlue).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6C7A89"))
+	selectedStyle := lipgloss.NewStyle().Bold(true)
+	if focused {
+		selectedStyle = selectedStyle.Reverse(true)
+	}
+
+	lines := make([]string, 0, height)
+	for _, row := range t.rows[offset:end] {
+		if row.file < 0 {
+			summary := dirSummary(files, t.dirFiles(row.dir))
+			label := truncateLeft(row.dir, max(width-lipgloss.Width(summary)-1, 1))
+			padding := strings.Repeat(" ", max(width-lipgloss.Width(label)-lipgloss.Width(summary), 1))
+			lines = append(lines, dirStyle.Render(label)+padding+summary)
+			continue
+		}
+
+		f := files[row.file]
+		indent := ""
+		if treeDir(f) != "." {
+			indent = "  "
+		}
+		status := fileStatus(f)
+		counts := fileCounts(f)
+		nameWidth := max(width-len(indent)-2-lipgloss.Width(counts)-1, 1)
+		name := truncateLeft(treeName(f), nameWidth)
+		padding := strings.Repeat(" ", max(nameWidth-lipgloss.Width(name), 0))
+
+		if row.file == current {
+			line := fmt.Sprintf("%s%s %s%s %s", indent, status, name, padding, counts)
+			lines = append(lines, selectedStyle.Render(line))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s%s %s%s %s",
+			indent, statusStyle(status).Render(status), name, padding, mutedStyle.Render(counts)))
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+
+	return lipgloss.NewStyle().Width(width).MaxWidth(width).Render(strings.Join(lines, "\n"))
+}
+
+// dirFiles returns the files listed under the directory heading dir.
+func (t fileTree) dirFiles(dir string) []int {
+	var indexes []int
+	inDir := false
+	for _, row := range t.rows {
+		if row.file < 0 {
+			if inDir {
+				break
+			}
+			inDir = row.dir == dir
+			continue
+		}
+		if inDir {
+			indexes = append(indexes, row.file)
+		}
+	}
+	return indexes
+}
+
+// dirSummary counts a directory's added, modified, and deleted files,
+// omitting statuses no file has. Renamed and copied files count as added.
+func dirSummary(files []FileDiff, indexes []int) string {
+	counts := make(map[string]int)
+	for _, idx := range indexes {
+		status := fileStatus(files[idx])
+		if status == "R" || status == "C" {
+			status = "A"
+		}
+		counts[status]++
+	}
+
+	var parts []string
+	for _, s := range []struct{ status, sign string }{{"A", "+"}, {"M", "~"}, {"D", "-"}} {
+		if counts[s.status] > 0 {
+			parts = append(parts, statusStyle(s.status).Render(fmt.Sprintf("%s%d", s.sign, counts[s.status])))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// treeName labels a file in the tree by its base name, prefixed with where
+// it was renamed or copied from: the old base name when the directory is
+// unchanged, otherwise the full old path.
+func treeName(f FileDiff) string {
+	name := path.Base(f.TreePath())
+	switch {
+	case f.From == "":
+		return name
+	case path.Dir(f.From) == path.Dir(f.TreePath()):
+		return path.Base(f.From) + " → " + name
+	default:
+		return f.From + " → " + name
+	}
+}
+
+// fileStatus classifies a file as renamed (R), copied (C), added (A),
+// deleted (D), or modified (M) from which sides have content.
+func fileStatus(f FileDiff) string {
+	switch {
+	case f.From != "" && f.Copied:
+		return "C"
+	case f.From != "":
+		return "R"
+	}
+	hasOld, hasNew := f.OldContent != "", f.NewContent != ""
+	for _, edit := range f.Edits {
+		hasOld = hasOld || edit.AIndex >= 0
+		hasNew = hasNew || edit.BIndex >= 0
+	}
+	switch {
+	case hasNew && !hasOld:
+		return "A"
+	case hasOld && !hasNew:
+		return "D"
+	default:
+		return "M"
+	}
+}
+
+// statusStyle colors a [fileStatus] letter.
+func statusStyle(status string) lipgloss.Style {
+	switch status {
+	case "A":
+		return style.StyleAdded
+	case "D":
+		return style.StyleRemoved
+	default:
+		return style.StyleChanged
+	}
+}
+
+// fileCounts formats the lines a file adds and removes, "…" while its edits
+// are still pending, or "bin" for a binary file.
+func fileCounts(f FileDiff) string {
+	if f.Binary {
+		return "bin"
+	}
+	if f.Pending() {
+		return "…"
+	}
+	stat := diff.DiffStat(f.Edits)
+	return fmt.Sprintf("+%d -%d", stat.Added, stat.Removed)
+}
+
+// truncateLeft shortens s to width columns, keeping its end so file names
+// and the deepest directories stay readable.
+func truncateLeft(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	if width <= 1 {
+		return "…"
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[1:]
+	}
+	return "…" + string(runes)
+}
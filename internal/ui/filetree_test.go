@@ -0,0 +1,205 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stormlightlabs/git-storm/internal/diff"
+)
+
+func treeFiles() []FileDiff {
+	return []FileDiff{
+		{Path: "internal/ui/ui.go", OldContent: "a", NewContent: "b"},
+		{Path: "README.md", OldContent: "old", NewContent: "new"},
+		{Path: "cmd/main.go", NewContent: "package main"},
+		{Path: "internal/ui/filetree.go", NewContent: "package ui"},
+		{Path: "cmd/legacy.go", OldContent: "package main"},
+	}
+}
+
+func TestNewFileTree_GroupsByDirectory(t *testing.T) {
+	tree := newFileTree(treeFiles())
+
+	var got []string
+	for _, row := range tree.rows {
+		if row.file < 0 {
+			got = append(got, row.dir)
+		} else {
+			got = append(got, treeFiles()[row.file].Path)
+		}
+	}
+
+	want := []string{
+		"README.md",
+		"cmd/", "cmd/legacy.go", "cmd/main.go",
+		"internal/ui/", "internal/ui/filetree.go", "internal/ui/ui.go",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("rows = %v, want %v", got, want)
+	}
+}
+
+func TestFileTree_Step(t *testing.T) {
+	tree := newFileTree(treeFiles())
+
+	if got := tree.first(); got != 1 {
+		t.Errorf("first() = %d, want 1 (README.md)", got)
+	}
+	if got := tree.last(); got != 0 {
+		t.Errorf("last() = %d, want 0 (internal/ui/ui.go)", got)
+	}
+	if got := tree.step(1, 1); got != 4 {
+		t.Errorf("step(README.md, 1) = %d, want 4 (cmd/legacy.go)", got)
+	}
+	if got := tree.step(1, -1); got != 1 {
+		t.Errorf("step(README.md, -1) = %d, want it clamped to 1", got)
+	}
+	if got := tree.step(0, 1); got != 0 {
+		t.Errorf("step(last, 1) = %d, want it clamped to 0", got)
+	}
+}
+
+func TestFileStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		file FileDiff
+		want string
+	}{
+		{"added content", FileDiff{NewContent: "x"}, "A"},
+		{"deleted content", FileDiff{OldContent: "x"}, "D"},
+		{"modified content", FileDiff{OldContent: "x", NewContent: "y"}, "M"},
+		{"inserted edits", FileDiff{Edits: []diff.Edit{{Kind: diff.Insert, AIndex: -1, BIndex: 0}}}, "A"},
+		{"deleted edits", FileDiff{Edits: []diff.Edit{{Kind: diff.Delete, AIndex: 0, BIndex: -1}}}, "D"},
+		{"renamed", FileDiff{OldContent: "x", NewContent: "x", From: "old.go"}, "R"},
+		{"copied", FileDiff{OldContent: "x", NewContent: "x", From: "old.go", Copied: true}, "C"},
+	}
+	for _, tt := range tests {
+		if got := fileStatus(tt.file); got != tt.want {
+			t.Errorf("%s: fileStatus() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFileTree_Render(t *testing.T) {
+	files := treeFiles()
+	files[1].Edits = []diff.Edit{
+		{Kind: diff.Replace, AIndex: 0, BIndex: 0, Content: "old", NewContent: "new"},
+		{Kind: diff.Insert, AIndex: -1, BIndex: 1, Content: "more"},
+	}
+	tree := newFileTree(files)
+
+	out := tree.render(files, 1, 30, 10, false)
+	lines := strings.Split(out, "\n")
+	if len(lines) != 10 {
+		t.Fatalf("render() should pad to the pane height, got %d lines", len(lines))
+	}
+	for _, want := range []string{"README.md", "+2 -1", "cmd/", "+1", "-1", "internal/ui/", "+1 ~1", "filetree.go", "…"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("render() should contain %q, got:\n%s", want, out)
+		}
+	}
+
+	short := tree.render(files, 0, 30, 3, false)
+	if !strings.Contains(short, "ui.go") || strings.Contains(short, "README.md") {
+		t.Errorf("render() should scroll to the current file, got:\n%s", short)
+	}
+}
+
+func TestTreeName(t *testing.T) {
+	tests := []struct {
+		file FileDiff
+		want string
+	}{
+		{FileDiff{Path: "cmd/diff.go"}, "diff.go"},
+		{FileDiff{Path: "cmd/diff.go", From: "cmd/old.go"}, "old.go → diff.go"},
+		{FileDiff{Path: "cmd/diff.go", From: "internal/diff.go"}, "internal/diff.go → diff.go"},
+	}
+	for _, tt := range tests {
+		if got := treeName(tt.file); got != tt.want {
+			t.Errorf("treeName(%+v) = %q, want %q", tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestMultiFileDiffModel_RenameHeader(t *testing.T) {
+	files := []FileDiff{{OldPath: "HEAD~1:a.txt", NewPath: "HEAD:docs/a.txt", Path: "docs/a.txt", OldContent: "x", NewContent: "x", From: "a.txt", Similarity: 100}}
+	model := NewMultiFileDiffModel(files, false, diff.ViewSplit)
+	if header := model.renderMultiFileHeader(); !strings.Contains(header, "renamed 100%") {
+		t.Errorf("header should label the rename, got %q", header)
+	}
+}
+
+func TestTruncateLeft(t *testing.T) {
+	if got := truncateLeft("internal/changeset/", 10); got != "…hangeset/" {
+		t.Errorf("truncateLeft() = %q, want %q", got, "…hangeset/")
+	}
+	if got := truncateLeft("short", 10); got != "short" {
+		t.Errorf("truncateLeft() = %q, want it unchanged", got)
+	}
+}
+
+func TestMultiFileDiffModel_FileTree(t *testing.T) {
+	files := treeFiles()
+	for i := range files {
+		files[i].Edits = []diff.Edit{{Kind: diff.Equal, AIndex: 0, BIndex: 0, Content: files[i].Path}}
+	}
+
+	model := NewMultiFileDiffModel(files, false, diff.ViewSplit)
+	if model.showTree {
+		t.Fatal("small changesets should open with the paginator, not the tree")
+	}
+
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	model = updated.(MultiFileDiffModel)
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	model = updated.(MultiFileDiffModel)
+
+	if !model.showTree || !model.treeFocus {
+		t.Fatal("tab should open and focus the file tree")
+	}
+	if model.viewport.Width != 120-model.treeWidth() {
+		t.Errorf("viewport width = %d, want the space beside the tree", model.viewport.Width)
+	}
+	if model.renderPaginator() != "" {
+		t.Error("the tree should replace the paginator dots")
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	model = updated.(MultiFileDiffModel)
+	if model.paginator.Page != 1 {
+		t.Errorf("g should move to README.md, the first file in tree order, got page %d", model.paginator.Page)
+	}
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model = updated.(MultiFileDiffModel)
+	if model.paginator.Page != 4 {
+		t.Errorf("down should move to cmd/legacy.go in tree order, got page %d", model.paginator.Page)
+	}
+	if view := model.View(); !strings.Contains(view, "legacy.go") || !strings.Contains(view, "internal/ui/") {
+		t.Errorf("view should show the tree beside the diff, got:\n%s", view)
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(MultiFileDiffModel)
+	if model.treeFocus {
+		t.Error("enter should return focus to the diff")
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	model = updated.(MultiFileDiffModel)
+	if model.showTree || model.viewport.Width != 120 {
+		t.Error("t should hide the tree and give the diff the full width")
+	}
+}
+
+func TestMultiFileDiffModel_FileTreeForLargeChangesets(t *testing.T) {
+	files := make([]FileDiff, fileTreeThreshold+1)
+	for i := range files {
+		files[i] = FileDiff{Path: "pkg/file.go", Edits: []diff.Edit{}}
+	}
+
+	model := NewMultiFileDiffModel(files, false, diff.ViewSplit)
+	if !model.showTree || model.treeFocus {
+		t.Error("large changesets should open with the tree shown but unfocused")
+	}
+}
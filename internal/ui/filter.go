@@ -0,0 +1,204 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// listKeys are the filtering and sorting keys shared by the list TUIs.
+var listKeys = struct {
+	Filter key.Binding
+	Sort   key.Binding
+}{
+	Filter: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "filter"),
+	),
+	Sort: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "sort"),
+	),
+}
+
+// listRow is what filtering and sorting see of one item in a list TUI.
+type listRow struct {
+	types  []string // e.g. a commit's changelog category and conventional type
+	scope  string
+	author string
+	text   string // the summary or subject, matched by bare terms
+	date   time.Time
+	paths  func() []string // changed paths, loaded only for path: terms
+}
+
+// listSort is an order a list TUI shows its items in, cycled with s.
+type listSort int
+
+const (
+	sortNone   listSort = iota // the order items were given in
+	sortDate                   // newest first
+	sortType                   // grouped by type
+	sortScope                  // grouped by scope
+	sortAuthor                 // grouped by author
+	sortCount
+)
+
+func (s listSort) String() string {
+	return [...]string{"none", "date", "type", "scope", "author"}[s]
+}
+
+// listFilter narrows a list TUI to the items matching a query typed after /.
+//
+// The query is split on spaces and every term must match. A term like
+// type:fix, scope:ui, author:jane, or path:internal/ui matches only that
+// field; any other term matches the type, scope, author, or text. Matching is
+// case-insensitive and by substring.
+type listFilter struct {
+	input  textinput.Model
+	typing bool // Whether the prompt is open and receiving keys
+	query  string
+}
+
+func newListFilter() listFilter {
+	input := textinput.New()
+	input.Prompt = "/"
+	input.CharLimit = 256
+	return listFilter{input: input}
+}
+
+// start opens the filter prompt, editing the current query.
+func (f *listFilter) start() tea.Cmd {
+	f.typing = true
+	f.input.SetValue(f.query)
+	f.input.CursorEnd()
+	return f.input.Focus()
+}
+
+// update handles a key while the prompt is open. The query follows the input
+// as it's typed; enter closes the prompt and esc also clears the query.
+func (f *listFilter) update(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEnter:
+		f.typing = false
+		f.input.Blur()
+		return nil
+	case tea.KeyEsc:
+		f.typing = false
+		f.input.Blur()
+		f.query = ""
+		return nil
+	}
+
+	var cmd tea.Cmd
+	f.input, cmd = f.input.Update(msg)
+	f.query = strings.TrimSpace(f.input.Value())
+	return cmd
+}
+
+// active reports whether a query is narrowing the list.
+func (f listFilter) active() bool {
+	return f.query != ""
+}
+
+// apply returns the indices of the rows matching the query, ordered by by.
+// Ties keep the rows' order.
+func (f listFilter) apply(rows []listRow, by listSort) []int {
+	terms := strings.Fields(strings.ToLower(f.query))
+
+	var indices []int
+	for i, row := range rows {
+		if row.matches(terms) {
+			indices = append(indices, i)
+		}
+	}
+
+	slices.SortStableFunc(indices, func(a, b int) int {
+		ra, rb := rows[a], rows[b]
+		switch by {
+		case sortDate:
+			return rb.date.Compare(ra.date)
+		case sortType:
+			return compareLast(firstOf(ra.types), firstOf(rb.types))
+		case sortScope:
+			return compareLast(ra.scope, rb.scope)
+		case sortAuthor:
+			return compareLast(ra.author, rb.author)
+		}
+		return 0
+	})
+	return indices
+}
+
+// matches reports whether every lowercase term matches the row.
+func (r listRow) matches(terms []string) bool {
+	for _, term := range terms {
+		field, value, _ := strings.Cut(term, ":")
+		var ok bool
+		switch field {
+		case "type":
+			ok = slices.ContainsFunc(r.types, containsFold(value))
+		case "scope":
+			ok = containsFold(value)(r.scope)
+		case "author":
+			ok = containsFold(value)(r.author)
+		case "path":
+			ok = r.paths != nil && slices.ContainsFunc(r.paths(), containsFold(value))
+		default:
+			ok = slices.ContainsFunc(append([]string{r.scope, r.author, r.text}, r.types...), containsFold(term))
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// containsFold returns a predicate reporting whether a string contains the
+// lowercase term, ignoring case.
+func containsFold(term string) func(string) bool {
+	return func(s string) bool {
+		return strings.Contains(strings.ToLower(s), term)
+	}
+}
+
+// firstOf returns the first of values, or "".
+func firstOf(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// compareLast orders strings case-insensitively, with empty ones last.
+func compareLast(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// status describes the filter and sort for a header: the prompt while
+// typing, then the query and how many of total items it kept.
+func (f listFilter) status(shown, total int, by listSort) string {
+	var parts []string
+	switch {
+	case f.typing:
+		parts = append(parts, fmt.Sprintf("%s (%d/%d)", f.input.View(), shown, total))
+	case f.active():
+		parts = append(parts, fmt.Sprintf("filter %q (%d/%d)", f.query, shown, total))
+	}
+	if by != sortNone {
+		parts = append(parts, "sort: "+by.String())
+	}
+	return strings.Join(parts, " • ")
+}
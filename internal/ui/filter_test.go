@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestListFilter_Apply(t *testing.T) {
+	now := time.Now()
+	rows := []listRow{
+		{types: []string{"fixed", "fix"}, scope: "ui", author: "Jane", text: "Fix review crash", date: now.Add(-time.Hour),
+			paths: func() []string { return []string{"internal/ui/changeset_review.go"} }},
+		{types: []string{"added", "feat"}, scope: "cli", author: "Bob", text: "Add export", date: now},
+		{types: []string{"fixed", "fix"}, scope: "", author: "Bob", text: "Fix flag parsing", date: now.Add(-2 * time.Hour),
+			paths: func() []string { return []string{"cmd/main.go"} }},
+	}
+
+	tests := []struct {
+		query string
+		by    listSort
+		want  []int
+	}{
+		{query: "", by: sortNone, want: []int{0, 1, 2}},
+		{query: "fix", by: sortNone, want: []int{0, 2}},
+		{query: "type:fix path:internal/ui", by: sortNone, want: []int{0}},
+		{query: "AUTHOR:bob", by: sortNone, want: []int{1, 2}},
+		{query: "scope:cli crash", by: sortNone, want: nil},
+		{query: "", by: sortDate, want: []int{1, 0, 2}},
+		{query: "", by: sortType, want: []int{1, 0, 2}},
+		{query: "", by: sortScope, want: []int{1, 0, 2}},
+		{query: "", by: sortAuthor, want: []int{1, 2, 0}},
+	}
+
+	for _, tt := range tests {
+		f := newListFilter()
+		f.query = tt.query
+		if got := f.apply(rows, tt.by); !slices.Equal(got, tt.want) {
+			t.Errorf("apply(%q, %s) = %v, want %v", tt.query, tt.by, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stormlightlabs/git-storm/internal/style"
+)
+
+var (
+	matchStyle        = lipgloss.NewStyle().Background(style.SecurityColor).Foreground(style.Background)
+	currentMatchStyle = lipgloss.NewStyle().Background(style.AccentBlue).Foreground(style.Background).Bold(true)
+)
+
+// diffSearch finds a pattern in a diff viewer's rendered content.
+//
+// Matching is plain substring and smart-case: a pattern without uppercase
+// letters ignores case. Matches are found in the text as displayed, so line
+// numbers and both sides of a split row are searched.
+type diffSearch struct {
+	input   textinput.Model
+	typing  bool // Whether the prompt is open and receiving keys
+	pattern string
+	lines   []int // Content lines holding at least one match
+	current int   // Index into lines of the match last jumped to
+}
+
+func newDiffSearch() diffSearch {
+	input := textinput.New()
+	input.Prompt = "/"
+	input.CharLimit = 256
+	return diffSearch{input: input}
+}
+
+// start opens the search prompt.
+func (s *diffSearch) start() tea.Cmd {
+	s.typing = true
+	s.input.SetValue("")
+	return s.input.Focus()
+}
+
+// update handles a key while the prompt is open. submitted reports that
+// enter confirmed a new pattern; esc closes the prompt and keeps the previous one.
+func (s *diffSearch) update(msg tea.KeyMsg) (submitted bool, cmd tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		s.typing = false
+		s.input.Blur()
+		s.pattern = s.input.Value()
+		s.current = 0
+		return s.pattern != "", nil
+	case tea.KeyEsc:
+		s.typing = false
+		s.input.Blur()
+		return false, nil
+	}
+	s.input, cmd = s.input.Update(msg)
+	return false, cmd
+}
+
+// active reports whether a pattern is being highlighted.
+func (s diffSearch) active() bool {
+	return s.pattern != ""
+}
+
+// clear drops the pattern and its matches.
+func (s *diffSearch) clear() {
+	s.pattern = ""
+	s.lines = nil
+	s.current = 0
+}
+
+// highlight returns content with every match of the pattern highlighted,
+// the current one distinctly, and records the lines matches occur on.
+func (s *diffSearch) highlight(content string) string {
+	s.lines = nil
+	if s.pattern == "" {
+		return content
+	}
+
+	pattern, fold := s.pattern, !hasUpper(s.pattern)
+	if fold {
+		pattern = strings.ToLower(pattern)
+	}
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		plain := ansi.Strip(line)
+		haystack := plain
+		if fold {
+			haystack = strings.ToLower(plain)
+		}
+		if !strings.Contains(haystack, pattern) {
+			continue
+		}
+
+		hl := matchStyle
+		if len(s.lines) == s.current {
+			hl = currentMatchStyle
+		}
+		s.lines = append(s.lines, i)
+		lines[i] = highlightLine(line, plain, haystack, pattern, hl)
+	}
+	s.current = min(s.current, max(len(s.lines)-1, 0))
+	return strings.Join(lines, "\n")
+}
+
+// highlightLine restyles each occurrence of pattern in a styled line.
+// plain is the line without escape codes and haystack the text searched,
+// which differs from plain only in case.
+func highlightLine(line, plain, haystack, pattern string, hl lipgloss.Style) string {
+	var b strings.Builder
+	col, offset := 0, 0
+	for {
+		idx := strings.Index(haystack[offset:], pattern)
+		if idx < 0 {
+			break
+		}
+		start := ansi.StringWidth(haystack[:offset+idx])
+		end := ansi.StringWidth(haystack[:offset+idx+len(pattern)])
+		b.WriteString(ansi.Cut(line, col, start))
+		b.WriteString(hl.Render(ansi.Cut(plain, start, end)))
+		col, offset = end, offset+idx+len(pattern)
+	}
+	b.WriteString(ansi.TruncateLeft(line, col, ""))
+	return b.String()
+}
+
+// hasUpper reports whether s contains an uppercase letter.
+func hasUpper(s string) bool {
+	for _, r := range s {
+		if unicode.IsUpper(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// seek makes the first match at or after line the current one, wrapping to
+// the first match, and returns its line.
+func (s *diffSearch) seek(line int) (int, bool) {
+	if len(s.lines) == 0 {
+		return 0, false
+	}
+	s.current = 0
+	for i, l := range s.lines {
+		if l >= line {
+			s.current = i
+			break
+		}
+	}
+	return s.lines[s.current], true
+}
+
+// step moves to the match delta places from the current one, wrapping
+// around, and returns its line.
+func (s *diffSearch) step(delta int) (int, bool) {
+	if len(s.lines) == 0 {
+		return 0, false
+	}
+	n := len(s.lines)
+	s.current = ((s.current+delta)%n + n) % n
+	return s.lines[s.current], true
+}
+
+// status describes the search for a footer: the prompt while typing, then
+// the match position.
+func (s diffSearch) status() string {
+	switch {
+	case s.typing:
+		return s.input.View()
+	case !s.active():
+		return ""
+	case len(s.lines) == 0:
+		return fmt.Sprintf("no matches for %q", s.pattern)
+	default:
+		return fmt.Sprintf("%q %d/%d", s.pattern, s.current+1, len(s.lines))
+	}
+}
+
+// handleKey applies search keys to a viewer showing content in vp, reporting
+// whether msg was consumed. While the prompt is open it takes every key.
+func (s *diffSearch) handleKey(msg tea.KeyMsg, vp *viewport.Model, content string) (tea.Cmd, bool) {
+	if s.typing {
+		submitted, cmd := s.update(msg)
+		if !s.typing {
+			vp.SetContent(s.highlight(content))
+			if submitted {
+				line, ok := s.seek(vp.YOffset)
+				s.show(vp, content, line, ok)
+			}
+		}
+		return cmd, true
+	}
+
+	switch {
+	case key.Matches(msg, keys.Search):
+		return s.start(), true
+	case key.Matches(msg, keys.NextMatch) && s.active():
+		line, ok := s.step(1)
+		s.show(vp, content, line, ok)
+		return nil, true
+	case key.Matches(msg, keys.PrevMatch) && s.active():
+		line, ok := s.step(-1)
+		s.show(vp, content, line, ok)
+		return nil, true
+	case msg.Type == tea.KeyEsc && s.active():
+		s.clear()
+		vp.SetContent(content)
+		return nil, true
+	}
+	return nil, false
+}
+
+// show renders content into vp with the current match highlighted and, when
+// ok, scrolls so line sits a third of the way down.
+func (s *diffSearch) show(vp *viewport.Model, content string, line int, ok bool) {
+	vp.SetContent(s.highlight(content))
+	if ok {
+		vp.SetYOffset(line - vp.Height/3)
+	}
+}
+
+// footer returns the search's footer help, or "" when no search is open.
+func (s diffSearch) footer() string {
+	switch {
+	case s.typing:
+		return s.status() + "  (enter: search • esc: cancel)"
+	case s.active():
+		return s.status() + " • n/N: next/prev • esc: clear • q: quit"
+	}
+	return ""
+}
@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stormlightlabs/git-storm/internal/diff"
+	"github.com/stormlightlabs/git-storm/internal/style"
+)
+
+func TestDiffSearch_Highlight(t *testing.T) {
+	content := strings.Join([]string{
+		style.StyleAdded.Render("+ return foo(bar)"),
+		"  unchanged",
+		style.StyleRemoved.Render("- Foo := 1") + " | " + style.StyleAdded.Render("+ foo := 2"),
+	}, "\n")
+
+	s := newDiffSearch()
+	s.pattern = "foo"
+	out := s.highlight(content)
+
+	if ansi.Strip(out) != ansi.Strip(content) {
+		t.Errorf("highlighting should not change the displayed text, got:\n%s", ansi.Strip(out))
+	}
+	if len(s.lines) != 2 || s.lines[0] != 0 || s.lines[1] != 2 {
+		t.Errorf("match lines = %v, want [0 2]", s.lines)
+	}
+
+	s.pattern = "Foo"
+	s.highlight(content)
+	if len(s.lines) != 1 || s.lines[0] != 2 {
+		t.Errorf("an uppercase pattern should match case-sensitively, got lines %v", s.lines)
+	}
+}
+
+func TestHighlightLine(t *testing.T) {
+	line := "abc abc"
+	got := highlightLine(line, line, line, "bc", currentMatchStyle)
+	want := "a" + currentMatchStyle.Render("bc") + " a" + currentMatchStyle.Render("bc")
+	if got != want {
+		t.Errorf("highlightLine() = %q, want %q", got, want)
+	}
+}
+
+func TestDiffSearch_SeekAndStep(t *testing.T) {
+	s := newDiffSearch()
+	s.lines = []int{3, 10, 42}
+
+	if line, ok := s.seek(5); !ok || line != 10 {
+		t.Errorf("seek(5) = %d, %v; want 10, true", line, ok)
+	}
+	if line, _ := s.seek(50); line != 3 {
+		t.Errorf("seek past the last match should wrap to 3, got %d", line)
+	}
+	if line, _ := s.step(-1); line != 42 {
+		t.Errorf("step(-1) from the first match should wrap to 42, got %d", line)
+	}
+	if line, _ := s.step(1); line != 3 {
+		t.Errorf("step(1) from the last match should wrap to 3, got %d", line)
+	}
+
+	s.lines = nil
+	if _, ok := s.step(1); ok {
+		t.Error("step() without matches should report no match")
+	}
+}
+
+func TestDiffModel_Search(t *testing.T) {
+	edits := make([]diff.Edit, 0, 200)
+	for i := range 200 {
+		content := "filler line"
+		if i == 50 || i == 150 {
+			content = "needle line"
+		}
+		edits = append(edits, diff.Edit{Kind: diff.Insert, AIndex: -1, BIndex: i, Content: content})
+	}
+	model := NewDiffModel(edits, "old.go", "new.go", 120, 20)
+
+	send := func(msgs ...tea.KeyMsg) {
+		for _, msg := range msgs {
+			updated, _ := model.Update(msg)
+			model = updated.(DiffModel)
+		}
+	}
+
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	if !model.search.typing {
+		t.Fatal("/ should open the search prompt")
+	}
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("needle")}, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if footer := model.renderFooter(); !strings.Contains(footer, `"needle" 1/2`) {
+		t.Errorf("footer should show the match position, got %q", footer)
+	}
+	first := model.viewport.YOffset
+	if first == 0 {
+		t.Error("submitting a search should scroll to the first match")
+	}
+
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if model.viewport.YOffset <= first || !strings.Contains(model.renderFooter(), "2/2") {
+		t.Errorf("n should jump to the second match, offset %d", model.viewport.YOffset)
+	}
+
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+	if model.viewport.YOffset != first {
+		t.Errorf("N should return to the first match, offset %d want %d", model.viewport.YOffset, first)
+	}
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	model = updated.(DiffModel)
+	if model.search.active() || cmd != nil {
+		t.Error("esc should clear an active search rather than quit")
+	}
+	if strings.Contains(model.renderFooter(), "needle") {
+		t.Error("footer should drop the search once cleared")
+	}
+}
+
+func TestMultiFileDiffModel_SearchFollowsFile(t *testing.T) {
+	files := []FileDiff{
+		{Edits: []diff.Edit{{Kind: diff.Equal, AIndex: 0, BIndex: 0, Content: "alpha"}}, NewPath: "a.go"},
+		{Edits: []diff.Edit{{Kind: diff.Equal, AIndex: 0, BIndex: 0, Content: "beta alpha"}}, NewPath: "b.go"},
+	}
+	model := NewMultiFileDiffModel(files, true, diff.ViewUnified)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 100, Height: 20})
+	model = updated.(MultiFileDiffModel)
+
+	for _, msg := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune{'/'}},
+		{Type: tea.KeyRunes, Runes: []rune("beta")},
+		{Type: tea.KeyEnter},
+	} {
+		updated, _ = model.Update(msg)
+		model = updated.(MultiFileDiffModel)
+	}
+	if !strings.Contains(model.renderMultiFileFooter(), `no matches for "beta"`) {
+		t.Errorf("footer should report no matches in the first file, got %q", model.renderMultiFileFooter())
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	model = updated.(MultiFileDiffModel)
+	if !strings.Contains(model.renderMultiFileFooter(), `"beta" 1/1`) {
+		t.Errorf("the search should carry over to the next file, got %q", model.renderMultiFileFooter())
+	}
+}
@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stormlightlabs/git-storm/internal/diff"
+)
+
+// Staging configures hunk staging in a [MultiFileDiffModel]. Files are
+// expected to diff the index against the worktree, or HEAD against the index
+// when Unstage is set.
+type Staging struct {
+	// Unstage reverts the selected hunk in the index instead of adding it.
+	Unstage bool
+	// Write stores content as the staged version of path.
+	Write func(path, content string) error
+}
+
+// stageKey stages or unstages the selected hunk.
+var stageKey = key.NewBinding(
+	key.WithKeys("s"),
+	key.WithHelp("s", "stage hunk"),
+)
+
+// NewMultiFileDiffModelWithStaging creates a multi-file diff viewer in which
+// ']'/'[' select a hunk of the current file and 's' writes it to the index
+// through staging.
+func NewMultiFileDiffModelWithStaging(files []FileDiff, expanded bool, view diff.DiffViewKind, opts RenderOptions, staging Staging) MultiFileDiffModel {
+	model := NewMultiFileDiffModelWithOptions(files, expanded, view, opts)
+	model.staging = staging
+	return model
+}
+
+// stagingEnabled reports whether hunk staging is enabled.
+func (m MultiFileDiffModel) stagingEnabled() bool {
+	return m.staging.Write != nil
+}
+
+// stageBlocker explains why file's hunks can't be staged, or returns "" when
+// they can. Staging rebuilds the index from the edits, so every line must
+// compare exactly and the file must keep its path. Added and deleted files
+// change the index entry itself, which is left to git add and git rm.
+func (m MultiFileDiffModel) stageBlocker(file FileDiff) string {
+	switch {
+	case file.Binary:
+		return "binary files can't be staged by hunk"
+	case file.From != "":
+		return "renamed and copied files can't be staged by hunk"
+	case file.Created || file.Deleted:
+		return "added and deleted files are staged with git add and git rm"
+	case file.Whitespace != diff.Whitespace{} || m.render.IgnoreMatchingLines != nil:
+		return "hunks can't be staged while changes are ignored"
+	case file.Pending() || file.loadErr != nil:
+		return "no diff to stage"
+	}
+	return ""
+}
+
+// selectHunk moves the hunk selection by dir and scrolls to the selected hunk.
+func (m *MultiFileDiffModel) selectHunk(dir int) {
+	if len(m.hunks) == 0 {
+		return
+	}
+	m.selected = min(max(m.selected+dir, 0), len(m.hunks)-1)
+	m.viewport.SetYOffset(max(m.hunks[m.selected]-hunkContext, 0))
+}
+
+// stageSelected writes the current file with the selected hunk staged (or
+// unstaged) to the index, then recomputes the file's diff.
+func (m *MultiFileDiffModel) stageSelected() tea.Cmd {
+	file := &m.files[m.paginator.Page]
+	if reason := m.stageBlocker(*file); reason != "" {
+		m.stageStatus = reason
+		return nil
+	}
+	runs := diff.ChangeRuns(file.Edits)
+	if m.selected >= len(runs) {
+		m.stageStatus = "no hunk selected"
+		return nil
+	}
+
+	run := runs[m.selected]
+	selected := func(i int) bool { return i >= run[0] && i < run[1] }
+	take := selected
+	if m.staging.Unstage {
+		take = func(i int) bool { return !selected(i) }
+	}
+	content := strings.Join(diff.ApplySelected(file.Edits, take), "\n")
+
+	path := file.TreePath()
+	if err := m.staging.Write(path, content); err != nil {
+		m.stageStatus = err.Error()
+		return nil
+	}
+
+	verb := "staged"
+	if m.staging.Unstage {
+		file.NewContent = content
+		verb = "unstaged"
+	} else {
+		file.OldContent = content
+	}
+	file.Edits = nil
+	m.stageStatus = fmt.Sprintf("%s hunk %d of %s", verb, m.selected+1, path)
+	return m.updateViewport()
+}
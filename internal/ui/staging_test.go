@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stormlightlabs/git-storm/internal/diff"
+)
+
+// stagingModel opens a staging viewer over one file and computes its diff.
+func stagingModel(t *testing.T, file FileDiff, staging Staging) MultiFileDiffModel {
+	t.Helper()
+	model := NewMultiFileDiffModelWithStaging([]FileDiff{file}, false, diff.ViewUnified, RenderOptions{}, staging)
+	updated, cmd := model.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	return drainCmd(t, updated.(MultiFileDiffModel), cmd)
+}
+
+// pressKey sends a rune key and runs any diff computation it starts.
+func pressKey(t *testing.T, model MultiFileDiffModel, r rune) MultiFileDiffModel {
+	t.Helper()
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	return drainCmd(t, updated.(MultiFileDiffModel), cmd)
+}
+
+func TestMultiFileDiffModel_StageHunk(t *testing.T) {
+	index := "one\ntwo\nthree\nfour\nfive\nsix\nseven\n"
+	worktree := "ONE\ntwo\nthree\nfour\nfive\nsix\nSEVEN\n"
+	written := map[string]string{}
+	model := stagingModel(t, FileDiff{OldPath: "INDEX:a.txt", NewPath: "WORKTREE:a.txt", Path: "a.txt", OldContent: index, NewContent: worktree},
+		Staging{Write: func(path, content string) error {
+			written[path] = content
+			return nil
+		}})
+	if len(model.hunks) != 2 {
+		t.Fatalf("expected two hunks, got %v", model.hunks)
+	}
+
+	model = pressKey(t, model, ']')
+	if model.selected != 1 {
+		t.Fatalf("] should select the second hunk, selected = %d", model.selected)
+	}
+	model = pressKey(t, model, 's')
+
+	want := "one\ntwo\nthree\nfour\nfive\nsix\nSEVEN\n"
+	if written["a.txt"] != want {
+		t.Errorf("staged content = %q, want %q", written["a.txt"], want)
+	}
+	if model.files[0].OldContent != want || len(model.hunks) != 1 || model.selected != 0 {
+		t.Errorf("the staged hunk should leave the diff, hunks = %v, selected = %d", model.hunks, model.selected)
+	}
+	if !strings.Contains(model.View(), "staged hunk 2 of a.txt") {
+		t.Errorf("footer should report the staged hunk, got:\n%s", model.View())
+	}
+}
+
+func TestMultiFileDiffModel_UnstageHunk(t *testing.T) {
+	head := "one\ntwo\nthree\nfour\nfive\nsix\nseven\n"
+	index := "ONE\ntwo\nthree\nfour\nfive\nsix\nSEVEN\n"
+	var written string
+	model := stagingModel(t, FileDiff{OldPath: "HEAD:a.txt", NewPath: "INDEX:a.txt", Path: "a.txt", OldContent: head, NewContent: index},
+		Staging{Unstage: true, Write: func(_, content string) error {
+			written = content
+			return nil
+		}})
+
+	model = pressKey(t, model, 's')
+	if want := "one\ntwo\nthree\nfour\nfive\nsix\nSEVEN\n"; written != want {
+		t.Errorf("unstaged content = %q, want %q", written, want)
+	}
+	if len(model.hunks) != 1 {
+		t.Errorf("the unstaged hunk should leave the diff, hunks = %v", model.hunks)
+	}
+}
+
+func TestMultiFileDiffModel_StageBlocked(t *testing.T) {
+	calls := 0
+	write := func(string, string) error {
+		calls++
+		return errors.New("index locked")
+	}
+
+	renamed := stagingModel(t, FileDiff{NewPath: "b.txt", OldContent: "a\n", NewContent: "b\n", From: "a.txt"}, Staging{Write: write})
+	renamed = pressKey(t, renamed, 's')
+	if calls != 0 || !strings.Contains(renamed.View(), "renamed and copied files") {
+		t.Errorf("renamed files should not be staged, got:\n%s", renamed.View())
+	}
+
+	added := stagingModel(t, FileDiff{NewPath: "new.txt", NewContent: "new\n", Created: true}, Staging{Write: write})
+	added = pressKey(t, added, 's')
+	if calls != 0 || !strings.Contains(added.View(), "git add and git rm") {
+		t.Errorf("added files should not be staged by hunk, got:\n%s", added.View())
+	}
+
+	deleted := stagingModel(t, FileDiff{NewPath: "old.txt", OldContent: "old\n", Deleted: true}, Staging{Write: write})
+	deleted = pressKey(t, deleted, 's')
+	if calls != 0 || !strings.Contains(deleted.View(), "git add and git rm") || deleted.files[0].OldContent != "old\n" {
+		t.Errorf("deleted files should not be staged by hunk, got:\n%s", deleted.View())
+	}
+
+	failing := stagingModel(t, FileDiff{NewPath: "a.txt", OldContent: "a\n", NewContent: "b\n"}, Staging{Write: write})
+	failing = pressKey(t, failing, 's')
+	if calls != 1 || !strings.Contains(failing.View(), "index locked") || failing.files[0].OldContent != "a\n" {
+		t.Errorf("a failed write should be reported and leave the file alone, got:\n%s", failing.View())
+	}
+}
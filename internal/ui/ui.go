@@ -2,10 +2,12 @@ package ui
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/paginator"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -14,32 +16,196 @@ import (
 )
 
 // FileDiff represents a diff for a single file.
+//
+// Edits may be left nil when OldContent and NewContent are provided, in which
+// case they are computed on demand the first time the file is viewed.
 type FileDiff struct {
-	Edits   []diff.Edit
-	OldPath string
-	NewPath string
+	Edits      []diff.Edit
+	OldPath    string
+	NewPath    string
+	OldContent string
+	NewContent string
+	// Path is the repository-relative path listed in the file tree. When
+	// empty, NewPath is used.
+	Path string
+	// Blame holds per-line annotations for NewContent, shown beside changed lines in the split view.
+	Blame []string
+	// Binary marks contents that are not text. No edits are computed for
+	// binary files; the viewers show [FileDiff.Summary] instead.
+	Binary bool
+	// From is the repository-relative path the file was renamed or copied
+	// from, or empty. Copied and Similarity describe the match.
+	From       string
+	Copied     bool
+	Similarity int
+	// Created and Deleted mark a file that exists on only the new or the old
+	// side of the diff.
+	Created bool
+	Deleted bool
+	// Whitespace selects the whitespace differences ignored when edits are
+	// computed from the contents.
+	Whitespace diff.Whitespace
+
+	loadErr error // Why computing the edits failed, shown in place of the diff
 }
 
+// NewFileDiff builds a file's diff from both sides of its content, ignoring
+// opts.Whitespace. Binary contents are marked [FileDiff.Binary] or, when
+// opts.HexDump is set, replaced by hex dumps so their bytes can be compared
+// line by line.
+func NewFileDiff(oldPath, newPath, path, oldContent, newContent string, opts RenderOptions) FileDiff {
+	file := FileDiff{OldPath: oldPath, NewPath: newPath, Path: path, OldContent: oldContent, NewContent: newContent, Whitespace: opts.Whitespace}
+	if !diff.IsBinary(oldContent) && !diff.IsBinary(newContent) {
+		return file
+	}
+	if opts.HexDump {
+		file.OldContent, file.NewContent = diff.HexDump(oldContent), diff.HexDump(newContent)
+		return file
+	}
+	file.Binary = true
+	return file
+}
+
+// Summary describes a binary file's change by the sizes of both sides.
+func (f FileDiff) Summary() string {
+	return diff.BinarySummary(len(f.OldContent), len(f.NewContent))
+}
+
+// RenameLabel describes a rename or copy, e.g. "renamed 87%", or returns ""
+// when the file kept its path.
+func (f FileDiff) RenameLabel() string {
+	switch {
+	case f.From == "":
+		return ""
+	case f.Copied:
+		return fmt.Sprintf("copied %d%%", f.Similarity)
+	default:
+		return fmt.Sprintf("renamed %d%%", f.Similarity)
+	}
+}
+
+// TreePath returns the path the file is listed under in the file tree.
+func (f FileDiff) TreePath() string {
+	if f.Path != "" {
+		return f.Path
+	}
+	return f.NewPath
+}
+
+// Pending reports whether the file's edits have not been computed yet.
+// Binary files and files whose computation failed are never pending.
+func (f FileDiff) Pending() bool {
+	return f.Edits == nil && !f.Binary && f.loadErr == nil
+}
+
+// EnsureEdits computes and caches the file's edits from its contents if they
+// have not been computed yet.
+func (f *FileDiff) EnsureEdits() error {
+	if !f.Pending() {
+		return nil
+	}
+
+	edits, err := computeFileEdits(f.OldContent, f.NewContent, f.Whitespace)
+	if err != nil {
+		return fmt.Errorf("diff computation failed for %s: %w", f.NewPath, err)
+	}
+	f.Edits = edits
+	return nil
+}
+
+// computeFileEdits runs the Myers algorithm over the line-split contents,
+// ignoring the whitespace differences ws selects.
+func computeFileEdits(oldContent, newContent string, ws diff.Whitespace) ([]diff.Edit, error) {
+	myers := diff.IgnoreWhitespace(&diff.Myers{}, ws)
+	edits, err := myers.Compute(strings.Split(oldContent, "\n"), strings.Split(newContent, "\n"))
+	if err != nil {
+		return nil, err
+	}
+	if edits == nil {
+		edits = []diff.Edit{}
+	}
+	return edits, nil
+}
+
+// fileEditsComputedMsg delivers the result of a background diff computation.
+type fileEditsComputedMsg struct {
+	index int
+	edits []diff.Edit
+	err   error
+}
+
+// RenderOptions controls how the diff viewers treat large edit scripts.
+type RenderOptions struct {
+	// LargeDiffThreshold is the edit count above which only hunks are rendered.
+	// Zero uses [diff.DefaultLargeDiffThreshold]; a negative value disables the check.
+	LargeDiffThreshold int
+	// Force renders every diff in full regardless of its size.
+	Force bool
+	// IgnoreMatchingLines treats changes whose lines all match as unchanged.
+	IgnoreMatchingLines *regexp.Regexp
+	// AlignReplacements pairs changed lines positionally into Replace rows.
+	AlignReplacements bool
+	// Expanded shows every unchanged line instead of compressing long runs.
+	Expanded bool
+	// SplitMinUnchanged is the shortest unchanged run compressed in the split view.
+	// Zero uses [diff.DefaultMinUnchangedToHide].
+	SplitMinUnchanged int
+	// UnifiedMinUnchanged is the shortest unchanged run compressed in the unified view.
+	// Zero uses [diff.DefaultMinUnchangedToHide].
+	UnifiedMinUnchanged int
+	// Theme overrides the diff colors; nil uses [diff.DefaultTheme].
+	Theme *diff.Theme
+	// HexDump diffs binary files as hex dumps instead of summarizing them.
+	// It applies to files built with [NewFileDiff].
+	HexDump bool
+	// Whitespace selects whitespace differences to ignore. It applies to
+	// files built with [NewFileDiff].
+	Whitespace diff.Whitespace
+}
+
+// UseHunksOnly reports whether edits should fall back to the hunk-only view.
+func (o RenderOptions) UseHunksOnly(edits []diff.Edit) bool {
+	if o.Force {
+		return false
+	}
+	threshold := o.LargeDiffThreshold
+	if threshold == 0 {
+		threshold = diff.DefaultLargeDiffThreshold
+	}
+	return diff.IsLargeDiff(edits, threshold)
+}
+
+// largeDiffNotice is shown alongside diffs rendered in the hunk-only fallback.
+const largeDiffNotice = "large diff: showing hunks only (use --full to render everything)"
+
 // DiffModel holds the state for the side-by-side diff viewer.
 type DiffModel struct {
-	viewport viewport.Model
-	content  string
-	ready    bool
-	oldPath  string
-	newPath  string
+	viewport  viewport.Model
+	content   string
+	ready     bool
+	oldPath   string
+	newPath   string
+	hunksOnly bool
+	hunks     []int // Content line on which each changed block starts
+	search    diffSearch
 }
 
 // keyMap defines keyboard shortcuts for the diff viewer.
 type keyMap struct {
-	Up       key.Binding
-	Down     key.Binding
-	PageUp   key.Binding
-	PageDown key.Binding
-	HalfUp   key.Binding
-	HalfDown key.Binding
-	Top      key.Binding
-	Bottom   key.Binding
-	Quit     key.Binding
+	Up        key.Binding
+	Down      key.Binding
+	PageUp    key.Binding
+	PageDown  key.Binding
+	HalfUp    key.Binding
+	HalfDown  key.Binding
+	Top       key.Binding
+	Bottom    key.Binding
+	NextHunk  key.Binding
+	PrevHunk  key.Binding
+	Search    key.Binding
+	NextMatch key.Binding
+	PrevMatch key.Binding
+	Quit      key.Binding
 }
 
 var keys = keyMap{
@@ -75,17 +241,76 @@ var keys = keyMap{
 		key.WithKeys("end", "G"),
 		key.WithHelp("G/end", "bottom"),
 	),
+	NextHunk: key.NewBinding(
+		key.WithKeys("]"),
+		key.WithHelp("]", "next hunk"),
+	),
+	PrevHunk: key.NewBinding(
+		key.WithKeys("["),
+		key.WithHelp("[", "previous hunk"),
+	),
+	Search: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "search"),
+	),
+	NextMatch: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "next match"),
+	),
+	PrevMatch: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "previous match"),
+	),
 	Quit: key.NewBinding(
 		key.WithKeys("q", "esc", "ctrl+c"),
 		key.WithHelp("q", "quit"),
 	),
 }
 
+// hunkContext is how many lines above a hunk stay visible after jumping to it.
+const hunkContext = 2
+
+// hunkOffset returns the viewport offset that shows the first hunk below
+// (dir > 0) or above (dir < 0) the one at offset, reporting false when there
+// is none. hunks holds the content line each hunk starts on, in order.
+func hunkOffset(hunks []int, offset, dir int) (int, bool) {
+	if dir > 0 {
+		for _, line := range hunks {
+			if target := max(line-hunkContext, 0); target > offset {
+				return target, true
+			}
+		}
+		return offset, false
+	}
+	for i := len(hunks) - 1; i >= 0; i-- {
+		if target := max(hunks[i]-hunkContext, 0); target < offset {
+			return target, true
+		}
+	}
+	return offset, false
+}
+
 // NewDiffModel creates a new diff viewer model with the given edits.
 func NewDiffModel(edits []diff.Edit, oldPath, newPath string, terminalWidth, terminalHeight int) DiffModel {
+	return NewDiffModelWithOptions(edits, oldPath, newPath, terminalWidth, terminalHeight, RenderOptions{})
+}
+
+// NewDiffModelWithOptions creates a new diff viewer model, falling back to a
+// hunk-only view when the edit script exceeds the configured threshold.
+func NewDiffModelWithOptions(edits []diff.Edit, oldPath, newPath string, terminalWidth, terminalHeight int, opts RenderOptions) DiffModel {
 	formatter := &diff.SideBySideFormatter{
-		TerminalWidth:   terminalWidth,
-		ShowLineNumbers: true,
+		TerminalWidth:       terminalWidth,
+		ShowLineNumbers:     true,
+		Expanded:            opts.Expanded,
+		IgnoreMatchingLines: opts.IgnoreMatchingLines,
+		AlignReplacements:   opts.AlignReplacements,
+		MinUnchangedToHide:  opts.SplitMinUnchanged,
+		Theme:               opts.Theme,
+	}
+
+	hunksOnly := opts.UseHunksOnly(edits)
+	if hunksOnly {
+		edits = diff.HunksOnly(edits, 3)
 	}
 
 	content := formatter.Format(edits)
@@ -94,11 +319,14 @@ func NewDiffModel(edits []diff.Edit, oldPath, newPath string, terminalWidth, ter
 	vp.SetContent(content)
 
 	return DiffModel{
-		viewport: vp,
-		content:  content,
-		ready:    true,
-		oldPath:  oldPath,
-		newPath:  newPath,
+		viewport:  vp,
+		content:   content,
+		ready:     true,
+		oldPath:   oldPath,
+		newPath:   newPath,
+		hunksOnly: hunksOnly,
+		hunks:     formatter.HunkLines(edits),
+		search:    newDiffSearch(),
 	}
 }
 
@@ -113,6 +341,10 @@ func (m DiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if cmd, handled := m.search.handleKey(msg, &m.viewport, m.content); handled {
+			return m, cmd
+		}
+
 		switch {
 		case key.Matches(msg, keys.Quit):
 			return m, tea.Quit
@@ -140,12 +372,22 @@ func (m DiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case key.Matches(msg, keys.Bottom):
 			m.viewport.GotoBottom()
+
+		case key.Matches(msg, keys.NextHunk):
+			if offset, ok := hunkOffset(m.hunks, m.viewport.YOffset, 1); ok {
+				m.viewport.SetYOffset(offset)
+			}
+
+		case key.Matches(msg, keys.PrevHunk):
+			if offset, ok := hunkOffset(m.hunks, m.viewport.YOffset, -1); ok {
+				m.viewport.SetYOffset(offset)
+			}
 		}
 
 	case tea.WindowSizeMsg:
 		if !m.ready {
 			m.viewport = viewport.New(msg.Width, msg.Height-2)
-			m.viewport.SetContent(m.content)
+			m.viewport.SetContent(m.search.highlight(m.content))
 			m.ready = true
 		} else {
 			m.viewport.Width = msg.Width
@@ -176,9 +418,12 @@ func (m DiffModel) renderHeader() string {
 	oldLabel := lipgloss.NewStyle().Foreground(style.RemovedColor).Render("−")
 	newLabel := lipgloss.NewStyle().Foreground(style.AddedColor).Render("+")
 
-	return headerStyle.Render(
-		fmt.Sprintf("%s %s  %s %s", oldLabel, m.oldPath, newLabel, m.newPath),
-	)
+	header := fmt.Sprintf("%s %s  %s %s", oldLabel, m.oldPath, newLabel, m.newPath)
+	if m.hunksOnly {
+		header += "  " + style.StyleSecurity.Render("("+largeDiffNotice+")")
+	}
+
+	return headerStyle.Render(header)
 }
 
 // renderFooter creates the footer bar with help text and scroll position.
@@ -188,7 +433,10 @@ func (m DiffModel) renderFooter() string {
 		Faint(true).
 		Padding(0, 1)
 
-	helpText := "↑/↓: scroll • space/b: page • g/G: top/bottom • q: quit"
+	helpText := "↑/↓: scroll • space/b: page • g/G: top/bottom • ]/[: hunk • /: search • q: quit"
+	if search := m.search.footer(); search != "" {
+		helpText = search
+	}
 
 	scrollPercent := m.viewport.ScrollPercent()
 	scrollInfo := fmt.Sprintf("%.0f%%", scrollPercent*100)
@@ -204,19 +452,45 @@ func (m DiffModel) renderFooter() string {
 }
 
 // MultiFileDiffModel holds the state for viewing diffs across multiple files with pagination.
+//
+// Files whose edits are pending are computed lazily when their page is first
+// visited, so opening a large range does not block on diffing every file.
+//
+// A file tree sidebar groups the files by directory. It opens by default for
+// changesets larger than [fileTreeThreshold], replacing the paginator dots.
 type MultiFileDiffModel struct {
 	files     []FileDiff
 	paginator paginator.Model
+	tree      fileTree
+	showTree  bool
+	treeFocus bool // Whether arrow keys move through the file tree
+	content   string
+	hunks     []int // Content line on which each changed block of the current file starts
+	search    diffSearch
 	viewport  viewport.Model
+	spinner   spinner.Model
 	ready     bool
 	width     int
 	height    int
 	expanded  bool // Controls whether unchanged blocks are compressed
 	view      diff.DiffViewKind
+	render    RenderOptions
+	hunksOnly bool // Whether the current file fell back to the hunk-only view
+	loading   int  // Index of the file being computed, or -1
+
+	staging     Staging
+	selected    int    // Hunk of the current file that staging acts on
+	stageStatus string // Outcome of the last staging key, shown in the footer
 }
 
 // NewMultiFileDiffModel creates a new multi-file diff viewer with pagination.
 func NewMultiFileDiffModel(files []FileDiff, expanded bool, view diff.DiffViewKind) MultiFileDiffModel {
+	return NewMultiFileDiffModelWithOptions(files, expanded, view, RenderOptions{})
+}
+
+// NewMultiFileDiffModelWithOptions creates a new multi-file diff viewer whose
+// large files fall back to a hunk-only view according to opts.
+func NewMultiFileDiffModelWithOptions(files []FileDiff, expanded bool, view diff.DiffViewKind, opts RenderOptions) MultiFileDiffModel {
 	p := paginator.New()
 	p.Type = paginator.Dots
 	p.PerPage = 1
@@ -224,12 +498,22 @@ func NewMultiFileDiffModel(files []FileDiff, expanded bool, view diff.DiffViewKi
 	p.ActiveDot = lipgloss.NewStyle().Foreground(style.AccentBlue).Render("•")
 	p.InactiveDot = lipgloss.NewStyle().Foreground(lipgloss.Color("#6C7A89")).Render("•")
 
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = lipgloss.NewStyle().Foreground(style.AccentBlue)
+
 	model := MultiFileDiffModel{
 		files:     files,
 		paginator: p,
+		tree:      newFileTree(files),
+		search:    newDiffSearch(),
+		showTree:  len(files) > fileTreeThreshold,
+		spinner:   sp,
 		ready:     false,
 		expanded:  expanded,
 		view:      view,
+		render:    opts,
+		loading:   -1,
 	}
 
 	return model
@@ -247,24 +531,67 @@ func (m MultiFileDiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		m.stageStatus = ""
+		if cmd, handled := m.search.handleKey(msg, &m.viewport, m.content); handled {
+			return m, cmd
+		}
+		if m.treeFocus {
+			if cmd, handled := m.updateTree(msg); handled {
+				return m, cmd
+			}
+		}
+
 		switch {
 		case key.Matches(msg, keys.Quit):
 			return m, tea.Quit
 
+		case key.Matches(msg, key.NewBinding(key.WithKeys("tab"))):
+			if !m.showTree {
+				m.showTree = true
+				m.resizeViewport()
+				cmds = append(cmds, m.updateViewport())
+			}
+			m.treeFocus = !m.treeFocus
+
+		case key.Matches(msg, key.NewBinding(key.WithKeys("t"))):
+			m.showTree = !m.showTree
+			m.treeFocus = m.treeFocus && m.showTree
+			m.resizeViewport()
+			cmds = append(cmds, m.updateViewport())
+
 		case key.Matches(msg, key.NewBinding(key.WithKeys("e"))):
 			m.expanded = !m.expanded
-			m.updateViewport()
+			cmds = append(cmds, m.updateViewport())
+
+		case key.Matches(msg, key.NewBinding(key.WithKeys("v"))):
+			if m.view == diff.ViewUnified {
+				m.view = diff.ViewSplit
+			} else {
+				m.view = diff.ViewUnified
+			}
+			cmds = append(cmds, m.updateViewport())
 
 		case key.Matches(msg, key.NewBinding(key.WithKeys("left", "h"))):
 			m.paginator.PrevPage()
-			m.updateViewport()
+			m.selected = 0
+			cmds = append(cmds, m.updateViewport())
 			m.viewport.GotoTop()
 
 		case key.Matches(msg, key.NewBinding(key.WithKeys("right", "l"))):
 			m.paginator.NextPage()
-			m.updateViewport()
+			m.selected = 0
+			cmds = append(cmds, m.updateViewport())
 			m.viewport.GotoTop()
 
+		case m.stagingEnabled() && key.Matches(msg, stageKey):
+			cmds = append(cmds, m.stageSelected())
+
+		case m.stagingEnabled() && key.Matches(msg, keys.NextHunk):
+			m.selectHunk(1)
+
+		case m.stagingEnabled() && key.Matches(msg, keys.PrevHunk):
+			m.selectHunk(-1)
+
 		case key.Matches(msg, keys.Up):
 			m.viewport.ScrollUp(1)
 
@@ -288,6 +615,16 @@ func (m MultiFileDiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case key.Matches(msg, keys.Bottom):
 			m.viewport.GotoBottom()
+
+		case key.Matches(msg, keys.NextHunk):
+			if offset, ok := hunkOffset(m.hunks, m.viewport.YOffset, 1); ok {
+				m.viewport.SetYOffset(offset)
+			}
+
+		case key.Matches(msg, keys.PrevHunk):
+			if offset, ok := hunkOffset(m.hunks, m.viewport.YOffset, -1); ok {
+				m.viewport.SetYOffset(offset)
+			}
 		}
 
 	case tea.WindowSizeMsg:
@@ -295,14 +632,33 @@ func (m MultiFileDiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 
 		if !m.ready {
-			m.viewport = viewport.New(msg.Width, msg.Height-4)
+			m.viewport = viewport.New(m.diffWidth(), msg.Height-4)
 			m.ready = true
 		} else {
-			m.viewport.Width = msg.Width
-			m.viewport.Height = msg.Height - 4
+			m.resizeViewport()
+		}
+
+		cmds = append(cmds, m.updateViewport())
+
+	case fileEditsComputedMsg:
+		if msg.index >= 0 && msg.index < len(m.files) {
+			file := &m.files[msg.index]
+			file.Edits, file.loadErr = msg.edits, msg.err
+			if msg.err == nil && msg.edits == nil {
+				file.Edits = []diff.Edit{}
+			}
 		}
+		if msg.index == m.loading {
+			m.loading = -1
+		}
+		cmds = append(cmds, m.updateViewport())
 
-		m.updateViewport()
+	case spinner.TickMsg:
+		if m.loading >= 0 {
+			m.spinner, cmd = m.spinner.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+		return m, tea.Batch(cmds...)
 	}
 
 	m.viewport, cmd = m.viewport.Update(msg)
@@ -321,43 +677,168 @@ func (m MultiFileDiffModel) View() string {
 	footer := m.renderMultiFileFooter()
 	paginatorView := m.renderPaginator()
 
-	return fmt.Sprintf("%s\n%s\n%s\n%s", header, m.viewport.View(), paginatorView, footer)
+	body := m.viewport.View()
+	if m.loading >= 0 {
+		body = fmt.Sprintf("\n  %s Computing diff for %s...", m.spinner.View(), m.files[m.loading].NewPath)
+	}
+	if m.showTree {
+		body = lipgloss.JoinHorizontal(lipgloss.Top, m.renderTree(), body)
+	}
+
+	return fmt.Sprintf("%s\n%s\n%s\n%s", header, body, paginatorView, footer)
 }
 
 // updateViewport updates the viewport content to show the current file.
-func (m *MultiFileDiffModel) updateViewport() {
+//
+// When the current file's edits are still pending, it starts a background
+// computation and returns the command that delivers the result.
+func (m *MultiFileDiffModel) updateViewport() tea.Cmd {
 	if len(m.files) == 0 {
-		return
+		return nil
 	}
 
-	width := m.width
+	width := m.diffWidth()
 	if width <= 0 {
 		width = 80
 	}
 
-	currentFile := m.files[m.paginator.Page]
+	page := m.paginator.Page
+	currentFile := m.files[page]
+
+	if currentFile.Pending() {
+		if m.loading == page {
+			return nil
+		}
+		m.loading = page
+		m.hunks = nil
+		m.viewport.SetContent("")
+		return tea.Batch(m.spinner.Tick, computeFileEditsCmd(page, currentFile))
+	}
+
+	if currentFile.loadErr != nil {
+		m.hunks, m.content = nil, ""
+		m.viewport.SetContent(style.StyleRemoved.Render(currentFile.loadErr.Error()))
+		return nil
+	}
+
+	if currentFile.Binary {
+		m.hunks, m.hunksOnly = nil, false
+		m.content = style.StyleSecurity.Render(currentFile.Summary())
+		m.viewport.SetContent(m.search.highlight(m.content))
+		return nil
+	}
+
+	edits := currentFile.Edits
+	m.hunksOnly = m.render.UseHunksOnly(edits)
+	if m.hunksOnly {
+		edits = diff.HunksOnly(edits, 3)
+	}
 
 	var content string
 
 	switch m.view {
 	case diff.ViewUnified:
 		formatter := &diff.UnifiedFormatter{
-			TerminalWidth:   width,
-			ShowLineNumbers: true,
-			Expanded:        m.expanded,
-			EnableWordWrap:  false,
+			TerminalWidth:       width,
+			ShowLineNumbers:     true,
+			Expanded:            m.expanded,
+			EnableWordWrap:      false,
+			IgnoreMatchingLines: m.render.IgnoreMatchingLines,
+			AlignReplacements:   m.render.AlignReplacements,
+			MinUnchangedToHide:  m.render.UnifiedMinUnchanged,
+			Theme:               m.render.Theme,
 		}
-		content = formatter.Format(currentFile.Edits)
+		content = formatter.Format(edits)
+		m.hunks = formatter.HunkLines(edits)
 	default:
 		formatter := &diff.SideBySideFormatter{
-			TerminalWidth:   width,
-			ShowLineNumbers: true,
-			Expanded:        m.expanded,
-			EnableWordWrap:  false,
+			TerminalWidth:       width,
+			ShowLineNumbers:     true,
+			Expanded:            m.expanded,
+			EnableWordWrap:      false,
+			IgnoreMatchingLines: m.render.IgnoreMatchingLines,
+			AlignReplacements:   m.render.AlignReplacements,
+			MinUnchangedToHide:  m.render.SplitMinUnchanged,
+			Theme:               m.render.Theme,
+			Annotations:         currentFile.Blame,
 		}
-		content = formatter.Format(currentFile.Edits)
+		content = formatter.Format(edits)
+		m.hunks = formatter.HunkLines(edits)
+	}
+	m.selected = min(m.selected, max(len(m.hunks)-1, 0))
+	m.content = content
+	m.viewport.SetContent(m.search.highlight(content))
+	return nil
+}
+
+// updateTree handles a key press while the file tree has focus, reporting
+// whether the key was consumed. Keys it leaves alone keep their usual meaning.
+func (m *MultiFileDiffModel) updateTree(msg tea.KeyMsg) (tea.Cmd, bool) {
+	page := m.paginator.Page
+	switch {
+	case key.Matches(msg, keys.Up):
+		return m.gotoFile(m.tree.step(page, -1)), true
+	case key.Matches(msg, keys.Down):
+		return m.gotoFile(m.tree.step(page, 1)), true
+	case key.Matches(msg, keys.Top):
+		return m.gotoFile(m.tree.first()), true
+	case key.Matches(msg, keys.Bottom):
+		return m.gotoFile(m.tree.last()), true
+	case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+		m.treeFocus = false
+		return nil, true
+	}
+	return nil, false
+}
+
+// gotoFile shows the file at index from its top.
+func (m *MultiFileDiffModel) gotoFile(index int) tea.Cmd {
+	if index == m.paginator.Page {
+		return nil
+	}
+	m.paginator.Page = index
+	m.selected = 0
+	cmd := m.updateViewport()
+	m.viewport.GotoTop()
+	return cmd
+}
+
+// treeWidth returns the columns taken by the file tree, including its border,
+// or 0 when it is hidden.
+func (m MultiFileDiffModel) treeWidth() int {
+	if !m.showTree {
+		return 0
+	}
+	return min(max(m.width/4, 24), 40)
+}
+
+// diffWidth returns the columns left for the diff beside the file tree.
+func (m MultiFileDiffModel) diffWidth() int {
+	return max(m.width-m.treeWidth(), 0)
+}
+
+// resizeViewport fits the viewport to the window and the file tree.
+func (m *MultiFileDiffModel) resizeViewport() {
+	m.viewport.Width = m.diffWidth()
+	m.viewport.Height = m.height - 4
+}
+
+// renderTree renders the file tree sidebar with its right border.
+func (m MultiFileDiffModel) renderTree() string {
+	tree := m.tree.render(m.files, m.paginator.Page, m.treeWidth()-2, m.viewport.Height, m.treeFocus)
+	return lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder(), false, true, false, false).
+		BorderForeground(lipgloss.Color("#6C7A89")).
+		PaddingRight(1).
+		Render(tree)
+}
+
+// computeFileEditsCmd computes a file's edits off the UI loop.
+func computeFileEditsCmd(index int, file FileDiff) tea.Cmd {
+	return func() tea.Msg {
+		edits, err := computeFileEdits(file.OldContent, file.NewContent, file.Whitespace)
+		return fileEditsComputedMsg{index: index, edits: edits, err: err}
 	}
-	m.viewport.SetContent(content)
 }
 
 // renderMultiFileHeader creates the header showing current file paths.
@@ -378,14 +859,24 @@ func (m MultiFileDiffModel) renderMultiFileHeader() string {
 
 	fileIndicator := fmt.Sprintf("[%d/%d]", m.paginator.Page+1, len(m.files))
 
-	return headerStyle.Render(
-		fmt.Sprintf("%s %s %s  %s %s", fileIndicator, oldLabel, currentFile.OldPath, newLabel, currentFile.NewPath),
-	)
+	header := fmt.Sprintf("%s %s %s  %s %s", fileIndicator, oldLabel, currentFile.OldPath, newLabel, currentFile.NewPath)
+	if label := currentFile.RenameLabel(); label != "" {
+		header += "  " + style.StyleChanged.Render("("+label+")")
+	}
+	if m.hunksOnly {
+		header += "  " + style.StyleSecurity.Render("("+largeDiffNotice+")")
+	}
+	if m.stagingEnabled() && len(m.hunks) > 0 {
+		header += "  " + style.StyleChanged.Render(fmt.Sprintf("(hunk %d/%d)", m.selected+1, len(m.hunks)))
+	}
+
+	return headerStyle.Render(header)
 }
 
-// renderPaginator renders the pagination dots.
+// renderPaginator renders the pagination dots, which the file tree replaces
+// while it is shown.
 func (m MultiFileDiffModel) renderPaginator() string {
-	if len(m.files) <= 1 {
+	if len(m.files) <= 1 || m.showTree {
 		return ""
 	}
 
@@ -407,7 +898,28 @@ func (m MultiFileDiffModel) renderMultiFileFooter() string {
 		expandedIndicator = "expanded"
 	}
 
-	helpText := fmt.Sprintf("↑/↓: scroll • h/l: files • e: %s • q: quit", expandedIndicator)
+	viewIndicator := "split"
+	if m.view == diff.ViewUnified {
+		viewIndicator = "unified"
+	}
+
+	helpText := fmt.Sprintf("↑/↓: scroll • ]/[: hunk • h/l: files • tab: tree • /: search • e: %s • v: %s • q: quit", expandedIndicator, viewIndicator)
+	if m.stagingEnabled() {
+		verb := "stage"
+		if m.staging.Unstage {
+			verb = "unstage"
+		}
+		helpText = fmt.Sprintf("↑/↓: scroll • ]/[: select hunk • s: %s • h/l: files • tab: tree • /: search • e: %s • v: %s • q: quit", verb, expandedIndicator, viewIndicator)
+	}
+	if m.stageStatus != "" {
+		helpText = m.stageStatus
+	}
+	if m.treeFocus {
+		helpText = fmt.Sprintf("↑/↓: files • enter/tab: diff • t: hide tree • e: %s • v: %s • q: quit", expandedIndicator, viewIndicator)
+	}
+	if search := m.search.footer(); search != "" {
+		helpText = search
+	}
 
 	scrollPercent := m.viewport.ScrollPercent()
 	scrollInfo := fmt.Sprintf("%.0f%%", scrollPercent*100)
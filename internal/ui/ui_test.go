@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -330,3 +331,284 @@ func TestMultiFileDiffModel_RenderFooter(t *testing.T) {
 		t.Error("Footer should contain quit help")
 	}
 }
+
+func TestMultiFileDiffModel_LazyEdits(t *testing.T) {
+	files := []FileDiff{
+		{OldPath: "a.go", NewPath: "a.go", OldContent: "one\ntwo", NewContent: "one\nthree"},
+		{OldPath: "b.go", NewPath: "b.go", OldContent: "alpha", NewContent: "beta"},
+	}
+
+	model := NewMultiFileDiffModel(files, false, diff.ViewSplit)
+
+	updated, cmd := model.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	model = updated.(MultiFileDiffModel)
+
+	if !model.files[0].Pending() || !model.files[1].Pending() {
+		t.Fatal("No file should be computed before its computation command runs")
+	}
+	if !strings.Contains(model.View(), "Computing diff") {
+		t.Error("View should show a spinner while the current file is computing")
+	}
+
+	model = drainCmd(t, model, cmd)
+
+	if model.files[0].Pending() {
+		t.Error("First file should be computed after its page is visited")
+	}
+	if !model.files[1].Pending() {
+		t.Error("Second file should not be computed until its page is visited")
+	}
+	if !strings.Contains(model.View(), "three") {
+		t.Error("View should render the computed diff for the first file")
+	}
+
+	model.paginator.NextPage()
+	model = drainCmd(t, model, model.updateViewport())
+
+	if model.files[1].Pending() {
+		t.Error("Second file should be computed after its page is visited")
+	}
+}
+
+func TestMultiFileDiffModel_PagingWhileLoading(t *testing.T) {
+	files := []FileDiff{
+		{OldPath: "a.go", NewPath: "a.go", OldContent: "one", NewContent: "two"},
+		{OldPath: "b.go", NewPath: "b.go", OldContent: "alpha", NewContent: "beta"},
+	}
+	model := NewMultiFileDiffModel(files, false, diff.ViewSplit)
+
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	model = updated.(MultiFileDiffModel)
+	if model.loading != 0 {
+		t.Fatalf("loading = %d, want the first file", model.loading)
+	}
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRight})
+	model = updated.(MultiFileDiffModel)
+	if model.loading != 1 || cmd == nil {
+		t.Fatalf("paging to a pending file should start computing it, loading = %d", model.loading)
+	}
+
+	updated, cmd = model.Update(fileEditsComputedMsg{index: 0, err: errors.New("a.go failed")})
+	model = updated.(MultiFileDiffModel)
+	if model.loading != 1 {
+		t.Errorf("a stale result should not clear loading, got %d", model.loading)
+	}
+	if cmd != nil {
+		if _, ok := cmd().(fileEditsComputedMsg); ok {
+			t.Error("a stale result should not start a duplicate computation")
+		}
+	}
+	if view := model.View(); strings.Contains(view, "a.go failed") || !strings.Contains(view, "Computing diff") {
+		t.Errorf("the second page should still be computing, got:\n%s", view)
+	}
+
+	model = drainCmd(t, model, computeFileEditsCmd(1, model.files[1]))
+	if model.loading != -1 || strings.Contains(model.View(), "a.go failed") {
+		t.Errorf("the second file should render once computed, got:\n%s", model.View())
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	model = updated.(MultiFileDiffModel)
+	if !strings.Contains(model.View(), "a.go failed") {
+		t.Errorf("the first file should show its own error, got:\n%s", model.View())
+	}
+}
+
+func TestNewFileDiff_Binary(t *testing.T) {
+	text := NewFileDiff("a", "b", "notes.txt", "one\n", "two\n", RenderOptions{})
+	if text.Binary || !text.Pending() {
+		t.Error("text files should be diffed line by line")
+	}
+
+	png := NewFileDiff("a", "b", "logo.png", "\x89PNG\x00", "\x89PNG\x00\x01", RenderOptions{})
+	if !png.Binary || png.Pending() {
+		t.Fatal("binary files should be marked and never pending")
+	}
+	if want := "Binary files differ (size 5 B → 6 B)"; png.Summary() != want {
+		t.Errorf("Summary() = %q, want %q", png.Summary(), want)
+	}
+	if fileCounts(png) != "bin" {
+		t.Errorf("fileCounts() = %q, want bin", fileCounts(png))
+	}
+
+	hex := NewFileDiff("a", "b", "logo.png", "\x89PNG\x00", "", RenderOptions{HexDump: true})
+	if hex.Binary || !strings.HasPrefix(hex.OldContent, "00000000  89 50 4e 47 00") || hex.NewContent != "" {
+		t.Errorf("HexDump should replace binary contents with hex dumps, got %q", hex.OldContent)
+	}
+}
+
+func TestMultiFileDiffModel_BinaryFile(t *testing.T) {
+	files := []FileDiff{NewFileDiff("a", "b", "logo.png", "\x89PNG\x00", "\x89PNG\x00\x01", RenderOptions{})}
+	model := NewMultiFileDiffModel(files, false, diff.ViewSplit)
+
+	updated, cmd := model.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	model = drainCmd(t, updated.(MultiFileDiffModel), cmd)
+
+	if view := model.View(); !strings.Contains(view, "Binary files differ") || strings.Contains(view, "Computing diff") {
+		t.Errorf("View should show the binary summary, got:\n%s", view)
+	}
+}
+
+// drainCmd runs cmd and feeds any computed edit results back into the model.
+func drainCmd(t *testing.T, model MultiFileDiffModel, cmd tea.Cmd) MultiFileDiffModel {
+	t.Helper()
+	if cmd == nil {
+		return model
+	}
+
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			model = drainCmd(t, model, c)
+		}
+	case fileEditsComputedMsg:
+		updated, next := model.Update(msg)
+		model = drainCmd(t, updated.(MultiFileDiffModel), next)
+	}
+	return model
+}
+
+func TestDiffModel_LargeDiffFallback(t *testing.T) {
+	edits := make([]diff.Edit, 0, 401)
+	for i := range 200 {
+		edits = append(edits, diff.Edit{Kind: diff.Equal, AIndex: i, BIndex: i, Content: "same"})
+	}
+	edits = append(edits, diff.Edit{Kind: diff.Insert, AIndex: -1, BIndex: 200, Content: "inserted"})
+	for i := 200; i < 400; i++ {
+		edits = append(edits, diff.Edit{Kind: diff.Equal, AIndex: i, BIndex: i + 1, Content: "same"})
+	}
+
+	model := NewDiffModelWithOptions(edits, "old.txt", "new.txt", 120, 30, RenderOptions{LargeDiffThreshold: 100})
+	if !model.hunksOnly {
+		t.Fatal("Expected hunk-only fallback when edits exceed the threshold")
+	}
+	if !strings.Contains(model.renderHeader(), "hunks only") {
+		t.Error("Header should warn that only hunks are shown")
+	}
+	if !strings.Contains(model.content, "197 unchanged lines") {
+		t.Error("Hunk-only view should collapse everything outside the hunk context")
+	}
+
+	forced := NewDiffModelWithOptions(edits, "old.txt", "new.txt", 120, 30, RenderOptions{LargeDiffThreshold: 100, Force: true})
+	if forced.hunksOnly {
+		t.Error("Forced render should not fall back to hunks")
+	}
+	if strings.Contains(forced.renderHeader(), "hunks only") {
+		t.Error("Forced render header should not warn about hunks")
+	}
+
+	small := NewDiffModelWithOptions(edits[195:206], "old.txt", "new.txt", 120, 30, RenderOptions{LargeDiffThreshold: 100})
+	if small.hunksOnly {
+		t.Error("Diffs under the threshold should render in full")
+	}
+}
+
+func TestNewDiffModelWithOptions_Expanded(t *testing.T) {
+	var edits []diff.Edit
+	for i := range 50 {
+		edits = append(edits, diff.Edit{Kind: diff.Equal, AIndex: i, BIndex: i, Content: "same"})
+	}
+	edits = append(edits, diff.Edit{Kind: diff.Insert, AIndex: -1, BIndex: 50, Content: "inserted"})
+
+	compressed := NewDiffModelWithOptions(edits, "old.txt", "new.txt", 120, 30, RenderOptions{})
+	if !strings.Contains(compressed.content, "⋮") {
+		t.Fatal("Default single-file view should compress the unchanged run")
+	}
+
+	expanded := NewDiffModelWithOptions(edits, "old.txt", "new.txt", 120, 30, RenderOptions{Expanded: true})
+	if strings.Contains(expanded.content, "⋮") {
+		t.Error("Expanded single-file view should not contain compression markers")
+	}
+}
+
+func TestMultiFileDiffModel_PerViewMinUnchanged(t *testing.T) {
+	edits := []diff.Edit{{Kind: diff.Insert, AIndex: -1, BIndex: 0, Content: "inserted"}}
+	for i := range 9 {
+		edits = append(edits, diff.Edit{Kind: diff.Equal, AIndex: i, BIndex: i + 1, Content: "same"})
+	}
+	edits = append(edits, diff.Edit{Kind: diff.Delete, AIndex: 9, BIndex: -1, Content: "removed"})
+
+	files := []FileDiff{{Edits: edits, OldPath: "a.go", NewPath: "a.go"}}
+	opts := RenderOptions{SplitMinUnchanged: 12, UnifiedMinUnchanged: 9}
+	model := NewMultiFileDiffModelWithOptions(files, false, diff.ViewUnified, opts)
+
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	model = updated.(MultiFileDiffModel)
+	if !strings.Contains(model.viewport.View(), "⋮") {
+		t.Error("Unified view should compress the 9-line run with threshold 9")
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	model = updated.(MultiFileDiffModel)
+	if model.view != diff.ViewSplit {
+		t.Fatalf("Expected 'v' to switch to split view, got %v", model.view)
+	}
+	if strings.Contains(model.viewport.View(), "⋮") {
+		t.Error("Split view should not compress the 9-line run with threshold 12")
+	}
+}
+
+func TestHunkOffset(t *testing.T) {
+	hunks := []int{1, 30, 80}
+
+	tests := []struct {
+		offset, dir int
+		want        int
+		ok          bool
+	}{
+		{0, 1, 28, true},
+		{28, 1, 78, true},
+		{78, 1, 78, false},
+		{78, -1, 28, true},
+		{28, -1, 0, true},
+		{0, -1, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := hunkOffset(hunks, tt.offset, tt.dir)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("hunkOffset(%d, %d) = %d, %v; want %d, %v", tt.offset, tt.dir, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestDiffModels_HunkNavigation(t *testing.T) {
+	var edits []diff.Edit
+	for i := range 200 {
+		edit := diff.Edit{Kind: diff.Equal, AIndex: i, BIndex: i, Content: "same"}
+		if i == 100 || i == 150 {
+			edit = diff.Edit{Kind: diff.Insert, AIndex: -1, BIndex: i, Content: "changed"}
+		}
+		edits = append(edits, edit)
+	}
+
+	next := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{']'}}
+	prev := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'['}}
+
+	t.Run("single file", func(t *testing.T) {
+		var model tea.Model = NewDiffModelWithOptions(edits, "a.go", "a.go", 120, 20, RenderOptions{Expanded: true})
+		model, _ = model.Update(next)
+		if got := model.(DiffModel).viewport.YOffset; got != 100-hunkContext {
+			t.Errorf("] should scroll to the first hunk, offset %d", got)
+		}
+		model, _ = model.Update(next)
+		if got := model.(DiffModel).viewport.YOffset; got != 150-hunkContext {
+			t.Errorf("] should scroll to the second hunk, offset %d", got)
+		}
+		model, _ = model.Update(prev)
+		if got := model.(DiffModel).viewport.YOffset; got != 100-hunkContext {
+			t.Errorf("[ should return to the first hunk, offset %d", got)
+		}
+	})
+
+	t.Run("multi file compressed", func(t *testing.T) {
+		var model tea.Model = NewMultiFileDiffModel([]FileDiff{{Edits: edits, NewPath: "a.go"}}, false, diff.ViewUnified)
+		model, _ = model.Update(tea.WindowSizeMsg{Width: 120, Height: 10})
+		model, _ = model.Update(next)
+		multi := model.(MultiFileDiffModel)
+		lines := strings.Split(multi.content, "\n")
+		if top := lines[multi.viewport.YOffset+hunkContext]; !strings.Contains(top, "changed") {
+			t.Errorf("] should skip the compressed region to the first hunk, got %q", top)
+		}
+	})
+}
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/stormlightlabs/git-storm/internal/changelog"
+	"github.com/stormlightlabs/git-storm/internal/changeset"
+	"github.com/stormlightlabs/git-storm/internal/gitlog"
 )
 
 // BumpType represents the semantic version component to increment.
@@ -108,3 +110,48 @@ func LatestVersion(ch *changelog.Changelog) (string, bool) {
 
 	return "", false
 }
+
+// InferBumpFromCommits picks the bump level for a set of parsed conventional
+// commits: any breaking change (a "!" or BREAKING CHANGE footer) is major, any
+// feat is minor, and everything else is patch.
+func InferBumpFromCommits(commits []gitlog.CommitMeta) BumpType {
+	kind := BumpPatch
+	for _, meta := range commits {
+		if meta.Breaking {
+			return BumpMajor
+		}
+		if meta.Type == "feat" {
+			kind = BumpMinor
+		}
+	}
+	return kind
+}
+
+// BumpAuto is the --bump value that infers the bump from pending entries
+// with [InferBump].
+const BumpAuto = "auto"
+
+// InferBump picks the bump level for a set of changelog entries and explains
+// the choice: breaking entries and removals are major, additions and
+// deprecations are minor, and everything else is patch. The reason names the
+// first entry that decided the level.
+//
+// Deprecations are deliberately minor: they announce a future removal while
+// the deprecated API keeps working, so only the eventual removal breaks users.
+func InferBump(entries []changeset.Entry) (BumpType, string) {
+	kind, reason := BumpPatch, "no breaking changes, removals, additions, or deprecations"
+	for _, entry := range entries {
+		switch {
+		case entry.Breaking:
+			return BumpMajor, fmt.Sprintf("%q is a breaking change", entry.Summary)
+		case entry.Type == "removed":
+			return BumpMajor, fmt.Sprintf("%q removes a feature", entry.Summary)
+		case kind == BumpMinor:
+		case entry.Type == "added":
+			kind, reason = BumpMinor, fmt.Sprintf("%q adds a feature", entry.Summary)
+		case entry.Type == "deprecated":
+			kind, reason = BumpMinor, fmt.Sprintf("%q deprecates a feature", entry.Summary)
+		}
+	}
+	return kind, reason
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stormlightlabs/git-storm/internal/changelog"
+	"github.com/stormlightlabs/git-storm/internal/changeset"
+	"github.com/stormlightlabs/git-storm/internal/gitlog"
 )
 
 func TestParseAndBump(t *testing.T) {
@@ -85,3 +87,46 @@ func TestLatestVersion(t *testing.T) {
 		t.Fatal("expected LatestVersion to return false when no releases exist")
 	}
 }
+
+func TestInferBumpFromCommits(t *testing.T) {
+	cases := []struct {
+		name    string
+		commits []gitlog.CommitMeta
+		want    BumpType
+	}{
+		{"breaking footer", []gitlog.CommitMeta{{Type: "fix"}, {Type: "feat", Breaking: true}}, BumpMajor},
+		{"only feats", []gitlog.CommitMeta{{Type: "feat"}, {Type: "feat"}}, BumpMinor},
+		{"feats and fixes", []gitlog.CommitMeta{{Type: "fix"}, {Type: "feat"}}, BumpMinor},
+		{"only fixes", []gitlog.CommitMeta{{Type: "fix"}, {Type: "chore"}}, BumpPatch},
+	}
+
+	for _, tc := range cases {
+		if got := InferBumpFromCommits(tc.commits); got != tc.want {
+			t.Errorf("%s: InferBumpFromCommits() = %s, want %s", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestInferBump(t *testing.T) {
+	cases := []struct {
+		name    string
+		entries []changeset.Entry
+		want    BumpType
+		reason  string
+	}{
+		{"only deprecation", []changeset.Entry{{Type: "deprecated", Summary: "Deprecate --old"}}, BumpMinor, `"Deprecate --old" deprecates a feature`},
+		{"deprecation and fixes", []changeset.Entry{{Type: "fixed"}, {Type: "deprecated", Summary: "Deprecate --old"}}, BumpMinor, `"Deprecate --old" deprecates a feature`},
+		{"removal", []changeset.Entry{{Type: "deprecated"}, {Type: "removed", Summary: "Remove --old"}}, BumpMajor, `"Remove --old" removes a feature`},
+		{"breaking change", []changeset.Entry{{Type: "changed", Breaking: true, Summary: "Rename config"}, {Type: "deprecated"}}, BumpMajor, `"Rename config" is a breaking change`},
+		{"breaking deprecation", []changeset.Entry{{Type: "deprecated", Breaking: true, Summary: "Drop v1 API"}}, BumpMajor, `"Drop v1 API" is a breaking change`},
+		{"first addition decides", []changeset.Entry{{Type: "added", Summary: "Add A"}, {Type: "added", Summary: "Add B"}, {Type: "security"}}, BumpMinor, `"Add A" adds a feature`},
+		{"only fixes", []changeset.Entry{{Type: "fixed"}, {Type: "security"}}, BumpPatch, "no breaking changes, removals, additions, or deprecations"},
+	}
+
+	for _, tc := range cases {
+		got, reason := InferBump(tc.entries)
+		if got != tc.want || reason != tc.reason {
+			t.Errorf("%s: InferBump() = %s, %q; want %s, %q", tc.name, got, reason, tc.want, tc.reason)
+		}
+	}
+}